@@ -0,0 +1,299 @@
+package config
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"gopkg.in/yaml.v3"
+)
+
+//fileEnv is the env var of the config file, when it is not given with --config
+const fileEnv = "CONFIG_FILE"
+
+//redacted takes the place of the secrets when the config is printed
+const redacted = "<redacted>"
+
+//Options are the flags that tell how to load the config, rather than setting it
+type Options struct {
+	//File is the config file, read before the environment and the flags
+	File string
+	//PrintConfig prints the config once it is loaded, instead of serving it
+	PrintConfig bool
+}
+
+//Load returns the config of the service: the defaults, overridden by the config file, then by the environment
+//and then by the flags of args. The config file is the one of --config, or else of CONFIG_FILE, and is read
+//as JSON, YAML or TOML by its extension. The config is validated once every setting is applied
+func Load(args []string, lookupEnv func(string) (string, bool)) (*models.Config, Options, error) {
+	cfg := models.DefaultConfig()
+	options := Options{}
+	fields := settings(cfg)
+
+	flags := flag.NewFlagSet("leaderboard-service", flag.ContinueOnError)
+	flags.StringVar(&options.File, "config", "", "config file, read as JSON, YAML or TOML by its extension (env: "+fileEnv+")")
+	flags.BoolVar(&options.PrintConfig, "print-config", false, "print the config once it is loaded, without the secrets, and exit")
+	values := make(map[string]*flagValue, len(fields))
+	byFlag := make(map[string]setting, len(fields))
+	for _, field := range fields {
+		value := &flagValue{value: field.format(), isBool: field.value.Kind() == reflect.Bool}
+		values[field.flag()] = value
+		byFlag[field.flag()] = field
+		flags.Var(value, field.flag(), fmt.Sprintf("%s (env: %s, file: %s)", field.usage, field.env, field.key))
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, options, err
+	}
+	if flags.NArg() > 0 {
+		return nil, options, fmt.Errorf("unexpected arguments %q, every setting is a flag", flags.Args())
+	}
+
+	file := options.File
+	if file == "" {
+		file, _ = lookupEnv(fileEnv)
+	}
+	if file != "" {
+		if err := decodeFile(file, cfg); err != nil {
+			return nil, options, err
+		}
+		options.File = file
+	}
+
+	for _, field := range fields {
+		value, ok := lookupEnv(field.env)
+		if !ok {
+			continue
+		}
+		if err := field.set(value); err != nil {
+			return nil, options, fmt.Errorf("invalid %s %q: %s", field.env, value, err)
+		}
+	}
+
+	//every setting has a single flag, and a flag given twice keeps its last value
+	var flagErr error
+	flags.Visit(func(f *flag.Flag) {
+		field, ok := byFlag[f.Name]
+		if !ok || flagErr != nil {
+			return
+		}
+		if err := field.set(values[f.Name].value); err != nil {
+			flagErr = fmt.Errorf("invalid --%s %q: %s", f.Name, values[f.Name].value, err)
+		}
+	})
+	if flagErr != nil {
+		return nil, options, flagErr
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, options, err
+	}
+	return cfg, options, nil
+}
+
+//Write writes the config as YAML, which can be read back as a config file, with the secrets redacted
+func Write(w io.Writer, cfg *models.Config) error {
+	printed := *cfg
+	for _, field := range settings(&printed) {
+		if field.secret && field.value.String() != "" {
+			field.value.SetString(redacted)
+		}
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&printed); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+//decodeFile applies the settings of the config file to cfg, failing on the keys that are not settings
+func decodeFile(path string, cfg *models.Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("could not read the config file: %s", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = decodeJSON(data, cfg)
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(cfg)
+		//an empty file leaves every setting as it is
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+	case ".toml":
+		var values map[string]interface{}
+		err = toml.Unmarshal(data, &values)
+		if err == nil {
+			//the TOML values are all JSON values, so they are decoded as the JSON config would be
+			data, err = json.Marshal(values)
+		}
+		if err == nil {
+			err = decodeJSON(data, cfg)
+		}
+	default:
+		return fmt.Errorf("unknown format of the config file %s, expected one of: .json, .yaml, .yml, .toml", path)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid config file %s: %s", path, err)
+	}
+	return nil
+}
+
+func decodeJSON(data []byte, cfg *models.Config) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(cfg)
+}
+
+//setting is a field of the config that is set by an env var and a flag
+type setting struct {
+	//key is the path of the field in the config file, eg: server.read_timeout
+	key    string
+	env    string
+	usage  string
+	secret bool
+	value  reflect.Value
+}
+
+//settings returns the fields of cfg that have an env var, so they are set on cfg itself
+func settings(cfg *models.Config) []setting {
+	return structSettings(reflect.ValueOf(cfg).Elem(), "")
+}
+
+func structSettings(v reflect.Value, prefix string) []setting {
+	fields := make([]setting, 0)
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		key := prefix + strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if env := field.Tag.Get("env"); env != "" {
+			fields = append(fields, setting{
+				key:    key,
+				env:    env,
+				usage:  field.Tag.Get("usage"),
+				secret: field.Tag.Get("secret") == "true",
+				value:  v.Field(i),
+			})
+		} else if field.Type.Kind() == reflect.Struct {
+			fields = append(fields, structSettings(v.Field(i), key+".")...)
+		}
+	}
+	return fields
+}
+
+//flag returns the name of the flag of the setting, which is the one of its env var, eg: --http-read-timeout
+func (s setting) flag() string {
+	return strings.ToLower(strings.ReplaceAll(s.env, "_", "-"))
+}
+
+//set parses the value as the env vars and the flags are written: the lists are comma separated,
+//and the optional settings are left out when they are empty
+func (s setting) set(value string) error {
+	return setValue(s.value, value)
+}
+
+func setValue(v reflect.Value, value string) error {
+	if unmarshaler, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(value))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return errors.New("expected true or false")
+		}
+		v.SetBool(parsed)
+	case reflect.Int, reflect.Int64:
+		parsed, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return errors.New("expected an integer")
+		}
+		v.SetInt(parsed)
+	case reflect.Float64:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return errors.New("expected a number")
+		}
+		v.SetFloat(parsed)
+	case reflect.Slice:
+		values := make([]string, 0)
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+		v.Set(reflect.ValueOf(values))
+	case reflect.Ptr:
+		if strings.TrimSpace(value) == "" {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		parsed := reflect.New(v.Type().Elem())
+		if err := setValue(parsed.Elem(), value); err != nil {
+			return err
+		}
+		v.Set(parsed)
+	default:
+		return fmt.Errorf("settings of type %s are not supported", v.Type())
+	}
+	return nil
+}
+
+//format returns the value of the setting as it would be written in its env var
+func (s setting) format() string {
+	v := s.value
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if marshaler, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, _ := marshaler.MarshalText()
+		return string(text)
+	}
+	if values, ok := v.Interface().([]string); ok {
+		return strings.Join(values, ",")
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+//flagValue keeps the value of a flag, so it is only applied once the config file and the environment are
+type flagValue struct {
+	value  string
+	isBool bool
+}
+
+func (f *flagValue) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.value
+}
+
+func (f *flagValue) Set(value string) error {
+	f.value = value
+	return nil
+}
+
+func (f *flagValue) IsBoolFlag() bool {
+	return f.isBool
+}
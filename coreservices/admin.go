@@ -0,0 +1,349 @@
+package coreservices
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+func (bhs *BasicService) HandleDeleteUser(ctx context.Context, board string, userId string) (*models.User, error) {
+	id, err := bhs.adminUserID(ctx, board, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	//a hidden user is only in the frozen users, with her/his score
+	user, err := bhs.Core.StoreService.GetUserById(ctx, board, id)
+	if err == sql.ErrNoRows {
+		frozen, ok, err := bhs.frozenUser(ctx, board, id)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, userNotFoundError(id)
+		}
+		user = &models.User{UserID: id, Score: frozen.Score}
+	} else if err != nil {
+		return nil, err
+	}
+
+	err = bhs.applyAdminMutation(ctx, board, models.Mutation{Type: models.MutationDeleteUser, Board: board, UserID: id})
+	if err != nil {
+		return nil, err
+	}
+	bhs.recordAdminHistory(ctx, board, models.SourceAdminDeleteUser, []models.ScoreChange{{UserID: id, PreviousScore: user.Score}})
+
+	return user, nil
+}
+
+func (bhs *BasicService) HandleResetUser(ctx context.Context, board string, userId string) (*models.User, error) {
+	id, err := bhs.adminUserID(ctx, board, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := bhs.rankedUser(ctx, board, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = bhs.applyAdminMutation(ctx, board, models.Mutation{Type: models.MutationResetUser, Board: board, UserID: id})
+	if err != nil {
+		return nil, err
+	}
+	bhs.recordAdminHistory(ctx, board, models.SourceAdminResetUser, []models.ScoreChange{{UserID: id, PreviousScore: user.Score}})
+
+	return &models.User{UserID: id, Score: 0}, nil
+}
+
+func (bhs *BasicService) HandleFreezeUser(ctx context.Context, board string, userId string, request *models.FreezeUserRequest) (*models.FrozenUser, error) {
+	mode, err := models.ParseFreezeMode(request.Mode)
+	if err != nil {
+		return nil, models.NewValidationError(models.CodeInvalidFreezeMode, "The only freeze modes accepted are: keep and hide.")
+	}
+
+	id, err := bhs.adminUserID(ctx, board, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, frozen, err := bhs.frozenUser(ctx, board, id)
+	if err != nil {
+		return nil, err
+	}
+	if frozen {
+		return nil, userFrozenError(id)
+	}
+	user, err := bhs.rankedUser(ctx, board, id)
+	if err != nil {
+		return nil, err
+	}
+
+	hidden := mode == models.FreezeHide
+	err = bhs.applyAdminMutation(ctx, board, models.Mutation{Type: models.MutationFreezeUser, Board: board, UserID: id, Hidden: hidden})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.FrozenUser{UserID: id, Hidden: hidden}
+	if hidden {
+		response.Score = user.Score
+	}
+	return response, nil
+}
+
+func (bhs *BasicService) HandleUnfreezeUser(ctx context.Context, board string, userId string) (*models.FrozenUser, error) {
+	id, err := bhs.adminUserID(ctx, board, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	frozen, ok, err := bhs.frozenUser(ctx, board, id)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, models.NewNotFoundError(models.CodeUserNotFrozen, "User %d is not frozen.", id)
+	}
+
+	err = bhs.applyAdminMutation(ctx, board, models.Mutation{Type: models.MutationUnfreezeUser, Board: board, UserID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return frozen, nil
+}
+
+func (bhs *BasicService) HandleGetFrozenUsers(ctx context.Context, board string) (*models.GetFrozenUsersResponse, error) {
+	err := bhs.checkLeaderboard(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+
+	frozen, err := bhs.Core.StoreService.GetFrozenUsers(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GetFrozenUsersResponse{Frozen: frozen}, nil
+}
+
+func (bhs *BasicService) HandleMergeUsers(ctx context.Context, board string, userId string, request *models.MergeUsersRequest) (*models.User, error) {
+	strategy, err := models.ParseMergeStrategy(request.Strategy)
+	if err != nil {
+		return nil, models.NewValidationError(models.CodeInvalidMerge, "The only merge strategies accepted are: max and sum.")
+	}
+
+	id, err := bhs.adminUserID(ctx, board, userId)
+	if err != nil {
+		return nil, err
+	}
+	if request.Into == nil {
+		return nil, models.NewValidationError(models.CodeInvalidMerge, "The user to merge into must be included.")
+	}
+	into := *request.Into
+	if into == id {
+		return nil, models.NewValidationError(models.CodeInvalidMerge, "A user can not be merged into herself/himself.")
+	}
+
+	//the score of a frozen user must not change, nor be moved to another user
+	frozen, err := bhs.frozenUsers(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+	for _, frozenId := range []int{id, into} {
+		if frozen[frozenId] {
+			return nil, userFrozenError(frozenId)
+		}
+	}
+	_, err = bhs.rankedUser(ctx, board, id)
+	if err != nil {
+		return nil, err
+	}
+	//the user merged into may not exist yet, when she/he is only renamed
+	previous, err := bhs.Core.StoreService.GetUserById(ctx, board, into)
+	if err == sql.ErrNoRows {
+		previous, err = &models.User{UserID: into}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	mutation := models.Mutation{Type: models.MutationMergeUsers, Board: board, UserID: id, Into: into, Strategy: strategy}
+	err = bhs.applyAdminMutation(ctx, board, mutation)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := bhs.Core.StoreService.GetUserById(ctx, board, into)
+	if err != nil {
+		return nil, err
+	}
+	bhs.mergeHistory(ctx, board, id, into)
+	bhs.recordAdminHistory(ctx, board, models.SourceAdminMergeUsers, []models.ScoreChange{{UserID: into, PreviousScore: previous.Score, Score: user.Score}})
+
+	return user, nil
+}
+
+func (bhs *BasicService) HandleResetLeaderboard(ctx context.Context, board string) (*models.ResetLeaderboardResponse, error) {
+	err := bhs.checkLeaderboard(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed int
+	var changes []models.ScoreChange
+	mutation := models.Mutation{Type: models.MutationResetBoard, Board: board}
+	err = bhs.logAdminMutation(ctx, &mutation, func() error {
+		removed, err = bhs.Core.StoreService.CountUsers(ctx, board)
+		if err != nil {
+			return err
+		}
+		if bhs.Core.History != nil {
+			changes, err = bhs.resetChanges(ctx, board, removed)
+			if err != nil {
+				return err
+			}
+		}
+		return applyAdminMutation(ctx, bhs.Core.StoreService, mutation)
+	})
+	if err != nil {
+		return nil, err
+	}
+	bhs.publish(board)
+	bhs.recordAdminHistory(ctx, board, models.SourceAdminResetLeaderboard, changes)
+
+	return &models.ResetLeaderboardResponse{Name: board, Removed: removed}, nil
+}
+
+//resetChanges returns the changes of the scores of the count users of board and of its hidden users
+//that resetting it brings to 0
+func (bhs *BasicService) resetChanges(ctx context.Context, board string, count int) ([]models.ScoreChange, error) {
+	ranking, err := bhs.Core.StoreService.GetUsers(ctx, board, count)
+	if err != nil {
+		return nil, err
+	}
+	frozen, err := bhs.Core.StoreService.GetFrozenUsers(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]models.ScoreChange, 0, len(ranking)+len(frozen))
+	for _, user := range ranking {
+		changes = append(changes, models.ScoreChange{UserID: user.UserID, PreviousScore: user.Score})
+	}
+	for _, user := range frozen {
+		if user.Hidden && user.Score != 0 {
+			changes = append(changes, models.ScoreChange{UserID: user.UserID, PreviousScore: user.Score})
+		}
+	}
+	return changes, nil
+}
+
+//recordAdminHistory keeps the changes of an admin request in the history of the core, as the new score
+//of the users set by source
+func (bhs *BasicService) recordAdminHistory(ctx context.Context, board string, source string, changes []models.ScoreChange) {
+	if len(changes) == 0 {
+		return
+	}
+	submissions := make([]models.ScoreSubmission, len(changes))
+	for i, change := range changes {
+		submissions[i] = models.ScoreSubmission{UserID: change.UserID, Score: change.Score, Absolute: true}
+	}
+	bhs.recordHistory(ctx, board, source, submissions, changes)
+}
+
+//mergeHistory moves the history of the user from into the one of the user into, when there is a history.
+//The users are already merged by then, so a failure is only logged
+func (bhs *BasicService) mergeHistory(ctx context.Context, board string, from int, into int) {
+	if bhs.Core.History == nil {
+		return
+	}
+	if err := bhs.Core.History.MergeUsers(ctx, board, from, into); err != nil {
+		bhs.Core.GetLogger().Error(ctx, "error while merging the history", models.LogFields{"board": board, "error": err.Error()})
+	}
+}
+
+//applyAdminMutation applies the mutation to board and to the boards of all its periods, past and current,
+//and notifies the streams of board
+func (bhs *BasicService) applyAdminMutation(ctx context.Context, board string, mutation models.Mutation) error {
+	err := bhs.logAdminMutation(ctx, &mutation, func() error {
+		return applyAdminMutation(ctx, bhs.Core.StoreService, mutation)
+	})
+	if err != nil {
+		return err
+	}
+	bhs.publish(board)
+	return nil
+}
+
+//logAdminMutation sets the boards of the periods of the mutation, and then logs and applies it
+func (bhs *BasicService) logAdminMutation(ctx context.Context, mutation *models.Mutation, apply func() error) error {
+	boards, err := bhs.Core.StoreService.GetLeaderboards(ctx)
+	if err != nil {
+		return err
+	}
+	mutation.Periods = periodBoardsOf(boards, mutation.Board, "")
+	if len(mutation.Periods) == 0 {
+		mutation.Periods = nil
+	}
+	return bhs.logMutation(ctx, *mutation, apply)
+}
+
+//adminUserID parses the id of the user of an admin request, checking that the leaderboard exists
+func (bhs *BasicService) adminUserID(ctx context.Context, board string, userId string) (int, error) {
+	id, err := strconv.Atoi(userId)
+	if err != nil {
+		return 0, models.NewValidationError(models.CodeInvalidUserID, "User_Id must be an integer.")
+	}
+	if err := bhs.checkLeaderboard(ctx, board); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+//rankedUser returns the user of board, who must be in its ranking
+func (bhs *BasicService) rankedUser(ctx context.Context, board string, id int) (*models.User, error) {
+	user, err := bhs.Core.StoreService.GetUserById(ctx, board, id)
+	if err == sql.ErrNoRows {
+		return nil, userNotFoundError(id)
+	}
+	return user, err
+}
+
+//frozenUser returns how the user of board is frozen, and whether she/he is
+func (bhs *BasicService) frozenUser(ctx context.Context, board string, id int) (*models.FrozenUser, bool, error) {
+	frozen, err := bhs.Core.StoreService.GetFrozenUsers(ctx, board)
+	if err != nil {
+		return nil, false, err
+	}
+	for i := range frozen {
+		if frozen[i].UserID == id {
+			return &frozen[i], true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+//frozenUsers returns the ids of the frozen users of board
+func (bhs *BasicService) frozenUsers(ctx context.Context, board string) (map[int]bool, error) {
+	frozen, err := bhs.Core.StoreService.GetFrozenUsers(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[int]bool, len(frozen))
+	for _, user := range frozen {
+		ids[user.UserID] = true
+	}
+	return ids, nil
+}
+
+func userNotFoundError(id int) error {
+	return models.NewNotFoundError(models.CodeUserNotFound, "User %d not found.", id)
+}
+
+func userFrozenError(id int) error {
+	return models.NewConflictError(models.CodeUserFrozen, "User %d is frozen.", id)
+}
@@ -0,0 +1,329 @@
+package coreservices
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+//newAdminService returns a service that ranks the daily and weekly periods, where users 1, 2 and 3
+//submitted 30, 20 and 10 points today
+func newAdminService(t *testing.T) *BasicService {
+	service, _ := newPeriodsService(t, time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC))
+	submitTestScore(t, service, 1, &models.SubmitScoreRequest{Score: "+30"})
+	submitTestScore(t, service, 2, &models.SubmitScoreRequest{Score: "+20"})
+	submitTestScore(t, service, 3, &models.SubmitScoreRequest{Score: "+10"})
+	return service
+}
+
+//adminTestRankings returns the ranking of the default leaderboard and the one of its current day
+func adminTestRankings(t *testing.T, service *BasicService) ([]models.Ranking, []models.Ranking) {
+	ctx := context.Background()
+	ranking, err := service.Core.StoreService.GetUsers(ctx, models.DefaultLeaderboard, 10)
+	assert.NoError(t, err)
+	daily, err := service.Core.StoreService.GetUsers(ctx, models.PeriodBoard(models.DefaultLeaderboard, models.PeriodDaily, "2026-10-16"), 10)
+	assert.NoError(t, err)
+	return ranking, daily
+}
+
+func TestBasicService_HandleDeleteUser(t *testing.T) {
+	cases := []struct {
+		description      string
+		userId           string
+		expectedResponse *models.User
+		expectedError    error
+		expectedRanking  []models.Ranking
+	}{
+		{
+			description:      "should delete the user from the leaderboard and its periods",
+			userId:           "2",
+			expectedResponse: &models.User{UserID: 2, Score: 20},
+			expectedRanking: []models.Ranking{
+				{Position: 1, UserID: 1, Score: 30},
+				{Position: 2, UserID: 3, Score: 10},
+			},
+		},
+		{
+			description:   "should not delete a missing user",
+			userId:        "9",
+			expectedError: models.NewNotFoundError(models.CodeUserNotFound, "User 9 not found."),
+		},
+		{
+			description:   "should return an error when the user id is not an integer",
+			userId:        "abc",
+			expectedError: models.NewValidationError(models.CodeInvalidUserID, "User_Id must be an integer."),
+		},
+	}
+	for _, tc := range cases {
+		service := newAdminService(t)
+		before, _ := adminTestRankings(t, service)
+
+		res, err := service.HandleDeleteUser(context.Background(), models.DefaultLeaderboard, tc.userId)
+		assert.Equal(t, tc.expectedResponse, res, tc.description)
+		assert.Equal(t, tc.expectedError, err, tc.description)
+
+		ranking, daily := adminTestRankings(t, service)
+		if tc.expectedRanking == nil {
+			tc.expectedRanking = before
+		}
+		assert.Equal(t, tc.expectedRanking, ranking, tc.description)
+		assert.Equal(t, tc.expectedRanking, daily, tc.description)
+	}
+}
+
+func TestBasicService_HandleResetUser(t *testing.T) {
+	service := newAdminService(t)
+	ctx := context.Background()
+
+	res, err := service.HandleResetUser(ctx, models.DefaultLeaderboard, "1")
+	assert.NoError(t, err, "should reset the score of the user")
+	assert.Equal(t, &models.User{UserID: 1, Score: 0}, res, "should reset the score of the user")
+
+	expectedRanking := []models.Ranking{
+		{Position: 1, UserID: 2, Score: 20},
+		{Position: 2, UserID: 3, Score: 10},
+		{Position: 3, UserID: 1, Score: 0},
+	}
+	ranking, daily := adminTestRankings(t, service)
+	assert.Equal(t, expectedRanking, ranking, "should reset the score in the leaderboard")
+	assert.Equal(t, expectedRanking, daily, "should reset the score in the periods")
+
+	_, err = service.HandleResetUser(ctx, models.DefaultLeaderboard, "9")
+	assert.Equal(t, models.NewNotFoundError(models.CodeUserNotFound, "User 9 not found."), err, "should not reset a missing user")
+}
+
+func TestBasicService_HandleFreezeUser(t *testing.T) {
+	service := newAdminService(t)
+	ctx := context.Background()
+
+	_, err := service.HandleFreezeUser(ctx, models.DefaultLeaderboard, "1", &models.FreezeUserRequest{Mode: "ban"})
+	assert.Equal(t, models.NewValidationError(models.CodeInvalidFreezeMode, "The only freeze modes accepted are: keep and hide."), err, "should not freeze with an unknown mode")
+
+	res, err := service.HandleFreezeUser(ctx, models.DefaultLeaderboard, "1", &models.FreezeUserRequest{Mode: "hide"})
+	assert.NoError(t, err, "should hide a user")
+	assert.Equal(t, &models.FrozenUser{UserID: 1, Hidden: true, Score: 30}, res, "should hide a user with her/his score")
+	res, err = service.HandleFreezeUser(ctx, models.DefaultLeaderboard, "3", &models.FreezeUserRequest{})
+	assert.NoError(t, err, "should keep a frozen user in the ranking by default")
+	assert.Equal(t, &models.FrozenUser{UserID: 3}, res, "should keep a frozen user in the ranking by default")
+
+	_, err = service.HandleFreezeUser(ctx, models.DefaultLeaderboard, "1", &models.FreezeUserRequest{})
+	assert.Equal(t, models.NewConflictError(models.CodeUserFrozen, "User 1 is frozen."), err, "should not freeze a user twice")
+	_, err = service.HandleSubmitScore(ctx, models.DefaultLeaderboard, &models.SubmitScoreRequest{Score: "+5"}, "3")
+	assert.Equal(t, models.NewConflictError(models.CodeUserFrozen, "User 3 is frozen."), err, "should reject the scores of a frozen user")
+
+	expectedRanking := []models.Ranking{
+		{Position: 1, UserID: 2, Score: 20},
+		{Position: 2, UserID: 3, Score: 10},
+	}
+	ranking, daily := adminTestRankings(t, service)
+	assert.Equal(t, expectedRanking, ranking, "should take the hidden user out of the leaderboard")
+	assert.Equal(t, expectedRanking, daily, "should take the hidden user out of the periods")
+
+	frozen, err := service.HandleGetFrozenUsers(ctx, models.DefaultLeaderboard)
+	assert.NoError(t, err, "should get the frozen users")
+	assert.Equal(t, &models.GetFrozenUsersResponse{Frozen: []models.FrozenUser{{UserID: 1, Hidden: true, Score: 30}, {UserID: 3}}}, frozen, "should get the frozen users")
+
+	res, err = service.HandleUnfreezeUser(ctx, models.DefaultLeaderboard, "1")
+	assert.NoError(t, err, "should unfreeze a hidden user")
+	assert.Equal(t, &models.FrozenUser{UserID: 1, Hidden: true, Score: 30}, res, "should return how the user was frozen")
+	_, err = service.HandleUnfreezeUser(ctx, models.DefaultLeaderboard, "1")
+	assert.Equal(t, models.NewNotFoundError(models.CodeUserNotFrozen, "User 1 is not frozen."), err, "should not unfreeze a user twice")
+
+	expectedRanking = []models.Ranking{
+		{Position: 1, UserID: 1, Score: 30},
+		{Position: 2, UserID: 2, Score: 20},
+		{Position: 3, UserID: 3, Score: 10},
+	}
+	ranking, daily = adminTestRankings(t, service)
+	assert.Equal(t, expectedRanking, ranking, "should put the hidden user back in the leaderboard")
+	assert.Equal(t, expectedRanking, daily, "should put the hidden user back in the periods")
+}
+
+func TestBasicService_HandleMergeUsers(t *testing.T) {
+	cases := []struct {
+		description      string
+		userId           string
+		request          *models.MergeUsersRequest
+		frozen           string
+		expectedResponse *models.User
+		expectedError    error
+		expectedRanking  []models.Ranking
+	}{
+		{
+			description:      "should keep the highest score by default",
+			userId:           "3",
+			request:          &models.MergeUsersRequest{Into: &[]int{1}[0]},
+			expectedResponse: &models.User{UserID: 1, Score: 30},
+			expectedRanking: []models.Ranking{
+				{Position: 1, UserID: 1, Score: 30},
+				{Position: 2, UserID: 2, Score: 20},
+			},
+		},
+		{
+			description:      "should add the scores",
+			userId:           "3",
+			request:          &models.MergeUsersRequest{Into: &[]int{2}[0], Strategy: "sum"},
+			expectedResponse: &models.User{UserID: 2, Score: 30},
+			expectedRanking: []models.Ranking{
+				{Position: 1, UserID: 1, Score: 30},
+				{Position: 2, UserID: 2, Score: 30},
+			},
+		},
+		{
+			description:      "should rename a user into a missing one",
+			userId:           "1",
+			request:          &models.MergeUsersRequest{Into: &[]int{7}[0]},
+			expectedResponse: &models.User{UserID: 7, Score: 30},
+			expectedRanking: []models.Ranking{
+				{Position: 1, UserID: 7, Score: 30},
+				{Position: 2, UserID: 2, Score: 20},
+				{Position: 3, UserID: 3, Score: 10},
+			},
+		},
+		{
+			description:   "should not merge with an unknown strategy",
+			userId:        "3",
+			request:       &models.MergeUsersRequest{Into: &[]int{1}[0], Strategy: "min"},
+			expectedError: models.NewValidationError(models.CodeInvalidMerge, "The only merge strategies accepted are: max and sum."),
+		},
+		{
+			description:   "should not merge without the user to merge into",
+			userId:        "3",
+			request:       &models.MergeUsersRequest{},
+			expectedError: models.NewValidationError(models.CodeInvalidMerge, "The user to merge into must be included."),
+		},
+		{
+			description:   "should not merge a user into herself/himself",
+			userId:        "3",
+			request:       &models.MergeUsersRequest{Into: &[]int{3}[0]},
+			expectedError: models.NewValidationError(models.CodeInvalidMerge, "A user can not be merged into herself/himself."),
+		},
+		{
+			description:   "should not merge into a frozen user",
+			userId:        "3",
+			request:       &models.MergeUsersRequest{Into: &[]int{1}[0]},
+			frozen:        "1",
+			expectedError: models.NewConflictError(models.CodeUserFrozen, "User 1 is frozen."),
+		},
+		{
+			description:   "should not merge a missing user",
+			userId:        "9",
+			request:       &models.MergeUsersRequest{Into: &[]int{1}[0]},
+			expectedError: models.NewNotFoundError(models.CodeUserNotFound, "User 9 not found."),
+		},
+	}
+	for _, tc := range cases {
+		service := newAdminService(t)
+		ctx := context.Background()
+		if tc.frozen != "" {
+			_, err := service.HandleFreezeUser(ctx, models.DefaultLeaderboard, tc.frozen, &models.FreezeUserRequest{})
+			assert.NoError(t, err, tc.description)
+		}
+		before, _ := adminTestRankings(t, service)
+
+		res, err := service.HandleMergeUsers(ctx, models.DefaultLeaderboard, tc.userId, tc.request)
+		assert.Equal(t, tc.expectedResponse, res, tc.description)
+		assert.Equal(t, tc.expectedError, err, tc.description)
+
+		ranking, daily := adminTestRankings(t, service)
+		if tc.expectedRanking == nil {
+			tc.expectedRanking = before
+		}
+		assert.Equal(t, tc.expectedRanking, ranking, tc.description)
+		assert.Equal(t, tc.expectedRanking, daily, tc.description)
+	}
+}
+
+func TestBasicService_HandleResetLeaderboard(t *testing.T) {
+	service := newAdminService(t)
+	ctx := context.Background()
+
+	res, err := service.HandleResetLeaderboard(ctx, models.DefaultLeaderboard)
+	assert.NoError(t, err, "should reset the leaderboard")
+	assert.Equal(t, &models.ResetLeaderboardResponse{Name: models.DefaultLeaderboard, Removed: 3}, res, "should return how many users were removed")
+
+	ranking, daily := adminTestRankings(t, service)
+	assert.Empty(t, ranking, "should empty the leaderboard")
+	assert.Empty(t, daily, "should empty the periods")
+
+	_, err = service.HandleResetLeaderboard(ctx, "weekly")
+	assert.Equal(t, models.NewNotFoundError(models.CodeLeaderboardNotFound, "Leaderboard weekly not found."), err, "should not reset a missing leaderboard")
+}
+
+func TestBasicService_AdminHistory(t *testing.T) {
+	//the history starts with a submission of user 3
+	submitted := models.ScoreHistoryEntry{UserID: 3, Score: 10, Delta: &[]int{10}[0], Source: "game-3"}
+	cases := []struct {
+		description     string
+		apply           func(ctx context.Context, service *BasicService) error
+		expectedEntries map[int][]models.ScoreHistoryEntry
+	}{
+		{
+			description: "should record the reset of a user",
+			apply: func(ctx context.Context, service *BasicService) error {
+				_, err := service.HandleResetUser(ctx, models.DefaultLeaderboard, "1")
+				return err
+			},
+			expectedEntries: map[int][]models.ScoreHistoryEntry{
+				1: {{UserID: 1, PreviousScore: 30, Score: 0, Total: &[]int{0}[0], Source: models.SourceAdminResetUser}},
+				3: {submitted},
+			},
+		},
+		{
+			description: "should record the deletion of a user",
+			apply: func(ctx context.Context, service *BasicService) error {
+				_, err := service.HandleDeleteUser(ctx, models.DefaultLeaderboard, "2")
+				return err
+			},
+			expectedEntries: map[int][]models.ScoreHistoryEntry{
+				2: {{UserID: 2, PreviousScore: 20, Score: 0, Total: &[]int{0}[0], Source: models.SourceAdminDeleteUser}},
+				3: {submitted},
+			},
+		},
+		{
+			description: "should move the history of the merged user and record the merge",
+			apply: func(ctx context.Context, service *BasicService) error {
+				_, err := service.HandleMergeUsers(ctx, models.DefaultLeaderboard, "3", &models.MergeUsersRequest{Into: &[]int{1}[0], Strategy: "sum"})
+				return err
+			},
+			expectedEntries: map[int][]models.ScoreHistoryEntry{
+				1: {{UserID: 1, PreviousScore: 30, Score: 40, Total: &[]int{40}[0], Source: models.SourceAdminMergeUsers}, submitted},
+			},
+		},
+		{
+			description: "should record the reset of the leaderboard for each user",
+			apply: func(ctx context.Context, service *BasicService) error {
+				_, err := service.HandleResetLeaderboard(ctx, models.DefaultLeaderboard)
+				return err
+			},
+			expectedEntries: map[int][]models.ScoreHistoryEntry{
+				1: {{UserID: 1, PreviousScore: 30, Score: 0, Total: &[]int{0}[0], Source: models.SourceAdminResetLeaderboard}},
+				2: {{UserID: 2, PreviousScore: 20, Score: 0, Total: &[]int{0}[0], Source: models.SourceAdminResetLeaderboard}},
+				3: {{UserID: 3, PreviousScore: 10, Score: 0, Total: &[]int{0}[0], Source: models.SourceAdminResetLeaderboard}, submitted},
+			},
+		},
+	}
+	for _, tc := range cases {
+		ctx := context.Background()
+		service := newAdminService(t)
+		history := NewMemoryHistoryService(service.Core, 0, 0)
+		assert.NoError(t, history.Record(ctx, models.DefaultLeaderboard, []models.ScoreHistoryEntry{submitted}), tc.description)
+
+		assert.NoError(t, tc.apply(ctx, service), tc.description)
+		for id := 1; id <= 3; id++ {
+			entries, _, err := history.GetHistory(ctx, models.DefaultLeaderboard, id, models.HistoryQuery{Limit: 10})
+			assert.NoError(t, err, tc.description)
+			for i := range entries {
+				entries[i].CreatedAt = time.Time{}
+			}
+			expected := tc.expectedEntries[id]
+			if expected == nil {
+				expected = []models.ScoreHistoryEntry{}
+			}
+			assert.Equal(t, expected, entries, tc.description)
+		}
+	}
+}
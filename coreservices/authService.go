@@ -0,0 +1,134 @@
+package coreservices
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+//maxNonceLength bounds the memory kept for each accepted request
+const maxNonceLength = 128
+
+//NewHMACAuthService - will return an AuthService that verifies the requests signed with HMAC-SHA256 by the keys.
+//Requests whose timestamp is more than maxSkew away from now are refused, and so are the nonces
+//already used while their timestamp is accepted.
+//It will also add it to the core
+func NewHMACAuthService(core *models.Core, keys []models.APIKey, maxSkew time.Duration) (models.AuthService, error) {
+	if maxSkew <= 0 {
+		return nil, fmt.Errorf("the maximum skew of the timestamps must be positive")
+	}
+	authService := HMACAuthService{
+		core:    core,
+		keys:    make(map[string]models.APIKey, len(keys)),
+		maxSkew: maxSkew,
+		now:     time.Now,
+		nonces:  make(map[string]time.Time),
+	}
+	for _, key := range keys {
+		if err := key.Validate(); err != nil {
+			return nil, err
+		}
+		if _, ok := authService.keys[key.ID]; ok {
+			return nil, fmt.Errorf("the API key %s is repeated", key.ID)
+		}
+		authService.keys[key.ID] = key
+	}
+	core.Auth = &authService
+	return &authService, nil
+}
+
+//LoadAPIKeys reads the keys from a JSON file holding a list of keys, eg:
+//[{"id": "game-server", "secret": "...", "scope": "write"}]
+func LoadAPIKeys(path string) ([]models.APIKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var keys []models.APIKey
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("invalid API keys file %s: %w", path, err)
+	}
+	return keys, nil
+}
+
+//SignRequest returns the hex HMAC-SHA256 of the payload of the request, which clients send as its signature
+func SignRequest(secret string, request *models.SignedRequest) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(request.Payload())
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type HMACAuthService struct {
+	core    *models.Core
+	keys    map[string]models.APIKey
+	maxSkew time.Duration
+	now     func() time.Time
+
+	mu sync.Mutex
+	//nonces holds when each used nonce of each key can be forgotten, since its timestamp is no longer accepted
+	nonces    map[string]time.Time
+	nextPrune time.Time
+}
+
+func (a *HMACAuthService) Verify(ctx context.Context, request *models.SignedRequest) (*models.APIKey, error) {
+	if request.KeyID == "" || request.Timestamp == "" || request.Nonce == "" || request.Signature == "" {
+		return nil, models.NewUnauthorizedError(models.CodeMissingSignature, "Requests must be signed with the X-Api-Key, X-Timestamp, X-Nonce and X-Signature headers.")
+	}
+	if len(request.Nonce) > maxNonceLength {
+		return nil, models.NewUnauthorizedError(models.CodeInvalidSignature, "The nonce must have at most %d characters.", maxNonceLength)
+	}
+
+	//unknown keys and wrong signatures get the same error, so the ids of the keys can't be guessed
+	key, ok := a.keys[request.KeyID]
+	signature, err := hex.DecodeString(request.Signature)
+	if !ok || err != nil {
+		return nil, models.NewUnauthorizedError(models.CodeInvalidSignature, "The signature of the request is not valid.")
+	}
+	expected, _ := hex.DecodeString(SignRequest(key.Secret, request))
+	if !hmac.Equal(signature, expected) {
+		return nil, models.NewUnauthorizedError(models.CodeInvalidSignature, "The signature of the request is not valid.")
+	}
+
+	seconds, err := strconv.ParseInt(request.Timestamp, 10, 64)
+	if err != nil {
+		return nil, models.NewUnauthorizedError(models.CodeStaleRequest, "The timestamp must be in unix seconds.")
+	}
+	timestamp := time.Unix(seconds, 0)
+	now := a.now()
+	if timestamp.Before(now.Add(-a.maxSkew)) || timestamp.After(now.Add(a.maxSkew)) {
+		return nil, models.NewUnauthorizedError(models.CodeStaleRequest, "The timestamp of the request must be within %s of the time of the service.", a.maxSkew)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.prune(now)
+	nonce := key.ID + "\n" + request.Nonce
+	if _, used := a.nonces[nonce]; used {
+		return nil, models.NewUnauthorizedError(models.CodeReplayedRequest, "The nonce of the request was already used.")
+	}
+	a.nonces[nonce] = timestamp.Add(a.maxSkew)
+
+	return &key, nil
+}
+
+//prune forgets the nonces whose timestamps are no longer accepted, at most once per maxSkew
+func (a *HMACAuthService) prune(now time.Time) {
+	if now.Before(a.nextPrune) {
+		return
+	}
+	for nonce, expiry := range a.nonces {
+		if expiry.Before(now) {
+			delete(a.nonces, nonce)
+		}
+	}
+	a.nextPrune = now.Add(a.maxSkew)
+}
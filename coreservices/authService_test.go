@@ -0,0 +1,195 @@
+package coreservices
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHMACAuthService(t *testing.T) {
+	cases := []struct {
+		description   string
+		keys          []models.APIKey
+		maxSkew       time.Duration
+		expectedError bool
+	}{
+		{
+			description: "should return a new auth service",
+			keys:        []models.APIKey{{ID: "server", Secret: "s1", Scope: models.ScopeWrite}, {ID: "site", Secret: "s2", Scope: models.ScopeRead}},
+			maxSkew:     time.Minute,
+		},
+		{
+			description:   "should return error when a key has no secret",
+			keys:          []models.APIKey{{ID: "server", Scope: models.ScopeWrite}},
+			maxSkew:       time.Minute,
+			expectedError: true,
+		},
+		{
+			description:   "should return error when a key has an unknown scope",
+			keys:          []models.APIKey{{ID: "server", Secret: "s1", Scope: "owner"}},
+			maxSkew:       time.Minute,
+			expectedError: true,
+		},
+		{
+			description:   "should return error when a key is repeated",
+			keys:          []models.APIKey{{ID: "server", Secret: "s1", Scope: models.ScopeWrite}, {ID: "server", Secret: "s2", Scope: models.ScopeRead}},
+			maxSkew:       time.Minute,
+			expectedError: true,
+		},
+		{
+			description:   "should return error when the skew is not positive",
+			maxSkew:       0,
+			expectedError: true,
+		},
+	}
+	for _, tc := range cases {
+		core := &models.Core{}
+		authService, err := NewHMACAuthService(core, tc.keys, tc.maxSkew)
+		if tc.expectedError {
+			assert.Error(t, err, tc.description)
+			assert.Nil(t, core.Auth, tc.description)
+			continue
+		}
+		assert.NoError(t, err, tc.description)
+		assert.Equal(t, authService, core.Auth, tc.description)
+	}
+}
+
+func TestHMACAuthService_Verify(t *testing.T) {
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	key := models.APIKey{ID: "server", Secret: "secret", Scope: models.ScopeWrite}
+	//signed returns a request signed by the key at the time, with the nonce
+	signed := func(at time.Time, nonce string) *models.SignedRequest {
+		request := &models.SignedRequest{
+			KeyID:     key.ID,
+			Timestamp: strconv.FormatInt(at.Unix(), 10),
+			Nonce:     nonce,
+			Method:    "POST",
+			Path:      "/user/1/score",
+			Body:      []byte(`{"total": 100}`),
+		}
+		request.Signature = SignRequest(key.Secret, request)
+		return request
+	}
+
+	cases := []struct {
+		description   string
+		request       func() *models.SignedRequest
+		expectedError error
+	}{
+		{
+			description: "should accept a signed request",
+			request:     func() *models.SignedRequest { return signed(now, "n1") },
+		},
+		{
+			description: "should accept a request signed within the skew",
+			request:     func() *models.SignedRequest { return signed(now.Add(-4*time.Minute), "n2") },
+		},
+		{
+			description:   "should refuse a request without signature",
+			request:       func() *models.SignedRequest { return &models.SignedRequest{KeyID: key.ID, Method: "GET", Path: "/ranking"} },
+			expectedError: models.NewUnauthorizedError(models.CodeMissingSignature, "Requests must be signed with the X-Api-Key, X-Timestamp, X-Nonce and X-Signature headers."),
+		},
+		{
+			description: "should refuse a request of an unknown key",
+			request: func() *models.SignedRequest {
+				request := signed(now, "n3")
+				request.KeyID = "other"
+				return request
+			},
+			expectedError: models.NewUnauthorizedError(models.CodeInvalidSignature, "The signature of the request is not valid."),
+		},
+		{
+			description: "should refuse a request whose body changed",
+			request: func() *models.SignedRequest {
+				request := signed(now, "n4")
+				request.Body = []byte(`{"total": 1000000}`)
+				return request
+			},
+			expectedError: models.NewUnauthorizedError(models.CodeInvalidSignature, "The signature of the request is not valid."),
+		},
+		{
+			description: "should refuse a request whose signature is not hex",
+			request: func() *models.SignedRequest {
+				request := signed(now, "n5")
+				request.Signature = "not-hex"
+				return request
+			},
+			expectedError: models.NewUnauthorizedError(models.CodeInvalidSignature, "The signature of the request is not valid."),
+		},
+		{
+			description:   "should refuse a request signed too long ago",
+			request:       func() *models.SignedRequest { return signed(now.Add(-6*time.Minute), "n6") },
+			expectedError: models.NewUnauthorizedError(models.CodeStaleRequest, "The timestamp of the request must be within 5m0s of the time of the service."),
+		},
+		{
+			description:   "should refuse a request signed in the future",
+			request:       func() *models.SignedRequest { return signed(now.Add(6*time.Minute), "n7") },
+			expectedError: models.NewUnauthorizedError(models.CodeStaleRequest, "The timestamp of the request must be within 5m0s of the time of the service."),
+		},
+		{
+			description:   "should refuse a replayed request",
+			request:       func() *models.SignedRequest { return signed(now, "n1") },
+			expectedError: models.NewUnauthorizedError(models.CodeReplayedRequest, "The nonce of the request was already used."),
+		},
+	}
+
+	authService, err := NewHMACAuthService(&models.Core{}, []models.APIKey{key}, 5*time.Minute)
+	assert.NoError(t, err)
+	authService.(*HMACAuthService).now = func() time.Time { return now }
+	for _, tc := range cases {
+		result, err := authService.Verify(context.Background(), tc.request())
+		assert.Equal(t, tc.expectedError, err, tc.description)
+		if tc.expectedError == nil {
+			assert.Equal(t, &key, result, tc.description)
+		}
+	}
+}
+
+func TestHMACAuthService_ForgetsExpiredNonces(t *testing.T) {
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	authService, err := NewHMACAuthService(&models.Core{}, []models.APIKey{{ID: "site", Secret: "secret", Scope: models.ScopeRead}}, time.Minute)
+	assert.NoError(t, err)
+	hmacAuth := authService.(*HMACAuthService)
+	hmacAuth.now = func() time.Time { return now }
+
+	request := &models.SignedRequest{KeyID: "site", Timestamp: strconv.FormatInt(now.Unix(), 10), Nonce: "n1", Method: "GET", Path: "/ranking?type=top10"}
+	request.Signature = SignRequest("secret", request)
+	_, err = authService.Verify(context.Background(), request)
+	assert.NoError(t, err, "should accept the request")
+	assert.Len(t, hmacAuth.nonces, 1, "should keep the nonce while the timestamp is accepted")
+
+	now = now.Add(2 * time.Minute)
+	_, err = authService.Verify(context.Background(), request)
+	assert.Equal(t, models.NewUnauthorizedError(models.CodeStaleRequest, "The timestamp of the request must be within 1m0s of the time of the service."), err, "should refuse the stale request")
+
+	fresh := &models.SignedRequest{KeyID: "site", Timestamp: strconv.FormatInt(now.Unix(), 10), Nonce: "n2", Method: "GET", Path: "/ranking?type=top10"}
+	fresh.Signature = SignRequest("secret", fresh)
+	_, err = authService.Verify(context.Background(), fresh)
+	assert.NoError(t, err, "should accept a fresh request")
+	assert.Len(t, hmacAuth.nonces, 1, "should forget the nonce of the stale request")
+}
+
+func TestLoadAPIKeys(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "keys.json")
+	assert.NoError(t, os.WriteFile(valid, []byte(`[{"id": "server", "secret": "s1", "scope": "write"}]`), 0600))
+	invalid := filepath.Join(dir, "invalid.json")
+	assert.NoError(t, os.WriteFile(invalid, []byte(`{"id": "server"}`), 0600))
+
+	keys, err := LoadAPIKeys(valid)
+	assert.NoError(t, err, "should load the keys")
+	assert.Equal(t, []models.APIKey{{ID: "server", Secret: "s1", Scope: models.ScopeWrite}}, keys, "should load the keys")
+
+	_, err = LoadAPIKeys(invalid)
+	assert.Error(t, err, "should return error when the file is not a list of keys")
+
+	_, err = LoadAPIKeys(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err, "should return error when the file does not exist")
+}
@@ -0,0 +1,154 @@
+package coreservices
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+//concurrentSubmitters and submissionsPerSubmitter size the load on a single user
+const (
+	concurrentSubmitters    = 16
+	submissionsPerSubmitter = 25
+)
+
+//hammer runs submit from concurrentSubmitters goroutines, submissionsPerSubmitter times each
+func hammer(submit func(submitter, i int)) {
+	var wg sync.WaitGroup
+	for submitter := 0; submitter < concurrentSubmitters; submitter++ {
+		wg.Add(1)
+		go func(submitter int) {
+			defer wg.Done()
+			for i := 0; i < submissionsPerSubmitter; i++ {
+				submit(submitter, i)
+			}
+		}(submitter)
+	}
+	wg.Wait()
+}
+
+func TestConcurrency_RelativeScores(t *testing.T) {
+	for _, backend := range storeBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			ctx := context.Background()
+			core := &models.Core{}
+			store := backend.newStore(t, core)
+			service := NewCoreService(core)
+
+			var mu sync.Mutex
+			returned := make([]int, 0, concurrentSubmitters*submissionsPerSubmitter)
+			hammer(func(submitter, i int) {
+				response, err := service.HandleSubmitScore(ctx, models.DefaultLeaderboard, &models.SubmitScoreRequest{Score: "+1"}, "1")
+				if !assert.NoError(t, err) {
+					return
+				}
+				mu.Lock()
+				returned = append(returned, response.Score)
+				mu.Unlock()
+			})
+
+			total := concurrentSubmitters * submissionsPerSubmitter
+			user, err := store.GetUserById(ctx, models.DefaultLeaderboard, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, total, user.Score, "should apply every relative score exactly once")
+
+			count, err := store.CountUsers(ctx, models.DefaultLeaderboard)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, count, "should create the user only once")
+
+			//each submission must return its own total, without the updates of the others
+			sort.Ints(returned)
+			expected := make([]int, total)
+			for i := range expected {
+				expected[i] = i + 1
+			}
+			assert.Equal(t, expected, returned, "should return the score right after each submission")
+		})
+	}
+}
+
+func TestConcurrency_FirstSubmissions(t *testing.T) {
+	for _, backend := range storeBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			ctx := context.Background()
+			core := &models.Core{}
+			store := backend.newStore(t, core)
+			service := NewCoreService(core)
+
+			//every submitter submits the first score of the same new users at once
+			hammer(func(submitter, i int) {
+				_, err := service.HandleSubmitScore(ctx, models.DefaultLeaderboard, &models.SubmitScoreRequest{Score: "+2"}, strconv.Itoa(i))
+				assert.NoError(t, err)
+			})
+
+			count, err := store.CountUsers(ctx, models.DefaultLeaderboard)
+			assert.NoError(t, err)
+			assert.Equal(t, submissionsPerSubmitter, count, "should not create duplicated users")
+
+			ranking, err := store.GetUsers(ctx, models.DefaultLeaderboard, submissionsPerSubmitter+1)
+			assert.NoError(t, err)
+			for _, user := range ranking {
+				assert.Equal(t, 2*concurrentSubmitters, user.Score, "should sum the scores of user %d", user.UserID)
+			}
+		})
+	}
+}
+
+func TestConcurrency_Batches(t *testing.T) {
+	for _, backend := range storeBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			ctx := context.Background()
+			core := &models.Core{}
+			store := backend.newStore(t, core)
+			service := NewCoreService(core)
+
+			hammer(func(submitter, i int) {
+				_, err := service.HandleSubmitScores(ctx, models.DefaultLeaderboard, &models.SubmitScoresRequest{
+					Scores: []models.SubmitScoreRequest{
+						{UserID: 1, Score: "+1"},
+						{UserID: 2, Score: "+3"},
+						{UserID: 1, Score: "-2"},
+					},
+				})
+				assert.NoError(t, err)
+			})
+
+			total := concurrentSubmitters * submissionsPerSubmitter
+			for id, expected := range map[int]int{1: -total, 2: 3 * total} {
+				user, err := store.GetUserById(ctx, models.DefaultLeaderboard, id)
+				assert.NoError(t, err)
+				assert.Equal(t, expected, user.Score, "should apply every batch exactly once to user %d", id)
+			}
+		})
+	}
+}
+
+func TestConcurrency_PersistedScores(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, persistence := recoverTestStore(t, dir)
+	service := NewCoreService(&models.Core{StoreService: store, Persistence: persistence})
+
+	hammer(func(submitter, i int) {
+		request := &models.SubmitScoreRequest{Score: "+1"}
+		//absolute scores don't commute with relative ones, so the log must keep the order they were applied in
+		if i == submissionsPerSubmitter/2 {
+			request = &models.SubmitScoreRequest{Total: &[]int{submitter}[0]}
+		}
+		_, err := service.HandleSubmitScore(ctx, models.DefaultLeaderboard, request, "1")
+		assert.NoError(t, err)
+	})
+	expected, err := store.GetUserById(ctx, models.DefaultLeaderboard, 1)
+	assert.NoError(t, err)
+	assert.NoError(t, persistence.Close())
+
+	recovered, _ := recoverTestStore(t, dir)
+	user, err := recovered.GetUserById(ctx, models.DefaultLeaderboard, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, user, "should recover the same score that was applied")
+}
@@ -0,0 +1,33 @@
+package coreservices
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+//HandleGetReadiness checks every dependency the requests need, so the ones that failed are all reported at once.
+//The persistence is only checked when the store is persisted
+func (bhs *BasicService) HandleGetReadiness(ctx context.Context) (*models.ReadinessResponse, error) {
+	response := &models.ReadinessResponse{Ready: true, Checks: make(map[string]string)}
+	check := func(name string, err error) {
+		if err != nil {
+			response.Ready = false
+			response.Checks[name] = err.Error()
+			return
+		}
+		response.Checks[name] = models.CheckOK
+	}
+
+	if bhs.Core.StoreService == nil {
+		check("store", errors.New("the store is not connected"))
+	} else {
+		check("store", bhs.Core.StoreService.Ping(ctx))
+	}
+	if bhs.Core.Persistence != nil {
+		check("persistence", bhs.Core.Persistence.Ready())
+	}
+
+	return response, nil
+}
@@ -0,0 +1,74 @@
+package coreservices
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pedrocmart/leaderboard-service/mocks"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBasicService_HandleGetReadiness(t *testing.T) {
+	cases := []struct {
+		description      string
+		store            bool
+		pingError        error
+		persistence      bool
+		persistenceError error
+		expectedResponse *models.ReadinessResponse
+	}{
+		{
+			description:      "should be ready when the store can be reached",
+			store:            true,
+			expectedResponse: &models.ReadinessResponse{Ready: true, Checks: map[string]string{"store": models.CheckOK}},
+		},
+		{
+			description:      "should be ready when the store can be reached and the log was recovered",
+			store:            true,
+			persistence:      true,
+			expectedResponse: &models.ReadinessResponse{Ready: true, Checks: map[string]string{"store": models.CheckOK, "persistence": models.CheckOK}},
+		},
+		{
+			description:      "should not be ready when the store can't be reached",
+			store:            true,
+			pingError:        fmt.Errorf("mock-error"),
+			persistence:      true,
+			expectedResponse: &models.ReadinessResponse{Ready: false, Checks: map[string]string{"store": "mock-error", "persistence": models.CheckOK}},
+		},
+		{
+			description:      "should not be ready when the log is not open",
+			store:            true,
+			persistence:      true,
+			persistenceError: fmt.Errorf("the persistence log is not open"),
+			expectedResponse: &models.ReadinessResponse{Ready: false, Checks: map[string]string{"store": models.CheckOK, "persistence": "the persistence log is not open"}},
+		},
+		{
+			description:      "should not be ready without a store",
+			expectedResponse: &models.ReadinessResponse{Ready: false, Checks: map[string]string{"store": "the store is not connected"}},
+		},
+	}
+	for _, tc := range cases {
+		core := &models.Core{}
+		if tc.store {
+			core.StoreService = &mocks.StoreServiceMock{
+				PingFunc: func(ctx context.Context) error {
+					return tc.pingError
+				},
+			}
+		}
+		if tc.persistence {
+			core.Persistence = &mocks.PersistenceServiceMock{
+				ReadyFunc: func() error {
+					return tc.persistenceError
+				},
+			}
+		}
+		service := BasicService{Core: core}
+
+		response, err := service.HandleGetReadiness(context.Background())
+		assert.NoError(t, err, tc.description)
+		assert.Equal(t, tc.expectedResponse, response, tc.description)
+	}
+}
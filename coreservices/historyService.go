@@ -0,0 +1,163 @@
+package coreservices
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+//NewMemoryHistoryService - will return a HistoryService that keeps the history of the scores in memory.
+//Entries older than retention are dropped, and each user keeps at most maxEntries of them;
+//a retention or maxEntries that is not positive leaves that bound out.
+//It will also add it to the core
+func NewMemoryHistoryService(core *models.Core, retention time.Duration, maxEntries int) models.HistoryService {
+	historyService := MemoryHistoryService{
+		core:       core,
+		retention:  retention,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		boards:     make(map[string]map[int][]models.ScoreHistoryEntry),
+	}
+	core.History = &historyService
+	return &historyService
+}
+
+type MemoryHistoryService struct {
+	core       *models.Core
+	retention  time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu sync.RWMutex
+	//boards holds the entries of each user of each board, from the oldest to the most recent
+	boards map[string]map[int][]models.ScoreHistoryEntry
+}
+
+func (h *MemoryHistoryService) Record(ctx context.Context, board string, entries []models.ScoreHistoryEntry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	users, ok := h.boards[board]
+	if !ok {
+		users = make(map[int][]models.ScoreHistoryEntry)
+		h.boards[board] = users
+	}
+	//the entries are stamped under the lock, so the history of each user stays in time order
+	now := h.now()
+	cutoff := h.cutoff()
+	for _, entry := range entries {
+		entry.CreatedAt = now
+		users[entry.UserID] = h.bound(append(users[entry.UserID], entry), cutoff)
+	}
+
+	return nil
+}
+
+func (h *MemoryHistoryService) GetHistory(ctx context.Context, board string, id int, query models.HistoryQuery) ([]models.ScoreHistoryEntry, int, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	entries := h.boards[board][id]
+	cutoff := h.cutoff()
+	history := make([]models.ScoreHistoryEntry, 0)
+	total := 0
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if !cutoff.IsZero() && entry.CreatedAt.Before(cutoff) {
+			break
+		}
+		if !query.To.IsZero() && entry.CreatedAt.After(query.To) {
+			continue
+		}
+		if !query.From.IsZero() && entry.CreatedAt.Before(query.From) {
+			break
+		}
+		if total >= query.Offset && len(history) < query.Limit {
+			history = append(history, entry)
+		}
+		total++
+	}
+
+	return history, total, nil
+}
+
+func (h *MemoryHistoryService) DeleteLeaderboard(ctx context.Context, board string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.boards, board)
+	return nil
+}
+
+func (h *MemoryHistoryService) MergeUsers(ctx context.Context, board string, from int, into int) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	users, ok := h.boards[board]
+	if !ok || len(users[from]) == 0 {
+		return nil
+	}
+	//both histories are in time order, so they are merged as they are, the moved entries keeping their user id
+	moved, kept := users[from], users[into]
+	merged := make([]models.ScoreHistoryEntry, 0, len(moved)+len(kept))
+	for len(moved) > 0 && len(kept) > 0 {
+		if kept[0].CreatedAt.After(moved[0].CreatedAt) {
+			merged, moved = append(merged, moved[0]), moved[1:]
+		} else {
+			merged, kept = append(merged, kept[0]), kept[1:]
+		}
+	}
+	merged = append(append(merged, moved...), kept...)
+	delete(users, from)
+	users[into] = h.bound(merged, h.cutoff())
+
+	return nil
+}
+
+func (h *MemoryHistoryService) Prune(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	cutoff := h.cutoff()
+	for board, users := range h.boards {
+		for id, entries := range users {
+			entries = h.bound(entries, cutoff)
+			if len(entries) == 0 {
+				delete(users, id)
+				continue
+			}
+			users[id] = entries
+		}
+		if len(users) == 0 {
+			delete(h.boards, board)
+		}
+	}
+
+	return nil
+}
+
+//cutoff returns the time before which entries are dropped, or the zero time if they are kept forever
+func (h *MemoryHistoryService) cutoff() time.Time {
+	if h.retention <= 0 {
+		return time.Time{}
+	}
+	return h.now().Add(-h.retention)
+}
+
+//bound drops the entries created before cutoff and the oldest ones above maxEntries
+func (h *MemoryHistoryService) bound(entries []models.ScoreHistoryEntry, cutoff time.Time) []models.ScoreHistoryEntry {
+	first := 0
+	for first < len(entries) && !cutoff.IsZero() && entries[first].CreatedAt.Before(cutoff) {
+		first++
+	}
+	if h.maxEntries > 0 && len(entries)-first > h.maxEntries {
+		first = len(entries) - h.maxEntries
+	}
+	if first == 0 {
+		return entries
+	}
+	//copies the kept entries so the dropped ones can be collected
+	return append([]models.ScoreHistoryEntry(nil), entries[first:]...)
+}
@@ -0,0 +1,159 @@
+package coreservices
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+//newTestHistory returns a history whose clock is moved by the returned function
+func newTestHistory(retention time.Duration, maxEntries int) (*MemoryHistoryService, func(time.Duration)) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	history := NewMemoryHistoryService(&models.Core{}, retention, maxEntries).(*MemoryHistoryService)
+	history.now = func() time.Time { return now }
+	return history, func(d time.Duration) { now = now.Add(d) }
+}
+
+//recordScores records one entry per score for the user, a minute apart
+func recordScores(t *testing.T, history models.HistoryService, tick func(time.Duration), id int, scores ...int) {
+	for _, score := range scores {
+		err := history.Record(context.Background(), models.DefaultLeaderboard, []models.ScoreHistoryEntry{{UserID: id, Score: score}})
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when recording the history", err)
+		}
+		tick(time.Minute)
+	}
+}
+
+func TestMemoryHistoryService_GetHistory(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		description    string
+		query          models.HistoryQuery
+		expectedScores []int
+		expectedTotal  int
+	}{
+		{
+			description:    "should return the most recent entries first",
+			query:          models.HistoryQuery{Limit: 10},
+			expectedScores: []int{5, 4, 3, 2, 1},
+			expectedTotal:  5,
+		},
+		{
+			description:    "should return a page of the entries",
+			query:          models.HistoryQuery{Limit: 2, Offset: 1},
+			expectedScores: []int{4, 3},
+			expectedTotal:  5,
+		},
+		{
+			description:    "should return the entries in the time range",
+			query:          models.HistoryQuery{From: start.Add(time.Minute), To: start.Add(3 * time.Minute), Limit: 10},
+			expectedScores: []int{4, 3, 2},
+			expectedTotal:  3,
+		},
+		{
+			description:    "should return no entries after the end of the history",
+			query:          models.HistoryQuery{Limit: 10, Offset: 5},
+			expectedScores: []int{},
+			expectedTotal:  5,
+		},
+	}
+	for _, tc := range cases {
+		history, tick := newTestHistory(0, 0)
+		recordScores(t, history, tick, 1, 1, 2, 3, 4, 5)
+		recordScores(t, history, tick, 2, 100)
+
+		entries, total, err := history.GetHistory(context.Background(), models.DefaultLeaderboard, 1, tc.query)
+		assert.NoError(t, err, tc.description)
+		scores := make([]int, 0)
+		for _, entry := range entries {
+			scores = append(scores, entry.Score)
+		}
+		assert.Equal(t, tc.expectedScores, scores, tc.description)
+		assert.Equal(t, tc.expectedTotal, total, tc.description)
+	}
+}
+
+func TestMemoryHistoryService_Retention(t *testing.T) {
+	ctx := context.Background()
+	query := models.HistoryQuery{Limit: 10}
+
+	history, tick := newTestHistory(3*time.Minute, 0)
+	recordScores(t, history, tick, 1, 1, 2, 3, 4, 5)
+	entries, total, err := history.GetHistory(ctx, models.DefaultLeaderboard, 1, query)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total, "should drop the entries older than the retention")
+	assert.Equal(t, 5, entries[0].Score, "should keep the most recent entries")
+
+	tick(time.Hour)
+	assert.NoError(t, history.Prune(ctx))
+	assert.Empty(t, history.boards, "should prune the users without entries")
+
+	history, tick = newTestHistory(0, 2)
+	recordScores(t, history, tick, 1, 1, 2, 3, 4, 5)
+	entries, total, err = history.GetHistory(ctx, models.DefaultLeaderboard, 1, query)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total, "should keep at most the max entries of each user")
+	assert.Equal(t, 5, entries[0].Score, "should keep the most recent entries")
+
+	assert.NoError(t, history.DeleteLeaderboard(ctx, models.DefaultLeaderboard))
+	_, total, err = history.GetHistory(ctx, models.DefaultLeaderboard, 1, query)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total, "should delete the history of a deleted leaderboard")
+}
+
+func TestMemoryHistoryService_MergeUsers(t *testing.T) {
+	cases := []struct {
+		description    string
+		maxEntries     int
+		from           int
+		expectedScores []int
+		expectedIDs    []int
+	}{
+		{
+			description:    "should move the entries of the merged user in time order",
+			from:           1,
+			expectedScores: []int{20, 3, 10, 2, 1},
+			expectedIDs:    []int{2, 1, 2, 1, 1},
+		},
+		{
+			description:    "should keep at most the max entries after the merge",
+			maxEntries:     3,
+			from:           1,
+			expectedScores: []int{20, 3, 10},
+			expectedIDs:    []int{2, 1, 2},
+		},
+		{
+			description:    "should keep the history of the user merged into a user without any",
+			from:           3,
+			expectedScores: []int{20, 10},
+			expectedIDs:    []int{2, 2},
+		},
+	}
+	for _, tc := range cases {
+		ctx := context.Background()
+		history, tick := newTestHistory(0, tc.maxEntries)
+		recordScores(t, history, tick, 1, 1, 2)
+		recordScores(t, history, tick, 2, 10)
+		recordScores(t, history, tick, 1, 3)
+		recordScores(t, history, tick, 2, 20)
+
+		assert.NoError(t, history.MergeUsers(ctx, models.DefaultLeaderboard, tc.from, 2), tc.description)
+		entries, _, err := history.GetHistory(ctx, models.DefaultLeaderboard, 2, models.HistoryQuery{Limit: 10})
+		assert.NoError(t, err, tc.description)
+		scores, ids := make([]int, 0), make([]int, 0)
+		for _, entry := range entries {
+			scores = append(scores, entry.Score)
+			ids = append(ids, entry.UserID)
+		}
+		assert.Equal(t, tc.expectedScores, scores, tc.description)
+		assert.Equal(t, tc.expectedIDs, ids, "should keep the user id of the moved entries")
+
+		_, total, err := history.GetHistory(ctx, models.DefaultLeaderboard, tc.from, models.HistoryQuery{Limit: 10})
+		assert.NoError(t, err, tc.description)
+		assert.Equal(t, 0, total, "should leave no history to the merged user")
+	}
+}
@@ -0,0 +1,179 @@
+package coreservices
+
+import (
+	"context"
+	"time"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+//NewInstrumentedStoreService - will return a StoreService that records how long every call to the store
+//of the core takes in the metrics of the core, when there are some, and logs it at the debug level with the
+//id of the request of its context, and then passes it through.
+//It will also add it to the core, in place of the store it wraps
+func NewInstrumentedStoreService(core *models.Core) models.StoreService {
+	storeService := InstrumentedStoreService{
+		core:  core,
+		store: core.StoreService,
+	}
+	core.StoreService = &storeService
+	return &storeService
+}
+
+type InstrumentedStoreService struct {
+	core  *models.Core
+	store models.StoreService
+}
+
+//observe records the call to method that started at start, and failed with *err if any.
+//It is deferred, so *err is read once the call returned
+func (s *InstrumentedStoreService) observe(ctx context.Context, method string, start time.Time, err *error) {
+	duration := time.Since(start)
+	if s.core.Metrics != nil {
+		s.core.Metrics.ObserveStore(method, duration, *err)
+	}
+	logger := s.core.GetLogger()
+	if !logger.Enabled(models.LevelDebug) {
+		return
+	}
+	fields := models.LogFields{"method": method, "duration_ms": float64(duration.Microseconds()) / 1000}
+	if *err != nil {
+		fields["error"] = (*err).Error()
+	}
+	logger.Debug(ctx, "store call", fields)
+}
+
+func (s *InstrumentedStoreService) CreateLeaderboard(ctx context.Context, name string) (err error) {
+	defer s.observe(ctx, "CreateLeaderboard", time.Now(), &err)
+	return s.store.CreateLeaderboard(ctx, name)
+}
+
+func (s *InstrumentedStoreService) DeleteLeaderboard(ctx context.Context, name string) (err error) {
+	defer s.observe(ctx, "DeleteLeaderboard", time.Now(), &err)
+	return s.store.DeleteLeaderboard(ctx, name)
+}
+
+func (s *InstrumentedStoreService) DoesLeaderboardExist(ctx context.Context, name string) (result bool, err error) {
+	defer s.observe(ctx, "DoesLeaderboardExist", time.Now(), &err)
+	return s.store.DoesLeaderboardExist(ctx, name)
+}
+
+func (s *InstrumentedStoreService) GetLeaderboards(ctx context.Context) (result []string, err error) {
+	defer s.observe(ctx, "GetLeaderboards", time.Now(), &err)
+	return s.store.GetLeaderboards(ctx)
+}
+
+func (s *InstrumentedStoreService) CreateUser(ctx context.Context, board string, id int, total int) (err error) {
+	defer s.observe(ctx, "CreateUser", time.Now(), &err)
+	return s.store.CreateUser(ctx, board, id, total)
+}
+
+func (s *InstrumentedStoreService) UpdateRelativeUserScore(ctx context.Context, board string, id int, score int) (err error) {
+	defer s.observe(ctx, "UpdateRelativeUserScore", time.Now(), &err)
+	return s.store.UpdateRelativeUserScore(ctx, board, id, score)
+}
+
+func (s *InstrumentedStoreService) UpdateAbsoluteUserScore(ctx context.Context, board string, id int, score int) (err error) {
+	defer s.observe(ctx, "UpdateAbsoluteUserScore", time.Now(), &err)
+	return s.store.UpdateAbsoluteUserScore(ctx, board, id, score)
+}
+
+func (s *InstrumentedStoreService) UpsertUserScore(ctx context.Context, board string, submission models.ScoreSubmission) (result *models.ScoreChange, err error) {
+	defer s.observe(ctx, "UpsertUserScore", time.Now(), &err)
+	return s.store.UpsertUserScore(ctx, board, submission)
+}
+
+func (s *InstrumentedStoreService) SubmitScores(ctx context.Context, board string, submissions []models.ScoreSubmission) (result []models.ScoreChange, err error) {
+	defer s.observe(ctx, "SubmitScores", time.Now(), &err)
+	return s.store.SubmitScores(ctx, board, submissions)
+}
+
+func (s *InstrumentedStoreService) SubmitScoresWithPeriods(ctx context.Context, board string, periods []string, submissions []models.ScoreSubmission) (result []models.ScoreChange, created []string, err error) {
+	defer s.observe(ctx, "SubmitScoresWithPeriods", time.Now(), &err)
+	return s.store.SubmitScoresWithPeriods(ctx, board, periods, submissions)
+}
+
+func (s *InstrumentedStoreService) GetUsers(ctx context.Context, board string, top int) (result []models.Ranking, err error) {
+	defer s.observe(ctx, "GetUsers", time.Now(), &err)
+	return s.store.GetUsers(ctx, board, top)
+}
+
+func (s *InstrumentedStoreService) GetRanking(ctx context.Context, board string, query *models.RankingQuery) (result []models.Ranking, err error) {
+	defer s.observe(ctx, "GetRanking", time.Now(), &err)
+	return s.store.GetRanking(ctx, board, query)
+}
+
+func (s *InstrumentedStoreService) GetUsersAfter(ctx context.Context, board string, after *models.RankingCursor, limit int) (result []models.Ranking, cursor *models.RankingCursor, err error) {
+	defer s.observe(ctx, "GetUsersAfter", time.Now(), &err)
+	return s.store.GetUsersAfter(ctx, board, after, limit)
+}
+
+func (s *InstrumentedStoreService) GetUserById(ctx context.Context, board string, id int) (result *models.User, err error) {
+	defer s.observe(ctx, "GetUserById", time.Now(), &err)
+	return s.store.GetUserById(ctx, board, id)
+}
+
+func (s *InstrumentedStoreService) GetUsersBetween(ctx context.Context, board string, lower, upper int) (result []models.Ranking, err error) {
+	defer s.observe(ctx, "GetUsersBetween", time.Now(), &err)
+	return s.store.GetUsersBetween(ctx, board, lower, upper)
+}
+
+func (s *InstrumentedStoreService) DoesUserExist(ctx context.Context, board string, id int) (result bool, err error) {
+	defer s.observe(ctx, "DoesUserExist", time.Now(), &err)
+	return s.store.DoesUserExist(ctx, board, id)
+}
+
+func (s *InstrumentedStoreService) GetUserPosition(ctx context.Context, board string, id int, score int) (result int, err error) {
+	defer s.observe(ctx, "GetUserPosition", time.Now(), &err)
+	return s.store.GetUserPosition(ctx, board, id, score)
+}
+
+func (s *InstrumentedStoreService) CountUsers(ctx context.Context, board string) (result int, err error) {
+	defer s.observe(ctx, "CountUsers", time.Now(), &err)
+	return s.store.CountUsers(ctx, board)
+}
+
+func (s *InstrumentedStoreService) GetScores(ctx context.Context, board string) (result []int, err error) {
+	defer s.observe(ctx, "GetScores", time.Now(), &err)
+	return s.store.GetScores(ctx, board)
+}
+
+func (s *InstrumentedStoreService) DeleteUser(ctx context.Context, board string, id int) (err error) {
+	defer s.observe(ctx, "DeleteUser", time.Now(), &err)
+	return s.store.DeleteUser(ctx, board, id)
+}
+
+func (s *InstrumentedStoreService) ResetBoard(ctx context.Context, board string) (result int, err error) {
+	defer s.observe(ctx, "ResetBoard", time.Now(), &err)
+	return s.store.ResetBoard(ctx, board)
+}
+
+func (s *InstrumentedStoreService) MergeUsers(ctx context.Context, board string, from int, into int, strategy models.MergeStrategy) (err error) {
+	defer s.observe(ctx, "MergeUsers", time.Now(), &err)
+	return s.store.MergeUsers(ctx, board, from, into, strategy)
+}
+
+func (s *InstrumentedStoreService) FreezeUser(ctx context.Context, board string, id int, hidden bool) (err error) {
+	defer s.observe(ctx, "FreezeUser", time.Now(), &err)
+	return s.store.FreezeUser(ctx, board, id, hidden)
+}
+
+func (s *InstrumentedStoreService) UnfreezeUser(ctx context.Context, board string, id int) (result *models.FrozenUser, err error) {
+	defer s.observe(ctx, "UnfreezeUser", time.Now(), &err)
+	return s.store.UnfreezeUser(ctx, board, id)
+}
+
+func (s *InstrumentedStoreService) RestoreFrozenUser(ctx context.Context, board string, user models.FrozenUser) (err error) {
+	defer s.observe(ctx, "RestoreFrozenUser", time.Now(), &err)
+	return s.store.RestoreFrozenUser(ctx, board, user)
+}
+
+func (s *InstrumentedStoreService) GetFrozenUsers(ctx context.Context, board string) (result []models.FrozenUser, err error) {
+	defer s.observe(ctx, "GetFrozenUsers", time.Now(), &err)
+	return s.store.GetFrozenUsers(ctx, board)
+}
+
+func (s *InstrumentedStoreService) Ping(ctx context.Context) (err error) {
+	defer s.observe(ctx, "Ping", time.Now(), &err)
+	return s.store.Ping(ctx)
+}
@@ -0,0 +1,601 @@
+package coreservices
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+//NewMemoryStoreService - will return a StoreService that keeps the ranking in a native in-memory
+//order-statistic structure instead of a SQL table, so rankings and rank lookups run in O(log n).
+//It will also add it to the core
+func NewMemoryStoreService(core *models.Core) models.StoreService {
+	storeService := MemoryStoreService{
+		core:   core,
+		boards: make(map[string]*memoryBoard),
+	}
+	core.StoreService = &storeService
+	return &storeService
+}
+
+type MemoryStoreService struct {
+	core  *models.Core
+	clock reachedClock
+
+	mu     sync.RWMutex
+	boards map[string]*memoryBoard
+}
+
+//memoryBoard holds the users of a single leaderboard, indexed both by id and by ranking
+type memoryBoard struct {
+	policy  models.TieBreakPolicy
+	users   map[int]rankingKey
+	ranking *skipList
+	frozen  map[int]models.FrozenUser
+
+	//scoreCounts and distinctScores rank the distinct scores, only for the dense policy
+	scoreCounts    map[int]int
+	distinctScores *skipList
+}
+
+func newMemoryBoard(policy models.TieBreakPolicy) *memoryBoard {
+	b := &memoryBoard{
+		policy:  policy,
+		users:   make(map[int]rankingKey),
+		ranking: newSkipList(rankingKeyBefore(policy)),
+		frozen:  make(map[int]models.FrozenUser),
+	}
+	if policy == models.TieBreakDense {
+		b.scoreCounts = make(map[int]int)
+		b.distinctScores = newSkipList(func(a, b rankingKey) bool {
+			return a.score > b.score
+		})
+	}
+	return b
+}
+
+func (m *MemoryStoreService) CreateLeaderboard(ctx context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.boards[name]; ok {
+		return fmt.Errorf("leaderboard %s already exists", name)
+	}
+	m.boards[name] = newMemoryBoard(m.core.GetTieBreakPolicy())
+	return nil
+}
+
+func (m *MemoryStoreService) DeleteLeaderboard(ctx context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.boards, name)
+	return nil
+}
+
+func (m *MemoryStoreService) DoesLeaderboardExist(ctx context.Context, name string) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	_, ok := m.boards[name]
+	return ok, nil
+}
+
+func (m *MemoryStoreService) GetLeaderboards(ctx context.Context) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	leaderboards := make([]string, 0, len(m.boards))
+	for name := range m.boards {
+		leaderboards = append(leaderboards, name)
+	}
+	sort.Strings(leaderboards)
+	return leaderboards, nil
+}
+
+//board returns the leaderboard with the given name.
+//The caller must hold the lock
+func (m *MemoryStoreService) board(name string) (*memoryBoard, error) {
+	b, ok := m.boards[name]
+	if !ok {
+		return nil, fmt.Errorf("leaderboard %s does not exist", name)
+	}
+	return b, nil
+}
+
+func (m *MemoryStoreService) CreateUser(ctx context.Context, board string, id int, total int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, err := m.board(board)
+	if err != nil {
+		return err
+	}
+	if _, ok := b.users[id]; ok {
+		return fmt.Errorf("user %d already exists", id)
+	}
+	b.insert(rankingKey{userID: id, score: total, reachedAt: m.clock.now()})
+	return nil
+}
+
+func (m *MemoryStoreService) UpdateRelativeUserScore(ctx context.Context, board string, id int, score int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, err := m.board(board)
+	if err != nil {
+		return err
+	}
+	current, ok := b.users[id]
+	if !ok {
+		return nil
+	}
+	b.setScore(current, current.score+score, m.clock.now())
+	return nil
+}
+
+func (m *MemoryStoreService) UpdateAbsoluteUserScore(ctx context.Context, board string, id int, score int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, err := m.board(board)
+	if err != nil {
+		return err
+	}
+	current, ok := b.users[id]
+	if !ok {
+		return nil
+	}
+	b.setScore(current, score, m.clock.now())
+	return nil
+}
+
+func (m *MemoryStoreService) UpsertUserScore(ctx context.Context, board string, submission models.ScoreSubmission) (*models.ScoreChange, error) {
+	changes, err := m.SubmitScores(ctx, board, []models.ScoreSubmission{submission})
+	if err != nil {
+		return nil, err
+	}
+
+	return &changes[0], nil
+}
+
+func (m *MemoryStoreService) SubmitScores(ctx context.Context, board string, submissions []models.ScoreSubmission) ([]models.ScoreChange, error) {
+	changes, _, err := m.SubmitScoresWithPeriods(ctx, board, nil, submissions)
+	return changes, err
+}
+
+func (m *MemoryStoreService) SubmitScoresWithPeriods(ctx context.Context, board string, periods []string, submissions []models.ScoreSubmission) ([]models.ScoreChange, []string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, err := m.board(board)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	//the scores are checked on every board first, so the submissions can't fail once they are applied
+	//and they are all applied under the same lock
+	if err := b.checkScores(submissions); err != nil {
+		return nil, nil, err
+	}
+	for _, period := range periods {
+		if err := m.boards[period].checkScores(submissions); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	changes := m.submitScores(b, submissions)
+	created := make([]string, 0)
+	for _, period := range periods {
+		p, ok := m.boards[period]
+		if !ok {
+			p = newMemoryBoard(m.core.GetTieBreakPolicy())
+			m.boards[period] = p
+			created = append(created, period)
+		}
+		m.submitScores(p, submissions)
+	}
+
+	return changes, created, nil
+}
+
+//checkScores returns the error of the first relative submission whose score would not fit an integer
+//on the board, which is empty when it is nil
+func (b *memoryBoard) checkScores(submissions []models.ScoreSubmission) error {
+	scores := make(map[int]int)
+	for _, submission := range submissions {
+		score, ok := scores[submission.UserID]
+		if !ok && b != nil {
+			var current rankingKey
+			current, ok = b.users[submission.UserID]
+			score = current.score
+		}
+		if !ok || submission.Absolute {
+			scores[submission.UserID] = submission.Score
+			continue
+		}
+		score, err := relativeScore(submission.UserID, score, submission.Score)
+		if err != nil {
+			return err
+		}
+		scores[submission.UserID] = score
+	}
+	return nil
+}
+
+//submitScores applies the submissions to the board, returning their changes in the same order.
+//The caller must hold the lock
+func (m *MemoryStoreService) submitScores(b *memoryBoard, submissions []models.ScoreSubmission) []models.ScoreChange {
+	changes := make([]models.ScoreChange, len(submissions))
+	for i, submission := range submissions {
+		changes[i] = models.ScoreChange{UserID: submission.UserID, Score: submission.Score}
+		current, ok := b.users[submission.UserID]
+		if !ok {
+			b.insert(rankingKey{userID: submission.UserID, score: submission.Score, reachedAt: m.clock.now()})
+			changes[i].Created = true
+			continue
+		}
+		changes[i].PreviousScore = current.score
+		if !submission.Absolute {
+			changes[i].Score += current.score
+		}
+		b.setScore(current, changes[i].Score, m.clock.now())
+	}
+
+	return changes
+}
+
+func (b *memoryBoard) insert(key rankingKey) {
+	b.users[key.userID] = key
+	b.ranking.insert(key)
+	if b.distinctScores != nil {
+		if b.scoreCounts[key.score] == 0 {
+			b.distinctScores.insert(rankingKey{score: key.score})
+		}
+		b.scoreCounts[key.score]++
+	}
+}
+
+func (b *memoryBoard) remove(key rankingKey) {
+	delete(b.users, key.userID)
+	b.ranking.remove(key)
+	if b.distinctScores != nil {
+		b.scoreCounts[key.score]--
+		if b.scoreCounts[key.score] == 0 {
+			delete(b.scoreCounts, key.score)
+			b.distinctScores.remove(rankingKey{score: key.score})
+		}
+	}
+}
+
+//setScore moves the user from its current node in the ranking to the one of the new score.
+//A score that doesn't change keeps the time it was reached
+func (b *memoryBoard) setScore(current rankingKey, score int, reachedAt int64) {
+	if current.score == score {
+		return
+	}
+	b.remove(current)
+	b.insert(rankingKey{userID: current.userID, score: score, reachedAt: reachedAt})
+}
+
+//position returns the position of the user with the given key under the policy of the board
+func (b *memoryBoard) position(key rankingKey) int {
+	switch b.policy {
+	case models.TieBreakCompetition:
+		//counts the users before the first possible key with the same score
+		return b.ranking.countBefore(rankingKey{userID: math.MinInt, score: key.score, reachedAt: math.MinInt64}) + 1
+	case models.TieBreakDense:
+		return b.distinctScores.countBefore(rankingKey{score: key.score}) + 1
+	}
+	return b.ranking.rank(key)
+}
+
+func (m *MemoryStoreService) DoesUserExist(ctx context.Context, board string, id int) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	b, err := m.board(board)
+	if err != nil {
+		return false, err
+	}
+	_, ok := b.users[id]
+	return ok, nil
+}
+
+func (m *MemoryStoreService) GetUsers(ctx context.Context, board string, top int) ([]models.Ranking, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	b, err := m.board(board)
+	if err != nil {
+		return nil, err
+	}
+	return b.rankingFrom(1, top), nil
+}
+
+func (m *MemoryStoreService) GetUsersBetween(ctx context.Context, board string, pos, around int) ([]models.Ranking, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	b, err := m.board(board)
+	if err != nil {
+		return nil, err
+	}
+	offset, positionAround := usersBetweenWindow(pos, around)
+	return b.rankingFrom(offset+1, positionAround), nil
+}
+
+func (m *MemoryStoreService) GetRanking(ctx context.Context, board string, query *models.RankingQuery) ([]models.Ranking, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	b, err := m.board(board)
+	if err != nil {
+		return nil, err
+	}
+	position := 0
+	if query.Kind == models.RankingAroundUser {
+		key, ok := b.users[query.UserID]
+		if !ok {
+			return nil, sql.ErrNoRows
+		}
+		position = b.position(key)
+	}
+
+	first, limit := rankingWindow(query, b.ranking.length, position)
+	return filterRanking(b.rankingFrom(first, limit), query), nil
+}
+
+//rankingFrom returns up to limit users starting at the 1-based position first
+func (b *memoryBoard) rankingFrom(first, limit int) []models.Ranking {
+	ranking := make([]models.Ranking, 0)
+
+	firstNode := b.ranking.byRank(first)
+	position := first
+	for node := firstNode; node != nil && len(ranking) < limit; node = node.next() {
+		ranking = append(ranking, models.Ranking{
+			Position: position,
+			UserID:   node.key.userID,
+			Score:    node.key.score,
+		})
+		position++
+	}
+	if firstNode != nil {
+		sharePositions(ranking, b.policy, b.position(firstNode.key))
+	}
+
+	return ranking
+}
+
+func (m *MemoryStoreService) GetUsersAfter(ctx context.Context, board string, after *models.RankingCursor, limit int) ([]models.Ranking, *models.RankingCursor, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	b, err := m.board(board)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	first := 1
+	if after != nil {
+		key := rankingKey{userID: after.UserID, score: after.Score, reachedAt: after.ReachedAt}
+		//the cursor user may have left the ranking or moved since, so the page starts at the first key after it
+		first = b.ranking.countBefore(key) + 1
+		if node := b.ranking.byRank(first); node != nil && !b.ranking.before(key, node.key) {
+			first++
+		}
+	}
+
+	ranking := b.rankingFrom(first, limit)
+	if len(ranking) == 0 {
+		return ranking, nil, nil
+	}
+	last := b.users[ranking[len(ranking)-1].UserID]
+	return ranking, &models.RankingCursor{Score: last.score, ReachedAt: last.reachedAt, UserID: last.userID}, nil
+}
+
+func (m *MemoryStoreService) GetUserById(ctx context.Context, board string, id int) (*models.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	b, err := m.board(board)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := b.users[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+
+	return &models.User{
+		UserID: id,
+		Score:  key.score,
+	}, nil
+}
+
+func (m *MemoryStoreService) GetUserPosition(ctx context.Context, board string, id int, score int) (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	b, err := m.board(board)
+	if err != nil {
+		return 0, err
+	}
+	//the key in the board is used instead of the given score, which may be outdated
+	key, ok := b.users[id]
+	if !ok {
+		return 0, sql.ErrNoRows
+	}
+	return b.position(key), nil
+}
+
+func (m *MemoryStoreService) CountUsers(ctx context.Context, board string) (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	b, err := m.board(board)
+	if err != nil {
+		return 0, err
+	}
+	return b.ranking.length, nil
+}
+
+func (m *MemoryStoreService) GetScores(ctx context.Context, board string) ([]int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	b, err := m.board(board)
+	if err != nil {
+		return nil, err
+	}
+	scores := make([]int, 0, b.ranking.length)
+	for node := b.ranking.byRank(1); node != nil; node = node.next() {
+		scores = append(scores, node.key.score)
+	}
+	return scores, nil
+}
+
+func (m *MemoryStoreService) DeleteUser(ctx context.Context, board string, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, err := m.board(board)
+	if err != nil {
+		return err
+	}
+	key, ranked := b.users[id]
+	_, frozen := b.frozen[id]
+	if !ranked && !frozen {
+		return sql.ErrNoRows
+	}
+	if ranked {
+		b.remove(key)
+	}
+	delete(b.frozen, id)
+	return nil
+}
+
+func (m *MemoryStoreService) ResetBoard(ctx context.Context, board string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, err := m.board(board)
+	if err != nil {
+		return 0, err
+	}
+	removed := len(b.users)
+	reset := newMemoryBoard(b.policy)
+	for id, frozen := range b.frozen {
+		frozen.Score = 0
+		reset.frozen[id] = frozen
+	}
+	m.boards[board] = reset
+	return removed, nil
+}
+
+func (m *MemoryStoreService) MergeUsers(ctx context.Context, board string, from int, into int, strategy models.MergeStrategy) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, err := m.board(board)
+	if err != nil {
+		return err
+	}
+	fromKey, ok := b.users[from]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	b.remove(fromKey)
+
+	intoKey, ok := b.users[into]
+	if !ok {
+		//a rename keeps the time the score was reached
+		b.insert(rankingKey{userID: into, score: fromKey.score, reachedAt: fromKey.reachedAt})
+		return nil
+	}
+	b.setScore(intoKey, strategy.Merge(fromKey.score, intoKey.score), m.clock.now())
+	return nil
+}
+
+func (m *MemoryStoreService) FreezeUser(ctx context.Context, board string, id int, hidden bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, err := m.board(board)
+	if err != nil {
+		return err
+	}
+	key, ok := b.users[id]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	frozen := models.FrozenUser{UserID: id, Hidden: hidden}
+	//only a hidden user needs her/his score back when she/he is unfrozen
+	if hidden {
+		frozen.Score = key.score
+		b.remove(key)
+	}
+	b.frozen[id] = frozen
+	return nil
+}
+
+func (m *MemoryStoreService) UnfreezeUser(ctx context.Context, board string, id int) (*models.FrozenUser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, err := m.board(board)
+	if err != nil {
+		return nil, err
+	}
+	frozen, ok := b.frozen[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	delete(b.frozen, id)
+	if frozen.Hidden {
+		b.insert(rankingKey{userID: id, score: frozen.Score, reachedAt: m.clock.now()})
+	}
+	return &frozen, nil
+}
+
+func (m *MemoryStoreService) RestoreFrozenUser(ctx context.Context, board string, user models.FrozenUser) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, err := m.board(board)
+	if err != nil {
+		return err
+	}
+	b.frozen[user.UserID] = user
+	return nil
+}
+
+func (m *MemoryStoreService) GetFrozenUsers(ctx context.Context, board string) ([]models.FrozenUser, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	b, err := m.board(board)
+	if err != nil {
+		return nil, err
+	}
+	frozen := make([]models.FrozenUser, 0, len(b.frozen))
+	for _, user := range b.frozen {
+		frozen = append(frozen, user)
+	}
+	sort.Slice(frozen, func(i, j int) bool {
+		return frozen[i].UserID < frozen[j].UserID
+	})
+	return frozen, nil
+}
+
+//Ping waits for the lock of the store, since the users are kept in the process itself
+func (m *MemoryStoreService) Ping(ctx context.Context) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return nil
+}
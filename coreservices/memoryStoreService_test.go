@@ -0,0 +1,101 @@
+package coreservices
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMemoryStoreService(t *testing.T) {
+	core := &models.Core{}
+	store := NewMemoryStoreService(core)
+	assert.Equal(t, store, core.StoreService, "should add the store to the core")
+	assert.Nil(t, core.DB, "should not need a database")
+}
+
+func TestMemoryStoreService_CreateUser(t *testing.T) {
+	cases := []struct {
+		description   string
+		existingUsers []int
+		userId        int
+		score         int
+		expectedError error
+	}{
+		{
+			description: "should create an user",
+			userId:      1,
+			score:       100,
+		},
+		{
+			description:   "should return an error when the user already exists",
+			existingUsers: []int{50},
+			userId:        1,
+			score:         100,
+			expectedError: fmt.Errorf("user 1 already exists"),
+		},
+	}
+	for _, tc := range cases {
+		store := newTestStore(t, NewMemoryStoreService(&models.Core{}))
+		seedStore(t, store, tc.existingUsers...)
+
+		err := store.CreateUser(context.Background(), models.DefaultLeaderboard, tc.userId, tc.score)
+		assert.Equal(t, tc.expectedError, err, tc.description)
+	}
+}
+
+func TestMemoryStoreService_UpdateMissingUser(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, NewMemoryStoreService(&models.Core{}))
+
+	assert.NoError(t, store.UpdateAbsoluteUserScore(ctx, models.DefaultLeaderboard, 1, 10), "should ignore an absolute update of a missing user")
+	assert.NoError(t, store.UpdateRelativeUserScore(ctx, models.DefaultLeaderboard, 1, 10), "should ignore a relative update of a missing user")
+
+	ranking, err := store.GetUsers(ctx, models.DefaultLeaderboard, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, ranking, "should not create users on update")
+}
+
+func TestMemoryStoreService_RankingAfterUpdates(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, NewMemoryStoreService(&models.Core{}))
+	seedStore(t, store, 10, 20, 30)
+
+	assert.NoError(t, store.UpdateRelativeUserScore(ctx, models.DefaultLeaderboard, 1, 25))
+	assert.NoError(t, store.UpdateAbsoluteUserScore(ctx, models.DefaultLeaderboard, 3, 5))
+
+	ranking, err := store.GetUsers(ctx, models.DefaultLeaderboard, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Ranking{
+		{Position: 1, UserID: 1, Score: 35},
+		{Position: 2, UserID: 2, Score: 20},
+		{Position: 3, UserID: 3, Score: 5},
+	}, ranking, "should move updated users to their new position")
+}
+
+func TestMemoryStoreService_MissingLeaderboard(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStoreService(&models.Core{})
+	expectedError := fmt.Errorf("leaderboard weekly does not exist")
+
+	assert.Equal(t, expectedError, store.CreateUser(ctx, "weekly", 1, 10), "should not create users in a missing leaderboard")
+	_, err := store.GetUsers(ctx, "weekly", 10)
+	assert.Equal(t, expectedError, err, "should not rank a missing leaderboard")
+	_, err = store.GetUserById(ctx, "weekly", 1)
+	assert.Equal(t, expectedError, err, "should not get users of a missing leaderboard")
+}
+
+func BenchmarkMemoryStoreService_GetUsersBetween(b *testing.B) {
+	ctx := context.Background()
+	store := NewMemoryStoreService(&models.Core{})
+	store.CreateLeaderboard(ctx, models.DefaultLeaderboard)
+	for i := 0; i < 500000; i++ {
+		store.CreateUser(ctx, models.DefaultLeaderboard, i, i%100000)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		store.GetUsersBetween(ctx, models.DefaultLeaderboard, 250000, 3)
+	}
+}
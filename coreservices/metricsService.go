@@ -0,0 +1,281 @@
+package coreservices
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+//requestBuckets are the upper bounds, in seconds, of the buckets of the latencies of the requests
+var requestBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+//storeBuckets are the upper bounds, in seconds, of the buckets of the latencies of the store,
+//whose calls are much shorter than the requests they serve
+var storeBuckets = []float64{0.0001, 0.00025, 0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 1}
+
+//NewMetricsService - will return a MetricsService that keeps the metrics in memory and writes them
+//in the Prometheus text format, so they are scraped without an external collector.
+//It will also add it to the core
+func NewMetricsService(core *models.Core) models.MetricsService {
+	metricsService := PrometheusMetricsService{
+		core:        core,
+		requests:    make(map[string]*histogram),
+		submissions: make(map[string]float64),
+		newUsers:    make(map[string]float64),
+		store:       make(map[string]*histogram),
+		storeErrors: make(map[string]float64),
+	}
+	core.Metrics = &metricsService
+	return &metricsService
+}
+
+//PrometheusMetricsService keeps its counters and histograms by the labels of their series
+type PrometheusMetricsService struct {
+	core *models.Core
+
+	mu          sync.Mutex
+	requests    map[string]*histogram
+	submissions map[string]float64
+	newUsers    map[string]float64
+	store       map[string]*histogram
+	storeErrors map[string]float64
+}
+
+func (m *PrometheusMetricsService) ObserveRequest(route string, method string, status int, duration time.Duration) {
+	series := labels("route", route, "method", method, "status", strconv.Itoa(status))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	observe(m.requests, series, requestBuckets, duration.Seconds())
+}
+
+func (m *PrometheusMetricsService) ObserveSubmissions(board string, submissions []models.ScoreSubmission, changes []models.ScoreChange) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, submission := range submissions {
+		kind := "relative"
+		if submission.Absolute {
+			kind = "absolute"
+		}
+		m.submissions[labels("board", board, "kind", kind)]++
+		if i < len(changes) && changes[i].Created {
+			m.newUsers[labels("board", board)]++
+		}
+	}
+}
+
+func (m *PrometheusMetricsService) ObserveStore(method string, duration time.Duration, err error) {
+	series := labels("method", method)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	observe(m.store, series, storeBuckets, duration.Seconds())
+	//a missing row is an answer of the store, not a failure
+	if err != nil && err != sql.ErrNoRows {
+		m.storeErrors[series]++
+	}
+}
+
+func (m *PrometheusMetricsService) WriteMetrics(ctx context.Context, w io.Writer) error {
+	//the metrics read from the core are read first, so the lock is not held while the store is queried
+	players, err := m.players(ctx)
+	if err != nil {
+		return err
+	}
+	throttled, err := m.throttled(ctx)
+	if err != nil {
+		return err
+	}
+
+	buffered := bufio.NewWriter(w)
+	m.mu.Lock()
+	writeHistograms(buffered, "leaderboard_http_request_duration_seconds", "Latency of the HTTP requests, by route, method and status.", m.requests)
+	writeCounters(buffered, "leaderboard_http_requests_total", "HTTP requests served, by route, method and status.", histogramCounts(m.requests))
+	writeCounters(buffered, "leaderboard_score_submissions_total", "Score submissions applied, by leaderboard and kind: absolute or relative.", m.submissions)
+	writeCounters(buffered, "leaderboard_new_users_total", "Users created by the score submissions, by leaderboard.", m.newUsers)
+	writeHistograms(buffered, "leaderboard_store_duration_seconds", "Latency of the calls to the store, by method.", m.store)
+	writeCounters(buffered, "leaderboard_store_errors_total", "Failed calls to the store, by method.", m.storeErrors)
+	m.mu.Unlock()
+
+	writeGauges(buffered, "leaderboard_players", "Users in the ranking, by leaderboard.", players)
+	if throttled != nil {
+		writeCounters(buffered, "leaderboard_rate_limited_total", "Requests throttled by the rate limits, by rule.", throttled)
+	}
+	writeRuntime(buffered)
+	return buffered.Flush()
+}
+
+//players counts the users of every leaderboard, leaving out the boards of the periods
+func (m *PrometheusMetricsService) players(ctx context.Context) (map[string]float64, error) {
+	players := make(map[string]float64)
+	if m.core.StoreService == nil {
+		return players, nil
+	}
+	boards, err := m.core.StoreService.GetLeaderboards(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, board := range boards {
+		if models.IsPeriodBoard(board) {
+			continue
+		}
+		count, err := m.core.StoreService.CountUsers(ctx, board)
+		if err != nil {
+			return nil, err
+		}
+		players[labels("board", board)] = float64(count)
+	}
+	return players, nil
+}
+
+//throttled counts the requests throttled by each rate limit, or returns nil when there are none
+func (m *PrometheusMetricsService) throttled(ctx context.Context) (map[string]float64, error) {
+	if m.core.RateLimits == nil {
+		return nil, nil
+	}
+	statuses, err := m.core.RateLimits.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	throttled := make(map[string]float64, len(statuses))
+	for _, status := range statuses {
+		throttled[labels("route", status.Route, "method", status.Method, "by", string(status.By))] += float64(status.Throttled)
+	}
+	return throttled, nil
+}
+
+//histogram counts the observations in each bucket, the last one being +Inf
+type histogram struct {
+	bounds []float64
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+//observe adds value to the histogram of series, creating it with the bounds when it is the first one.
+//The caller must hold the lock
+func observe(histograms map[string]*histogram, series string, bounds []float64, value float64) {
+	h, ok := histograms[series]
+	if !ok {
+		h = &histogram{bounds: bounds, counts: make([]uint64, len(bounds)+1)}
+		histograms[series] = h
+	}
+	h.counts[sort.SearchFloat64s(bounds, value)]++
+	h.count++
+	h.sum += value
+}
+
+//histogramCounts returns the number of observations of each series
+func histogramCounts(histograms map[string]*histogram) map[string]float64 {
+	counts := make(map[string]float64, len(histograms))
+	for series, h := range histograms {
+		counts[series] = float64(h.count)
+	}
+	return counts
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+//labels returns the labels of a series from their names and values, eg: route="/ranking",status="200"
+func labels(pairs ...string) string {
+	parts := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, pairs[i], labelEscaper.Replace(pairs[i+1])))
+	}
+	return strings.Join(parts, ",")
+}
+
+//sortedSeries returns the series of the metric in order, so the output is the same on every scrape
+func sortedSeries(series map[string]float64) []string {
+	keys := make([]string, 0, len(series))
+	for key := range series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func writeHeader(w io.Writer, name string, help string, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+//writeSample writes a sample of the metric, with its labels when it has any
+func writeSample(w io.Writer, name string, series string, value float64) {
+	if series == "" {
+		fmt.Fprintf(w, "%s %s\n", name, formatValue(value))
+		return
+	}
+	fmt.Fprintf(w, "%s{%s} %s\n", name, series, formatValue(value))
+}
+
+func writeCounters(w io.Writer, name string, help string, counters map[string]float64) {
+	writeHeader(w, name, help, "counter")
+	for _, series := range sortedSeries(counters) {
+		writeSample(w, name, series, counters[series])
+	}
+}
+
+func writeGauges(w io.Writer, name string, help string, gauges map[string]float64) {
+	writeHeader(w, name, help, "gauge")
+	for _, series := range sortedSeries(gauges) {
+		writeSample(w, name, series, gauges[series])
+	}
+}
+
+//writeHistograms writes the cumulative buckets of every histogram, then its sum and count
+func writeHistograms(w io.Writer, name string, help string, histograms map[string]*histogram) {
+	writeHeader(w, name, help, "histogram")
+	for _, series := range sortedSeries(histogramCounts(histograms)) {
+		h := histograms[series]
+		prefix := series
+		if prefix != "" {
+			prefix += ","
+		}
+		var cumulative uint64
+		for i, count := range h.counts {
+			cumulative += count
+			bound := math.Inf(1)
+			if i < len(h.bounds) {
+				bound = h.bounds[i]
+			}
+			writeSample(w, name+"_bucket", prefix+labels("le", formatValue(bound)), float64(cumulative))
+		}
+		writeSample(w, name+"_sum", series, h.sum)
+		writeSample(w, name+"_count", series, float64(h.count))
+	}
+}
+
+//writeRuntime writes the stats of the Go runtime, named as the Prometheus client names them
+func writeRuntime(w io.Writer) {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+
+	writeGauges(w, "go_goroutines", "Number of goroutines that currently exist.", map[string]float64{"": float64(runtime.NumGoroutine())})
+	writeGauges(w, "go_info", "Information about the Go environment.", map[string]float64{labels("version", runtime.Version()): 1})
+	writeGauges(w, "go_memstats_alloc_bytes", "Number of bytes allocated and still in use.", map[string]float64{"": float64(stats.Alloc)})
+	writeCounters(w, "go_memstats_alloc_bytes_total", "Total number of bytes allocated, even if freed.", map[string]float64{"": float64(stats.TotalAlloc)})
+	writeGauges(w, "go_memstats_sys_bytes", "Number of bytes obtained from system.", map[string]float64{"": float64(stats.Sys)})
+	writeGauges(w, "go_memstats_heap_inuse_bytes", "Number of heap bytes that are in use.", map[string]float64{"": float64(stats.HeapInuse)})
+	writeGauges(w, "go_memstats_heap_objects", "Number of allocated objects.", map[string]float64{"": float64(stats.HeapObjects)})
+	writeCounters(w, "go_gc_cycles_total", "Number of completed GC cycles.", map[string]float64{"": float64(stats.NumGC)})
+	writeCounters(w, "go_gc_pause_seconds_total", "Total time the GC stopped the world.", map[string]float64{"": float64(stats.PauseTotalNs) / float64(time.Second)})
+}
+
+func formatValue(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
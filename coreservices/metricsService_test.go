@@ -0,0 +1,110 @@
+package coreservices
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pedrocmart/leaderboard-service/mocks"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrometheusMetricsService_WriteMetrics(t *testing.T) {
+	core := &models.Core{}
+	store := newTestStore(t, NewMemoryStoreService(core))
+	seedStore(t, store, 30, 20, 10)
+	assert.NoError(t, store.CreateLeaderboard(context.Background(), "weekly@daily@2026-01-01"))
+	core.RateLimits = &mocks.RateLimitServiceMock{
+		StatusFunc: func(ctx context.Context) ([]models.RateLimitStatus, error) {
+			return []models.RateLimitStatus{{RateLimitRule: models.RateLimitRule{Route: "*", By: models.RateLimitByIP}, Throttled: 4}}, nil
+		},
+	}
+	metrics := NewMetricsService(core)
+	assert.Equal(t, metrics, core.Metrics, "should add the metrics to the core")
+
+	metrics.ObserveRequest("/ranking", "GET", 200, 30*time.Millisecond)
+	metrics.ObserveRequest("/ranking", "GET", 200, 2*time.Second)
+	metrics.ObserveRequest("/user/{user_id}/score", "POST", 400, time.Millisecond)
+	metrics.ObserveSubmissions(models.DefaultLeaderboard,
+		[]models.ScoreSubmission{{UserID: 1, Score: 5}, {UserID: 4, Score: 7, Absolute: true}, {UserID: 5, Score: 1}},
+		[]models.ScoreChange{{UserID: 1}, {UserID: 4, Created: true}, {UserID: 5, Created: true}})
+	metrics.ObserveStore("GetUserById", time.Millisecond, sql.ErrNoRows)
+	metrics.ObserveStore("CountUsers", 3*time.Second, fmt.Errorf("mock-error"))
+
+	var buffer bytes.Buffer
+	assert.NoError(t, metrics.WriteMetrics(context.Background(), &buffer))
+	output := buffer.String()
+
+	expectedLines := []struct {
+		description string
+		line        string
+	}{
+		{"should write the type of the metrics", "# TYPE leaderboard_http_request_duration_seconds histogram\n"},
+		{"should count the requests in the buckets they fall in", `leaderboard_http_request_duration_seconds_bucket{route="/ranking",method="GET",status="200",le="0.025"} 0` + "\n"},
+		{"should count the requests in the cumulative buckets", `leaderboard_http_request_duration_seconds_bucket{route="/ranking",method="GET",status="200",le="0.05"} 1` + "\n"},
+		{"should count every request in the +Inf bucket", `leaderboard_http_request_duration_seconds_bucket{route="/ranking",method="GET",status="200",le="+Inf"} 2` + "\n"},
+		{"should add up the latencies of the requests", `leaderboard_http_request_duration_seconds_sum{route="/ranking",method="GET",status="200"} 2.03` + "\n"},
+		{"should count the requests by route and status", `leaderboard_http_requests_total{route="/user/{user_id}/score",method="POST",status="400"} 1` + "\n"},
+		{"should count the relative submissions", `leaderboard_score_submissions_total{board="default",kind="relative"} 2` + "\n"},
+		{"should count the absolute submissions", `leaderboard_score_submissions_total{board="default",kind="absolute"} 1` + "\n"},
+		{"should count the users created", `leaderboard_new_users_total{board="default"} 2` + "\n"},
+		{"should count the calls to the store", `leaderboard_store_duration_seconds_count{method="GetUserById"} 1` + "\n"},
+		{"should count the failed calls to the store", `leaderboard_store_errors_total{method="CountUsers"} 1` + "\n"},
+		{"should count the players of the leaderboards", `leaderboard_players{board="default"} 3` + "\n"},
+		{"should count the throttled requests", `leaderboard_rate_limited_total{route="*",method="",by="ip"} 4` + "\n"},
+		{"should write the stats of the runtime", "# TYPE go_goroutines gauge\n"},
+	}
+	for _, expected := range expectedLines {
+		assert.Contains(t, output, expected.line, expected.description)
+	}
+	assert.NotContains(t, output, `leaderboard_store_errors_total{method="GetUserById"}`, "should not count a missing row as a failure")
+	assert.NotContains(t, output, `board="weekly@daily@2026-01-01"`, "should leave out the boards of the periods")
+}
+
+func TestPrometheusMetricsService_WriteMetricsError(t *testing.T) {
+	core := &models.Core{
+		StoreService: &mocks.StoreServiceMock{
+			GetLeaderboardsFunc: func(ctx context.Context) ([]string, error) {
+				return nil, fmt.Errorf("mock-error")
+			},
+		},
+	}
+	metrics := NewMetricsService(core)
+
+	var buffer bytes.Buffer
+	assert.Error(t, metrics.WriteMetrics(context.Background(), &buffer), "should return error when the store fails")
+	assert.Empty(t, buffer.String(), "should not write anything when the store fails")
+}
+
+func TestInstrumentedStoreService(t *testing.T) {
+	var observed []string
+	core := &models.Core{
+		StoreService: &mocks.StoreServiceMock{
+			CountUsersFunc: func(ctx context.Context, board string) (int, error) {
+				return 3, nil
+			},
+			GetUserByIdFunc: func(ctx context.Context, board string, id int) (*models.User, error) {
+				return nil, sql.ErrNoRows
+			},
+		},
+		Metrics: &mocks.MetricsServiceMock{
+			ObserveStoreFunc: func(method string, duration time.Duration, err error) {
+				observed = append(observed, fmt.Sprintf("%s %v", method, err))
+			},
+		},
+	}
+	store := NewInstrumentedStoreService(core)
+	assert.Equal(t, store, core.StoreService, "should replace the store of the core")
+
+	count, err := store.CountUsers(context.Background(), models.DefaultLeaderboard)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count, "should return what the store returns")
+	_, err = store.GetUserById(context.Background(), models.DefaultLeaderboard, 1)
+	assert.Equal(t, sql.ErrNoRows, err, "should return the errors of the store")
+
+	assert.Equal(t, []string{"CountUsers <nil>", "GetUserById sql: no rows in result set"}, observed, "should observe every call with its error")
+}
@@ -0,0 +1,69 @@
+package coreservices
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+const (
+	//mutationHeaderSize is the size of the length and the CRC-32 checksum written before each mutation
+	mutationHeaderSize = 8
+	//mutationMaxSize protects the reader from allocating a corrupted length
+	mutationMaxSize = 1 << 20
+)
+
+var errCorruptMutation = errors.New("corrupt mutation record")
+
+//writeMutation writes the mutation to w as a record holding its length, its CRC-32 checksum and its JSON.
+//The record is written with a single call, so a crash can only leave it truncated at the end of the log
+func writeMutation(w io.Writer, mutation models.Mutation) error {
+	payload, err := json.Marshal(mutation)
+	if err != nil {
+		return err
+	}
+
+	record := make([]byte, mutationHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(record[0:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(record[4:8], crc32.ChecksumIEEE(payload))
+	copy(record[mutationHeaderSize:], payload)
+
+	_, err = w.Write(record)
+	return err
+}
+
+//readMutation reads the next record from r, returning the mutation and the size of the record.
+//It returns io.EOF at the end of the log, and another error when the record is truncated or corrupt
+func readMutation(r io.Reader) (models.Mutation, int64, error) {
+	var mutation models.Mutation
+
+	header := make([]byte, mutationHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return mutation, 0, err
+	}
+	size := binary.BigEndian.Uint32(header[0:4])
+	if size > mutationMaxSize {
+		return mutation, 0, fmt.Errorf("%w: length %d", errCorruptMutation, size)
+	}
+
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return mutation, 0, err
+	}
+	if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(header[4:8]) {
+		return mutation, 0, fmt.Errorf("%w: checksum mismatch", errCorruptMutation)
+	}
+	if err := json.Unmarshal(payload, &mutation); err != nil {
+		return mutation, 0, fmt.Errorf("%w: %s", errCorruptMutation, err)
+	}
+
+	return mutation, int64(mutationHeaderSize + size), nil
+}
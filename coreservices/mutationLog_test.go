@@ -0,0 +1,65 @@
+package coreservices
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadMutation(t *testing.T) {
+	mutation := models.Mutation{Type: models.MutationRelativeScore, Board: "weekly", UserID: 7, Score: -20}
+	var record bytes.Buffer
+	assert.NoError(t, writeMutation(&record, mutation))
+
+	corrupt := append([]byte{}, record.Bytes()...)
+	corrupt[len(corrupt)-2] ^= 0xff
+
+	cases := []struct {
+		description      string
+		log              []byte
+		expectedMutation models.Mutation
+		expectedSize     int64
+		expectedError    error
+	}{
+		{
+			description:      "should read a written mutation",
+			log:              record.Bytes(),
+			expectedMutation: mutation,
+			expectedSize:     int64(record.Len()),
+		},
+		{
+			description:   "should return EOF at the end of the log",
+			log:           []byte{},
+			expectedError: io.EOF,
+		},
+		{
+			description:   "should return an error when the header is truncated",
+			log:           record.Bytes()[:3],
+			expectedError: io.ErrUnexpectedEOF,
+		},
+		{
+			description:   "should return an error when the payload is truncated",
+			log:           record.Bytes()[:record.Len()-1],
+			expectedError: io.ErrUnexpectedEOF,
+		},
+		{
+			description:   "should return an error when the checksum does not match",
+			log:           corrupt,
+			expectedError: errCorruptMutation,
+		},
+	}
+	for _, tc := range cases {
+		result, size, err := readMutation(bytes.NewReader(tc.log))
+		if tc.expectedError != nil {
+			assert.True(t, errors.Is(err, tc.expectedError), tc.description)
+			continue
+		}
+		assert.NoError(t, err, tc.description)
+		assert.Equal(t, tc.expectedMutation, result, tc.description)
+		assert.Equal(t, tc.expectedSize, size, tc.description)
+	}
+}
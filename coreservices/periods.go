@@ -0,0 +1,135 @@
+package coreservices
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+//periodBoards returns the boards of the current periods of board, which every submission to board also feeds
+func (bhs *BasicService) periodBoards(board string) []string {
+	calendar := bhs.Core.Periods
+	if calendar == nil {
+		return nil
+	}
+
+	now := calendar.Now()
+	boards := make([]string, 0, len(calendar.Periods))
+	for _, period := range calendar.Periods {
+		boards = append(boards, models.PeriodBoard(board, period, calendar.ID(period, now)))
+	}
+	return boards
+}
+
+//pruneArchives deletes the oldest past periods of the boards that were just created, keeping as many as the
+//calendar archives. The scores are already applied by then, so a failure is only logged
+func (bhs *BasicService) pruneArchives(ctx context.Context, created []string) {
+	if len(created) == 0 || bhs.Core.Periods.Archives <= 0 {
+		return
+	}
+
+	boards, err := bhs.Core.StoreService.GetLeaderboards(ctx)
+	if err != nil {
+		bhs.Core.GetLogger().Error(ctx, "error while pruning the archived periods", models.LogFields{"error": err.Error()})
+		return
+	}
+	for _, name := range created {
+		board, period, _, _ := models.ParsePeriodBoard(name)
+		//the ids of the periods sort in time order
+		archives := periodBoardsOf(boards, board, period)
+		sort.Strings(archives)
+		//keeps the current period besides the archived ones
+		for len(archives) > bhs.Core.Periods.Archives+1 {
+			if err := bhs.deleteBoard(ctx, archives[0]); err != nil {
+				bhs.Core.GetLogger().Error(ctx, "error while pruning an archived period", models.LogFields{"board": archives[0], "error": err.Error()})
+			}
+			archives = archives[1:]
+		}
+	}
+}
+
+//deletePeriodBoards deletes the boards of every period of board
+func (bhs *BasicService) deletePeriodBoards(ctx context.Context, board string) error {
+	boards, err := bhs.Core.StoreService.GetLeaderboards(ctx)
+	if err != nil {
+		return err
+	}
+	for _, periodBoard := range periodBoardsOf(boards, board, "") {
+		if err := bhs.deleteBoard(ctx, periodBoard); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//deleteBoard deletes the board from the store, writing it first to the persistence log
+func (bhs *BasicService) deleteBoard(ctx context.Context, board string) error {
+	mutation := models.Mutation{Type: models.MutationDeleteLeaderboard, Board: board}
+	return bhs.logMutation(ctx, mutation, func() error {
+		return bhs.Core.StoreService.DeleteLeaderboard(ctx, board)
+	})
+}
+
+//periodBoardsOf returns the boards of the periods of board among boards, only the ones of period if it is not empty
+func periodBoardsOf(boards []string, board string, period models.Period) []string {
+	matching := make([]string, 0)
+	for _, name := range boards {
+		b, p, _, ok := models.ParsePeriodBoard(name)
+		if ok && b == board && (period == "" || p == period) {
+			matching = append(matching, name)
+		}
+	}
+	return matching
+}
+
+//rankingPeriod returns the period asked for by a ranking request and the id of the period it selects,
+//which is the current one when the request has no id
+func (bhs *BasicService) rankingPeriod(requestPeriod string, requestPeriodId string) (models.Period, string, error) {
+	if requestPeriod == "" {
+		requestPeriod = string(models.PeriodAllTime)
+	}
+	period, err := models.ParsePeriod(requestPeriod)
+	if err != nil {
+		return "", "", models.NewValidationError(models.CodeInvalidPeriod, "The only periods accepted are: all_time, daily, weekly and monthly.")
+	}
+	if period == models.PeriodAllTime {
+		if requestPeriodId != "" {
+			return "", "", models.NewValidationError(models.CodeInvalidPeriodID, "The all_time period has no period id.")
+		}
+		return period, "", nil
+	}
+
+	calendar := bhs.Core.Periods
+	if calendar == nil || !calendar.IsEnabled(period) {
+		return "", "", models.NewNotFoundError(models.CodePeriodNotEnabled, "The %s period is not enabled.", period)
+	}
+	if requestPeriodId == "" {
+		return period, calendar.ID(period, calendar.Now()), nil
+	}
+	if err := calendar.ValidateID(period, requestPeriodId); err != nil {
+		return "", "", models.NewValidationError(models.CodeInvalidPeriodID, "The period id %s is not the start of a %s period.", requestPeriodId, period)
+	}
+	return period, requestPeriodId, nil
+}
+
+//rankingBoard returns the leaderboard that holds the ranking of board in the period.
+//It is empty when nobody submitted a score yet in the current period
+func (bhs *BasicService) rankingBoard(ctx context.Context, board string, period models.Period, periodId string) (string, error) {
+	if period == models.PeriodAllTime {
+		return board, nil
+	}
+
+	periodBoard := models.PeriodBoard(board, period, periodId)
+	exists, err := bhs.Core.StoreService.DoesLeaderboardExist(ctx, periodBoard)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return periodBoard, nil
+	}
+	if periodId == bhs.Core.Periods.ID(period, bhs.Core.Periods.Now()) {
+		return "", nil
+	}
+	return "", models.NewNotFoundError(models.CodePeriodNotFound, "The %s period %s of leaderboard %s not found.", period, periodId, board)
+}
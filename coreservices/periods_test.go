@@ -0,0 +1,215 @@
+package coreservices
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeriodCalendar_ID(t *testing.T) {
+	cases := []struct {
+		description string
+		calendar    models.PeriodCalendar
+		period      models.Period
+		time        time.Time
+		expectedID  string
+	}{
+		{
+			description: "should return the day",
+			period:      models.PeriodDaily,
+			time:        time.Date(2026, 10, 17, 23, 59, 0, 0, time.UTC),
+			expectedID:  "2026-10-17",
+		},
+		{
+			description: "should return the first day of the week",
+			calendar:    models.PeriodCalendar{WeekStart: time.Monday},
+			period:      models.PeriodWeekly,
+			time:        time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+			expectedID:  "2026-10-12",
+		},
+		{
+			description: "should return the first day of a week starting on sunday",
+			calendar:    models.PeriodCalendar{WeekStart: time.Sunday},
+			period:      models.PeriodWeekly,
+			time:        time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+			expectedID:  "2026-10-18",
+		},
+		{
+			description: "should return the month",
+			period:      models.PeriodMonthly,
+			time:        time.Date(2026, 10, 31, 12, 0, 0, 0, time.UTC),
+			expectedID:  "2026-10",
+		},
+		{
+			description: "should use the timezone of the calendar",
+			calendar:    models.PeriodCalendar{Location: time.FixedZone("UTC+2", 2*60*60), WeekStart: time.Monday},
+			period:      models.PeriodWeekly,
+			time:        time.Date(2026, 10, 18, 23, 0, 0, 0, time.UTC),
+			expectedID:  "2026-10-19",
+		},
+		{
+			description: "should start the days at the day start",
+			calendar:    models.PeriodCalendar{DayStart: 6 * time.Hour},
+			period:      models.PeriodMonthly,
+			time:        time.Date(2026, 11, 1, 5, 0, 0, 0, time.UTC),
+			expectedID:  "2026-10",
+		},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.expectedID, tc.calendar.ID(tc.period, tc.time), tc.description)
+	}
+}
+
+func TestPeriodCalendar_ValidateID(t *testing.T) {
+	calendar := models.PeriodCalendar{WeekStart: time.Monday, DayStart: 6 * time.Hour}
+	cases := []struct {
+		description string
+		period      models.Period
+		id          string
+		expectedErr bool
+	}{
+		{description: "should accept a day", period: models.PeriodDaily, id: "2026-10-17"},
+		{description: "should accept the first day of a week", period: models.PeriodWeekly, id: "2026-10-12"},
+		{description: "should accept a month", period: models.PeriodMonthly, id: "2026-10"},
+		{description: "should refuse a day that does not start a week", period: models.PeriodWeekly, id: "2026-10-13", expectedErr: true},
+		{description: "should refuse a day for a month", period: models.PeriodMonthly, id: "2026-10-01", expectedErr: true},
+		{description: "should refuse an invalid date", period: models.PeriodDaily, id: "2026-02-30", expectedErr: true},
+	}
+	for _, tc := range cases {
+		err := calendar.ValidateID(tc.period, tc.id)
+		assert.Equal(t, tc.expectedErr, err != nil, tc.description)
+	}
+}
+
+//newPeriodsService returns a service on a memory store that ranks the daily and weekly periods,
+//with a clock that is moved by the returned function
+func newPeriodsService(t *testing.T, start time.Time) (*BasicService, func(time.Duration)) {
+	now := start
+	core := &models.Core{
+		Periods: &models.PeriodCalendar{
+			Periods:   []models.Period{models.PeriodDaily, models.PeriodWeekly},
+			WeekStart: time.Monday,
+			Archives:  1,
+			Clock:     func() time.Time { return now },
+		},
+	}
+	newTestStore(t, NewMemoryStoreService(core))
+	return NewCoreService(core).(*BasicService), func(d time.Duration) { now = now.Add(d) }
+}
+
+//submitTestScore submits the score of the user to the default leaderboard
+func submitTestScore(t *testing.T, service *BasicService, userId int, request *models.SubmitScoreRequest) {
+	_, err := service.HandleSubmitScore(context.Background(), models.DefaultLeaderboard, request, fmt.Sprint(userId))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when submitting the score of %d", err, userId)
+	}
+}
+
+func TestBasicService_Periods(t *testing.T) {
+	//friday
+	service, tick := newPeriodsService(t, time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC))
+	ctx := context.Background()
+
+	submitTestScore(t, service, 1, &models.SubmitScoreRequest{Score: "+10"})
+	_, err := service.HandleSubmitScores(ctx, models.DefaultLeaderboard, &models.SubmitScoresRequest{
+		Scores: []models.SubmitScoreRequest{{UserID: 2, Total: &[]int{5}[0]}},
+	})
+	assert.NoError(t, err)
+	//saturday
+	tick(24 * time.Hour)
+	submitTestScore(t, service, 1, &models.SubmitScoreRequest{Score: "+1"})
+	//monday, a new week
+	tick(48 * time.Hour)
+	submitTestScore(t, service, 2, &models.SubmitScoreRequest{Score: "+20"})
+
+	cases := []struct {
+		description      string
+		request          *models.GetRankingRequest
+		expectedResponse *models.GetRankingResponse
+		expectedError    error
+	}{
+		{
+			description: "should return the all time ranking",
+			request:     &models.GetRankingRequest{Type: "top10"},
+			expectedResponse: &models.GetRankingResponse{Ranking: []models.Ranking{
+				{Position: 1, UserID: 2, Score: 25},
+				{Position: 2, UserID: 1, Score: 11},
+			}},
+		},
+		{
+			description: "should return the ranking of the current week",
+			request:     &models.GetRankingRequest{Type: "top10", Period: "weekly"},
+			expectedResponse: &models.GetRankingResponse{
+				Period:   models.PeriodWeekly,
+				PeriodID: "2026-10-19",
+				Ranking:  []models.Ranking{{Position: 1, UserID: 2, Score: 20}},
+			},
+		},
+		{
+			description: "should return the ranking of a past week",
+			request:     &models.GetRankingRequest{Type: "top10", Period: "weekly", PeriodID: "2026-10-12"},
+			expectedResponse: &models.GetRankingResponse{
+				Period:   models.PeriodWeekly,
+				PeriodID: "2026-10-12",
+				Ranking: []models.Ranking{
+					{Position: 1, UserID: 1, Score: 11},
+					{Position: 2, UserID: 2, Score: 5},
+				},
+			},
+		},
+		{
+			description: "should return the ranking of a past day",
+			request:     &models.GetRankingRequest{Type: "user1/1", Period: "daily", PeriodID: "2026-10-17"},
+			expectedResponse: &models.GetRankingResponse{
+				Period:   models.PeriodDaily,
+				PeriodID: "2026-10-17",
+				Ranking:  []models.Ranking{{Position: 1, UserID: 1, Score: 1}},
+			},
+		},
+		{
+			description:   "should return error when the past period is no longer archived",
+			request:       &models.GetRankingRequest{Type: "top10", Period: "daily", PeriodID: "2026-10-16"},
+			expectedError: models.NewNotFoundError(models.CodePeriodNotFound, "The daily period 2026-10-16 of leaderboard default not found."),
+		},
+		{
+			description:   "should return error when the period is unknown",
+			request:       &models.GetRankingRequest{Type: "top10", Period: "yearly"},
+			expectedError: models.NewValidationError(models.CodeInvalidPeriod, "The only periods accepted are: all_time, daily, weekly and monthly."),
+		},
+		{
+			description:   "should return error when the period is not enabled",
+			request:       &models.GetRankingRequest{Type: "top10", Period: "monthly"},
+			expectedError: models.NewNotFoundError(models.CodePeriodNotEnabled, "The monthly period is not enabled."),
+		},
+		{
+			description:   "should return error when the all time period has an id",
+			request:       &models.GetRankingRequest{Type: "top10", Period: "all_time", PeriodID: "2026-10-12"},
+			expectedError: models.NewValidationError(models.CodeInvalidPeriodID, "The all_time period has no period id."),
+		},
+		{
+			description:   "should return error when the period id is not the start of the period",
+			request:       &models.GetRankingRequest{Type: "top10", Period: "weekly", PeriodID: "2026-10-13"},
+			expectedError: models.NewValidationError(models.CodeInvalidPeriodID, "The period id 2026-10-13 is not the start of a weekly period."),
+		},
+	}
+	for _, tc := range cases {
+		res, err := service.HandleGetRanking(ctx, models.DefaultLeaderboard, tc.request)
+		assert.Equal(t, tc.expectedResponse, res, tc.description)
+		assert.Equal(t, tc.expectedError, err, tc.description)
+	}
+
+	//tuesday, nobody submitted a score yet
+	tick(24 * time.Hour)
+	res, err := service.HandleGetRanking(ctx, models.DefaultLeaderboard, &models.GetRankingRequest{Type: "top10", Period: "daily"})
+	assert.NoError(t, err)
+	assert.Equal(t, &models.GetRankingResponse{Period: models.PeriodDaily, PeriodID: "2026-10-20", Ranking: []models.Ranking{}}, res,
+		"should return an empty ranking for a period without scores")
+
+	leaderboards, err := service.HandleGetLeaderboards(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{models.DefaultLeaderboard}, leaderboards.Leaderboards, "should not list the boards of the periods")
+}
@@ -0,0 +1,403 @@
+package coreservices
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+const (
+	walPrefix      = "wal-"
+	snapshotPrefix = "snapshot-"
+	logExtension   = ".log"
+)
+
+//NewPersistenceService - will return a PersistenceService that keeps the mutations of the store in an
+//append-only log inside dir, which is compacted into snapshots. With fsync every mutation is flushed to
+//the disk before it is applied.
+//It will also add it to the core
+func NewPersistenceService(core *models.Core, dir string, fsync bool) (models.PersistenceService, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	persistenceService := FilePersistenceService{
+		core:  core,
+		dir:   dir,
+		fsync: fsync,
+	}
+	core.Persistence = &persistenceService
+	return &persistenceService, nil
+}
+
+//FilePersistenceService keeps numbered log and snapshot files: snapshot-N holds the store as it was
+//before wal-N was opened, so the store is recovered from the last snapshot and the logs from N on
+type FilePersistenceService struct {
+	core  *models.Core
+	dir   string
+	fsync bool
+
+	//mu serializes the mutations, so the order of the log is the order they were applied in
+	mu  sync.Mutex
+	seq int
+	wal *os.File
+}
+
+func (p *FilePersistenceService) Log(ctx context.Context, mutation models.Mutation, apply func() error) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.wal == nil {
+		return errors.New("the persistence log is not open")
+	}
+	//a mutation that is not applied is cut off the log, so it is not replayed on the next start
+	info, err := p.wal.Stat()
+	if err != nil {
+		return err
+	}
+	if err := writeMutation(p.wal, mutation); err != nil {
+		return p.truncate(ctx, info.Size(), err)
+	}
+	if p.fsync {
+		if err := p.wal.Sync(); err != nil {
+			return p.truncate(ctx, info.Size(), err)
+		}
+	}
+
+	if err := apply(); err != nil {
+		return p.truncate(ctx, info.Size(), err)
+	}
+	return nil
+}
+
+//truncate cuts the log back to size after the mutation written past it failed with cause, which it returns.
+//A log that can't be cut back is closed, since it would replay a mutation that was not applied.
+//The caller must hold the lock
+func (p *FilePersistenceService) truncate(ctx context.Context, size int64, cause error) error {
+	err := p.wal.Truncate(size)
+	if err == nil && p.fsync {
+		err = p.wal.Sync()
+	}
+	if err != nil {
+		p.core.GetLogger().Error(ctx, "closing the log that could not be truncated", models.LogFields{"offset": size, "error": err.Error()})
+		p.wal.Close()
+		p.wal = nil
+	}
+	return cause
+}
+
+func (p *FilePersistenceService) Snapshot(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	//a closed log is not opened again, since nothing is written to it after the shutdown
+	if p.wal == nil {
+		return errors.New("the persistence log is not open")
+	}
+	return p.snapshot(ctx)
+}
+
+func (p *FilePersistenceService) Recover(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	snapshots, err := p.sequences(snapshotPrefix)
+	if err != nil {
+		return err
+	}
+	wals, err := p.sequences(walPrefix)
+	if err != nil {
+		return err
+	}
+
+	first := 0
+	if len(snapshots) > 0 {
+		first = snapshots[len(snapshots)-1]
+		p.seq = first
+		if err := p.replay(ctx, p.path(snapshotPrefix, first)); err != nil {
+			return err
+		}
+	}
+	for _, seq := range wals {
+		//older logs are already in the snapshot
+		if seq < first {
+			continue
+		}
+		p.seq = seq
+		if err := p.replay(ctx, p.path(walPrefix, seq)); err != nil {
+			return err
+		}
+	}
+
+	//compacts what was recovered and opens a new log
+	return p.snapshot(ctx)
+}
+
+func (p *FilePersistenceService) Ready() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	//the log is opened once the store is recovered, and closed on shutdown
+	if p.wal == nil {
+		return errors.New("the persistence log is not open")
+	}
+	return nil
+}
+
+func (p *FilePersistenceService) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.wal == nil {
+		return nil
+	}
+	//without fsync the last mutations may still be in the buffers of the system
+	err := p.wal.Sync()
+	if closeErr := p.wal.Close(); err == nil {
+		err = closeErr
+	}
+	p.wal = nil
+	return err
+}
+
+//snapshot writes the store to the next snapshot, switches to the next log and removes the older files.
+//The caller must hold the lock
+func (p *FilePersistenceService) snapshot(ctx context.Context) error {
+	next := p.seq + 1
+	if err := p.writeSnapshot(ctx, p.path(snapshotPrefix, next)); err != nil {
+		return err
+	}
+
+	wal, err := os.OpenFile(p.path(walPrefix, next), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	if p.wal != nil {
+		p.wal.Close()
+	}
+	p.wal = wal
+	p.seq = next
+
+	for _, prefix := range []string{snapshotPrefix, walPrefix} {
+		sequences, err := p.sequences(prefix)
+		if err != nil {
+			return err
+		}
+		for _, seq := range sequences {
+			if seq < next {
+				if err := os.Remove(p.path(prefix, seq)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+//writeSnapshot writes every leaderboard and user of the store to path.
+//The users are written in the order of the ranking, so replaying them keeps the order of equal scores
+func (p *FilePersistenceService) writeSnapshot(ctx context.Context, path string) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	boards, err := p.core.StoreService.GetLeaderboards(ctx)
+	if err != nil {
+		return err
+	}
+	for _, board := range boards {
+		if err := writeMutation(w, models.Mutation{Type: models.MutationCreateLeaderboard, Board: board}); err != nil {
+			return err
+		}
+
+		total, err := p.core.StoreService.CountUsers(ctx, board)
+		if err != nil {
+			return err
+		}
+		if total > 0 {
+			ranking, err := p.core.StoreService.GetUsers(ctx, board, total)
+			if err != nil {
+				return err
+			}
+			for _, user := range ranking {
+				mutation := models.Mutation{Type: models.MutationAbsoluteScore, Board: board, UserID: user.UserID, Score: user.Score}
+				if err := writeMutation(w, mutation); err != nil {
+					return err
+				}
+			}
+		}
+
+		//the frozen users are restored as they are, since they may not be in the ranking:
+		//the hidden ones never are, and the others are not once their board is reset
+		frozen, err := p.core.StoreService.GetFrozenUsers(ctx, board)
+		if err != nil {
+			return err
+		}
+		for _, user := range frozen {
+			mutation := models.Mutation{Type: models.MutationRestoreFrozenUser, Board: board, UserID: user.UserID, Score: user.Score, Hidden: user.Hidden}
+			if err := writeMutation(w, mutation); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+//replay applies the mutations of the file at path to the store.
+//A crash in the middle of a write leaves a truncated record at the end of the file, which is cut off
+func (p *FilePersistenceService) replay(ctx context.Context, path string) error {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	var offset int64
+	for {
+		mutation, size, err := readMutation(r)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			p.core.GetLogger().Warn(ctx, "truncating the log", models.LogFields{"path": path, "offset": offset, "error": err.Error()})
+			return f.Truncate(offset)
+		}
+		offset += size
+
+		if err := applyMutation(ctx, p.core.StoreService, mutation); err != nil {
+			p.core.GetLogger().Warn(ctx, "skipping a mutation of the log", models.LogFields{"path": path, "mutation": mutation, "error": err.Error()})
+		}
+	}
+}
+
+//applyMutation applies a mutation of the log to the store
+func applyMutation(ctx context.Context, store models.StoreService, mutation models.Mutation) error {
+	switch mutation.Type {
+	case models.MutationCreateLeaderboard:
+		exists, err := store.DoesLeaderboardExist(ctx, mutation.Board)
+		if err != nil || exists {
+			return err
+		}
+		return store.CreateLeaderboard(ctx, mutation.Board)
+	case models.MutationDeleteLeaderboard:
+		return store.DeleteLeaderboard(ctx, mutation.Board)
+	case models.MutationAbsoluteScore, models.MutationRelativeScore:
+		return applySubmissions(ctx, store, mutation, []models.ScoreSubmission{scoreSubmission(mutation)})
+	case models.MutationBatch:
+		submissions := make([]models.ScoreSubmission, len(mutation.Mutations))
+		for i, m := range mutation.Mutations {
+			submissions[i] = scoreSubmission(m)
+		}
+		return applySubmissions(ctx, store, mutation, submissions)
+	case models.MutationRestoreFrozenUser:
+		return store.RestoreFrozenUser(ctx, mutation.Board, models.FrozenUser{UserID: mutation.UserID, Score: mutation.Score, Hidden: mutation.Hidden})
+	case models.MutationDeleteUser, models.MutationResetUser, models.MutationMergeUsers,
+		models.MutationFreezeUser, models.MutationUnfreezeUser, models.MutationResetBoard:
+		return applyAdminMutation(ctx, store, mutation)
+	}
+	return fmt.Errorf("unknown mutation type %q", mutation.Type)
+}
+
+//applySubmissions applies the submissions of a score mutation to its board and to the boards of its periods
+func applySubmissions(ctx context.Context, store models.StoreService, mutation models.Mutation, submissions []models.ScoreSubmission) error {
+	_, _, err := store.SubmitScoresWithPeriods(ctx, mutation.Board, mutation.Periods, submissions)
+	return err
+}
+
+//applyAdminMutation applies an admin mutation to its board and then to the boards of its periods,
+//skipping the boards of the periods that the user is not part of
+func applyAdminMutation(ctx context.Context, store models.StoreService, mutation models.Mutation) error {
+	for i, board := range append([]string{mutation.Board}, mutation.Periods...) {
+		err := applyBoardMutation(ctx, store, board, mutation)
+		if err == sql.ErrNoRows && i > 0 {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//applyBoardMutation applies an admin mutation to a single board
+func applyBoardMutation(ctx context.Context, store models.StoreService, board string, mutation models.Mutation) error {
+	switch mutation.Type {
+	case models.MutationDeleteUser:
+		return store.DeleteUser(ctx, board, mutation.UserID)
+	case models.MutationResetUser:
+		return store.UpdateAbsoluteUserScore(ctx, board, mutation.UserID, 0)
+	case models.MutationMergeUsers:
+		return store.MergeUsers(ctx, board, mutation.UserID, mutation.Into, mutation.Strategy)
+	case models.MutationFreezeUser:
+		return store.FreezeUser(ctx, board, mutation.UserID, mutation.Hidden)
+	case models.MutationUnfreezeUser:
+		_, err := store.UnfreezeUser(ctx, board, mutation.UserID)
+		return err
+	case models.MutationResetBoard:
+		_, err := store.ResetBoard(ctx, board)
+		return err
+	}
+	return fmt.Errorf("unknown admin mutation type %q", mutation.Type)
+}
+
+//scoreSubmission returns the submission of a score mutation
+func scoreSubmission(mutation models.Mutation) models.ScoreSubmission {
+	return models.ScoreSubmission{
+		UserID:   mutation.UserID,
+		Score:    mutation.Score,
+		Absolute: mutation.Type == models.MutationAbsoluteScore,
+	}
+}
+
+func (p *FilePersistenceService) path(prefix string, seq int) string {
+	return filepath.Join(p.dir, fmt.Sprintf("%s%020d%s", prefix, seq, logExtension))
+}
+
+//sequences returns the sorted sequence numbers of the files with the given prefix
+func (p *FilePersistenceService) sequences(prefix string) ([]int, error) {
+	paths, err := filepath.Glob(filepath.Join(p.dir, prefix+"*"+logExtension))
+	if err != nil {
+		return nil, err
+	}
+
+	sequences := make([]int, 0, len(paths))
+	for _, path := range paths {
+		name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), prefix), logExtension)
+		seq, err := strconv.Atoi(name)
+		if err != nil {
+			continue
+		}
+		sequences = append(sequences, seq)
+	}
+	sort.Ints(sequences)
+	return sequences, nil
+}
@@ -0,0 +1,251 @@
+package coreservices
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+//recoverTestStore creates a memory store and recovers it from the persistence log in dir
+func recoverTestStore(t *testing.T, dir string) (models.StoreService, models.PersistenceService) {
+	core := &models.Core{}
+	store := newTestStore(t, NewMemoryStoreService(core))
+	persistence, err := NewPersistenceService(core, dir, false)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening the persistence", err)
+	}
+	if err := persistence.Recover(context.Background()); err != nil {
+		t.Fatalf("an error '%s' was not expected when recovering the store", err)
+	}
+	t.Cleanup(func() { persistence.Close() })
+	return store, persistence
+}
+
+//logTestMutation logs the mutation and applies it to store
+func logTestMutation(t *testing.T, store models.StoreService, persistence models.PersistenceService, mutation models.Mutation) {
+	ctx := context.Background()
+	err := persistence.Log(ctx, mutation, func() error {
+		return applyMutation(ctx, store, mutation)
+	})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when logging %+v", err, mutation)
+	}
+}
+
+func TestFilePersistenceService_Recover(t *testing.T) {
+	mutations := []models.Mutation{
+		{Type: models.MutationCreateLeaderboard, Board: "weekly"},
+		{Type: models.MutationAbsoluteScore, Board: models.DefaultLeaderboard, UserID: 1, Score: 10},
+		{Type: models.MutationRelativeScore, Board: models.DefaultLeaderboard, UserID: 2, Score: 30},
+		{Type: models.MutationRelativeScore, Board: models.DefaultLeaderboard, UserID: 1, Score: 5},
+		{Type: models.MutationAbsoluteScore, Board: "weekly", UserID: 3, Score: 7, Periods: []string{"weekly@daily@2026-01-01"}},
+		{Type: models.MutationCreateLeaderboard, Board: "daily"},
+		{Type: models.MutationDeleteLeaderboard, Board: "daily"},
+		{Type: models.MutationBatch, Board: models.DefaultLeaderboard, Mutations: []models.Mutation{
+			{Type: models.MutationRelativeScore, Board: models.DefaultLeaderboard, UserID: 2, Score: 5},
+			{Type: models.MutationAbsoluteScore, Board: models.DefaultLeaderboard, UserID: 4, Score: 1},
+		}},
+		{Type: models.MutationAbsoluteScore, Board: models.DefaultLeaderboard, UserID: 5, Score: 50},
+		{Type: models.MutationAbsoluteScore, Board: models.DefaultLeaderboard, UserID: 6, Score: 3},
+		{Type: models.MutationAbsoluteScore, Board: models.DefaultLeaderboard, UserID: 7, Score: 9},
+		{Type: models.MutationFreezeUser, Board: models.DefaultLeaderboard, UserID: 5, Hidden: true},
+		{Type: models.MutationFreezeUser, Board: models.DefaultLeaderboard, UserID: 4},
+		{Type: models.MutationMergeUsers, Board: models.DefaultLeaderboard, UserID: 6, Into: 1, Strategy: models.MergeSum},
+		{Type: models.MutationDeleteUser, Board: models.DefaultLeaderboard, UserID: 7},
+	}
+	expectedLeaderboards := []string{models.DefaultLeaderboard, "weekly", "weekly@daily@2026-01-01"}
+	expectedRanking := []models.Ranking{
+		{Position: 1, UserID: 2, Score: 35},
+		{Position: 2, UserID: 1, Score: 18},
+		{Position: 3, UserID: 4, Score: 1},
+	}
+	expectedFrozen := []models.FrozenUser{{UserID: 4}, {UserID: 5, Hidden: true, Score: 50}}
+
+	cases := []struct {
+		description string
+		snapshot    bool
+		restarts    int
+	}{
+		{
+			description: "should recover the store from the log",
+		},
+		{
+			description: "should recover the store from a snapshot",
+			snapshot:    true,
+		},
+		{
+			description: "should not apply the log twice after several restarts",
+			restarts:    3,
+		},
+	}
+	for _, tc := range cases {
+		ctx := context.Background()
+		dir := t.TempDir()
+		store, persistence := recoverTestStore(t, dir)
+		for _, mutation := range mutations {
+			logTestMutation(t, store, persistence, mutation)
+		}
+		if tc.snapshot {
+			assert.NoError(t, persistence.Snapshot(ctx), tc.description)
+		}
+		assert.NoError(t, persistence.Close(), tc.description)
+
+		for i := 0; i <= tc.restarts; i++ {
+			store, persistence = recoverTestStore(t, dir)
+			assert.NoError(t, persistence.Close(), tc.description)
+		}
+
+		leaderboards, err := store.GetLeaderboards(ctx)
+		assert.NoError(t, err, tc.description)
+		assert.Equal(t, expectedLeaderboards, leaderboards, tc.description)
+		ranking, err := store.GetUsers(ctx, models.DefaultLeaderboard, 10)
+		assert.NoError(t, err, tc.description)
+		assert.Equal(t, expectedRanking, ranking, tc.description)
+		frozen, err := store.GetFrozenUsers(ctx, models.DefaultLeaderboard)
+		assert.NoError(t, err, tc.description)
+		assert.Equal(t, expectedFrozen, frozen, tc.description)
+
+		files, err := filepath.Glob(filepath.Join(dir, "*.log"))
+		assert.NoError(t, err, tc.description)
+		assert.Len(t, files, 2, "should only keep the last snapshot and log")
+	}
+}
+
+func TestFilePersistenceService_RecoverFrozenUsersAfterReset(t *testing.T) {
+	mutations := []models.Mutation{
+		{Type: models.MutationAbsoluteScore, Board: models.DefaultLeaderboard, UserID: 1, Score: 10},
+		{Type: models.MutationAbsoluteScore, Board: models.DefaultLeaderboard, UserID: 2, Score: 20},
+		{Type: models.MutationFreezeUser, Board: models.DefaultLeaderboard, UserID: 1},
+		{Type: models.MutationFreezeUser, Board: models.DefaultLeaderboard, UserID: 2, Hidden: true},
+		{Type: models.MutationResetBoard, Board: models.DefaultLeaderboard},
+	}
+	expectedFrozen := []models.FrozenUser{{UserID: 1}, {UserID: 2, Hidden: true}}
+
+	cases := []struct {
+		description string
+		snapshot    bool
+	}{
+		{
+			description: "should recover the frozen users of a reset board from the log",
+		},
+		{
+			description: "should recover the frozen users of a reset board from a snapshot",
+			snapshot:    true,
+		},
+	}
+	for _, tc := range cases {
+		ctx := context.Background()
+		dir := t.TempDir()
+		store, persistence := recoverTestStore(t, dir)
+		for _, mutation := range mutations {
+			logTestMutation(t, store, persistence, mutation)
+		}
+		if tc.snapshot {
+			assert.NoError(t, persistence.Snapshot(ctx), tc.description)
+		}
+		assert.NoError(t, persistence.Close(), tc.description)
+
+		store, _ = recoverTestStore(t, dir)
+		frozen, err := store.GetFrozenUsers(ctx, models.DefaultLeaderboard)
+		assert.NoError(t, err, tc.description)
+		assert.Equal(t, expectedFrozen, frozen, tc.description)
+		ranking, err := store.GetUsers(ctx, models.DefaultLeaderboard, 10)
+		assert.NoError(t, err, tc.description)
+		assert.Empty(t, ranking, tc.description)
+	}
+}
+
+func TestFilePersistenceService_RecoverTruncatedLog(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, persistence := recoverTestStore(t, dir)
+	logTestMutation(t, store, persistence, models.Mutation{Type: models.MutationAbsoluteScore, Board: models.DefaultLeaderboard, UserID: 1, Score: 10})
+	logTestMutation(t, store, persistence, models.Mutation{Type: models.MutationAbsoluteScore, Board: models.DefaultLeaderboard, UserID: 2, Score: 20})
+	assert.NoError(t, persistence.Close())
+
+	//simulates a crash in the middle of writing the last record
+	wal := persistence.(*FilePersistenceService).path(walPrefix, 1)
+	info, err := os.Stat(wal)
+	assert.NoError(t, err)
+	assert.NoError(t, os.Truncate(wal, info.Size()-3))
+
+	store, _ = recoverTestStore(t, dir)
+	ranking, err := store.GetUsers(ctx, models.DefaultLeaderboard, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Ranking{{Position: 1, UserID: 1, Score: 10}}, ranking, "should start with the records before the truncated one")
+}
+
+func TestFilePersistenceService_LogFailedMutation(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, persistence := recoverTestStore(t, dir)
+	logTestMutation(t, store, persistence, models.Mutation{Type: models.MutationAbsoluteScore, Board: models.DefaultLeaderboard, UserID: 1, Score: 10})
+
+	failure := errors.New("the store is not reachable")
+	err := persistence.Log(ctx, models.Mutation{Type: models.MutationAbsoluteScore, Board: models.DefaultLeaderboard, UserID: 2, Score: 20}, func() error {
+		return failure
+	})
+	assert.Equal(t, failure, err, "should return the error of the mutation that was not applied")
+	assert.NoError(t, persistence.Ready(), "should keep the log open after a mutation that was not applied")
+	err = persistence.Log(ctx, models.Mutation{Type: models.MutationRelativeScore, Board: "weekly", UserID: 1, Score: 5}, func() error {
+		return applyMutation(ctx, store, models.Mutation{Type: models.MutationRelativeScore, Board: "weekly", UserID: 1, Score: 5})
+	})
+	assert.Error(t, err, "should fail a mutation of a leaderboard that does not exist")
+	logTestMutation(t, store, persistence, models.Mutation{Type: models.MutationRelativeScore, Board: models.DefaultLeaderboard, UserID: 1, Score: 5})
+	assert.NoError(t, persistence.Close())
+
+	store, _ = recoverTestStore(t, dir)
+	ranking, err := store.GetUsers(ctx, models.DefaultLeaderboard, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Ranking{{Position: 1, UserID: 1, Score: 15}}, ranking, "should only recover the mutations that were applied")
+	exists, err := store.DoesLeaderboardExist(ctx, "weekly")
+	assert.NoError(t, err)
+	assert.False(t, exists, "should only recover the mutations that were applied")
+}
+
+func TestFilePersistenceService_LogBeforeRecover(t *testing.T) {
+	core := &models.Core{}
+	persistence, err := NewPersistenceService(core, t.TempDir(), false)
+	assert.NoError(t, err)
+	assert.Equal(t, persistence, core.Persistence, "should add the persistence to the core")
+	assert.Error(t, persistence.Ready(), "should not be ready before the log is recovered")
+
+	applied := false
+	err = persistence.Log(context.Background(), models.Mutation{Type: models.MutationCreateLeaderboard, Board: "weekly"}, func() error {
+		applied = true
+		return nil
+	})
+	assert.Error(t, err, "should not log before the log is recovered")
+	assert.False(t, applied, "should not apply a mutation that was not logged")
+}
+
+func TestFilePersistenceService_Close(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, persistence := recoverTestStore(t, dir)
+	logTestMutation(t, store, persistence, models.Mutation{Type: models.MutationAbsoluteScore, Board: models.DefaultLeaderboard, UserID: 1, Score: 10})
+	assert.NoError(t, persistence.Ready(), "should be ready once the log is recovered")
+	assert.NoError(t, persistence.Close())
+	assert.NoError(t, persistence.Close(), "should close the log only once")
+	assert.Error(t, persistence.Ready(), "should not be ready after the log is closed")
+
+	err := persistence.Log(ctx, models.Mutation{Type: models.MutationAbsoluteScore, Board: models.DefaultLeaderboard, UserID: 2, Score: 20}, func() error {
+		return nil
+	})
+	assert.Error(t, err, "should not log after the log is closed")
+	assert.Error(t, persistence.Snapshot(ctx), "should not open the log again after it is closed")
+
+	files, err := filepath.Glob(filepath.Join(dir, walPrefix+"*"+logExtension))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1, "should not switch to another log after the log is closed")
+
+	store, _ = recoverTestStore(t, dir)
+	ranking, err := store.GetUsers(ctx, models.DefaultLeaderboard, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Ranking{{Position: 1, UserID: 1, Score: 10}}, ranking, "should recover the mutations logged before the close")
+}
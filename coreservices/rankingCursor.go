@@ -0,0 +1,33 @@
+package coreservices
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+//encodeRankingCursor returns the opaque form of the cursor that is handed to the clients
+func encodeRankingCursor(cursor *models.RankingCursor) (string, error) {
+	bytes, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+//decodeRankingCursor returns the cursor of its opaque form
+func decodeRankingCursor(encoded string) (*models.RankingCursor, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	cursor := new(models.RankingCursor)
+	decoder := json.NewDecoder(strings.NewReader(string(bytes)))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(cursor); err != nil {
+		return nil, err
+	}
+	return cursor, nil
+}
@@ -0,0 +1,52 @@
+package coreservices
+
+import (
+	"math"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+//rankingWindow returns the 1-based first position and the number of users selected by the query,
+//out of total users. position is the position of the user of user queries
+func rankingWindow(query *models.RankingQuery, total int, position int) (first int, limit int) {
+	switch query.Kind {
+	case models.RankingTop:
+		return 1, query.Count
+	case models.RankingBottom:
+		first = total - query.Count + 1
+		if first < 1 {
+			first = 1
+		}
+		return first, total - first + 1
+	case models.RankingAt:
+		offset, limit := usersBetweenWindow(query.Position, query.Around)
+		return offset + 1, limit
+	case models.RankingAroundUser:
+		offset, limit := usersBetweenWindow(position, query.Around)
+		return offset + 1, limit
+	case models.RankingRange:
+		return query.From, query.To - query.From + 1
+	case models.RankingPercentile:
+		return 1, int(math.Ceil(float64(total) * (100 - query.Percentile) / 100))
+	}
+	return 1, 0
+}
+
+//needsTotal returns whether the window of the query depends on the number of users of the board
+func needsTotal(query *models.RankingQuery) bool {
+	return query.Kind == models.RankingBottom || query.Kind == models.RankingPercentile
+}
+
+//filterRanking keeps the users of the ranking that pass the filters of the query
+func filterRanking(ranking []models.Ranking, query *models.RankingQuery) []models.Ranking {
+	if query.MinScore == nil {
+		return ranking
+	}
+	filtered := make([]models.Ranking, 0, len(ranking))
+	for _, user := range ranking {
+		if user.Score >= *query.MinScore {
+			filtered = append(filtered, user)
+		}
+	}
+	return filtered
+}
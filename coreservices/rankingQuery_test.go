@@ -0,0 +1,113 @@
+package coreservices
+
+import (
+	"testing"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRankingQuery(t *testing.T) {
+	cases := []struct {
+		description   string
+		rankingType   string
+		expectedQuery *models.RankingQuery
+		expectedError error
+	}{
+		{
+			description:   "should parse top",
+			rankingType:   "Top100",
+			expectedQuery: &models.RankingQuery{Kind: models.RankingTop, Count: 100},
+		},
+		{
+			description:   "should parse bottom",
+			rankingType:   "bottom5",
+			expectedQuery: &models.RankingQuery{Kind: models.RankingBottom, Count: 5},
+		},
+		{
+			description:   "should parse at",
+			rankingType:   "at100/3",
+			expectedQuery: &models.RankingQuery{Kind: models.RankingAt, Position: 100, Around: 3},
+		},
+		{
+			description:   "should parse range",
+			rankingType:   "range10-20",
+			expectedQuery: &models.RankingQuery{Kind: models.RankingRange, From: 10, To: 20},
+		},
+		{
+			description:   "should parse user",
+			rankingType:   "USER123/2",
+			expectedQuery: &models.RankingQuery{Kind: models.RankingAroundUser, UserID: 123, Around: 2},
+		},
+		{
+			description:   "should parse a decimal percentile",
+			rankingType:   "percentile99.5",
+			expectedQuery: &models.RankingQuery{Kind: models.RankingPercentile, Percentile: 99.5},
+		},
+		{
+			description:   "should parse a negative score filter",
+			rankingType:   "top10,score>=-20",
+			expectedQuery: &models.RankingQuery{Kind: models.RankingTop, Count: 10, MinScore: &[]int{-20}[0]},
+		},
+		{
+			description:   "should reject a thousands separator",
+			rankingType:   "top1,000",
+			expectedError: models.NewValidationError(models.CodeInvalidRankingType, "Unexpected '0' at position 6 of the type, expected a score>={x} filter."),
+		},
+		{
+			description:   "should reject a decimal position",
+			rankingType:   "at1.5/2",
+			expectedError: models.NewValidationError(models.CodeInvalidRankingType, "Unexpected '.' at position 4 of the type, expected a digit or '/'."),
+		},
+		{
+			description:   "should reject a type that ends early",
+			rankingType:   "user12/",
+			expectedError: models.NewValidationError(models.CodeInvalidRankingType, "Unexpected end of the type at position 8, expected the number of users around the user."),
+		},
+		{
+			description:   "should reject trailing characters",
+			rankingType:   "top10 ",
+			expectedError: models.NewValidationError(models.CodeInvalidRankingType, "Unexpected ' ' at position 6 of the type, expected the end of the type or a ,score>={x} filter."),
+		},
+		{
+			description:   "should reject a type without a keyword",
+			rankingType:   "100",
+			expectedError: models.NewValidationError(models.CodeInvalidRankingType, "Unexpected '1' at position 1 of the type, expected a ranking type: "+"top{n}, bottom{n}, at{position}/{n}, range{from}-{to}, user{id}/{n} or percentile{p}, optionally followed by ,score>={x}."),
+		},
+		{
+			description:   "should reject an unknown filter",
+			rankingType:   "top10,id>=3",
+			expectedError: models.NewValidationError(models.CodeInvalidRankingType, "Unexpected 'i' at position 7 of the type, expected a score>={x} filter."),
+		},
+		{
+			description:   "should reject a repeated filter",
+			rankingType:   "top10,score>=3,score>=4",
+			expectedError: models.NewValidationError(models.CodeInvalidRankingType, "The score filter at position 16 of the type is repeated."),
+		},
+		{
+			description:   "should reject a number that is too big",
+			rankingType:   "top99999999999999999999",
+			expectedError: models.NewValidationError(models.CodeInvalidPosition, "The number at position 4 of the type is too big."),
+		},
+		{
+			description:   "should reject a range that ends before it starts",
+			rankingType:   "range20-10",
+			expectedError: models.NewValidationError(models.CodeInvalidPosition, "The range must start at a position greater than 0 and end at or after it."),
+		},
+		{
+			description:   "should reject the percentile 100",
+			rankingType:   "percentile100",
+			expectedError: models.NewValidationError(models.CodeInvalidPosition, "The percentile must be at least 0 and less than 100."),
+		},
+		{
+			description:   "should reject a bottom of 0 users",
+			rankingType:   "bottom0",
+			expectedError: models.NewValidationError(models.CodeInvalidPosition, "The position must be greater than 0."),
+		},
+	}
+	for _, tc := range cases {
+		query, err := models.ParseRankingQuery(tc.rankingType)
+		assert.Equal(t, tc.expectedQuery, query, tc.description)
+		assert.Equal(t, tc.expectedError, err, tc.description)
+	}
+}
@@ -0,0 +1,147 @@
+package coreservices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+//rateLimitPruneInterval is how often the buckets that are full again are forgotten
+const rateLimitPruneInterval = time.Minute
+
+//NewTokenBucketRateLimitService - will return a RateLimitService that keeps a token bucket in memory
+//for each rule and key of the requests.
+//It will also add it to the core
+func NewTokenBucketRateLimitService(core *models.Core, rules []models.RateLimitRule) (models.RateLimitService, error) {
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	rateLimitService := TokenBucketRateLimitService{
+		core:      core,
+		rules:     rules,
+		now:       time.Now,
+		buckets:   make(map[bucketKey]*tokenBucket),
+		throttled: make([]int64, len(rules)),
+	}
+	core.RateLimits = &rateLimitService
+	return &rateLimitService, nil
+}
+
+type TokenBucketRateLimitService struct {
+	core  *models.Core
+	rules []models.RateLimitRule
+	now   func() time.Time
+
+	mu      sync.Mutex
+	buckets map[bucketKey]*tokenBucket
+	//throttled holds how many requests each rule throttled
+	throttled []int64
+	nextPrune time.Time
+}
+
+//bucketKey is the bucket of a key of the requests of a rule
+type bucketKey struct {
+	rule int
+	key  string
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+func (s *TokenBucketRateLimitService) Allow(ctx context.Context, request *models.RateLimitRequest) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.prune(now)
+
+	var wait time.Duration
+	var buckets []*tokenBucket
+	for i, rule := range s.rules {
+		key := request.Key(rule.By)
+		if key == "" || !rule.Matches(request) {
+			continue
+		}
+		bucket, ok := s.buckets[bucketKey{rule: i, key: key}]
+		if !ok {
+			bucket = &tokenBucket{tokens: float64(rule.Burst), updated: now}
+			s.buckets[bucketKey{rule: i, key: key}] = bucket
+		}
+		refill(bucket, rule, now)
+		if bucket.tokens < 1 {
+			s.throttled[i]++
+			//the time until the bucket has a whole token again
+			if w := time.Duration(math.Ceil((1 - bucket.tokens) / rule.Rate * float64(time.Second))); w > wait {
+				wait = w
+			}
+		}
+		buckets = append(buckets, bucket)
+	}
+	if wait > 0 {
+		return wait, nil
+	}
+
+	for _, bucket := range buckets {
+		bucket.tokens--
+	}
+	return 0, nil
+}
+
+func (s *TokenBucketRateLimitService) Status(ctx context.Context) ([]models.RateLimitStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := make([]models.RateLimitStatus, len(s.rules))
+	for i, rule := range s.rules {
+		status[i] = models.RateLimitStatus{RateLimitRule: rule, Throttled: s.throttled[i]}
+	}
+	return status, nil
+}
+
+//refill adds the tokens of the time since the bucket was last updated, up to the burst of the rule
+func refill(bucket *tokenBucket, rule models.RateLimitRule, now time.Time) {
+	if elapsed := now.Sub(bucket.updated).Seconds(); elapsed > 0 {
+		bucket.tokens = math.Min(float64(rule.Burst), bucket.tokens+elapsed*rule.Rate)
+	}
+	bucket.updated = now
+}
+
+//prune forgets the buckets that are full again, at most once per rateLimitPruneInterval,
+//since a new bucket starts full
+func (s *TokenBucketRateLimitService) prune(now time.Time) {
+	if now.Before(s.nextPrune) {
+		return
+	}
+	for key, bucket := range s.buckets {
+		rule := s.rules[key.rule]
+		refill(bucket, rule, now)
+		if bucket.tokens >= float64(rule.Burst) {
+			delete(s.buckets, key)
+		}
+	}
+	s.nextPrune = now.Add(rateLimitPruneInterval)
+}
+
+//LoadRateLimits reads the rules from a JSON file holding a list of rules, eg:
+//[{"route": "/user/{user_id}/score", "method": "POST", "by": "user", "rate": 1, "burst": 5}]
+func LoadRateLimits(path string) ([]models.RateLimitRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rules []models.RateLimitRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("invalid rate limits file %s: %w", path, err)
+	}
+	return rules, nil
+}
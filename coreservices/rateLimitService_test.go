@@ -0,0 +1,174 @@
+package coreservices
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pedrocmart/leaderboard-service/mocks"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTokenBucketRateLimitService(t *testing.T) {
+	cases := []struct {
+		description   string
+		rules         []models.RateLimitRule
+		expectedError bool
+	}{
+		{
+			description: "should return a new rate limit service",
+			rules:       []models.RateLimitRule{{Route: "*", By: models.RateLimitByIP, Rate: 10, Burst: 20}},
+		},
+		{
+			description:   "should return error when a rule has no route",
+			rules:         []models.RateLimitRule{{By: models.RateLimitByIP, Rate: 10, Burst: 20}},
+			expectedError: true,
+		},
+		{
+			description:   "should return error when a rule has an unknown key",
+			rules:         []models.RateLimitRule{{Route: "*", By: "country", Rate: 10, Burst: 20}},
+			expectedError: true,
+		},
+		{
+			description:   "should return error when a rule has no rate",
+			rules:         []models.RateLimitRule{{Route: "*", By: models.RateLimitByIP, Burst: 20}},
+			expectedError: true,
+		},
+		{
+			description:   "should return error when a rule has no burst",
+			rules:         []models.RateLimitRule{{Route: "*", By: models.RateLimitByIP, Rate: 10}},
+			expectedError: true,
+		},
+	}
+	for _, tc := range cases {
+		core := &models.Core{}
+		rateLimitService, err := NewTokenBucketRateLimitService(core, tc.rules)
+		if tc.expectedError {
+			assert.Error(t, err, tc.description)
+			assert.Nil(t, core.RateLimits, tc.description)
+			continue
+		}
+		assert.NoError(t, err, tc.description)
+		assert.Equal(t, rateLimitService, core.RateLimits, tc.description)
+	}
+}
+
+func TestTokenBucketRateLimitService_Allow(t *testing.T) {
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	rules := []models.RateLimitRule{
+		{Route: "/user/{user_id}/score", Method: "POST", By: models.RateLimitByUser, Rate: 1, Burst: 2},
+		{Route: models.AllRoutes, By: models.RateLimitByAPIKey, Rate: 10, Burst: 3},
+	}
+	score := func(userId string, apiKey string) *models.RateLimitRequest {
+		return &models.RateLimitRequest{Route: "/user/{user_id}/score", Method: "POST", UserID: userId, APIKey: apiKey, IP: "10.0.0.1"}
+	}
+	ranking := &models.RateLimitRequest{Route: "/ranking", Method: "GET", IP: "10.0.0.2"}
+
+	cases := []struct {
+		description  string
+		elapsed      time.Duration
+		request      *models.RateLimitRequest
+		expectedWait time.Duration
+	}{
+		{description: "should allow the first score of the user", request: score("1", "server")},
+		{description: "should allow the burst of the user", request: score("1", "server")},
+		{description: "should throttle the user above the burst", request: score("1", "server"), expectedWait: time.Second},
+		{description: "should allow the scores of another user", request: score("2", "server")},
+		{description: "should throttle the key above its burst", request: score("3", "server"), expectedWait: 100 * time.Millisecond},
+		{description: "should allow another key", request: score("3", "website")},
+		{description: "should count the requests that are not signed by their IP", request: ranking},
+		{description: "should refill the buckets with the time", elapsed: 500 * time.Millisecond, request: score("3", "server")},
+		{description: "should not take tokens of the buckets when a request is throttled", request: score("1", "server"), expectedWait: 500 * time.Millisecond},
+		{description: "should allow the user once the bucket has a token", elapsed: 500 * time.Millisecond, request: score("1", "server")},
+	}
+
+	rateLimitService, err := NewTokenBucketRateLimitService(&models.Core{}, rules)
+	assert.NoError(t, err)
+	rateLimitService.(*TokenBucketRateLimitService).now = func() time.Time { return now }
+	for _, tc := range cases {
+		now = now.Add(tc.elapsed)
+		wait, err := rateLimitService.Allow(context.Background(), tc.request)
+		assert.NoError(t, err, tc.description)
+		assert.Equal(t, tc.expectedWait, wait, tc.description)
+	}
+
+	status, err := rateLimitService.Status(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []models.RateLimitStatus{{RateLimitRule: rules[0], Throttled: 2}, {RateLimitRule: rules[1], Throttled: 1}}, status, "should count the throttled requests of each rule")
+}
+
+func TestTokenBucketRateLimitService_ForgetsFullBuckets(t *testing.T) {
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	rateLimitService, err := NewTokenBucketRateLimitService(&models.Core{}, []models.RateLimitRule{{Route: models.AllRoutes, By: models.RateLimitByIP, Rate: 1, Burst: 1}})
+	assert.NoError(t, err)
+	tokenBuckets := rateLimitService.(*TokenBucketRateLimitService)
+	tokenBuckets.now = func() time.Time { return now }
+
+	_, err = rateLimitService.Allow(context.Background(), &models.RateLimitRequest{Route: "/ranking", IP: "10.0.0.1"})
+	assert.NoError(t, err)
+	assert.Len(t, tokenBuckets.buckets, 1, "should keep the bucket that is not full")
+
+	now = now.Add(2 * rateLimitPruneInterval)
+	_, err = rateLimitService.Allow(context.Background(), &models.RateLimitRequest{Route: "/ranking", IP: "10.0.0.2"})
+	assert.NoError(t, err)
+	assert.Len(t, tokenBuckets.buckets, 1, "should forget the bucket that is full again")
+}
+
+func TestLoadRateLimits(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "rate-limits.json")
+	assert.NoError(t, os.WriteFile(valid, []byte(`[{"route": "/user/{user_id}/score", "method": "POST", "by": "user", "rate": 1, "burst": 5}]`), 0600))
+	invalid := filepath.Join(dir, "invalid.json")
+	assert.NoError(t, os.WriteFile(invalid, []byte(`{"route": "*"}`), 0600))
+
+	rules, err := LoadRateLimits(valid)
+	assert.NoError(t, err, "should load the rules")
+	assert.Equal(t, []models.RateLimitRule{{Route: "/user/{user_id}/score", Method: "POST", By: models.RateLimitByUser, Rate: 1, Burst: 5}}, rules, "should load the rules")
+
+	_, err = LoadRateLimits(invalid)
+	assert.Error(t, err, "should return error when the file is not a list of rules")
+}
+
+func TestBasicService_HandleGetRateLimits(t *testing.T) {
+	status := []models.RateLimitStatus{{RateLimitRule: models.RateLimitRule{Route: models.AllRoutes, By: models.RateLimitByIP, Rate: 1, Burst: 1}, Throttled: 3}}
+	cases := []struct {
+		description      string
+		rateLimits       models.RateLimitService
+		expectedResponse *models.GetRateLimitsResponse
+		expectedError    error
+	}{
+		{
+			description: "should return the rate limits",
+			rateLimits: &mocks.RateLimitServiceMock{
+				StatusFunc: func(ctx context.Context) ([]models.RateLimitStatus, error) {
+					return status, nil
+				},
+			},
+			expectedResponse: &models.GetRateLimitsResponse{RateLimits: status},
+		},
+		{
+			description: "should return error when Status",
+			rateLimits: &mocks.RateLimitServiceMock{
+				StatusFunc: func(ctx context.Context) ([]models.RateLimitStatus, error) {
+					return nil, fmt.Errorf("mock-error")
+				},
+			},
+			expectedError: fmt.Errorf("mock-error"),
+		},
+		{
+			description:   "should return error when the rate limits are not enabled",
+			expectedError: models.NewNotFoundError(models.CodeRateLimitsNotEnabled, "The rate limits are not enabled."),
+		},
+	}
+	for _, tc := range cases {
+		basicAPIService := BasicService{Core: &models.Core{RateLimits: tc.rateLimits}}
+
+		res, err := basicAPIService.HandleGetRateLimits(context.Background())
+		assert.Equal(t, tc.expectedResponse, res, tc.description)
+		assert.Equal(t, tc.expectedError, err, tc.description)
+	}
+}
@@ -0,0 +1,75 @@
+package coreservices
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+//NewMemoryReviewQueueService - will return a ReviewQueueService that keeps the review queue in memory.
+//Once it holds maxEntries the oldest entries are dropped, a maxEntries that is not positive keeps all of them.
+//It will also add it to the core
+func NewMemoryReviewQueueService(core *models.Core, maxEntries int) models.ReviewQueueService {
+	reviewQueueService := MemoryReviewQueueService{
+		core:       core,
+		maxEntries: maxEntries,
+		now:        time.Now,
+	}
+	core.Reviews = &reviewQueueService
+	return &reviewQueueService
+}
+
+type MemoryReviewQueueService struct {
+	core       *models.Core
+	maxEntries int
+	now        func() time.Time
+
+	mu sync.RWMutex
+	//entries go from the oldest to the most recent
+	entries []models.ReviewEntry
+	lastID  int64
+}
+
+func (q *MemoryReviewQueueService) Add(ctx context.Context, entries []models.ReviewEntry) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := q.now()
+	for _, entry := range entries {
+		q.lastID++
+		entry.ID = q.lastID
+		entry.CreatedAt = now
+		q.entries = append(q.entries, entry)
+	}
+	if q.maxEntries > 0 && len(q.entries) > q.maxEntries {
+		//copies the kept entries so the dropped ones can be collected
+		q.entries = append([]models.ReviewEntry(nil), q.entries[len(q.entries)-q.maxEntries:]...)
+	}
+
+	return nil
+}
+
+func (q *MemoryReviewQueueService) List(ctx context.Context, query models.ReviewQuery) ([]models.ReviewEntry, int, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	reviews := make([]models.ReviewEntry, 0)
+	total := 0
+	for i := len(q.entries) - 1; i >= 0; i-- {
+		entry := q.entries[i]
+		if query.Board != "" && entry.Board != query.Board {
+			continue
+		}
+		if query.Verdict != "" && entry.Verdict != query.Verdict {
+			continue
+		}
+		if total >= query.Offset && len(reviews) < query.Limit {
+			reviews = append(reviews, entry)
+		}
+		total++
+	}
+
+	return reviews, total, nil
+}
@@ -0,0 +1,69 @@
+package coreservices
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryReviewQueueService_List(t *testing.T) {
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	core := &models.Core{}
+	reviews := NewMemoryReviewQueueService(core, 3)
+	reviews.(*MemoryReviewQueueService).now = func() time.Time { return now }
+	assert.Equal(t, reviews, core.Reviews, "should add the review queue to the core")
+
+	err := reviews.Add(context.Background(), []models.ReviewEntry{
+		{Board: models.DefaultLeaderboard, UserID: 1, Verdict: models.VerdictRejected},
+		{Board: "weekly", UserID: 2, Verdict: models.VerdictFlagged},
+		{Board: models.DefaultLeaderboard, UserID: 3, Verdict: models.VerdictFlagged},
+		{Board: models.DefaultLeaderboard, UserID: 4, Verdict: models.VerdictRejected},
+	})
+	assert.NoError(t, err)
+
+	cases := []struct {
+		description   string
+		query         models.ReviewQuery
+		expectedIDs   []int64
+		expectedTotal int
+	}{
+		{
+			description:   "should list the most recent entries, dropping the oldest above the maximum",
+			query:         models.ReviewQuery{Limit: 10},
+			expectedIDs:   []int64{4, 3, 2},
+			expectedTotal: 3,
+		},
+		{
+			description:   "should list the entries of the board",
+			query:         models.ReviewQuery{Board: models.DefaultLeaderboard, Limit: 10},
+			expectedIDs:   []int64{4, 3},
+			expectedTotal: 2,
+		},
+		{
+			description:   "should list the entries of the verdict",
+			query:         models.ReviewQuery{Verdict: models.VerdictFlagged, Limit: 10},
+			expectedIDs:   []int64{3, 2},
+			expectedTotal: 2,
+		},
+		{
+			description:   "should list a page of the entries",
+			query:         models.ReviewQuery{Limit: 1, Offset: 1},
+			expectedIDs:   []int64{3},
+			expectedTotal: 3,
+		},
+	}
+	for _, tc := range cases {
+		entries, total, err := reviews.List(context.Background(), tc.query)
+		assert.NoError(t, err, tc.description)
+		assert.Equal(t, tc.expectedTotal, total, tc.description)
+		ids := make([]int64, len(entries))
+		for i, entry := range entries {
+			ids[i] = entry.ID
+			assert.Equal(t, now, entry.CreatedAt, tc.description)
+		}
+		assert.Equal(t, tc.expectedIDs, ids, tc.description)
+	}
+}
@@ -2,10 +2,11 @@ package coreservices
 
 import (
 	"context"
-	"errors"
-	"fmt"
+	"database/sql"
+	"math"
 	"regexp"
 	"strconv"
+	"time"
 
 	"github.com/pedrocmart/leaderboard-service/models"
 )
@@ -14,7 +15,8 @@ import (
 //It will also add it to the core
 func NewCoreService(core *models.Core) models.Service {
 	basicService := BasicService{
-		Core: core,
+		Core:        core,
+		reviewLocks: &boardLocks{},
 	}
 	core.Service = &basicService
 	return &basicService
@@ -22,153 +24,687 @@ func NewCoreService(core *models.Core) models.Service {
 
 type BasicService struct {
 	Core *models.Core
+	//reviewLocks holds back the submissions of a board while the ones reviewed before them are applied
+	reviewLocks *boardLocks
 }
 
-func (bhs *BasicService) HandleSubmitScore(ctx context.Context, request *models.SubmitScoreRequest, userId string) (*models.SubmitScoreResponse, error) {
-	var err error
-	var score, currentScore int
-	var isAbsolute bool
+//leaderboardNameRegex restricts board names to characters that are safe in a URL path segment
+var leaderboardNameRegex = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
 
-	if request.Score != "" && request.Total != nil {
-		err := fmt.Errorf("You can only submit the absolute score or the relative score.")
+//relativeScoreRegex makes sure the user only inputs + or - in the beginning, followed by a number
+//eg: -100 or +100
+var relativeScoreRegex = regexp.MustCompile(`^[+-]\d+$`)
+
+func (bhs *BasicService) HandleCreateLeaderboard(ctx context.Context, request *models.CreateLeaderboardRequest) (*models.LeaderboardResponse, error) {
+	if !leaderboardNameRegex.MatchString(request.Name) {
+		return nil, models.NewValidationError(models.CodeInvalidLeaderboardName, "The leaderboard name must have between 1 and 64 letters, numbers, [_] or [-].")
+	}
+
+	exists, err := bhs.Core.StoreService.DoesLeaderboardExist(ctx, request.Name)
+	if err != nil {
 		return nil, err
 	}
+	if exists {
+		return nil, models.NewConflictError(models.CodeLeaderboardExists, "Leaderboard %s already exists.", request.Name)
+	}
 
-	request.UserID, err = strconv.Atoi(userId)
+	mutation := models.Mutation{Type: models.MutationCreateLeaderboard, Board: request.Name}
+	err = bhs.logMutation(ctx, mutation, func() error {
+		return bhs.Core.StoreService.CreateLeaderboard(ctx, request.Name)
+	})
 	if err != nil {
-		err := fmt.Errorf("User_Id must be an integer.")
 		return nil, err
 	}
 
-	exists, err := bhs.Core.StoreService.DoesUserExist(ctx, request.UserID)
+	return &models.LeaderboardResponse{Name: request.Name}, nil
+}
+
+func (bhs *BasicService) HandleGetLeaderboards(ctx context.Context) (*models.GetLeaderboardsResponse, error) {
+	leaderboards, err := bhs.Core.StoreService.GetLeaderboards(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if request.Total != nil {
-		score = *request.Total
-		isAbsolute = true
-	} else {
-		//makes sure the user only inputs + or - in the beginning, followed by a number
-		//eg: -100 or +100
-		re := regexp.MustCompile(`^[+|-](\d+)$`)
-		subMatchAll := re.FindAllString(request.Score, -1)
-		if len(subMatchAll) > 0 {
-			score, err = strconv.Atoi(subMatchAll[0])
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			err = errors.New("Wrong format for the relative score. It must start with a [+] or [-] symbol.")
-			return nil, err
+	//the boards of the periods are part of their leaderboard
+	names := make([]string, 0, len(leaderboards))
+	for _, leaderboard := range leaderboards {
+		if !models.IsPeriodBoard(leaderboard) {
+			names = append(names, leaderboard)
 		}
 	}
 
-	if !exists {
-		err := bhs.Core.StoreService.CreateUser(ctx, request.UserID, score)
+	return &models.GetLeaderboardsResponse{Leaderboards: names}, nil
+}
+
+func (bhs *BasicService) HandleDeleteLeaderboard(ctx context.Context, board string) (*models.LeaderboardResponse, error) {
+	if board == models.DefaultLeaderboard {
+		return nil, models.NewConflictError(models.CodeDefaultLeaderboard, "The default leaderboard can not be deleted.")
+	}
+
+	err := bhs.checkLeaderboard(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+
+	err = bhs.deleteBoard(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+
+	err = bhs.deletePeriodBoards(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+
+	if bhs.Core.History != nil {
+		err = bhs.Core.History.DeleteLeaderboard(ctx, board)
 		if err != nil {
 			return nil, err
 		}
-		currentScore = score
-	} else {
-		if isAbsolute {
-			err := bhs.Core.StoreService.UpdateAbsoluteUserScore(ctx, request.UserID, score)
-			if err != nil {
-				return nil, err
-			}
-			currentScore = score
-		} else {
-			err = bhs.Core.StoreService.UpdateRelativeUserScore(ctx, request.UserID, score)
-			if err != nil {
-				return nil, err
-			}
+	}
+	//ends the streams of the leaderboard, which can't find it anymore
+	bhs.publish(board)
 
-			user, err := bhs.Core.StoreService.GetUserById(ctx, request.UserID)
-			if err != nil {
-				return nil, err
-			}
-			currentScore = user.Score
-		}
+	return &models.LeaderboardResponse{Name: board}, nil
+}
+
+//logMutation applies the mutation with apply, writing it first to the persistence log when there is one
+func (bhs *BasicService) logMutation(ctx context.Context, mutation models.Mutation, apply func() error) error {
+	if bhs.Core.Persistence == nil {
+		return apply()
 	}
+	return bhs.Core.Persistence.Log(ctx, mutation, apply)
+}
+
+//publish notifies the streams of board that its scores changed, when there are streams
+func (bhs *BasicService) publish(board string) {
+	if bhs.Core.Stream != nil {
+		bhs.Core.Stream.Publish(board)
+	}
+}
+
+//checkLeaderboard returns an error if the board does not exist
+func (bhs *BasicService) checkLeaderboard(ctx context.Context, board string) error {
+	exists, err := bhs.Core.StoreService.DoesLeaderboardExist(ctx, board)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return models.NewNotFoundError(models.CodeLeaderboardNotFound, "Leaderboard %s not found.", board)
+	}
+	return nil
+}
+
+func (bhs *BasicService) HandleSubmitScore(ctx context.Context, board string, request *models.SubmitScoreRequest, userId string) (*models.SubmitScoreResponse, error) {
+	var err error
+
+	request.UserID, err = strconv.Atoi(userId)
+	if err != nil {
+		err := models.NewValidationError(models.CodeInvalidUserID, "User_Id must be an integer.")
+		return nil, err
+	}
+
+	submission, err := parseScoreSubmission(request)
+	if err != nil {
+		return nil, err
+	}
+
+	err = bhs.checkLeaderboard(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+
+	frozen, err := bhs.frozenUsers(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+	if frozen[submission.UserID] {
+		return nil, userFrozenError(submission.UserID)
+	}
+
+	unlock := bhs.lockReviews(board)
+	defer unlock()
+	reviews, err := bhs.reviewSubmissions(ctx, board, request.Source, []models.ScoreSubmission{*submission})
+	if err != nil {
+		return nil, err
+	}
+	review := reviews[0]
+	if review != nil && review.Verdict == models.VerdictRejected {
+		bhs.queueReviews(ctx, []models.ReviewEntry{*review})
+		return nil, rejectionError(review)
+	}
+
+	//the user is created or updated and her/his new score read in a single transaction with the boards of the periods,
+	//so concurrent submissions for the same user can't create duplicates or read each other's updates,
+	//and a failure leaves no board written that the log does not have
+	var changes []models.ScoreChange
+	var created []string
+	mutation := scoreMutation(board, *submission)
+	mutation.Periods = bhs.periodBoards(board)
+	err = bhs.logMutation(ctx, mutation, func() error {
+		changes, created, err = bhs.Core.StoreService.SubmitScoresWithPeriods(ctx, board, mutation.Periods, []models.ScoreSubmission{*submission})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	bhs.pruneArchives(ctx, created)
+	bhs.recordHistory(ctx, board, request.Source, []models.ScoreSubmission{*submission}, changes)
+	bhs.observeSubmissions(board, []models.ScoreSubmission{*submission}, changes)
+	bhs.publish(board)
+	if review != nil {
+		bhs.queueReviews(ctx, flaggedReviews([]*models.ReviewEntry{review}, changes))
+	}
+
 	response := new(models.SubmitScoreResponse)
 	response.UserID = request.UserID
-	response.Score = currentScore
+	response.Score = changes[0].Score
 
 	return response, nil
 }
 
-func (bhs *BasicService) HandleGetRanking(ctx context.Context, rankingType string) (*models.GetRankingResponse, error) {
-	var ranking []models.Ranking
+//maxBatchScores limits how many scores can be submitted in a single batch
+const maxBatchScores = 1000
 
-	//regex to make sure the user inputs "top" and a number after it
-	//eg: top100
-	isTopType, err := regexp.MatchString(`(?i)^top(\d+)$`, rankingType)
+func (bhs *BasicService) HandleSubmitScores(ctx context.Context, board string, request *models.SubmitScoresRequest) (*models.SubmitScoresResponse, error) {
+	mode := request.Mode
+	if mode == "" {
+		mode = models.BatchAtomic
+	}
+	if mode != models.BatchAtomic && mode != models.BatchBestEffort {
+		return nil, models.NewValidationError(models.CodeInvalidBatch, "The only modes accepted are: atomic and best_effort.")
+	}
+	if len(request.Scores) == 0 || len(request.Scores) > maxBatchScores {
+		return nil, models.NewValidationError(models.CodeInvalidBatch, "A batch must have between 1 and %d scores.", maxBatchScores)
+	}
+
+	err := bhs.checkLeaderboard(ctx, board)
 	if err != nil {
 		return nil, err
 	}
 
-	if isTopType {
-		//gets the number from rankingType
-		re := regexp.MustCompile(`[-]?\d[\d,]*[\.]?[\d{2}]*`)
-		subMatchAll := re.FindAllString(rankingType, -1)
-		topPositions := 0
-		if len(subMatchAll) > 0 {
-			topPositions, err = strconv.Atoi(subMatchAll[0])
-			if err != nil {
-				return nil, err
-			}
+	frozen, err := bhs.frozenUsers(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]models.SubmitScoreResult, len(request.Scores))
+	submissions := make([]models.ScoreSubmission, 0, len(request.Scores))
+	//applied holds the index in results of each submission
+	applied := make([]int, 0, len(request.Scores))
+	invalid := false
+	for i := range request.Scores {
+		results[i].UserID = request.Scores[i].UserID
+		submission, err := parseScoreSubmission(&request.Scores[i])
+		if err == nil && frozen[submission.UserID] {
+			err = userFrozenError(submission.UserID)
+		}
+		if err != nil {
+			results[i].Error = err.Error()
+			results[i].Code = models.ErrorCode(err)
+			invalid = true
+			continue
+		}
+		submissions = append(submissions, *submission)
+		applied = append(applied, i)
+	}
+
+	unlock := bhs.lockReviews(board)
+	defer unlock()
+	reviews, err := bhs.reviewSubmissions(ctx, board, request.Source, submissions)
+	if err != nil {
+		return nil, err
+	}
+	//the rejected submissions are invalid, and the rest keep their review to be queued once applied
+	var rejected []models.ReviewEntry
+	kept := 0
+	for j, review := range reviews {
+		if review != nil && review.Verdict == models.VerdictRejected {
+			err := rejectionError(review)
+			results[applied[j]].Error = err.Error()
+			results[applied[j]].Code = models.ErrorCode(err)
+			rejected = append(rejected, *review)
+			invalid = true
+			continue
+		}
+		submissions[kept], applied[kept], reviews[kept] = submissions[j], applied[j], review
+		kept++
+	}
+	submissions, applied, reviews = submissions[:kept], applied[:kept], reviews[:kept]
+	bhs.queueReviews(ctx, rejected)
+
+	response := &models.SubmitScoresResponse{Results: results}
+	if invalid && mode == models.BatchAtomic {
+		for _, i := range applied {
+			results[i].Error = "Not applied since other scores of the batch are invalid."
+			results[i].Code = models.CodeBatchNotApplied
+		}
+		return response, nil
+	}
+	if len(submissions) == 0 {
+		return response, nil
+	}
+
+	mutation := models.Mutation{Type: models.MutationBatch, Board: board, Periods: bhs.periodBoards(board)}
+	for _, submission := range submissions {
+		mutation.Mutations = append(mutation.Mutations, scoreMutation(board, submission))
+	}
+	var changes []models.ScoreChange
+	var created []string
+	err = bhs.logMutation(ctx, mutation, func() error {
+		changes, created, err = bhs.Core.StoreService.SubmitScoresWithPeriods(ctx, board, mutation.Periods, submissions)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	bhs.pruneArchives(ctx, created)
+
+	bhs.recordHistory(ctx, board, request.Source, submissions, changes)
+	bhs.observeSubmissions(board, submissions, changes)
+	bhs.publish(board)
+	bhs.queueReviews(ctx, flaggedReviews(reviews, changes))
+
+	for j, i := range applied {
+		results[i].Score = &changes[j].Score
+	}
+	response.Applied = len(applied)
+
+	return response, nil
+}
+
+//recordHistory keeps the changes of the submissions in the history of the core, when there is one.
+//The scores are already applied by then, so a failure is only logged
+func (bhs *BasicService) recordHistory(ctx context.Context, board string, source string, submissions []models.ScoreSubmission, changes []models.ScoreChange) {
+	if bhs.Core.History == nil {
+		return
+	}
+
+	entries := make([]models.ScoreHistoryEntry, len(submissions))
+	for i, submission := range submissions {
+		score := submission.Score
+		entries[i] = models.ScoreHistoryEntry{
+			UserID:        changes[i].UserID,
+			PreviousScore: changes[i].PreviousScore,
+			Score:         changes[i].Score,
+			Source:        source,
 		}
-		if topPositions <= 0 {
-			return nil, errors.New("The position must be greater than 0.")
+		if submission.Absolute {
+			entries[i].Total = &score
+		} else {
+			entries[i].Delta = &score
 		}
+	}
+	if err := bhs.Core.History.Record(ctx, board, entries); err != nil {
+		bhs.Core.GetLogger().Error(ctx, "error while recording the history", models.LogFields{"board": board, "error": err.Error()})
+	}
+}
 
-		ranking, err = bhs.Core.StoreService.GetUsers(ctx, topPositions)
+//observeSubmissions counts the applied submissions in the metrics of the core, when there are some
+func (bhs *BasicService) observeSubmissions(board string, submissions []models.ScoreSubmission, changes []models.ScoreChange) {
+	if bhs.Core.Metrics == nil {
+		return
+	}
+	bhs.Core.Metrics.ObserveSubmissions(board, submissions, changes)
+}
+
+//parseScoreSubmission validates the absolute or relative score of the request
+func parseScoreSubmission(request *models.SubmitScoreRequest) (*models.ScoreSubmission, error) {
+	if request.Score != "" && request.Total != nil {
+		err := models.NewValidationError(models.CodeInvalidScore, "You can only submit the absolute score or the relative score.")
+		return nil, err
+	}
+
+	if request.Total != nil {
+		return &models.ScoreSubmission{UserID: request.UserID, Score: *request.Total, Absolute: true}, nil
+	}
+
+	if !relativeScoreRegex.MatchString(request.Score) {
+		err := models.NewValidationError(models.CodeInvalidScore, "Wrong format for the relative score. It must start with a [+] or [-] symbol.")
+		return nil, err
+	}
+	score, err := strconv.Atoi(request.Score)
+	if err != nil {
+		//the digits are checked by the regex, so only a score that doesn't fit an integer is left
+		return nil, models.NewValidationError(models.CodeInvalidScore, "The relative score must be between %d and %d.", math.MinInt, math.MaxInt)
+	}
+
+	return &models.ScoreSubmission{UserID: request.UserID, Score: score}, nil
+}
+
+//scoreMutation returns the mutation of the persistence log for the submission
+func scoreMutation(board string, submission models.ScoreSubmission) models.Mutation {
+	mutation := models.Mutation{Type: models.MutationRelativeScore, Board: board, UserID: submission.UserID, Score: submission.Score}
+	if submission.Absolute {
+		mutation.Type = models.MutationAbsoluteScore
+	}
+	return mutation
+}
+
+func (bhs *BasicService) HandleGetRanking(ctx context.Context, board string, request *models.GetRankingRequest) (*models.GetRankingResponse, error) {
+	period, periodId, err := bhs.rankingPeriod(request.Period, request.PeriodID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = bhs.checkLeaderboard(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+
+	rankingBoard, err := bhs.rankingBoard(ctx, board, period, periodId)
+	if err != nil {
+		return nil, err
+	}
+	if rankingBoard == "" {
+		//nobody submitted a score yet in the current period
+		return &models.GetRankingResponse{Period: period, PeriodID: periodId, Ranking: []models.Ranking{}}, nil
+	}
+	response, err := bhs.getRanking(ctx, rankingBoard, request.Type)
+	if err != nil {
+		return nil, err
+	}
+	if period != models.PeriodAllTime {
+		response.Period = period
+		response.PeriodID = periodId
+	}
+
+	return response, nil
+}
+
+const (
+	//defaultRankingPageLimit is the number of users of a page of the ranking when the request has no limit
+	defaultRankingPageLimit = 100
+	maxRankingPageLimit     = 1000
+)
+
+func (bhs *BasicService) HandleGetRankingPage(ctx context.Context, board string, request *models.GetRankingPageRequest) (*models.GetRankingPageResponse, error) {
+	limit := defaultRankingPageLimit
+	if request.Limit != "" {
+		var err error
+		limit, err = strconv.Atoi(request.Limit)
+		if err != nil || limit <= 0 || limit > maxRankingPageLimit {
+			return nil, models.NewValidationError(models.CodeInvalidLimit, "The limit must be a number between 1 and %d.", maxRankingPageLimit)
+		}
+	}
+
+	var cursor *models.RankingCursor
+	if request.Cursor != "" {
+		var err error
+		cursor, err = decodeRankingCursor(request.Cursor)
 		if err != nil {
-			return nil, err
+			return nil, models.NewValidationError(models.CodeInvalidCursor, "The cursor must be the next_cursor of a previous page.")
 		}
+	}
+
+	period, periodId, err := bhs.rankingPeriod(request.Period, request.PeriodID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = bhs.checkLeaderboard(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.GetRankingPageResponse{Ranking: []models.Ranking{}}
+	if period != models.PeriodAllTime {
+		response.Period = period
+		response.PeriodID = periodId
+	}
+	rankingBoard, err := bhs.rankingBoard(ctx, board, period, periodId)
+	if err != nil {
+		return nil, err
+	}
+	if rankingBoard == "" {
+		//nobody submitted a score yet in the current period
+		return response, nil
+	}
 
-	} else {
-		//regex to make sure the user inputs "at", a number after, followed by slash and another number
-		//eg: at100/3
-		isAtType, err := regexp.MatchString(`(?i)^at(\d+)/(\d+)$`, rankingType) //At100/3
+	ranking, last, err := bhs.Core.StoreService.GetUsersAfter(ctx, rankingBoard, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	response.Ranking = ranking
+	//a short page is the last one
+	if len(ranking) == limit && last != nil {
+		response.NextCursor, err = encodeRankingCursor(last)
 		if err != nil {
 			return nil, err
 		}
+	}
 
-		if !isAtType {
-			return nil, errors.New("The only formats accepted for type are: Top100 and At100/3.")
-		}
+	return response, nil
+}
 
-		//gets the two numbers from rankingType
-		re := regexp.MustCompile(`[-]?\d[\d,]*[\.]?[\d{2}]*`)
-		subMatchAll := re.FindAllString(rankingType, -1)
-		topPositions, around := 0, 0
+//streamKeepAlive is how often a stream sends a keepalive, so idle connections are not closed by proxies
+const streamKeepAlive = 30 * time.Second
 
-		if len(subMatchAll) > 0 {
-			topPositions, err = strconv.Atoi(subMatchAll[0])
-			if err != nil {
-				return nil, err
-			}
+func (bhs *BasicService) HandleStreamRanking(ctx context.Context, board string, request *models.GetRankingRequest, send func(*models.RankingUpdate) error) error {
+	if bhs.Core.Stream == nil {
+		return models.NewNotFoundError(models.CodeStreamNotEnabled, "The ranking stream is not enabled.")
+	}
+
+	//subscribes before reading the ranking, so the changes made in between are not missed
+	subscription := bhs.Core.Stream.Subscribe(board)
+	defer bhs.Core.Stream.Unsubscribe(subscription)
 
-			around, err = strconv.Atoi(subMatchAll[1])
+	last, err := bhs.HandleGetRanking(ctx, board, request)
+	if err != nil {
+		return err
+	}
+	err = send(rankingSnapshot(last))
+	if err != nil {
+		return err
+	}
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-subscription.Done:
+			return nil
+		case <-keepAlive.C:
+			err = send(&models.RankingUpdate{Type: models.RankingKeepAlive})
+		case <-subscription.Notify:
+			var current *models.GetRankingResponse
+			current, err = bhs.HandleGetRanking(ctx, board, request)
 			if err != nil {
-				return nil, err
+				return err
+			}
+			update := rankingUpdate(last, current)
+			last = current
+			if update == nil {
+				//the change was outside of the ranking
+				continue
 			}
+			err = send(update)
 		}
-
-		if topPositions <= 0 || around <= 0 {
-			return nil, errors.New("The positions must be greater than 0.")
+		if err != nil {
+			return err
 		}
+	}
+}
 
-		//get lower and upper values
-		ranking, err = bhs.Core.StoreService.GetUsersBetween(ctx, topPositions, around)
-		if err != nil {
-			return nil, err
+func rankingSnapshot(ranking *models.GetRankingResponse) *models.RankingUpdate {
+	return &models.RankingUpdate{
+		Type:     models.RankingSnapshot,
+		Period:   ranking.Period,
+		PeriodID: ranking.PeriodID,
+		Ranking:  ranking.Ranking,
+	}
+}
+
+//rankingUpdate returns the update from the last ranking to the current one, or nil if it did not change
+func rankingUpdate(last *models.GetRankingResponse, current *models.GetRankingResponse) *models.RankingUpdate {
+	if last.PeriodID != current.PeriodID {
+		return rankingSnapshot(current)
+	}
+
+	previous := make(map[int]models.Ranking, len(last.Ranking))
+	for _, ranking := range last.Ranking {
+		previous[ranking.UserID] = ranking
+	}
+	update := &models.RankingUpdate{Type: models.RankingDiff, Period: current.Period, PeriodID: current.PeriodID}
+	for _, ranking := range current.Ranking {
+		if p, ok := previous[ranking.UserID]; !ok || p != ranking {
+			update.Changed = append(update.Changed, ranking)
+		}
+		delete(previous, ranking.UserID)
+	}
+	//the users left in previous are not in the current ranking anymore
+	for _, ranking := range last.Ranking {
+		if _, ok := previous[ranking.UserID]; ok {
+			update.Removed = append(update.Removed, ranking.UserID)
 		}
 	}
 
+	if len(update.Changed) == 0 && len(update.Removed) == 0 {
+		return nil
+	}
+	return update
+}
+
+//getRanking returns the ranking of the given type of board
+func (bhs *BasicService) getRanking(ctx context.Context, board string, rankingType string) (*models.GetRankingResponse, error) {
+	query, err := models.ParseRankingQuery(rankingType)
+	if err != nil {
+		return nil, err
+	}
+
+	ranking, err := bhs.Core.StoreService.GetRanking(ctx, board, query)
+	if err == sql.ErrNoRows && query.Kind == models.RankingAroundUser {
+		return nil, models.NewNotFoundError(models.CodeUserNotFound, "User %d not found.", query.UserID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	response := new(models.GetRankingResponse)
 	response.Ranking = ranking
 
 	return response, nil
 }
+
+func (bhs *BasicService) HandleGetUserRank(ctx context.Context, board string, userId string) (*models.GetUserRankResponse, error) {
+	id, err := strconv.Atoi(userId)
+	if err != nil {
+		return nil, models.NewValidationError(models.CodeInvalidUserID, "User_Id must be an integer.")
+	}
+
+	err = bhs.checkLeaderboard(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+
+	user, position, err := bhs.getUserPosition(ctx, board, id)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := bhs.Core.StoreService.CountUsers(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(models.GetUserRankResponse)
+	response.UserID = user.UserID
+	response.Position = position
+	response.Score = user.Score
+	response.Total = total
+	response.Percentile = userPercentile(position, total)
+
+	return response, nil
+}
+
+//getUserPosition returns the user with its position in the ranking of board
+func (bhs *BasicService) getUserPosition(ctx context.Context, board string, id int) (*models.User, int, error) {
+	user, err := bhs.Core.StoreService.GetUserById(ctx, board, id)
+	if err == sql.ErrNoRows {
+		return nil, 0, models.NewNotFoundError(models.CodeUserNotFound, "User %d not found.", id)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	position, err := bhs.Core.StoreService.GetUserPosition(ctx, board, user.UserID, user.Score)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return user, position, nil
+}
+
+const (
+	defaultHistoryLimit = 50
+	maxHistoryLimit     = 1000
+)
+
+func (bhs *BasicService) HandleGetUserHistory(ctx context.Context, board string, userId string, request *models.GetUserHistoryRequest) (*models.GetUserHistoryResponse, error) {
+	id, err := strconv.Atoi(userId)
+	if err != nil {
+		return nil, models.NewValidationError(models.CodeInvalidUserID, "User_Id must be an integer.")
+	}
+
+	query := models.HistoryQuery{Limit: defaultHistoryLimit}
+	if request.From != "" {
+		query.From, err = time.Parse(time.RFC3339, request.From)
+		if err != nil {
+			return nil, models.NewValidationError(models.CodeInvalidHistoryQuery, "From must be a date in the RFC 3339 format, eg: 2006-01-02T15:04:05Z.")
+		}
+	}
+	if request.To != "" {
+		query.To, err = time.Parse(time.RFC3339, request.To)
+		if err != nil {
+			return nil, models.NewValidationError(models.CodeInvalidHistoryQuery, "To must be a date in the RFC 3339 format, eg: 2006-01-02T15:04:05Z.")
+		}
+	}
+	if request.Limit != "" {
+		query.Limit, err = strconv.Atoi(request.Limit)
+		if err != nil || query.Limit < 1 || query.Limit > maxHistoryLimit {
+			return nil, models.NewValidationError(models.CodeInvalidHistoryQuery, "Limit must be an integer between 1 and %d.", maxHistoryLimit)
+		}
+	}
+	if request.Offset != "" {
+		query.Offset, err = strconv.Atoi(request.Offset)
+		if err != nil || query.Offset < 0 {
+			return nil, models.NewValidationError(models.CodeInvalidHistoryQuery, "Offset must be an integer greater than or equal to 0.")
+		}
+	}
+
+	if bhs.Core.History == nil {
+		return nil, models.NewNotFoundError(models.CodeHistoryNotEnabled, "The score history is not enabled.")
+	}
+
+	err = bhs.checkLeaderboard(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+
+	history, total, err := bhs.Core.History.GetHistory(ctx, board, id, query)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(models.GetUserHistoryResponse)
+	response.UserID = id
+	response.Total = total
+	response.History = history
+
+	return response, nil
+}
+
+func (bhs *BasicService) HandleGetRateLimits(ctx context.Context) (*models.GetRateLimitsResponse, error) {
+	if bhs.Core.RateLimits == nil {
+		return nil, models.NewNotFoundError(models.CodeRateLimitsNotEnabled, "The rate limits are not enabled.")
+	}
+
+	status, err := bhs.Core.RateLimits.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GetRateLimitsResponse{RateLimits: status}, nil
+}
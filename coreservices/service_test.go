@@ -2,8 +2,11 @@ package coreservices
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/pedrocmart/leaderboard-service/mocks"
 	"github.com/pedrocmart/leaderboard-service/models"
@@ -26,30 +29,28 @@ func TestNewCoreService(t *testing.T) {
 	}
 	for _, tc := range cases {
 		NewCoreService(tc.core)
-		tc.expectedCore.Service = &BasicService{Core: tc.expectedCore}
+		tc.expectedCore.Service = &BasicService{Core: tc.expectedCore, reviewLocks: &boardLocks{}}
 		assert.Equal(t, tc.expectedCore, tc.core, tc.description)
 	}
 }
 
 func TestBasicService_HandleSubmitScore(t *testing.T) {
 	cases := []struct {
-		description                  string
-		basicAPIService              BasicService
-		ctx                          context.Context
-		request                      *models.SubmitScoreRequest
-		userIdRequest                string
-		expectedResponse             *models.SubmitScoreResponse
-		expectedError                error
-		createUserFuncError          error
-		doesUserExistFuncError       error
-		doesUserExistFunc            bool
-		updateAbsoluteUserScoreError error
-		updateRelativeUserScoreError error
-		getUserByIdError             error
-		getUserById                  *models.User
+		description        string
+		basicAPIService    BasicService
+		ctx                context.Context
+		request            *models.SubmitScoreRequest
+		userIdRequest      string
+		expectedResponse   *models.SubmitScoreResponse
+		expectedError      error
+		expectedSubmission *models.ScoreSubmission
+		change             *models.ScoreChange
+		submitError        error
+		leaderboardMissing bool
+		frozen             []models.FrozenUser
 	}{
 		{
-			description: "should insert and return user with absolute score",
+			description: "should return error when the leaderboard does not exist",
 			basicAPIService: BasicService{
 				Core: &models.Core{},
 			},
@@ -58,30 +59,29 @@ func TestBasicService_HandleSubmitScore(t *testing.T) {
 			request: &models.SubmitScoreRequest{
 				Total: &[]int{320}[0],
 			},
-			doesUserExistFunc:   false,
-			createUserFuncError: nil,
-			expectedResponse: &models.SubmitScoreResponse{
-				UserID: 1,
-				Score:  320,
-			},
+			leaderboardMissing: true,
+			expectedResponse:   nil,
+			expectedError:      models.NewNotFoundError(models.CodeLeaderboardNotFound, "Leaderboard default not found."),
 		},
 		{
-			description: "should return error when converting user_id to int",
+			description: "should upsert and return user with absolute score",
 			basicAPIService: BasicService{
 				Core: &models.Core{},
 			},
 			ctx:           context.Background(),
-			userIdRequest: "abc",
+			userIdRequest: "1",
 			request: &models.SubmitScoreRequest{
 				Total: &[]int{320}[0],
 			},
-			doesUserExistFunc:   false,
-			createUserFuncError: nil,
-			expectedResponse:    nil,
-			expectedError:       fmt.Errorf("User_Id must be an integer."),
+			change:             &models.ScoreChange{UserID: 1, Score: 320, Created: true},
+			expectedSubmission: &models.ScoreSubmission{UserID: 1, Score: 320, Absolute: true},
+			expectedResponse: &models.SubmitScoreResponse{
+				UserID: 1,
+				Score:  320,
+			},
 		},
 		{
-			description: "should return error when sending score and total at the same time",
+			description: "should reject the score of a frozen user",
 			basicAPIService: BasicService{
 				Core: &models.Core{},
 			},
@@ -89,46 +89,39 @@ func TestBasicService_HandleSubmitScore(t *testing.T) {
 			userIdRequest: "1",
 			request: &models.SubmitScoreRequest{
 				Total: &[]int{320}[0],
-				Score: "-100",
 			},
-			doesUserExistFunc:   false,
-			createUserFuncError: nil,
-			expectedResponse:    nil,
-			expectedError:       fmt.Errorf("You can only submit the absolute score or the relative score."),
+			frozen:        []models.FrozenUser{{UserID: 1}},
+			expectedError: models.NewConflictError(models.CodeUserFrozen, "User 1 is frozen."),
 		},
 		{
-			description: "should return error when checks DoesUserExist ",
+			description: "should return error when converting user_id to int",
 			basicAPIService: BasicService{
 				Core: &models.Core{},
 			},
 			ctx:           context.Background(),
-			userIdRequest: "1",
+			userIdRequest: "abc",
 			request: &models.SubmitScoreRequest{
-				Score: "-100",
+				Total: &[]int{320}[0],
 			},
-			doesUserExistFunc:      false,
-			createUserFuncError:    nil,
-			expectedResponse:       nil,
-			expectedError:          fmt.Errorf("mock-error"),
-			doesUserExistFuncError: fmt.Errorf("mock-error"),
+			expectedResponse: nil,
+			expectedError:    models.NewValidationError(models.CodeInvalidUserID, "User_Id must be an integer."),
 		},
 		{
-			description: "should return error when CreateUser",
+			description: "should return error when sending score and total at the same time",
 			basicAPIService: BasicService{
 				Core: &models.Core{},
 			},
 			ctx:           context.Background(),
 			userIdRequest: "1",
 			request: &models.SubmitScoreRequest{
+				Total: &[]int{320}[0],
 				Score: "-100",
 			},
-			doesUserExistFunc:   false,
-			expectedResponse:    nil,
-			expectedError:       fmt.Errorf("mock-error"),
-			createUserFuncError: fmt.Errorf("mock-error"),
+			expectedResponse: nil,
+			expectedError:    models.NewValidationError(models.CodeInvalidScore, "You can only submit the absolute score or the relative score."),
 		},
 		{
-			description: "should return error when UpdateAbsoluteUserScore",
+			description: "should return error when the store fails with absolute score",
 			basicAPIService: BasicService{
 				Core: &models.Core{},
 			},
@@ -137,13 +130,13 @@ func TestBasicService_HandleSubmitScore(t *testing.T) {
 			request: &models.SubmitScoreRequest{
 				Total: &[]int{320}[0],
 			},
-			doesUserExistFunc:            true,
-			expectedResponse:             nil,
-			expectedError:                fmt.Errorf("mock-error"),
-			updateAbsoluteUserScoreError: fmt.Errorf("mock-error"),
+			submitError:        fmt.Errorf("mock-error"),
+			expectedSubmission: &models.ScoreSubmission{UserID: 1, Score: 320, Absolute: true},
+			expectedResponse:   nil,
+			expectedError:      fmt.Errorf("mock-error"),
 		},
 		{
-			description: "should return error when UpdateRelativeUserScore",
+			description: "should return error when the store fails with relative score",
 			basicAPIService: BasicService{
 				Core: &models.Core{},
 			},
@@ -152,13 +145,13 @@ func TestBasicService_HandleSubmitScore(t *testing.T) {
 			request: &models.SubmitScoreRequest{
 				Score: "-100",
 			},
-			doesUserExistFunc:            true,
-			expectedResponse:             nil,
-			expectedError:                fmt.Errorf("mock-error"),
-			updateRelativeUserScoreError: fmt.Errorf("mock-error"),
+			submitError:        fmt.Errorf("mock-error"),
+			expectedSubmission: &models.ScoreSubmission{UserID: 1, Score: -100},
+			expectedResponse:   nil,
+			expectedError:      fmt.Errorf("mock-error"),
 		},
 		{
-			description: "should return error when GetUserById",
+			description: "should upsert and return user with relative score",
 			basicAPIService: BasicService{
 				Core: &models.Core{},
 			},
@@ -167,111 +160,438 @@ func TestBasicService_HandleSubmitScore(t *testing.T) {
 			request: &models.SubmitScoreRequest{
 				Score: "-100",
 			},
-			doesUserExistFunc: true,
-			expectedResponse:  nil,
-			expectedError:     fmt.Errorf("mock-error"),
-			getUserByIdError:  fmt.Errorf("mock-error"),
+			change: &models.ScoreChange{
+				UserID:        1,
+				PreviousScore: 50,
+				Score:         -50,
+			},
+			expectedSubmission: &models.ScoreSubmission{UserID: 1, Score: -100},
+			expectedResponse: &models.SubmitScoreResponse{
+				UserID: 1,
+				Score:  -50,
+			},
 		},
 		{
-			description: "should update and return user with absolute score",
+			description: "should return error when converting score regex",
 			basicAPIService: BasicService{
 				Core: &models.Core{},
 			},
 			ctx:           context.Background(),
 			userIdRequest: "1",
 			request: &models.SubmitScoreRequest{
-				Total: &[]int{320}[0],
-			},
-			doesUserExistFunc: true,
-			expectedResponse: &models.SubmitScoreResponse{
-				UserID: 1,
-				Score:  320,
+				Score: "-100a",
 			},
+			expectedResponse: nil,
+			expectedError:    models.NewValidationError(models.CodeInvalidScore, "Wrong format for the relative score. It must start with a [+] or [-] symbol."),
 		},
 		{
-			description: "should update and return user with relative score",
+			description: "should return a validation error when the relative score doesn't start with + or -",
 			basicAPIService: BasicService{
 				Core: &models.Core{},
 			},
 			ctx:           context.Background(),
 			userIdRequest: "1",
 			request: &models.SubmitScoreRequest{
-				Score: "-100",
-			},
-			doesUserExistFunc: true,
-			getUserById: &models.User{
-				UserID: 1,
-				Score:  -100,
-			},
-			expectedResponse: &models.SubmitScoreResponse{
-				UserID: 1,
-				Score:  -100,
+				Score: "|100",
 			},
+			expectedResponse: nil,
+			expectedError:    models.NewValidationError(models.CodeInvalidScore, "Wrong format for the relative score. It must start with a [+] or [-] symbol."),
 		},
 		{
-			description: "should return error when converting score regex",
+			description: "should return a validation error when the relative score doesn't fit an integer",
 			basicAPIService: BasicService{
 				Core: &models.Core{},
 			},
 			ctx:           context.Background(),
 			userIdRequest: "1",
 			request: &models.SubmitScoreRequest{
-				Score: "-100a",
+				Score: "+99999999999999999999",
 			},
-			doesUserExistFunc: true,
-			expectedResponse:  nil,
-			expectedError:     fmt.Errorf("Wrong format for the relative score. It must start with a [+] or [-] symbol."),
-			getUserByIdError:  fmt.Errorf("Wrong format for the relative score. It must start with a [+] or [-] symbol."),
+			expectedResponse: nil,
+			expectedError:    models.NewValidationError(models.CodeInvalidScore, "The relative score must be between %d and %d.", math.MinInt, math.MaxInt),
 		},
 	}
 	for _, tc := range cases {
+		var submitted *models.ScoreSubmission
 		mockedStoreService := mocks.StoreServiceMock{
-			CreateUserFunc: func(ctx context.Context, id int, total int) error {
-				return tc.createUserFuncError
+			DoesLeaderboardExistFunc: func(ctx context.Context, name string) (bool, error) {
+				return !tc.leaderboardMissing, nil
 			},
-			DoesUserExistFunc: func(ctx context.Context, id int) (bool, error) {
-				return tc.doesUserExistFunc, tc.doesUserExistFuncError
+			GetFrozenUsersFunc: func(ctx context.Context, board string) ([]models.FrozenUser, error) {
+				return tc.frozen, nil
 			},
-			GetUserByIdFunc: func(ctx context.Context, id int) (*models.User, error) {
-				return tc.getUserById, tc.getUserByIdError
+			SubmitScoresWithPeriodsFunc: func(ctx context.Context, board string, periods []string, submissions []models.ScoreSubmission) ([]models.ScoreChange, []string, error) {
+				submitted = &submissions[0]
+				if tc.submitError != nil {
+					return nil, nil, tc.submitError
+				}
+				return []models.ScoreChange{*tc.change}, nil, nil
 			},
-			UpdateAbsoluteUserScoreFunc: func(ctx context.Context, id int, score int) error {
-				return tc.updateAbsoluteUserScoreError
+		}
+
+		tc.basicAPIService.Core.StoreService = &mockedStoreService
+
+		res, err := tc.basicAPIService.HandleSubmitScore(tc.ctx, models.DefaultLeaderboard, tc.request, tc.userIdRequest)
+		assert.Equal(t, tc.expectedResponse, res, tc.description)
+		assert.Equal(t, tc.expectedError, err, tc.description)
+		assert.Equal(t, tc.expectedSubmission, submitted, tc.description)
+	}
+}
+
+func TestBasicService_HandleSubmitScorePersistence(t *testing.T) {
+	cases := []struct {
+		description      string
+		request          *models.SubmitScoreRequest
+		logError         error
+		expectedMutation models.Mutation
+		expectedError    error
+		expectedApplied  bool
+	}{
+		{
+			description:      "should log an absolute score before applying it",
+			request:          &models.SubmitScoreRequest{Total: &[]int{320}[0]},
+			expectedMutation: models.Mutation{Type: models.MutationAbsoluteScore, Board: models.DefaultLeaderboard, UserID: 1, Score: 320},
+			expectedApplied:  true,
+		},
+		{
+			description:      "should log a relative score before applying it",
+			request:          &models.SubmitScoreRequest{Score: "-100"},
+			expectedMutation: models.Mutation{Type: models.MutationRelativeScore, Board: models.DefaultLeaderboard, UserID: 1, Score: -100},
+			expectedApplied:  true,
+		},
+		{
+			description:      "should not apply a score that could not be logged",
+			request:          &models.SubmitScoreRequest{Total: &[]int{320}[0]},
+			logError:         fmt.Errorf("mock-error"),
+			expectedMutation: models.Mutation{Type: models.MutationAbsoluteScore, Board: models.DefaultLeaderboard, UserID: 1, Score: 320},
+			expectedError:    fmt.Errorf("mock-error"),
+		},
+	}
+	for _, tc := range cases {
+		applied := false
+		mockedStoreService := mocks.StoreServiceMock{
+			DoesLeaderboardExistFunc: func(ctx context.Context, name string) (bool, error) {
+				return true, nil
+			},
+			GetFrozenUsersFunc: func(ctx context.Context, board string) ([]models.FrozenUser, error) {
+				return nil, nil
 			},
-			UpdateRelativeUserScoreFunc: func(ctx context.Context, id int, score int) error {
-				return tc.updateRelativeUserScoreError
+			SubmitScoresWithPeriodsFunc: func(ctx context.Context, board string, periods []string, submissions []models.ScoreSubmission) ([]models.ScoreChange, []string, error) {
+				applied = true
+				return []models.ScoreChange{{UserID: submissions[0].UserID, Score: submissions[0].Score, Created: true}}, nil, nil
+			},
+		}
+		var logged []models.Mutation
+		mockedPersistence := mocks.PersistenceServiceMock{
+			LogFunc: func(ctx context.Context, mutation models.Mutation, apply func() error) error {
+				logged = append(logged, mutation)
+				if tc.logError != nil {
+					return tc.logError
+				}
+				return apply()
 			},
 		}
+		basicAPIService := BasicService{
+			Core: &models.Core{StoreService: &mockedStoreService, Persistence: &mockedPersistence},
+		}
 
-		tc.basicAPIService.Core.StoreService = &mockedStoreService
+		_, err := basicAPIService.HandleSubmitScore(context.Background(), models.DefaultLeaderboard, tc.request, "1")
+		assert.Equal(t, tc.expectedError, err, tc.description)
+		assert.Equal(t, []models.Mutation{tc.expectedMutation}, logged, tc.description)
+		assert.Equal(t, tc.expectedApplied, applied, tc.description)
+	}
+}
 
-		res, err := tc.basicAPIService.HandleSubmitScore(tc.ctx, tc.request, tc.userIdRequest)
+func TestBasicService_HandleSubmitScoreHistory(t *testing.T) {
+	cases := []struct {
+		description     string
+		request         *models.SubmitScoreRequest
+		expectedEntries []models.ScoreHistoryEntry
+	}{
+		{
+			description:     "should record an absolute score with its total",
+			request:         &models.SubmitScoreRequest{Total: &[]int{320}[0], Source: "client-1"},
+			expectedEntries: []models.ScoreHistoryEntry{{UserID: 1, PreviousScore: 100, Score: 320, Total: &[]int{320}[0], Source: "client-1"}},
+		},
+		{
+			description:     "should record a relative score with its delta",
+			request:         &models.SubmitScoreRequest{Score: "-40", Source: "client-1"},
+			expectedEntries: []models.ScoreHistoryEntry{{UserID: 1, PreviousScore: 100, Score: 60, Delta: &[]int{-40}[0], Source: "client-1"}},
+		},
+	}
+	for _, tc := range cases {
+		mockedStoreService := mocks.StoreServiceMock{
+			DoesLeaderboardExistFunc: func(ctx context.Context, name string) (bool, error) {
+				return true, nil
+			},
+			GetFrozenUsersFunc: func(ctx context.Context, board string) ([]models.FrozenUser, error) {
+				return nil, nil
+			},
+			SubmitScoresWithPeriodsFunc: func(ctx context.Context, board string, periods []string, submissions []models.ScoreSubmission) ([]models.ScoreChange, []string, error) {
+				score := submissions[0].Score
+				if !submissions[0].Absolute {
+					score += 100
+				}
+				return []models.ScoreChange{{UserID: submissions[0].UserID, PreviousScore: 100, Score: score}}, nil, nil
+			},
+		}
+		var recorded []models.ScoreHistoryEntry
+		mockedHistory := mocks.HistoryServiceMock{
+			RecordFunc: func(ctx context.Context, board string, entries []models.ScoreHistoryEntry) error {
+				recorded = append(recorded, entries...)
+				return nil
+			},
+		}
+		basicAPIService := BasicService{
+			Core: &models.Core{StoreService: &mockedStoreService, History: &mockedHistory},
+		}
+
+		_, err := basicAPIService.HandleSubmitScore(context.Background(), models.DefaultLeaderboard, tc.request, "1")
+		assert.NoError(t, err, tc.description)
+		assert.Equal(t, tc.expectedEntries, recorded, tc.description)
+	}
+}
+
+func TestBasicService_HandleSubmitScores(t *testing.T) {
+	cases := []struct {
+		description         string
+		request             *models.SubmitScoresRequest
+		leaderboardMissing  bool
+		frozen              []models.FrozenUser
+		submitScoresError   error
+		expectedSubmissions []models.ScoreSubmission
+		expectedResponse    *models.SubmitScoresResponse
+		expectedError       error
+	}{
+		{
+			description: "should return an error when the mode is unknown",
+			request: &models.SubmitScoresRequest{
+				Mode:   "some",
+				Scores: []models.SubmitScoreRequest{{UserID: 1, Total: &[]int{10}[0]}},
+			},
+			expectedError: models.NewValidationError(models.CodeInvalidBatch, "The only modes accepted are: atomic and best_effort."),
+		},
+		{
+			description:   "should return an error when the batch is empty",
+			request:       &models.SubmitScoresRequest{},
+			expectedError: models.NewValidationError(models.CodeInvalidBatch, "A batch must have between 1 and 1000 scores."),
+		},
+		{
+			description: "should return an error when the leaderboard does not exist",
+			request: &models.SubmitScoresRequest{
+				Scores: []models.SubmitScoreRequest{{UserID: 1, Total: &[]int{10}[0]}},
+			},
+			leaderboardMissing: true,
+			expectedError:      models.NewNotFoundError(models.CodeLeaderboardNotFound, "Leaderboard default not found."),
+		},
+		{
+			description: "should report the scores of the frozen users and apply the others in best effort mode",
+			request: &models.SubmitScoresRequest{
+				Mode: models.BatchBestEffort,
+				Scores: []models.SubmitScoreRequest{
+					{UserID: 1, Total: &[]int{10}[0]},
+					{UserID: 2, Score: "+5"},
+				},
+			},
+			frozen:              []models.FrozenUser{{UserID: 2, Hidden: true, Score: 40}},
+			expectedSubmissions: []models.ScoreSubmission{{UserID: 1, Score: 10, Absolute: true}},
+			expectedResponse: &models.SubmitScoresResponse{
+				Applied: 1,
+				Results: []models.SubmitScoreResult{
+					{UserID: 1, Score: &[]int{10}[0]},
+					{UserID: 2, Error: "User 2 is frozen.", Code: models.CodeUserFrozen},
+				},
+			},
+		},
+		{
+			description: "should apply all the scores of the batch",
+			request: &models.SubmitScoresRequest{
+				Scores: []models.SubmitScoreRequest{
+					{UserID: 1, Total: &[]int{10}[0]},
+					{UserID: 2, Score: "-5"},
+				},
+			},
+			expectedSubmissions: []models.ScoreSubmission{
+				{UserID: 1, Score: 10, Absolute: true},
+				{UserID: 2, Score: -5},
+			},
+			expectedResponse: &models.SubmitScoresResponse{
+				Applied: 2,
+				Results: []models.SubmitScoreResult{
+					{UserID: 1, Score: &[]int{10}[0]},
+					{UserID: 2, Score: &[]int{-5}[0]},
+				},
+			},
+		},
+		{
+			description: "should apply none of the scores of an atomic batch with an invalid score",
+			request: &models.SubmitScoresRequest{
+				Mode: models.BatchAtomic,
+				Scores: []models.SubmitScoreRequest{
+					{UserID: 1, Total: &[]int{10}[0]},
+					{UserID: 2, Score: "5"},
+				},
+			},
+			expectedResponse: &models.SubmitScoresResponse{
+				Results: []models.SubmitScoreResult{
+					{UserID: 1, Error: "Not applied since other scores of the batch are invalid.", Code: models.CodeBatchNotApplied},
+					{UserID: 2, Error: "Wrong format for the relative score. It must start with a [+] or [-] symbol.", Code: models.CodeInvalidScore},
+				},
+			},
+		},
+		{
+			description: "should apply the valid scores of a best effort batch",
+			request: &models.SubmitScoresRequest{
+				Mode: models.BatchBestEffort,
+				Scores: []models.SubmitScoreRequest{
+					{UserID: 1, Total: &[]int{10}[0], Score: "+1"},
+					{UserID: 2, Score: "+5"},
+				},
+			},
+			expectedSubmissions: []models.ScoreSubmission{
+				{UserID: 2, Score: 5},
+			},
+			expectedResponse: &models.SubmitScoresResponse{
+				Applied: 1,
+				Results: []models.SubmitScoreResult{
+					{UserID: 1, Error: "You can only submit the absolute score or the relative score.", Code: models.CodeInvalidScore},
+					{UserID: 2, Score: &[]int{5}[0]},
+				},
+			},
+		},
+		{
+			description: "should return an error when the store fails",
+			request: &models.SubmitScoresRequest{
+				Scores: []models.SubmitScoreRequest{{UserID: 1, Total: &[]int{10}[0]}},
+			},
+			submitScoresError: fmt.Errorf("mock-error"),
+			expectedSubmissions: []models.ScoreSubmission{
+				{UserID: 1, Score: 10, Absolute: true},
+			},
+			expectedError: fmt.Errorf("mock-error"),
+		},
+	}
+	for _, tc := range cases {
+		var submitted []models.ScoreSubmission
+		mockedStoreService := mocks.StoreServiceMock{
+			DoesLeaderboardExistFunc: func(ctx context.Context, name string) (bool, error) {
+				return !tc.leaderboardMissing, nil
+			},
+			GetFrozenUsersFunc: func(ctx context.Context, board string) ([]models.FrozenUser, error) {
+				return tc.frozen, nil
+			},
+			SubmitScoresWithPeriodsFunc: func(ctx context.Context, board string, periods []string, submissions []models.ScoreSubmission) ([]models.ScoreChange, []string, error) {
+				submitted = submissions
+				if tc.submitScoresError != nil {
+					return nil, nil, tc.submitScoresError
+				}
+				changes := make([]models.ScoreChange, len(submissions))
+				for i, submission := range submissions {
+					changes[i] = models.ScoreChange{UserID: submission.UserID, Score: submission.Score, Created: true}
+				}
+				return changes, nil, nil
+			},
+		}
+		basicAPIService := BasicService{
+			Core: &models.Core{StoreService: &mockedStoreService},
+		}
+
+		res, err := basicAPIService.HandleSubmitScores(context.Background(), models.DefaultLeaderboard, tc.request)
 		assert.Equal(t, tc.expectedResponse, res, tc.description)
 		assert.Equal(t, tc.expectedError, err, tc.description)
+		assert.Equal(t, tc.expectedSubmissions, submitted, tc.description)
 	}
 }
 
 func TestBasicService_HandleGetRanking(t *testing.T) {
 	cases := []struct {
-		description          string
-		basicAPIService      BasicService
-		ctx                  context.Context
-		request              string
-		expectedResponse     *models.GetRankingResponse
-		expectedError        error
-		getUsersBetweenError error
-		getUsersBetween      []models.Ranking
-		getUsersError        error
-		getUsers             []models.Ranking
+		description        string
+		basicAPIService    BasicService
+		ctx                context.Context
+		request            string
+		expectedResponse   *models.GetRankingResponse
+		expectedError      error
+		getRanking         []models.Ranking
+		getRankingError    error
+		leaderboardMissing bool
+		expectedQuery      *models.RankingQuery
 	}{
+		{
+			description: "should return ranking using type User",
+			basicAPIService: BasicService{
+				Core: &models.Core{},
+			},
+			ctx:           context.Background(),
+			request:       "User7/1",
+			expectedQuery: &models.RankingQuery{Kind: models.RankingAroundUser, UserID: 7, Around: 1},
+			getRanking: []models.Ranking{
+				{
+					Position: 1,
+					UserID:   1,
+					Score:    100,
+				},
+				{
+					Position: 2,
+					UserID:   7,
+					Score:    50,
+				},
+			},
+			expectedResponse: &models.GetRankingResponse{
+				Ranking: []models.Ranking{
+					{
+						Position: 1,
+						UserID:   1,
+						Score:    100,
+					},
+					{
+						Position: 2,
+						UserID:   7,
+						Score:    50,
+					},
+				},
+			},
+		},
+		{
+			description: "should return error using type User with position 0",
+			basicAPIService: BasicService{
+				Core: &models.Core{},
+			},
+			ctx:              context.Background(),
+			request:          "user7/0",
+			expectedResponse: nil,
+			expectedError:    models.NewValidationError(models.CodeInvalidPosition, "The positions must be greater than 0."),
+		},
+		{
+			description: "should return error using type User with a missing user",
+			basicAPIService: BasicService{
+				Core: &models.Core{},
+			},
+			ctx:              context.Background(),
+			request:          "user7/3",
+			getRankingError:  sql.ErrNoRows,
+			expectedResponse: nil,
+			expectedError:    models.NewNotFoundError(models.CodeUserNotFound, "User 7 not found."),
+		},
+		{
+			description: "should return error when the leaderboard does not exist",
+			basicAPIService: BasicService{
+				Core: &models.Core{},
+			},
+			ctx:                context.Background(),
+			request:            "top100",
+			leaderboardMissing: true,
+			expectedResponse:   nil,
+			expectedError:      models.NewNotFoundError(models.CodeLeaderboardNotFound, "Leaderboard default not found."),
+		},
 		{
 			description: "should return ranking using type Top",
 			basicAPIService: BasicService{
 				Core: &models.Core{},
 			},
-			ctx:     context.Background(),
-			request: "top100",
-			getUsers: []models.Ranking{
+			ctx:           context.Background(),
+			request:       "top100",
+			expectedQuery: &models.RankingQuery{Kind: models.RankingTop, Count: 100},
+			getRanking: []models.Ranking{
 				{
 					Position: 1,
 					UserID:   1,
@@ -303,9 +623,10 @@ func TestBasicService_HandleGetRanking(t *testing.T) {
 			basicAPIService: BasicService{
 				Core: &models.Core{},
 			},
-			ctx:     context.Background(),
-			request: "At100/1",
-			getUsersBetween: []models.Ranking{
+			ctx:           context.Background(),
+			request:       "At100/1",
+			expectedQuery: &models.RankingQuery{Kind: models.RankingAt, Position: 100, Around: 1},
+			getRanking: []models.Ranking{
 				{
 					Position: 1,
 					UserID:   1,
@@ -340,7 +661,7 @@ func TestBasicService_HandleGetRanking(t *testing.T) {
 			ctx:              context.Background(),
 			request:          "top0",
 			expectedResponse: nil,
-			expectedError:    fmt.Errorf("The position must be greater than 0."),
+			expectedError:    models.NewValidationError(models.CodeInvalidPosition, "The position must be greater than 0."),
 		},
 		{
 			description: "should return error using invalid type",
@@ -350,40 +671,59 @@ func TestBasicService_HandleGetRanking(t *testing.T) {
 			ctx:              context.Background(),
 			request:          "invalid0",
 			expectedResponse: nil,
-			expectedError:    fmt.Errorf("The only formats accepted for type are: Top100 and At100/3."),
+			expectedError:    models.NewValidationError(models.CodeInvalidRankingType, "Unknown ranking type \"invalid\" at position 1, expected: top{n}, bottom{n}, at{position}/{n}, range{from}-{to}, user{id}/{n} or percentile{p}, optionally followed by ,score>={x}."),
 		},
 		{
-			description: "should return error when GetUsers",
+			description: "should return error when GetRanking",
 			basicAPIService: BasicService{
 				Core: &models.Core{},
 			},
 			ctx:              context.Background(),
 			request:          "top10",
 			expectedResponse: nil,
-			getUsersError:    fmt.Errorf("mock-error"),
+			getRankingError:  fmt.Errorf("mock-error"),
 			expectedError:    fmt.Errorf("mock-error"),
 		},
 		{
-			description: "should return error when GetUsersBetween",
+			description: "should return error using position 0",
 			basicAPIService: BasicService{
 				Core: &models.Core{},
 			},
-			ctx:                  context.Background(),
-			request:              "At100/1",
-			expectedResponse:     nil,
-			getUsersBetweenError: fmt.Errorf("mock-error"),
-			expectedError:        fmt.Errorf("mock-error"),
+			ctx:              context.Background(),
+			request:          "At100/0",
+			expectedResponse: nil,
+			expectedError:    models.NewValidationError(models.CodeInvalidPosition, "The positions must be greater than 0."),
 		},
 		{
-			description: "should return error using position 0",
+			description: "should pass the parsed query with its filter to the store",
+			basicAPIService: BasicService{
+				Core: &models.Core{},
+			},
+			ctx:           context.Background(),
+			request:       "BOTTOM10,score>=-5",
+			expectedQuery: &models.RankingQuery{Kind: models.RankingBottom, Count: 10, MinScore: &[]int{-5}[0]},
+			getRanking:    []models.Ranking{{Position: 9, UserID: 3, Score: 0}},
+			expectedResponse: &models.GetRankingResponse{
+				Ranking: []models.Ranking{{Position: 9, UserID: 3, Score: 0}},
+			},
+		},
+		{
+			description: "should return error using a number with a thousands separator",
 			basicAPIService: BasicService{
 				Core: &models.Core{},
 			},
-			ctx:                  context.Background(),
-			request:              "At100/0",
-			expectedResponse:     nil,
-			getUsersBetweenError: fmt.Errorf("The positions must be greater than 0."),
-			expectedError:        fmt.Errorf("The positions must be greater than 0."),
+			ctx:           context.Background(),
+			request:       "top1,000",
+			expectedError: models.NewValidationError(models.CodeInvalidRankingType, "Unexpected '0' at position 6 of the type, expected a score>={x} filter."),
+		},
+		{
+			description: "should return error using a decimal position",
+			basicAPIService: BasicService{
+				Core: &models.Core{},
+			},
+			ctx:           context.Background(),
+			request:       "at1.5/2",
+			expectedError: models.NewValidationError(models.CodeInvalidRankingType, "Unexpected '.' at position 4 of the type, expected a digit or '/'."),
 		},
 		// {
 		// 	description: "should return error when converting user_id to int",
@@ -398,7 +738,7 @@ func TestBasicService_HandleGetRanking(t *testing.T) {
 		// 	doesUserExistFunc:   false,
 		// 	createUserFuncError: nil,
 		// 	expectedResponse:    nil,
-		// 	expectedError:       fmt.Errorf("User_Id must be an integer."),
+		// 	expectedError:       models.NewValidationError(models.CodeInvalidUserID, "User_Id must be an integer."),
 		// },
 		// {
 		// 	description: "should return error when sending score and total at the same time",
@@ -414,7 +754,7 @@ func TestBasicService_HandleGetRanking(t *testing.T) {
 		// 	doesUserExistFunc:   false,
 		// 	createUserFuncError: nil,
 		// 	expectedResponse:    nil,
-		// 	expectedError:       fmt.Errorf("You can only submit the absolute score or the relative score."),
+		// 	expectedError:       models.NewValidationError(models.CodeInvalidScore, "You can only submit the absolute score or the relative score."),
 		// },
 		// {
 		// 	description: "should return error when checks DoesUserExist ",
@@ -540,24 +880,564 @@ func TestBasicService_HandleGetRanking(t *testing.T) {
 		// 	},
 		// 	doesUserExistFunc: true,
 		// 	expectedResponse:  nil,
-		// 	expectedError:     fmt.Errorf("Wrong format for the relative score. It must start with a [+] or [-] symbol."),
-		// 	getUserByIdError:  fmt.Errorf("Wrong format for the relative score. It must start with a [+] or [-] symbol."),
+		// 	expectedError:     models.NewValidationError(models.CodeInvalidScore, "Wrong format for the relative score. It must start with a [+] or [-] symbol."),
+		// 	getUserByIdError:  models.NewValidationError(models.CodeInvalidScore, "Wrong format for the relative score. It must start with a [+] or [-] symbol."),
 		// },
 	}
 	for _, tc := range cases {
 		mockedStoreService := mocks.StoreServiceMock{
-			GetUsersFunc: func(ctx context.Context, top int) ([]models.Ranking, error) {
-				return tc.getUsers, tc.getUsersError
+			DoesLeaderboardExistFunc: func(ctx context.Context, name string) (bool, error) {
+				return !tc.leaderboardMissing, nil
 			},
-			GetUsersBetweenFunc: func(ctx context.Context, lower, upper int) ([]models.Ranking, error) {
-				return tc.getUsersBetween, tc.getUsersBetweenError
+			GetRankingFunc: func(ctx context.Context, board string, query *models.RankingQuery) ([]models.Ranking, error) {
+				if tc.expectedQuery != nil {
+					assert.Equal(t, tc.expectedQuery, query, tc.description)
+				}
+				return tc.getRanking, tc.getRankingError
 			},
 		}
 
 		tc.basicAPIService.Core.StoreService = &mockedStoreService
 
-		res, err := tc.basicAPIService.HandleGetRanking(tc.ctx, tc.request)
+		res, err := tc.basicAPIService.HandleGetRanking(tc.ctx, models.DefaultLeaderboard, &models.GetRankingRequest{Type: tc.request})
+		assert.Equal(t, tc.expectedResponse, res, tc.description)
+		assert.Equal(t, tc.expectedError, err, tc.description)
+	}
+}
+
+func TestBasicService_HandleGetRankingPage(t *testing.T) {
+	cursor := &models.RankingCursor{Score: 50, ReachedAt: 7, UserID: 2}
+	encoded, err := encodeRankingCursor(cursor)
+	assert.NoError(t, err)
+
+	cases := []struct {
+		description        string
+		request            *models.GetRankingPageRequest
+		leaderboardMissing bool
+		getUsersAfter      []models.Ranking
+		getUsersAfterError error
+		expectedAfter      *models.RankingCursor
+		expectedLimit      int
+		expectedResponse   *models.GetRankingPageResponse
+		expectedError      error
+	}{
+		{
+			description:   "should return the first page with the cursor of its last user",
+			request:       &models.GetRankingPageRequest{Limit: "2"},
+			getUsersAfter: []models.Ranking{{Position: 1, UserID: 1, Score: 70}, {Position: 2, UserID: 2, Score: 50}},
+			expectedLimit: 2,
+			expectedResponse: &models.GetRankingPageResponse{
+				Ranking:    []models.Ranking{{Position: 1, UserID: 1, Score: 70}, {Position: 2, UserID: 2, Score: 50}},
+				NextCursor: encoded,
+			},
+		},
+		{
+			description:   "should return the last page without a cursor",
+			request:       &models.GetRankingPageRequest{Cursor: encoded},
+			getUsersAfter: []models.Ranking{{Position: 3, UserID: 3, Score: 40}},
+			expectedAfter: cursor,
+			expectedLimit: defaultRankingPageLimit,
+			expectedResponse: &models.GetRankingPageResponse{
+				Ranking: []models.Ranking{{Position: 3, UserID: 3, Score: 40}},
+			},
+		},
+		{
+			description:   "should return error when the limit is too big",
+			request:       &models.GetRankingPageRequest{Limit: "1001"},
+			expectedError: models.NewValidationError(models.CodeInvalidLimit, "The limit must be a number between 1 and 1000."),
+		},
+		{
+			description:   "should return error when the limit is not a number",
+			request:       &models.GetRankingPageRequest{Limit: "ten"},
+			expectedError: models.NewValidationError(models.CodeInvalidLimit, "The limit must be a number between 1 and 1000."),
+		},
+		{
+			description:   "should return error when the cursor is not valid",
+			request:       &models.GetRankingPageRequest{Cursor: "not-a-cursor"},
+			expectedError: models.NewValidationError(models.CodeInvalidCursor, "The cursor must be the next_cursor of a previous page."),
+		},
+		{
+			description:        "should return error when the leaderboard does not exist",
+			request:            &models.GetRankingPageRequest{},
+			leaderboardMissing: true,
+			expectedError:      models.NewNotFoundError(models.CodeLeaderboardNotFound, "Leaderboard default not found."),
+		},
+		{
+			description:        "should return error when GetUsersAfter",
+			request:            &models.GetRankingPageRequest{},
+			getUsersAfterError: fmt.Errorf("mock-error"),
+			expectedLimit:      defaultRankingPageLimit,
+			expectedError:      fmt.Errorf("mock-error"),
+		},
+	}
+	for _, tc := range cases {
+		basicAPIService := BasicService{
+			Core: &models.Core{
+				StoreService: &mocks.StoreServiceMock{
+					DoesLeaderboardExistFunc: func(ctx context.Context, name string) (bool, error) {
+						return !tc.leaderboardMissing, nil
+					},
+					GetUsersAfterFunc: func(ctx context.Context, board string, after *models.RankingCursor, limit int) ([]models.Ranking, *models.RankingCursor, error) {
+						assert.Equal(t, tc.expectedAfter, after, tc.description)
+						assert.Equal(t, tc.expectedLimit, limit, tc.description)
+						if len(tc.getUsersAfter) == 0 {
+							return tc.getUsersAfter, nil, tc.getUsersAfterError
+						}
+						return tc.getUsersAfter, cursor, tc.getUsersAfterError
+					},
+				},
+			},
+		}
+
+		res, err := basicAPIService.HandleGetRankingPage(context.Background(), models.DefaultLeaderboard, tc.request)
 		assert.Equal(t, tc.expectedResponse, res, tc.description)
 		assert.Equal(t, tc.expectedError, err, tc.description)
 	}
 }
+
+func TestBasicService_HandleCreateLeaderboard(t *testing.T) {
+	cases := []struct {
+		description            string
+		request                *models.CreateLeaderboardRequest
+		doesLeaderboardExist   bool
+		createLeaderboardError error
+		expectedResponse       *models.LeaderboardResponse
+		expectedError          error
+	}{
+		{
+			description:      "should create a leaderboard",
+			request:          &models.CreateLeaderboardRequest{Name: "weekly_2"},
+			expectedResponse: &models.LeaderboardResponse{Name: "weekly_2"},
+		},
+		{
+			description:   "should return error when the name is invalid",
+			request:       &models.CreateLeaderboardRequest{Name: "weekly/2"},
+			expectedError: models.NewValidationError(models.CodeInvalidLeaderboardName, "The leaderboard name must have between 1 and 64 letters, numbers, [_] or [-]."),
+		},
+		{
+			description:   "should return error when the name is empty",
+			request:       &models.CreateLeaderboardRequest{},
+			expectedError: models.NewValidationError(models.CodeInvalidLeaderboardName, "The leaderboard name must have between 1 and 64 letters, numbers, [_] or [-]."),
+		},
+		{
+			description:          "should return error when the leaderboard already exists",
+			request:              &models.CreateLeaderboardRequest{Name: "weekly"},
+			doesLeaderboardExist: true,
+			expectedError:        models.NewConflictError(models.CodeLeaderboardExists, "Leaderboard weekly already exists."),
+		},
+		{
+			description:            "should return error when CreateLeaderboard",
+			request:                &models.CreateLeaderboardRequest{Name: "weekly"},
+			createLeaderboardError: fmt.Errorf("mock-error"),
+			expectedError:          fmt.Errorf("mock-error"),
+		},
+	}
+	for _, tc := range cases {
+		basicAPIService := BasicService{
+			Core: &models.Core{
+				StoreService: &mocks.StoreServiceMock{
+					DoesLeaderboardExistFunc: func(ctx context.Context, name string) (bool, error) {
+						return tc.doesLeaderboardExist, nil
+					},
+					CreateLeaderboardFunc: func(ctx context.Context, name string) error {
+						return tc.createLeaderboardError
+					},
+				},
+			},
+		}
+
+		res, err := basicAPIService.HandleCreateLeaderboard(context.Background(), tc.request)
+		assert.Equal(t, tc.expectedResponse, res, tc.description)
+		assert.Equal(t, tc.expectedError, err, tc.description)
+	}
+}
+
+func TestBasicService_HandleGetLeaderboards(t *testing.T) {
+	cases := []struct {
+		description          string
+		getLeaderboards      []string
+		getLeaderboardsError error
+		expectedResponse     *models.GetLeaderboardsResponse
+		expectedError        error
+	}{
+		{
+			description:      "should list the leaderboards",
+			getLeaderboards:  []string{"default", "weekly"},
+			expectedResponse: &models.GetLeaderboardsResponse{Leaderboards: []string{"default", "weekly"}},
+		},
+		{
+			description:      "should not list the boards of the periods",
+			getLeaderboards:  []string{"default", "default@weekly@2026-01-05", "weekly"},
+			expectedResponse: &models.GetLeaderboardsResponse{Leaderboards: []string{"default", "weekly"}},
+		},
+		{
+			description:          "should return error when GetLeaderboards",
+			getLeaderboardsError: fmt.Errorf("mock-error"),
+			expectedError:        fmt.Errorf("mock-error"),
+		},
+	}
+	for _, tc := range cases {
+		basicAPIService := BasicService{
+			Core: &models.Core{
+				StoreService: &mocks.StoreServiceMock{
+					GetLeaderboardsFunc: func(ctx context.Context) ([]string, error) {
+						return tc.getLeaderboards, tc.getLeaderboardsError
+					},
+				},
+			},
+		}
+
+		res, err := basicAPIService.HandleGetLeaderboards(context.Background())
+		assert.Equal(t, tc.expectedResponse, res, tc.description)
+		assert.Equal(t, tc.expectedError, err, tc.description)
+	}
+}
+
+func TestBasicService_HandleDeleteLeaderboard(t *testing.T) {
+	cases := []struct {
+		description            string
+		board                  string
+		doesLeaderboardExist   bool
+		deleteLeaderboardError error
+		getLeaderboardsError   error
+		expectedResponse       *models.LeaderboardResponse
+		expectedDeleted        []string
+		expectedError          error
+	}{
+		{
+			description:          "should delete a leaderboard",
+			board:                "weekly",
+			doesLeaderboardExist: true,
+			expectedResponse:     &models.LeaderboardResponse{Name: "weekly"},
+			expectedDeleted:      []string{"weekly", "weekly@daily@2026-01-01", "weekly@daily@2026-01-02"},
+		},
+		{
+			description:          "should return error when deleting the default leaderboard",
+			board:                models.DefaultLeaderboard,
+			doesLeaderboardExist: true,
+			expectedError:        models.NewConflictError(models.CodeDefaultLeaderboard, "The default leaderboard can not be deleted."),
+		},
+		{
+			description:   "should return error when the leaderboard does not exist",
+			board:         "weekly",
+			expectedError: models.NewNotFoundError(models.CodeLeaderboardNotFound, "Leaderboard weekly not found."),
+		},
+		{
+			description:            "should return error when DeleteLeaderboard",
+			board:                  "weekly",
+			doesLeaderboardExist:   true,
+			deleteLeaderboardError: fmt.Errorf("mock-error"),
+			expectedDeleted:        []string{"weekly"},
+			expectedError:          fmt.Errorf("mock-error"),
+		},
+		{
+			description:          "should return error when GetLeaderboards",
+			board:                "weekly",
+			doesLeaderboardExist: true,
+			getLeaderboardsError: fmt.Errorf("mock-error"),
+			expectedDeleted:      []string{"weekly"},
+			expectedError:        fmt.Errorf("mock-error"),
+		},
+	}
+	for _, tc := range cases {
+		var deleted []string
+		basicAPIService := BasicService{
+			Core: &models.Core{
+				StoreService: &mocks.StoreServiceMock{
+					DoesLeaderboardExistFunc: func(ctx context.Context, name string) (bool, error) {
+						return tc.doesLeaderboardExist, nil
+					},
+					DeleteLeaderboardFunc: func(ctx context.Context, name string) error {
+						deleted = append(deleted, name)
+						return tc.deleteLeaderboardError
+					},
+					GetLeaderboardsFunc: func(ctx context.Context) ([]string, error) {
+						boards := []string{models.DefaultLeaderboard, "weekly", "weekly@daily@2026-01-01", "weekly@daily@2026-01-02", "weekly2@daily@2026-01-01"}
+						return boards, tc.getLeaderboardsError
+					},
+				},
+			},
+		}
+
+		res, err := basicAPIService.HandleDeleteLeaderboard(context.Background(), tc.board)
+		assert.Equal(t, tc.expectedResponse, res, tc.description)
+		assert.Equal(t, tc.expectedError, err, tc.description)
+		assert.Equal(t, tc.expectedDeleted, deleted, tc.description)
+	}
+}
+
+func TestBasicService_HandleGetUserRank(t *testing.T) {
+	cases := []struct {
+		description          string
+		userIdRequest        string
+		leaderboardMissing   bool
+		getUserById          *models.User
+		getUserByIdError     error
+		getUserPosition      int
+		getUserPositionError error
+		countUsers           int
+		countUsersError      error
+		expectedResponse     *models.GetUserRankResponse
+		expectedError        error
+	}{
+		{
+			description:     "should return the rank of the user",
+			userIdRequest:   "7",
+			getUserById:     &models.User{UserID: 7, Score: 50},
+			getUserPosition: 3,
+			countUsers:      10,
+			expectedResponse: &models.GetUserRankResponse{
+				UserID:     7,
+				Position:   3,
+				Score:      50,
+				Total:      10,
+				Percentile: 70,
+			},
+		},
+		{
+			description:   "should return error when converting user_id to int",
+			userIdRequest: "abc",
+			expectedError: models.NewValidationError(models.CodeInvalidUserID, "User_Id must be an integer."),
+		},
+		{
+			description:        "should return error when the leaderboard does not exist",
+			userIdRequest:      "7",
+			leaderboardMissing: true,
+			expectedError:      models.NewNotFoundError(models.CodeLeaderboardNotFound, "Leaderboard default not found."),
+		},
+		{
+			description:      "should return error when the user does not exist",
+			userIdRequest:    "7",
+			getUserByIdError: sql.ErrNoRows,
+			expectedError:    models.NewNotFoundError(models.CodeUserNotFound, "User 7 not found."),
+		},
+		{
+			description:      "should return error when GetUserById",
+			userIdRequest:    "7",
+			getUserByIdError: fmt.Errorf("mock-error"),
+			expectedError:    fmt.Errorf("mock-error"),
+		},
+		{
+			description:          "should return error when GetUserPosition",
+			userIdRequest:        "7",
+			getUserById:          &models.User{UserID: 7, Score: 50},
+			getUserPositionError: fmt.Errorf("mock-error"),
+			expectedError:        fmt.Errorf("mock-error"),
+		},
+		{
+			description:     "should return error when CountUsers",
+			userIdRequest:   "7",
+			getUserById:     &models.User{UserID: 7, Score: 50},
+			getUserPosition: 3,
+			countUsersError: fmt.Errorf("mock-error"),
+			expectedError:   fmt.Errorf("mock-error"),
+		},
+	}
+	for _, tc := range cases {
+		basicAPIService := BasicService{
+			Core: &models.Core{
+				StoreService: &mocks.StoreServiceMock{
+					DoesLeaderboardExistFunc: func(ctx context.Context, name string) (bool, error) {
+						return !tc.leaderboardMissing, nil
+					},
+					GetUserByIdFunc: func(ctx context.Context, board string, id int) (*models.User, error) {
+						return tc.getUserById, tc.getUserByIdError
+					},
+					GetUserPositionFunc: func(ctx context.Context, board string, id int, score int) (int, error) {
+						return tc.getUserPosition, tc.getUserPositionError
+					},
+					CountUsersFunc: func(ctx context.Context, board string) (int, error) {
+						return tc.countUsers, tc.countUsersError
+					},
+				},
+			},
+		}
+
+		res, err := basicAPIService.HandleGetUserRank(context.Background(), models.DefaultLeaderboard, tc.userIdRequest)
+		assert.Equal(t, tc.expectedResponse, res, tc.description)
+		assert.Equal(t, tc.expectedError, err, tc.description)
+	}
+}
+
+func TestBasicService_HandleGetUserHistory(t *testing.T) {
+	history := []models.ScoreHistoryEntry{{UserID: 7, PreviousScore: 40, Score: 50, Source: "client-1"}}
+	cases := []struct {
+		description        string
+		userIdRequest      string
+		request            *models.GetUserHistoryRequest
+		historyDisabled    bool
+		leaderboardMissing bool
+		getHistoryError    error
+		expectedQuery      models.HistoryQuery
+		expectedResponse   *models.GetUserHistoryResponse
+		expectedError      error
+	}{
+		{
+			description:      "should return the history of the user",
+			userIdRequest:    "7",
+			request:          &models.GetUserHistoryRequest{},
+			expectedQuery:    models.HistoryQuery{Limit: defaultHistoryLimit},
+			expectedResponse: &models.GetUserHistoryResponse{UserID: 7, Total: 1, History: history},
+		},
+		{
+			description:   "should return the history of the user in the range",
+			userIdRequest: "7",
+			request:       &models.GetUserHistoryRequest{From: "2026-01-01T00:00:00Z", To: "2026-01-02T00:00:00Z", Limit: "10", Offset: "20"},
+			expectedQuery: models.HistoryQuery{
+				From:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:     time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+				Limit:  10,
+				Offset: 20,
+			},
+			expectedResponse: &models.GetUserHistoryResponse{UserID: 7, Total: 1, History: history},
+		},
+		{
+			description:   "should return error when converting user_id to int",
+			userIdRequest: "abc",
+			request:       &models.GetUserHistoryRequest{},
+			expectedError: models.NewValidationError(models.CodeInvalidUserID, "User_Id must be an integer."),
+		},
+		{
+			description:   "should return error when from is not a date",
+			userIdRequest: "7",
+			request:       &models.GetUserHistoryRequest{From: "yesterday"},
+			expectedError: models.NewValidationError(models.CodeInvalidHistoryQuery, "From must be a date in the RFC 3339 format, eg: 2006-01-02T15:04:05Z."),
+		},
+		{
+			description:   "should return error when to is not a date",
+			userIdRequest: "7",
+			request:       &models.GetUserHistoryRequest{To: "2026-01-02"},
+			expectedError: models.NewValidationError(models.CodeInvalidHistoryQuery, "To must be a date in the RFC 3339 format, eg: 2006-01-02T15:04:05Z."),
+		},
+		{
+			description:   "should return error when the limit is too big",
+			userIdRequest: "7",
+			request:       &models.GetUserHistoryRequest{Limit: "1001"},
+			expectedError: models.NewValidationError(models.CodeInvalidHistoryQuery, "Limit must be an integer between 1 and 1000."),
+		},
+		{
+			description:   "should return error when the offset is negative",
+			userIdRequest: "7",
+			request:       &models.GetUserHistoryRequest{Offset: "-1"},
+			expectedError: models.NewValidationError(models.CodeInvalidHistoryQuery, "Offset must be an integer greater than or equal to 0."),
+		},
+		{
+			description:     "should return error when the history is not enabled",
+			userIdRequest:   "7",
+			request:         &models.GetUserHistoryRequest{},
+			historyDisabled: true,
+			expectedError:   models.NewNotFoundError(models.CodeHistoryNotEnabled, "The score history is not enabled."),
+		},
+		{
+			description:        "should return error when the leaderboard does not exist",
+			userIdRequest:      "7",
+			request:            &models.GetUserHistoryRequest{},
+			leaderboardMissing: true,
+			expectedError:      models.NewNotFoundError(models.CodeLeaderboardNotFound, "Leaderboard default not found."),
+		},
+		{
+			description:     "should return error when GetHistory",
+			userIdRequest:   "7",
+			request:         &models.GetUserHistoryRequest{},
+			getHistoryError: fmt.Errorf("mock-error"),
+			expectedQuery:   models.HistoryQuery{Limit: defaultHistoryLimit},
+			expectedError:   fmt.Errorf("mock-error"),
+		},
+	}
+	for _, tc := range cases {
+		core := &models.Core{
+			StoreService: &mocks.StoreServiceMock{
+				DoesLeaderboardExistFunc: func(ctx context.Context, name string) (bool, error) {
+					return !tc.leaderboardMissing, nil
+				},
+			},
+		}
+		if !tc.historyDisabled {
+			core.History = &mocks.HistoryServiceMock{
+				GetHistoryFunc: func(ctx context.Context, board string, id int, query models.HistoryQuery) ([]models.ScoreHistoryEntry, int, error) {
+					assert.Equal(t, tc.expectedQuery, query, tc.description)
+					if tc.getHistoryError != nil {
+						return nil, 0, tc.getHistoryError
+					}
+					return history, len(history), nil
+				},
+			}
+		}
+		basicAPIService := BasicService{Core: core}
+
+		res, err := basicAPIService.HandleGetUserHistory(context.Background(), models.DefaultLeaderboard, tc.userIdRequest, tc.request)
+		assert.Equal(t, tc.expectedResponse, res, tc.description)
+		assert.Equal(t, tc.expectedError, err, tc.description)
+	}
+}
+
+func TestBasicService_HandleStreamRanking(t *testing.T) {
+	core := &models.Core{}
+	store := newTestStore(t, NewMemoryStoreService(core))
+	seedStore(t, store, 30, 20, 10)
+	NewStreamService(core)
+	service := NewCoreService(core).(*BasicService)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := make(chan *models.RankingUpdate, 10)
+	done := make(chan error)
+	go func() {
+		done <- service.HandleStreamRanking(ctx, models.DefaultLeaderboard, &models.GetRankingRequest{Type: "top2"}, func(update *models.RankingUpdate) error {
+			updates <- update
+			return nil
+		})
+	}()
+	next := func() *models.RankingUpdate {
+		select {
+		case update := <-updates:
+			return update
+		case <-time.After(time.Second):
+			t.Fatal("an update was expected")
+			return nil
+		}
+	}
+
+	assert.Equal(t, &models.RankingUpdate{Type: models.RankingSnapshot, Ranking: []models.Ranking{
+		{Position: 1, UserID: 1, Score: 30},
+		{Position: 2, UserID: 2, Score: 20},
+	}}, next(), "should send the ranking first")
+
+	//user 3 moves to the top, pushing user 2 out of the ranking
+	_, err := service.HandleSubmitScore(ctx, models.DefaultLeaderboard, &models.SubmitScoreRequest{Score: "+30"}, "3")
+	assert.NoError(t, err)
+	assert.Equal(t, &models.RankingUpdate{Type: models.RankingDiff,
+		Changed: []models.Ranking{{Position: 1, UserID: 3, Score: 40}, {Position: 2, UserID: 1, Score: 30}},
+		Removed: []int{2},
+	}, next(), "should send the changes of the ranking")
+
+	//a change outside of the ranking sends nothing, so the next update is the one of user 1
+	_, err = service.HandleSubmitScore(ctx, models.DefaultLeaderboard, &models.SubmitScoreRequest{Score: "+1"}, "2")
+	assert.NoError(t, err)
+	_, err = service.HandleSubmitScore(ctx, models.DefaultLeaderboard, &models.SubmitScoreRequest{Score: "+5"}, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, &models.RankingUpdate{Type: models.RankingDiff,
+		Changed: []models.Ranking{{Position: 2, UserID: 1, Score: 35}},
+	}, next(), "should only send the changes of the ranking")
+
+	cancel()
+	assert.NoError(t, <-done, "should end the stream when the context is done")
+
+	err = service.HandleStreamRanking(context.Background(), "weekly", &models.GetRankingRequest{Type: "top2"}, func(update *models.RankingUpdate) error {
+		t.Fatal("no update was expected")
+		return nil
+	})
+	assert.Equal(t, models.NewNotFoundError(models.CodeLeaderboardNotFound, "Leaderboard weekly not found."), err, "should return error when the leaderboard does not exist")
+
+	err = (&BasicService{Core: &models.Core{}}).HandleStreamRanking(context.Background(), "weekly", &models.GetRankingRequest{Type: "top2"}, nil)
+	assert.Equal(t, models.NewNotFoundError(models.CodeStreamNotEnabled, "The ranking stream is not enabled."), err, "should return error when the stream is not enabled")
+
+	//on shutdown the streams are closed, which ends them after their snapshot
+	core.Stream.Close()
+	sent := 0
+	err = service.HandleStreamRanking(context.Background(), models.DefaultLeaderboard, &models.GetRankingRequest{Type: "top2"}, func(update *models.RankingUpdate) error {
+		sent++
+		return nil
+	})
+	assert.NoError(t, err, "should end the stream when the streams are closed")
+	assert.Equal(t, 1, sent, "should only send the ranking when the streams are closed")
+}
@@ -0,0 +1,172 @@
+package coreservices
+
+import "math/rand"
+
+const (
+	skipListMaxLevel    = 32
+	skipListProbability = 0.25
+)
+
+//skipList is an order-statistic skip list: besides the forward pointers, every level
+//keeps the number of nodes it jumps over (span), so the rank of a node and the node at
+//a given rank can both be found in O(log n).
+//Nodes are ordered by the before function of the list.
+type skipList struct {
+	head   *skipListNode
+	level  int
+	length int
+	before func(a, b rankingKey) bool
+	rand   *rand.Rand
+}
+
+//rankingKey identifies a user in the ranking
+type rankingKey struct {
+	userID    int
+	score     int
+	reachedAt int64
+}
+
+type skipListNode struct {
+	key    rankingKey
+	levels []skipListLevel
+}
+
+type skipListLevel struct {
+	forward *skipListNode
+	span    int
+}
+
+func newSkipList(before func(a, b rankingKey) bool) *skipList {
+	return &skipList{
+		head:   &skipListNode{levels: make([]skipListLevel, skipListMaxLevel)},
+		level:  1,
+		before: before,
+		rand:   rand.New(rand.NewSource(1)),
+	}
+}
+
+func (sl *skipList) randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && sl.rand.Float64() < skipListProbability {
+		level++
+	}
+	return level
+}
+
+func (sl *skipList) insert(key rankingKey) {
+	var update [skipListMaxLevel]*skipListNode
+	var rank [skipListMaxLevel]int
+
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		if i < sl.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.levels[i].forward != nil && sl.before(x.levels[i].forward.key, key) {
+			rank[i] += x.levels[i].span
+			x = x.levels[i].forward
+		}
+		update[i] = x
+	}
+
+	level := sl.randomLevel()
+	if level > sl.level {
+		for i := sl.level; i < level; i++ {
+			rank[i] = 0
+			update[i] = sl.head
+			update[i].levels[i].span = sl.length
+		}
+		sl.level = level
+	}
+
+	x = &skipListNode{key: key, levels: make([]skipListLevel, level)}
+	for i := 0; i < level; i++ {
+		x.levels[i].forward = update[i].levels[i].forward
+		update[i].levels[i].forward = x
+
+		x.levels[i].span = update[i].levels[i].span - (rank[0] - rank[i])
+		update[i].levels[i].span = (rank[0] - rank[i]) + 1
+	}
+	for i := level; i < sl.level; i++ {
+		update[i].levels[i].span++
+	}
+	sl.length++
+}
+
+//remove deletes the node with the given key, returning false if it is not in the list
+func (sl *skipList) remove(key rankingKey) bool {
+	var update [skipListMaxLevel]*skipListNode
+
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.levels[i].forward != nil && sl.before(x.levels[i].forward.key, key) {
+			x = x.levels[i].forward
+		}
+		update[i] = x
+	}
+
+	x = x.levels[0].forward
+	if x == nil || x.key != key {
+		return false
+	}
+
+	for i := 0; i < sl.level; i++ {
+		if update[i].levels[i].forward == x {
+			update[i].levels[i].span += x.levels[i].span - 1
+			update[i].levels[i].forward = x.levels[i].forward
+		} else {
+			update[i].levels[i].span--
+		}
+	}
+	for sl.level > 1 && sl.head.levels[sl.level-1].forward == nil {
+		sl.level--
+	}
+	sl.length--
+	return true
+}
+
+//countBefore returns how many nodes go before the given key, whether the key is in the list or not
+func (sl *skipList) countBefore(key rankingKey) int {
+	count := 0
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.levels[i].forward != nil && sl.before(x.levels[i].forward.key, key) {
+			count += x.levels[i].span
+			x = x.levels[i].forward
+		}
+	}
+	return count
+}
+
+//rank returns the 1-based position of the node with the given key, or 0 if it is not in the list
+func (sl *skipList) rank(key rankingKey) int {
+	count := sl.countBefore(key)
+	if node := sl.byRank(count + 1); node == nil || node.key != key {
+		return 0
+	}
+	return count + 1
+}
+
+//byRank returns the node at the given 1-based position, or nil if it is out of range
+func (sl *skipList) byRank(rank int) *skipListNode {
+	if rank < 1 || rank > sl.length {
+		return nil
+	}
+	traversed := 0
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.levels[i].forward != nil && traversed+x.levels[i].span <= rank {
+			traversed += x.levels[i].span
+			x = x.levels[i].forward
+		}
+		if traversed == rank {
+			return x
+		}
+	}
+	return nil
+}
+
+//next returns the node that follows n in the ranking
+func (n *skipListNode) next() *skipListNode {
+	return n.levels[0].forward
+}
@@ -0,0 +1,73 @@
+package coreservices
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkipList(t *testing.T) {
+	cases := []struct {
+		description string
+		users       int
+		operations  int
+	}{
+		{
+			description: "should keep rank and byRank consistent with a sorted slice on a small list",
+			users:       10,
+			operations:  200,
+		},
+		{
+			description: "should keep rank and byRank consistent with a sorted slice on a big list",
+			users:       2000,
+			operations:  10000,
+		},
+	}
+	for _, tc := range cases {
+		rnd := rand.New(rand.NewSource(42))
+		before := rankingKeyBefore(models.TieBreakFirst)
+		sl := newSkipList(before)
+		keys := make(map[int]rankingKey)
+
+		for i := 0; i < tc.operations; i++ {
+			id := rnd.Intn(tc.users)
+			key := rankingKey{userID: id, score: rnd.Intn(tc.users) - tc.users/2, reachedAt: int64(rnd.Intn(tc.users))}
+			if current, ok := keys[id]; ok {
+				assert.True(t, sl.remove(current), tc.description)
+				if rnd.Intn(4) == 0 {
+					delete(keys, id)
+					continue
+				}
+			}
+			sl.insert(key)
+			keys[id] = key
+		}
+
+		expected := make([]rankingKey, 0, len(keys))
+		for _, key := range keys {
+			expected = append(expected, key)
+		}
+		sort.Slice(expected, func(i, j int) bool {
+			return before(expected[i], expected[j])
+		})
+
+		assert.Equal(t, len(expected), sl.length, tc.description)
+		node := sl.byRank(1)
+		for i, e := range expected {
+			assert.Equal(t, i+1, sl.rank(e), tc.description)
+			assert.Equal(t, i, sl.countBefore(e), tc.description)
+			assert.Equal(t, e, sl.byRank(i+1).key, tc.description)
+			assert.Equal(t, e, node.key, tc.description)
+			node = node.next()
+		}
+		missing := rankingKey{userID: tc.users + 1}
+		assert.Nil(t, node, tc.description)
+		assert.Nil(t, sl.byRank(0), tc.description)
+		assert.Nil(t, sl.byRank(len(expected)+1), tc.description)
+		assert.Equal(t, 0, sl.rank(missing), tc.description)
+		assert.False(t, sl.remove(missing), tc.description)
+	}
+}
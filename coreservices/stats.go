@@ -0,0 +1,146 @@
+package coreservices
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+const (
+	defaultStatsBuckets = 10
+	maxStatsBuckets     = 100
+	maxStatsPercentiles = 20
+)
+
+//defaultStatsPercentiles are the median and the scores of the top 10% and the top 1%
+var defaultStatsPercentiles = []float64{50, 90, 99}
+
+func (bhs *BasicService) HandleGetRankingStats(ctx context.Context, board string, request *models.GetRankingStatsRequest) (*models.GetRankingStatsResponse, error) {
+	percentiles, err := parsePercentiles(request.Percentiles)
+	if err != nil {
+		return nil, err
+	}
+	buckets := defaultStatsBuckets
+	if request.Buckets != "" {
+		buckets, err = strconv.Atoi(request.Buckets)
+		if err != nil || buckets < 1 || buckets > maxStatsBuckets {
+			return nil, models.NewValidationError(models.CodeInvalidStatsQuery, "Buckets must be an integer between 1 and %d.", maxStatsBuckets)
+		}
+	}
+
+	period, periodId, err := bhs.rankingPeriod(request.Period, request.PeriodID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = bhs.checkLeaderboard(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+
+	rankingBoard, err := bhs.rankingBoard(ctx, board, period, periodId)
+	if err != nil {
+		return nil, err
+	}
+	//nobody submitted a score yet in the current period when there is no board
+	scores := []int{}
+	if rankingBoard != "" {
+		scores, err = bhs.Core.StoreService.GetScores(ctx, rankingBoard)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	response := scoreStats(scores, percentiles, buckets)
+	if period != models.PeriodAllTime {
+		response.Period = period
+		response.PeriodID = periodId
+	}
+	return response, nil
+}
+
+//parsePercentiles parses the comma separated percentiles of a stats request
+func parsePercentiles(s string) ([]float64, error) {
+	if s == "" {
+		return defaultStatsPercentiles, nil
+	}
+	fields := strings.Split(s, ",")
+	if len(fields) > maxStatsPercentiles {
+		return nil, models.NewValidationError(models.CodeInvalidStatsQuery, "At most %d percentiles can be computed at once.", maxStatsPercentiles)
+	}
+	percentiles := make([]float64, len(fields))
+	for i, field := range fields {
+		percentile, err := strconv.ParseFloat(field, 64)
+		if err != nil || percentile < 0 || percentile > 100 {
+			return nil, models.NewValidationError(models.CodeInvalidStatsQuery, "The percentile %q must be a number between 0 and 100.", field)
+		}
+		percentiles[i] = percentile
+	}
+	return percentiles, nil
+}
+
+//scoreStats computes the distribution of the scores, which go from the highest to the lowest
+func scoreStats(scores []int, percentiles []float64, buckets int) *models.GetRankingStatsResponse {
+	response := &models.GetRankingStatsResponse{
+		Players:     len(scores),
+		Percentiles: []models.ScorePercentile{},
+		Histogram:   []models.HistogramBucket{},
+	}
+	n := len(scores)
+	if n == 0 {
+		return response
+	}
+	//ascending returns the i-th lowest score
+	ascending := func(i int) int {
+		return scores[n-1-i]
+	}
+
+	response.Min = ascending(0)
+	response.Max = ascending(n - 1)
+	sum := 0.0
+	for _, score := range scores {
+		sum += float64(score)
+	}
+	response.Mean = sum / float64(n)
+	if n%2 == 1 {
+		response.Median = float64(ascending(n / 2))
+	} else {
+		response.Median = (float64(ascending(n/2-1)) + float64(ascending(n/2))) / 2
+	}
+
+	//nearest rank: the lowest score that is higher than or equal to the percentile of the scores
+	for _, percentile := range percentiles {
+		rank := int(math.Ceil(percentile / 100 * float64(n)))
+		if rank < 1 {
+			rank = 1
+		}
+		response.Percentiles = append(response.Percentiles, models.ScorePercentile{Percentile: percentile, Score: ascending(rank - 1)})
+	}
+
+	//the buckets have the same integer width, and the last one ends at the highest score
+	span := response.Max - response.Min + 1
+	width := (span + buckets - 1) / buckets
+	for from := response.Min; from <= response.Max; from += width {
+		to := from + width - 1
+		if to > response.Max {
+			to = response.Max
+		}
+		response.Histogram = append(response.Histogram, models.HistogramBucket{From: from, To: to})
+	}
+	for _, score := range scores {
+		response.Histogram[(score-response.Min)/width].Count++
+	}
+	return response
+}
+
+//userPercentile returns the percentage of the players ranked below the position
+func userPercentile(position int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	percentile := float64(total-position) / float64(total) * 100
+	return math.Round(percentile*100) / 100
+}
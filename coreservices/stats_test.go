@@ -0,0 +1,119 @@
+package coreservices
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pedrocmart/leaderboard-service/mocks"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBasicService_HandleGetRankingStats(t *testing.T) {
+	cases := []struct {
+		description        string
+		request            *models.GetRankingStatsRequest
+		leaderboardMissing bool
+		getScores          []int
+		getScoresError     error
+		expectedResponse   *models.GetRankingStatsResponse
+		expectedError      error
+	}{
+		{
+			description: "should return the stats with the default percentiles and buckets",
+			request:     &models.GetRankingStatsRequest{},
+			getScores:   []int{100, 90, 80, 70, 60, 50, 40, 30, 20, 10},
+			expectedResponse: &models.GetRankingStatsResponse{
+				Players: 10,
+				Min:     10,
+				Max:     100,
+				Mean:    55,
+				Median:  55,
+				Percentiles: []models.ScorePercentile{
+					{Percentile: 50, Score: 50},
+					{Percentile: 90, Score: 90},
+					{Percentile: 99, Score: 100},
+				},
+				Histogram: []models.HistogramBucket{
+					{From: 10, To: 19, Count: 1}, {From: 20, To: 29, Count: 1}, {From: 30, To: 39, Count: 1},
+					{From: 40, To: 49, Count: 1}, {From: 50, To: 59, Count: 1}, {From: 60, To: 69, Count: 1},
+					{From: 70, To: 79, Count: 1}, {From: 80, To: 89, Count: 1}, {From: 90, To: 99, Count: 1},
+					{From: 100, To: 100, Count: 1},
+				},
+			},
+		},
+		{
+			description: "should return the stats with the requested percentiles and buckets",
+			request:     &models.GetRankingStatsRequest{Percentiles: "0,25,100", Buckets: "2"},
+			getScores:   []int{7, 5, 5, -3, -4},
+			expectedResponse: &models.GetRankingStatsResponse{
+				Players: 5,
+				Min:     -4,
+				Max:     7,
+				Mean:    2,
+				Median:  5,
+				Percentiles: []models.ScorePercentile{
+					{Percentile: 0, Score: -4},
+					{Percentile: 25, Score: -3},
+					{Percentile: 100, Score: 7},
+				},
+				Histogram: []models.HistogramBucket{{From: -4, To: 1, Count: 2}, {From: 2, To: 7, Count: 3}},
+			},
+		},
+		{
+			description: "should return empty stats when the leaderboard has no players",
+			request:     &models.GetRankingStatsRequest{},
+			getScores:   []int{},
+			expectedResponse: &models.GetRankingStatsResponse{
+				Percentiles: []models.ScorePercentile{},
+				Histogram:   []models.HistogramBucket{},
+			},
+		},
+		{
+			description:   "should return error when a percentile is out of range",
+			request:       &models.GetRankingStatsRequest{Percentiles: "50,101"},
+			expectedError: models.NewValidationError(models.CodeInvalidStatsQuery, "The percentile %q must be a number between 0 and 100.", "101"),
+		},
+		{
+			description:   "should return error when a percentile is not a number",
+			request:       &models.GetRankingStatsRequest{Percentiles: "p50"},
+			expectedError: models.NewValidationError(models.CodeInvalidStatsQuery, "The percentile %q must be a number between 0 and 100.", "p50"),
+		},
+		{
+			description:   "should return error when the buckets are out of range",
+			request:       &models.GetRankingStatsRequest{Buckets: "0"},
+			expectedError: models.NewValidationError(models.CodeInvalidStatsQuery, "Buckets must be an integer between 1 and 100."),
+		},
+		{
+			description:        "should return error when the leaderboard does not exist",
+			request:            &models.GetRankingStatsRequest{},
+			leaderboardMissing: true,
+			expectedError:      models.NewNotFoundError(models.CodeLeaderboardNotFound, "Leaderboard default not found."),
+		},
+		{
+			description:    "should return error when GetScores",
+			request:        &models.GetRankingStatsRequest{},
+			getScoresError: fmt.Errorf("mock-error"),
+			expectedError:  fmt.Errorf("mock-error"),
+		},
+	}
+	for _, tc := range cases {
+		basicAPIService := BasicService{
+			Core: &models.Core{
+				StoreService: &mocks.StoreServiceMock{
+					DoesLeaderboardExistFunc: func(ctx context.Context, name string) (bool, error) {
+						return !tc.leaderboardMissing, nil
+					},
+					GetScoresFunc: func(ctx context.Context, board string) ([]int, error) {
+						return tc.getScores, tc.getScoresError
+					},
+				},
+			},
+		}
+
+		res, err := basicAPIService.HandleGetRankingStats(context.Background(), models.DefaultLeaderboard, tc.request)
+		assert.Equal(t, tc.expectedResponse, res, tc.description)
+		assert.Equal(t, tc.expectedError, err, tc.description)
+	}
+}
@@ -0,0 +1,128 @@
+package coreservices
+
+import (
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	_ "modernc.org/ql/driver"
+)
+
+//sqlDialect is what sets apart the databases BasicStoreService keeps the rankings in, whose queries are shared
+type sqlDialect struct {
+	//schema creates the tables, and their indexes, when they don't exist yet
+	schema []string
+	//lockBoard, when set, runs first in every transaction that writes a leaderboard, taking its name as $1,
+	//so the transactions of a leaderboard are applied one at a time on the databases that don't serialize them
+	lockBoard string
+	//singleConnection keeps the store to a single connection, for the databases that write one transaction at a time
+	//and would fail the others as busy rather than waiting for them
+	singleConnection bool
+}
+
+//qlDialect serializes the transactions on its own, and has types of its own
+var qlDialect = sqlDialect{
+	schema: []string{
+		"CREATE TABLE IF NOT EXISTS leaderboards (name STRING);",
+		"CREATE TABLE IF NOT EXISTS users (board STRING, id INT, score INT, reached_at INT);",
+		"CREATE TABLE IF NOT EXISTS frozen_users (board STRING, id INT, score INT, hidden BOOL);",
+	},
+}
+
+//sqliteDialect takes the standard types, and a single connection that also keeps an in memory database to itself
+var sqliteDialect = sqlDialect{
+	schema: []string{
+		"CREATE TABLE IF NOT EXISTS leaderboards (name TEXT);",
+		"CREATE TABLE IF NOT EXISTS users (board TEXT, id BIGINT, score BIGINT, reached_at BIGINT);",
+		"CREATE INDEX IF NOT EXISTS users_board_id ON users (board, id);",
+		"CREATE TABLE IF NOT EXISTS frozen_users (board TEXT, id BIGINT, score BIGINT, hidden BOOLEAN);",
+		"CREATE INDEX IF NOT EXISTS frozen_users_board_id ON frozen_users (board, id);",
+	},
+	singleConnection: true,
+}
+
+//postgresDialect reads committed rows by default, so two submissions of a user could both read the same score:
+//a transaction advisory lock on the leaderboard keeps them apart until one commits
+var postgresDialect = sqlDialect{
+	schema:    sqliteDialect.schema,
+	lockBoard: "SELECT pg_advisory_xact_lock(hashtext($1))",
+}
+
+//storeBackend opens the store of a backend with the store settings of the config, adding it to the core
+type storeBackend func(core *models.Core, settings models.StoreConfig) (models.StoreService, error)
+
+//storeRegistry has a backend for each of models.StoreBackends
+var storeRegistry = map[string]storeBackend{
+	"ql":       openQLMemoryStore,
+	"ql-file":  openQLFileStore,
+	"sqlite":   openSQLiteStore,
+	"postgres": openPostgresStore,
+	"memory": func(core *models.Core, settings models.StoreConfig) (models.StoreService, error) {
+		return NewMemoryStoreService(core), nil
+	},
+}
+
+//OpenStoreService opens the store of the backend of the settings, creating its tables when they don't exist yet.
+//It will also add it to the core
+func OpenStoreService(core *models.Core, settings models.StoreConfig) (models.StoreService, error) {
+	open, ok := storeRegistry[settings.Backend]
+	if !ok {
+		return nil, fmt.Errorf("unknown store backend %q", settings.Backend)
+	}
+	store, err := open(core, settings)
+	if err != nil {
+		return nil, fmt.Errorf("could not open the %s store: %s", settings.Backend, err)
+	}
+	return store, nil
+}
+
+//CloseDB closes the database of a store once the reads in flight are done.
+//ql reads the rows of a query in a goroutine that outlives them, and would race with the close:
+//a transaction is started first, since ql only starts it once those reads are done
+func CloseDB(db *sql.DB) error {
+	if tx, err := db.Begin(); err == nil {
+		tx.Rollback()
+	}
+	return db.Close()
+}
+
+//qlMemoryStores numbers the in memory ql databases, since the ones opened with the same name are shared
+var qlMemoryStores int64
+
+func openQLMemoryStore(core *models.Core, settings models.StoreConfig) (models.StoreService, error) {
+	name := fmt.Sprintf("memory://leaderboard-%d.db", atomic.AddInt64(&qlMemoryStores, 1))
+	return openSQLStore(core, "ql-mem", name, qlDialect)
+}
+
+func openQLFileStore(core *models.Core, settings models.StoreConfig) (models.StoreService, error) {
+	return openSQLStore(core, "ql", settings.Path, qlDialect)
+}
+
+func openSQLiteStore(core *models.Core, settings models.StoreConfig) (models.StoreService, error) {
+	//the busy timeout waits for the other processes that write the same file
+	return openSQLStore(core, "sqlite3", settings.Path+"?_busy_timeout=5000", sqliteDialect)
+}
+
+func openPostgresStore(core *models.Core, settings models.StoreConfig) (models.StoreService, error) {
+	return openSQLStore(core, "postgres", settings.DSN, postgresDialect)
+}
+
+//openSQLStore opens the database of the driver and creates the tables of the dialect in it
+func openSQLStore(core *models.Core, driver string, dataSource string, dialect sqlDialect) (models.StoreService, error) {
+	db, err := sql.Open(driver, dataSource)
+	if err != nil {
+		return nil, err
+	}
+	if dialect.singleConnection {
+		db.SetMaxOpenConns(1)
+	}
+	if err := createStoreTables(db, dialect); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("could not create the tables: %s", err)
+	}
+	return newSQLStoreService(core, db, dialect), nil
+}
@@ -0,0 +1,94 @@
+package coreservices
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenStoreService(t *testing.T) {
+	for _, backend := range models.StoreBackends {
+		_, ok := storeRegistry[backend]
+		assert.True(t, ok, "should have a backend for %s", backend)
+	}
+	assert.Len(t, storeRegistry, len(models.StoreBackends), "should only have the backends the config takes")
+
+	for _, backend := range storeBackends {
+		_, ok := storeRegistry[backend.name]
+		assert.True(t, ok, "should test the behaviour of the backend %s", backend.name)
+	}
+	assert.Len(t, storeBackends, len(storeRegistry), "should test the behaviour of every backend")
+
+	core := &models.Core{}
+	store, err := OpenStoreService(core, models.StoreConfig{Backend: "redis"})
+	assert.Nil(t, store, "should not open an unknown backend")
+	assert.Equal(t, fmt.Errorf(`unknown store backend "redis"`), err, "should not open an unknown backend")
+	assert.Nil(t, core.StoreService, "should not add a store to the core")
+
+	store, err = OpenStoreService(core, models.StoreConfig{Backend: "sqlite", Path: filepath.Join(t.TempDir(), "missing", "leaderboard.sqlite")})
+	assert.Nil(t, store, "should not open a database that can't be created")
+	if assert.Error(t, err, "should not open a database that can't be created") {
+		assert.Contains(t, err.Error(), "could not open the sqlite store: could not create the tables", "should tell which store could not be opened")
+	}
+}
+
+func TestOpenStoreService_Reopen(t *testing.T) {
+	cases := []struct {
+		description string
+		settings    func(t *testing.T) models.StoreConfig
+	}{
+		{
+			description: "should keep the rankings of the ql file",
+			settings: func(t *testing.T) models.StoreConfig {
+				return models.StoreConfig{Backend: "ql-file", Path: filepath.Join(t.TempDir(), "leaderboard.db")}
+			},
+		},
+		{
+			description: "should keep the rankings of the sqlite file",
+			settings: func(t *testing.T) models.StoreConfig {
+				return models.StoreConfig{Backend: "sqlite", Path: filepath.Join(t.TempDir(), "leaderboard.sqlite")}
+			},
+		},
+		{
+			description: "should keep the rankings of the postgres database",
+			settings:    postgresTestConfig,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			settings := tc.settings(t)
+
+			core := &models.Core{}
+			store, err := OpenStoreService(core, settings)
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening the store", err)
+			}
+			newTestStore(t, store)
+			seedStore(t, store, 10, 20)
+			assert.NoError(t, store.FreezeUser(ctx, models.DefaultLeaderboard, 2, true), tc.description)
+			assert.NoError(t, CloseDB(core.DB), tc.description)
+
+			reopened := &models.Core{}
+			store, err = OpenStoreService(reopened, settings)
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening the store again", err)
+			}
+			defer CloseDB(reopened.DB)
+
+			leaderboards, err := store.GetLeaderboards(ctx)
+			assert.NoError(t, err, tc.description)
+			assert.Equal(t, []string{models.DefaultLeaderboard}, leaderboards, tc.description)
+			ranking, err := store.GetUsers(ctx, models.DefaultLeaderboard, 10)
+			assert.NoError(t, err, tc.description)
+			assert.Equal(t, []models.Ranking{{Position: 1, UserID: 1, Score: 10}}, ranking, tc.description)
+			frozen, err := store.GetFrozenUsers(ctx, models.DefaultLeaderboard)
+			assert.NoError(t, err, tc.description)
+			assert.Equal(t, []models.FrozenUser{{UserID: 2, Score: 20, Hidden: true}}, frozen, tc.description)
+		})
+	}
+}
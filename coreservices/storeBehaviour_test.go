@@ -0,0 +1,909 @@
+package coreservices
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+//storeBackends are the backends of the registry, which must all behave the same way
+var storeBackends = []struct {
+	name     string
+	newStore func(t *testing.T, core *models.Core) models.StoreService
+}{
+	{
+		name: "ql",
+		newStore: openTestStore(func(t *testing.T) models.StoreConfig {
+			return models.StoreConfig{Backend: "ql"}
+		}),
+	},
+	{
+		name: "ql-file",
+		newStore: openTestStore(func(t *testing.T) models.StoreConfig {
+			return models.StoreConfig{Backend: "ql-file", Path: filepath.Join(t.TempDir(), "leaderboard.db")}
+		}),
+	},
+	{
+		name: "sqlite",
+		newStore: openTestStore(func(t *testing.T) models.StoreConfig {
+			return models.StoreConfig{Backend: "sqlite", Path: filepath.Join(t.TempDir(), "leaderboard.sqlite")}
+		}),
+	},
+	{
+		name:     "postgres",
+		newStore: openTestStore(postgresTestConfig),
+	},
+	{
+		name: "memory",
+		newStore: openTestStore(func(t *testing.T) models.StoreConfig {
+			return models.StoreConfig{Backend: "memory"}
+		}),
+	},
+}
+
+//postgresTestDSN is the env var of the Postgres database the postgres backend is tested against, set by make test.
+//The backend is skipped when it is not set
+const postgresTestDSN = "TEST_POSTGRES_DSN"
+
+//postgresTestConfig returns the settings of the test database, dropping the tables that the previous tests left
+func postgresTestConfig(t *testing.T) models.StoreConfig {
+	dsn := os.Getenv(postgresTestDSN)
+	if dsn == "" {
+		t.Skipf("%s is not set, make test serves a database for it", postgresTestDSN)
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening the database", err)
+	}
+	defer db.Close()
+	if _, err := db.Exec("DROP TABLE IF EXISTS leaderboards, users, frozen_users"); err != nil {
+		t.Fatalf("an error '%s' was not expected when dropping the tables", err)
+	}
+	return models.StoreConfig{Backend: "postgres", DSN: dsn}
+}
+
+//openTestStore returns a function that opens the store of the settings from the registry, closing it once the test ends
+func openTestStore(settings func(t *testing.T) models.StoreConfig) func(t *testing.T, core *models.Core) models.StoreService {
+	return func(t *testing.T, core *models.Core) models.StoreService {
+		store, err := OpenStoreService(core, settings(t))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening the store", err)
+		}
+		if core.DB != nil {
+			db := core.DB
+			t.Cleanup(func() { CloseDB(db) })
+		}
+		return newTestStore(t, store)
+	}
+}
+
+//newTestStore creates the default leaderboard in store
+func newTestStore(t *testing.T, store models.StoreService) models.StoreService {
+	if err := store.CreateLeaderboard(context.Background(), models.DefaultLeaderboard); err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the default leaderboard", err)
+	}
+	return store
+}
+
+//seedStore creates one user per score in the default leaderboard, with ids starting at 1
+func seedStore(t *testing.T, store models.StoreService, scores ...int) {
+	seedBoard(t, store, models.DefaultLeaderboard, scores...)
+}
+
+//seedBoard creates one user per score in board, with ids starting at 1
+func seedBoard(t *testing.T, store models.StoreService, board string, scores ...int) {
+	for i, score := range scores {
+		if err := store.CreateUser(context.Background(), board, i+1, score); err != nil {
+			t.Fatalf("an error '%s' was not expected when creating user %d", err, i+1)
+		}
+	}
+}
+
+func TestStoreServiceBehaviour_Leaderboards(t *testing.T) {
+	for _, backend := range storeBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := backend.newStore(t, &models.Core{})
+
+			assert.NoError(t, store.Ping(ctx), "should be reachable")
+			assert.NoError(t, store.CreateLeaderboard(ctx, "weekly"), "should create a leaderboard")
+			assert.Equal(t, fmt.Errorf("leaderboard weekly already exists"), store.CreateLeaderboard(ctx, "weekly"), "should not create a leaderboard twice")
+
+			exists, err := store.DoesLeaderboardExist(ctx, "weekly")
+			assert.NoError(t, err, "should check an existing leaderboard")
+			assert.True(t, exists, "should check an existing leaderboard")
+
+			leaderboards, err := store.GetLeaderboards(ctx)
+			assert.NoError(t, err, "should list the leaderboards")
+			assert.Equal(t, []string{models.DefaultLeaderboard, "weekly"}, leaderboards, "should list the leaderboards sorted by name")
+
+			seedStore(t, store, 10, 20)
+			seedBoard(t, store, "weekly", 5)
+
+			ranking, err := store.GetUsers(ctx, "weekly", 10)
+			assert.NoError(t, err, "should get the ranking of a leaderboard")
+			assert.Equal(t, []models.Ranking{{Position: 1, UserID: 1, Score: 5}}, ranking, "should only rank the users of the leaderboard")
+
+			assert.NoError(t, store.UpdateAbsoluteUserScore(ctx, "weekly", 1, 50), "should update a user of a leaderboard")
+			user, err := store.GetUserById(ctx, models.DefaultLeaderboard, 1)
+			assert.NoError(t, err, "should get the user of another leaderboard")
+			assert.Equal(t, &models.User{UserID: 1, Score: 10}, user, "should not update the same user in other leaderboards")
+
+			assert.NoError(t, store.DeleteLeaderboard(ctx, "weekly"), "should delete a leaderboard")
+			exists, err = store.DoesLeaderboardExist(ctx, "weekly")
+			assert.NoError(t, err, "should check a deleted leaderboard")
+			assert.False(t, exists, "should check a deleted leaderboard")
+
+			assert.NoError(t, store.CreateLeaderboard(ctx, "weekly"), "should create a deleted leaderboard again")
+			ranking, err = store.GetUsers(ctx, "weekly", 10)
+			assert.NoError(t, err, "should get the ranking of a recreated leaderboard")
+			assert.Empty(t, ranking, "should delete the users with their leaderboard")
+		})
+	}
+}
+
+func TestStoreServiceBehaviour_Users(t *testing.T) {
+	for _, backend := range storeBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := backend.newStore(t, &models.Core{})
+			seedStore(t, store, 10, 20)
+
+			exists, err := store.DoesUserExist(ctx, models.DefaultLeaderboard, 1)
+			assert.NoError(t, err, "should check an existing user")
+			assert.True(t, exists, "should check an existing user")
+
+			exists, err = store.DoesUserExist(ctx, models.DefaultLeaderboard, 3)
+			assert.NoError(t, err, "should check a missing user")
+			assert.False(t, exists, "should check a missing user")
+
+			assert.NoError(t, store.UpdateRelativeUserScore(ctx, models.DefaultLeaderboard, 1, -15), "should update a relative score")
+			user, err := store.GetUserById(ctx, models.DefaultLeaderboard, 1)
+			assert.NoError(t, err, "should get the user updated with a relative score")
+			assert.Equal(t, &models.User{UserID: 1, Score: -5}, user, "should get the user updated with a relative score")
+
+			assert.NoError(t, store.UpdateAbsoluteUserScore(ctx, models.DefaultLeaderboard, 2, 7), "should update an absolute score")
+			user, err = store.GetUserById(ctx, models.DefaultLeaderboard, 2)
+			assert.NoError(t, err, "should get the user updated with an absolute score")
+			assert.Equal(t, &models.User{UserID: 2, Score: 7}, user, "should get the user updated with an absolute score")
+
+			_, err = store.GetUserById(ctx, models.DefaultLeaderboard, 3)
+			assert.Equal(t, sql.ErrNoRows, err, "should return sql.ErrNoRows for a missing user")
+
+			change, err := store.UpsertUserScore(ctx, models.DefaultLeaderboard, models.ScoreSubmission{UserID: 3, Score: 4})
+			assert.NoError(t, err, "should upsert a missing user")
+			assert.Equal(t, &models.ScoreChange{UserID: 3, Score: 4, Created: true}, change, "should create a missing user with the score")
+
+			change, err = store.UpsertUserScore(ctx, models.DefaultLeaderboard, models.ScoreSubmission{UserID: 3, Score: 6})
+			assert.NoError(t, err, "should upsert an existing user")
+			assert.Equal(t, &models.ScoreChange{UserID: 3, PreviousScore: 4, Score: 10}, change, "should return the score updated with a relative score")
+		})
+	}
+}
+
+func TestStoreServiceBehaviour_SubmitScores(t *testing.T) {
+	for _, backend := range storeBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := backend.newStore(t, &models.Core{})
+			seedStore(t, store, 10, 20)
+
+			changes, err := store.SubmitScores(ctx, models.DefaultLeaderboard, []models.ScoreSubmission{
+				{UserID: 1, Score: 5},
+				{UserID: 2, Score: 3, Absolute: true},
+				{UserID: 3, Score: 40},
+				{UserID: 3, Score: -15},
+				{UserID: 4, Score: 8, Absolute: true},
+			})
+			assert.NoError(t, err, "should submit a batch of scores")
+			assert.Equal(t, []models.ScoreChange{
+				{UserID: 1, PreviousScore: 10, Score: 15},
+				{UserID: 2, PreviousScore: 20, Score: 3},
+				{UserID: 3, Score: 40, Created: true},
+				{UserID: 3, PreviousScore: 40, Score: 25},
+				{UserID: 4, Score: 8, Created: true},
+			}, changes, "should return the changes in the order of the batch")
+
+			ranking, err := store.GetUsers(ctx, models.DefaultLeaderboard, 10)
+			assert.NoError(t, err)
+			assert.Equal(t, []models.Ranking{
+				{Position: 1, UserID: 3, Score: 25},
+				{Position: 2, UserID: 1, Score: 15},
+				{Position: 3, UserID: 4, Score: 8},
+				{Position: 4, UserID: 2, Score: 3},
+			}, ranking, "should rank the scores of the batch")
+		})
+	}
+}
+
+func TestStoreServiceBehaviour_SubmitScoresOverflow(t *testing.T) {
+	periodBoard := models.PeriodBoard(models.DefaultLeaderboard, models.PeriodDaily, "2026-01-01")
+	overflow := func(id int) error {
+		return models.NewValidationError(models.CodeInvalidScore, "The score of user %d must stay between %d and %d.", id, math.MinInt, math.MaxInt)
+	}
+	cases := []struct {
+		description   string
+		submissions   []models.ScoreSubmission
+		periodScores  []int
+		expectedError error
+	}{
+		{
+			description: "should take a relative score up to math.MaxInt",
+			submissions: []models.ScoreSubmission{{UserID: 1, Score: -1}, {UserID: 1, Score: 1}},
+		},
+		{
+			description:   "should reject a relative score above math.MaxInt",
+			submissions:   []models.ScoreSubmission{{UserID: 1, Score: 1}},
+			expectedError: overflow(1),
+		},
+		{
+			description:   "should reject a relative score below math.MinInt",
+			submissions:   []models.ScoreSubmission{{UserID: 2, Score: -1}},
+			expectedError: overflow(2),
+		},
+		{
+			description:   "should reject the whole batch when a score overflows",
+			submissions:   []models.ScoreSubmission{{UserID: 3, Score: 5}, {UserID: 1, Score: math.MaxInt}},
+			expectedError: overflow(1),
+		},
+		{
+			description:   "should reject a score that overflows with an earlier score of the batch",
+			submissions:   []models.ScoreSubmission{{UserID: 3, Score: math.MaxInt, Absolute: true}, {UserID: 3, Score: 1}},
+			expectedError: overflow(3),
+		},
+		{
+			description:   "should reject a score that overflows on the board of a period",
+			submissions:   []models.ScoreSubmission{{UserID: 3, Score: 1}},
+			periodScores:  []int{0, 0, math.MaxInt},
+			expectedError: overflow(3),
+		},
+	}
+	for _, backend := range storeBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			for _, tc := range cases {
+				ctx := context.Background()
+				store := backend.newStore(t, &models.Core{})
+				seedStore(t, store, math.MaxInt, math.MinInt, 0)
+				assert.NoError(t, store.CreateLeaderboard(ctx, periodBoard), tc.description)
+				seedBoard(t, store, periodBoard, tc.periodScores...)
+				before, err := store.GetUsers(ctx, models.DefaultLeaderboard, 10)
+				assert.NoError(t, err, tc.description)
+
+				_, _, err = store.SubmitScoresWithPeriods(ctx, models.DefaultLeaderboard, []string{periodBoard}, tc.submissions)
+				assert.Equal(t, tc.expectedError, err, tc.description)
+				if tc.expectedError != nil {
+					ranking, err := store.GetUsers(ctx, models.DefaultLeaderboard, 10)
+					assert.NoError(t, err, tc.description)
+					assert.Equal(t, before, ranking, "should not change the ranking: "+tc.description)
+				}
+			}
+		})
+	}
+}
+
+func TestStoreServiceBehaviour_Ranking(t *testing.T) {
+	cases := []struct {
+		description    string
+		scores         []int
+		top            int
+		pos            int
+		around         int
+		expectedResult []models.Ranking
+	}{
+		{
+			description: "should get the top users ordered by score",
+			scores:      []int{5, 452, 101},
+			top:         2,
+			expectedResult: []models.Ranking{
+				{Position: 1, UserID: 2, Score: 452},
+				{Position: 2, UserID: 3, Score: 101},
+			},
+		},
+		{
+			description: "should get every user when top is bigger than the ranking",
+			scores:      []int{-72, 19},
+			top:         10,
+			expectedResult: []models.Ranking{
+				{Position: 1, UserID: 2, Score: 19},
+				{Position: 2, UserID: 1, Score: -72},
+			},
+		},
+		{
+			description:    "should get an empty ranking",
+			top:            10,
+			expectedResult: []models.Ranking{},
+		},
+		{
+			description: "should get users around a position",
+			scores:      []int{70, 60, 50, 40, 30, 20, 10},
+			pos:         4,
+			around:      1,
+			expectedResult: []models.Ranking{
+				{Position: 3, UserID: 3, Score: 50},
+				{Position: 4, UserID: 4, Score: 40},
+				{Position: 5, UserID: 5, Score: 30},
+			},
+		},
+		{
+			description: "should get the top user and the users below it",
+			scores:      []int{70, 60, 50, 40},
+			pos:         1,
+			around:      2,
+			expectedResult: []models.Ranking{
+				{Position: 1, UserID: 1, Score: 70},
+				{Position: 2, UserID: 2, Score: 60},
+				{Position: 3, UserID: 3, Score: 50},
+			},
+		},
+		{
+			description: "should get users around the last position",
+			scores:      []int{70, 60, 50, 40},
+			pos:         4,
+			around:      1,
+			expectedResult: []models.Ranking{
+				{Position: 3, UserID: 3, Score: 50},
+				{Position: 4, UserID: 4, Score: 40},
+			},
+		},
+		{
+			description:    "should get an empty ranking around a position out of range",
+			scores:         []int{70, 60},
+			pos:            10,
+			around:         1,
+			expectedResult: []models.Ranking{},
+		},
+	}
+	for _, backend := range storeBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			for i, tc := range cases {
+				t.Run(fmt.Sprint(i), func(t *testing.T) {
+					store := backend.newStore(t, &models.Core{})
+					seedStore(t, store, tc.scores...)
+
+					var result []models.Ranking
+					var err error
+					if tc.top > 0 {
+						result, err = store.GetUsers(context.Background(), models.DefaultLeaderboard, tc.top)
+					} else {
+						result, err = store.GetUsersBetween(context.Background(), models.DefaultLeaderboard, tc.pos, tc.around)
+					}
+					assert.NoError(t, err, tc.description)
+					assert.Equal(t, tc.expectedResult, result, tc.description)
+				})
+			}
+		})
+	}
+}
+
+func TestStoreServiceBehaviour_Rank(t *testing.T) {
+	for _, backend := range storeBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := backend.newStore(t, &models.Core{})
+
+			total, err := store.CountUsers(ctx, models.DefaultLeaderboard)
+			assert.NoError(t, err, "should count an empty leaderboard")
+			assert.Equal(t, 0, total, "should count an empty leaderboard")
+
+			seedStore(t, store, 30, 50, 10, 40)
+
+			total, err = store.CountUsers(ctx, models.DefaultLeaderboard)
+			assert.NoError(t, err, "should count the users")
+			assert.Equal(t, 4, total, "should count the users")
+
+			for _, expected := range []struct{ id, score, position int }{
+				{id: 2, score: 50, position: 1},
+				{id: 4, score: 40, position: 2},
+				{id: 1, score: 30, position: 3},
+				{id: 3, score: 10, position: 4},
+			} {
+				position, err := store.GetUserPosition(ctx, models.DefaultLeaderboard, expected.id, expected.score)
+				assert.NoError(t, err, "should get the position of user %d", expected.id)
+				assert.Equal(t, expected.position, position, "should get the position of user %d", expected.id)
+			}
+		})
+	}
+}
+
+func TestStoreServiceBehaviour_Scores(t *testing.T) {
+	for _, backend := range storeBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := backend.newStore(t, &models.Core{})
+
+			scores, err := store.GetScores(ctx, models.DefaultLeaderboard)
+			assert.NoError(t, err, "should get the scores of an empty leaderboard")
+			assert.Empty(t, scores, "should get the scores of an empty leaderboard")
+
+			seedStore(t, store, 30, -5, 50, 30, 10)
+
+			scores, err = store.GetScores(ctx, models.DefaultLeaderboard)
+			assert.NoError(t, err, "should get the scores")
+			assert.Equal(t, []int{50, 30, 30, 10, -5}, scores, "should get the scores from the highest to the lowest")
+		})
+	}
+}
+
+func TestStoreServiceBehaviour_DeleteUser(t *testing.T) {
+	for _, backend := range storeBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := backend.newStore(t, &models.Core{})
+			seedStore(t, store, 30, 20, 10)
+
+			assert.NoError(t, store.DeleteUser(ctx, models.DefaultLeaderboard, 2), "should delete a user")
+			assert.Equal(t, sql.ErrNoRows, store.DeleteUser(ctx, models.DefaultLeaderboard, 2), "should not delete a missing user")
+
+			assert.NoError(t, store.FreezeUser(ctx, models.DefaultLeaderboard, 3, true), "should hide a user")
+			assert.NoError(t, store.DeleteUser(ctx, models.DefaultLeaderboard, 3), "should delete a hidden user")
+			frozen, err := store.GetFrozenUsers(ctx, models.DefaultLeaderboard)
+			assert.NoError(t, err)
+			assert.Empty(t, frozen, "should unfreeze a deleted user")
+
+			ranking, err := store.GetUsers(ctx, models.DefaultLeaderboard, 10)
+			assert.NoError(t, err)
+			assert.Equal(t, []models.Ranking{{Position: 1, UserID: 1, Score: 30}}, ranking, "should rank the users left")
+		})
+	}
+}
+
+func TestStoreServiceBehaviour_ResetBoard(t *testing.T) {
+	for _, backend := range storeBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := backend.newStore(t, &models.Core{})
+			seedStore(t, store, 30, 20, 10)
+			assert.NoError(t, store.FreezeUser(ctx, models.DefaultLeaderboard, 1, true))
+			assert.NoError(t, store.FreezeUser(ctx, models.DefaultLeaderboard, 2, false))
+
+			removed, err := store.ResetBoard(ctx, models.DefaultLeaderboard)
+			assert.NoError(t, err, "should reset a leaderboard")
+			assert.Equal(t, 2, removed, "should return how many users were in the ranking")
+
+			total, err := store.CountUsers(ctx, models.DefaultLeaderboard)
+			assert.NoError(t, err)
+			assert.Equal(t, 0, total, "should remove every user from the ranking")
+
+			frozen, err := store.GetFrozenUsers(ctx, models.DefaultLeaderboard)
+			assert.NoError(t, err)
+			assert.Equal(t, []models.FrozenUser{{UserID: 1, Hidden: true}, {UserID: 2}}, frozen, "should keep the frozen users without their score")
+		})
+	}
+}
+
+func TestStoreServiceBehaviour_MergeUsers(t *testing.T) {
+	cases := []struct {
+		description     string
+		from            int
+		into            int
+		strategy        models.MergeStrategy
+		expectedError   error
+		expectedRanking []models.Ranking
+	}{
+		{
+			description: "should keep the highest score",
+			from:        1,
+			into:        3,
+			strategy:    models.MergeMax,
+			expectedRanking: []models.Ranking{
+				{Position: 1, UserID: 3, Score: 30},
+				{Position: 2, UserID: 2, Score: 20},
+			},
+		},
+		{
+			description: "should add the scores",
+			from:        1,
+			into:        2,
+			strategy:    models.MergeSum,
+			expectedRanking: []models.Ranking{
+				{Position: 1, UserID: 2, Score: 50},
+				{Position: 2, UserID: 3, Score: 10},
+			},
+		},
+		{
+			description: "should rename a user into a missing one",
+			from:        3,
+			into:        9,
+			strategy:    models.MergeMax,
+			expectedRanking: []models.Ranking{
+				{Position: 1, UserID: 1, Score: 30},
+				{Position: 2, UserID: 2, Score: 20},
+				{Position: 3, UserID: 9, Score: 10},
+			},
+		},
+		{
+			description:   "should not merge a missing user",
+			from:          8,
+			into:          1,
+			strategy:      models.MergeMax,
+			expectedError: sql.ErrNoRows,
+			expectedRanking: []models.Ranking{
+				{Position: 1, UserID: 1, Score: 30},
+				{Position: 2, UserID: 2, Score: 20},
+				{Position: 3, UserID: 3, Score: 10},
+			},
+		},
+	}
+	for _, backend := range storeBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			for i, tc := range cases {
+				t.Run(fmt.Sprint(i), func(t *testing.T) {
+					ctx := context.Background()
+					store := backend.newStore(t, &models.Core{})
+					seedStore(t, store, 30, 20, 10)
+
+					err := store.MergeUsers(ctx, models.DefaultLeaderboard, tc.from, tc.into, tc.strategy)
+					assert.Equal(t, tc.expectedError, err, tc.description)
+
+					ranking, err := store.GetUsers(ctx, models.DefaultLeaderboard, 10)
+					assert.NoError(t, err)
+					assert.Equal(t, tc.expectedRanking, ranking, tc.description)
+				})
+			}
+		})
+	}
+}
+
+func TestStoreServiceBehaviour_SubmitScoresWithPeriods(t *testing.T) {
+	daily := models.PeriodBoard(models.DefaultLeaderboard, models.PeriodDaily, "2026-01-01")
+	weekly := models.PeriodBoard(models.DefaultLeaderboard, models.PeriodWeekly, "2025-12-29")
+	for _, backend := range storeBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := backend.newStore(t, &models.Core{})
+			seedStore(t, store, 30)
+			assert.NoError(t, store.CreateLeaderboard(ctx, weekly))
+
+			changes, created, err := store.SubmitScoresWithPeriods(ctx, models.DefaultLeaderboard, []string{daily, weekly}, []models.ScoreSubmission{
+				{UserID: 1, Score: 5},
+				{UserID: 2, Score: 20, Absolute: true},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, []models.ScoreChange{
+				{UserID: 1, PreviousScore: 30, Score: 35},
+				{UserID: 2, Score: 20, Created: true},
+			}, changes, "should return the changes of the board")
+			assert.Equal(t, []string{daily}, created, "should only create the boards of the periods that did not exist")
+
+			ranking, err := store.GetUsers(ctx, models.DefaultLeaderboard, 10)
+			assert.NoError(t, err)
+			assert.Equal(t, []models.Ranking{{Position: 1, UserID: 1, Score: 35}, {Position: 2, UserID: 2, Score: 20}}, ranking)
+			for _, board := range []string{daily, weekly} {
+				ranking, err = store.GetUsers(ctx, board, 10)
+				assert.NoError(t, err)
+				assert.Equal(t, []models.Ranking{{Position: 1, UserID: 2, Score: 20}, {Position: 2, UserID: 1, Score: 5}}, ranking, "should apply the scores to the board of the period")
+			}
+		})
+	}
+}
+
+func TestStoreServiceBehaviour_FreezeUser(t *testing.T) {
+	for _, backend := range storeBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := backend.newStore(t, &models.Core{})
+			seedStore(t, store, 30, 20, 10)
+
+			assert.Equal(t, sql.ErrNoRows, store.FreezeUser(ctx, models.DefaultLeaderboard, 4, false), "should not freeze a missing user")
+			assert.NoError(t, store.FreezeUser(ctx, models.DefaultLeaderboard, 1, true), "should hide a user")
+			assert.NoError(t, store.FreezeUser(ctx, models.DefaultLeaderboard, 3, false), "should freeze a user in the ranking")
+
+			frozen, err := store.GetFrozenUsers(ctx, models.DefaultLeaderboard)
+			assert.NoError(t, err, "should get the frozen users")
+			assert.Equal(t, []models.FrozenUser{{UserID: 1, Hidden: true, Score: 30}, {UserID: 3}}, frozen, "should get the frozen users by id")
+
+			ranking, err := store.GetUsers(ctx, models.DefaultLeaderboard, 10)
+			assert.NoError(t, err)
+			assert.Equal(t, []models.Ranking{
+				{Position: 1, UserID: 2, Score: 20},
+				{Position: 2, UserID: 3, Score: 10},
+			}, ranking, "should only take the hidden user out of the ranking")
+
+			user, err := store.UnfreezeUser(ctx, models.DefaultLeaderboard, 1)
+			assert.NoError(t, err, "should unfreeze a hidden user")
+			assert.Equal(t, &models.FrozenUser{UserID: 1, Hidden: true, Score: 30}, user, "should return how the user was frozen")
+			_, err = store.UnfreezeUser(ctx, models.DefaultLeaderboard, 1)
+			assert.Equal(t, sql.ErrNoRows, err, "should not unfreeze a user that is not frozen")
+
+			ranking, err = store.GetUsers(ctx, models.DefaultLeaderboard, 10)
+			assert.NoError(t, err)
+			assert.Equal(t, []models.Ranking{
+				{Position: 1, UserID: 1, Score: 30},
+				{Position: 2, UserID: 2, Score: 20},
+				{Position: 3, UserID: 3, Score: 10},
+			}, ranking, "should put the hidden user back with her/his score")
+		})
+	}
+}
+
+func TestStoreServiceBehaviour_RestoreFrozenUser(t *testing.T) {
+	for _, backend := range storeBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := backend.newStore(t, &models.Core{})
+			seedStore(t, store, 30, 20)
+
+			assert.NoError(t, store.RestoreFrozenUser(ctx, models.DefaultLeaderboard, models.FrozenUser{UserID: 1}), "should freeze a user in the ranking")
+			assert.NoError(t, store.RestoreFrozenUser(ctx, models.DefaultLeaderboard, models.FrozenUser{UserID: 5, Hidden: true, Score: 40}), "should freeze a user that is not in the ranking")
+			assert.NoError(t, store.RestoreFrozenUser(ctx, models.DefaultLeaderboard, models.FrozenUser{UserID: 5, Hidden: true, Score: 50}), "should restore a frozen user again")
+
+			frozen, err := store.GetFrozenUsers(ctx, models.DefaultLeaderboard)
+			assert.NoError(t, err)
+			assert.Equal(t, []models.FrozenUser{{UserID: 1}, {UserID: 5, Hidden: true, Score: 50}}, frozen, "should keep the frozen users as they were restored")
+
+			ranking, err := store.GetUsers(ctx, models.DefaultLeaderboard, 10)
+			assert.NoError(t, err)
+			assert.Equal(t, []models.Ranking{
+				{Position: 1, UserID: 1, Score: 30},
+				{Position: 2, UserID: 2, Score: 20},
+			}, ranking, "should not change the ranking")
+		})
+	}
+}
+
+func TestStoreServiceBehaviour_TieBreak(t *testing.T) {
+	cases := []struct {
+		description     string
+		policy          models.TieBreakPolicy
+		expectedRanking []models.Ranking
+		expectedBetween []models.Ranking
+		expectedRank    int
+	}{
+		{
+			description: "should rank first the user that reached the score first",
+			policy:      models.TieBreakFirst,
+			expectedRanking: []models.Ranking{
+				{Position: 1, UserID: 1, Score: 80},
+				{Position: 2, UserID: 3, Score: 50},
+				{Position: 3, UserID: 2, Score: 50},
+				{Position: 4, UserID: 5, Score: 50},
+				{Position: 5, UserID: 4, Score: 30},
+			},
+			expectedBetween: []models.Ranking{
+				{Position: 3, UserID: 2, Score: 50},
+				{Position: 4, UserID: 5, Score: 50},
+				{Position: 5, UserID: 4, Score: 30},
+			},
+			expectedRank: 4,
+		},
+		{
+			description: "should rank first the user with the lowest id",
+			policy:      models.TieBreakLowestID,
+			expectedRanking: []models.Ranking{
+				{Position: 1, UserID: 1, Score: 80},
+				{Position: 2, UserID: 2, Score: 50},
+				{Position: 3, UserID: 3, Score: 50},
+				{Position: 4, UserID: 5, Score: 50},
+				{Position: 5, UserID: 4, Score: 30},
+			},
+			expectedBetween: []models.Ranking{
+				{Position: 3, UserID: 3, Score: 50},
+				{Position: 4, UserID: 5, Score: 50},
+				{Position: 5, UserID: 4, Score: 30},
+			},
+			expectedRank: 4,
+		},
+		{
+			description: "should share positions and skip the next ones",
+			policy:      models.TieBreakCompetition,
+			expectedRanking: []models.Ranking{
+				{Position: 1, UserID: 1, Score: 80},
+				{Position: 2, UserID: 2, Score: 50},
+				{Position: 2, UserID: 3, Score: 50},
+				{Position: 2, UserID: 5, Score: 50},
+				{Position: 5, UserID: 4, Score: 30},
+			},
+			expectedBetween: []models.Ranking{
+				{Position: 2, UserID: 3, Score: 50},
+				{Position: 2, UserID: 5, Score: 50},
+				{Position: 5, UserID: 4, Score: 30},
+			},
+			expectedRank: 2,
+		},
+		{
+			description: "should share positions without skipping the next ones",
+			policy:      models.TieBreakDense,
+			expectedRanking: []models.Ranking{
+				{Position: 1, UserID: 1, Score: 80},
+				{Position: 2, UserID: 2, Score: 50},
+				{Position: 2, UserID: 3, Score: 50},
+				{Position: 2, UserID: 5, Score: 50},
+				{Position: 3, UserID: 4, Score: 30},
+			},
+			expectedBetween: []models.Ranking{
+				{Position: 2, UserID: 3, Score: 50},
+				{Position: 2, UserID: 5, Score: 50},
+				{Position: 3, UserID: 4, Score: 30},
+			},
+			expectedRank: 2,
+		},
+	}
+	for _, backend := range storeBackends {
+		for _, tc := range cases {
+			t.Run(backend.name+"/"+string(tc.policy), func(t *testing.T) {
+				ctx := context.Background()
+				store := backend.newStore(t, &models.Core{TieBreakPolicy: tc.policy})
+
+				//user 3 reaches 50 first, user 2 next and user 5 last, after an update
+				for _, user := range []models.User{{UserID: 3, Score: 50}, {UserID: 1, Score: 80}, {UserID: 2, Score: 50}, {UserID: 4, Score: 30}, {UserID: 5, Score: 40}} {
+					assert.NoError(t, store.CreateUser(ctx, models.DefaultLeaderboard, user.UserID, user.Score))
+				}
+				assert.NoError(t, store.UpdateRelativeUserScore(ctx, models.DefaultLeaderboard, 5, 10))
+				assert.NoError(t, store.UpdateAbsoluteUserScore(ctx, models.DefaultLeaderboard, 3, 50), "should keep the time an unchanged score was reached")
+
+				for i := 0; i < 2; i++ {
+					ranking, err := store.GetUsers(ctx, models.DefaultLeaderboard, 10)
+					assert.NoError(t, err)
+					assert.Equal(t, tc.expectedRanking, ranking, tc.description)
+				}
+
+				assert.Equal(t, tc.expectedRanking, rankingPages(t, store, 2), tc.description)
+
+				between, err := store.GetUsersBetween(ctx, models.DefaultLeaderboard, 4, 1)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedBetween, between, tc.description)
+
+				position, err := store.GetUserPosition(ctx, models.DefaultLeaderboard, 5, 50)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedRank, position, tc.description)
+			})
+		}
+	}
+}
+
+//rankingPages walks the whole ranking of the default leaderboard in pages of limit users
+func rankingPages(t *testing.T, store models.StoreService, limit int) []models.Ranking {
+	ranking := []models.Ranking{}
+	var cursor *models.RankingCursor
+	for {
+		page, last, err := store.GetUsersAfter(context.Background(), models.DefaultLeaderboard, cursor, limit)
+		assert.NoError(t, err)
+		ranking = append(ranking, page...)
+		if len(page) < limit {
+			return ranking
+		}
+		cursor = last
+	}
+}
+
+func TestStoreServiceBehaviour_RankingPages(t *testing.T) {
+	for _, backend := range storeBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := backend.newStore(t, &models.Core{})
+
+			page, cursor, err := store.GetUsersAfter(ctx, models.DefaultLeaderboard, nil, 2)
+			assert.NoError(t, err, "should get an empty page")
+			assert.Equal(t, []models.Ranking{}, page, "should get an empty page")
+			assert.Nil(t, cursor, "should not get a cursor of an empty page")
+
+			seedStore(t, store, 70, 60, 50, 40, 30, 20)
+
+			page, cursor, err = store.GetUsersAfter(ctx, models.DefaultLeaderboard, nil, 2)
+			assert.NoError(t, err, "should get the first page")
+			assert.Equal(t, []models.Ranking{
+				{Position: 1, UserID: 1, Score: 70},
+				{Position: 2, UserID: 2, Score: 60},
+			}, page, "should get the first page")
+
+			//user 2 leaves the page it was returned in, and user 5 moves over the cursor
+			assert.NoError(t, store.UpdateAbsoluteUserScore(ctx, models.DefaultLeaderboard, 2, 10))
+			assert.NoError(t, store.UpdateAbsoluteUserScore(ctx, models.DefaultLeaderboard, 5, 100))
+
+			page, cursor, err = store.GetUsersAfter(ctx, models.DefaultLeaderboard, cursor, 2)
+			assert.NoError(t, err, "should get the page after a cursor user that moved")
+			assert.Equal(t, []models.Ranking{
+				{Position: 3, UserID: 3, Score: 50},
+				{Position: 4, UserID: 4, Score: 40},
+			}, page, "should get the page after a cursor user that moved")
+
+			page, cursor, err = store.GetUsersAfter(ctx, models.DefaultLeaderboard, cursor, 2)
+			assert.NoError(t, err, "should get the last page")
+			assert.Equal(t, []models.Ranking{
+				{Position: 5, UserID: 6, Score: 20},
+				{Position: 6, UserID: 2, Score: 10},
+			}, page, "should get the last page")
+
+			page, _, err = store.GetUsersAfter(ctx, models.DefaultLeaderboard, cursor, 2)
+			assert.NoError(t, err, "should get an empty page after the last user")
+			assert.Equal(t, []models.Ranking{}, page, "should get an empty page after the last user")
+		})
+	}
+}
+
+func TestStoreServiceBehaviour_GetRanking(t *testing.T) {
+	cases := []struct {
+		description    string
+		rankingType    string
+		expectedResult []models.Ranking
+		expectedError  error
+	}{
+		{
+			description: "should get the bottom users",
+			rankingType: "bottom2",
+			expectedResult: []models.Ranking{
+				{Position: 4, UserID: 4, Score: 40},
+				{Position: 5, UserID: 5, Score: 30},
+			},
+		},
+		{
+			description: "should get every user when bottom is bigger than the ranking",
+			rankingType: "bottom10",
+			expectedResult: []models.Ranking{
+				{Position: 1, UserID: 1, Score: 70},
+				{Position: 2, UserID: 2, Score: 60},
+				{Position: 3, UserID: 3, Score: 50},
+				{Position: 4, UserID: 4, Score: 40},
+				{Position: 5, UserID: 5, Score: 30},
+			},
+		},
+		{
+			description: "should get a range of positions",
+			rankingType: "range2-3",
+			expectedResult: []models.Ranking{
+				{Position: 2, UserID: 2, Score: 60},
+				{Position: 3, UserID: 3, Score: 50},
+			},
+		},
+		{
+			description:    "should get an empty range out of the ranking",
+			rankingType:    "range6-9",
+			expectedResult: []models.Ranking{},
+		},
+		{
+			description: "should get the users at or above a percentile",
+			rankingType: "percentile60",
+			expectedResult: []models.Ranking{
+				{Position: 1, UserID: 1, Score: 70},
+				{Position: 2, UserID: 2, Score: 60},
+			},
+		},
+		{
+			description: "should get the users around an user",
+			rankingType: "user4/1",
+			expectedResult: []models.Ranking{
+				{Position: 3, UserID: 3, Score: 50},
+				{Position: 4, UserID: 4, Score: 40},
+				{Position: 5, UserID: 5, Score: 30},
+			},
+		},
+		{
+			description:   "should not find a missing user",
+			rankingType:   "user9/1",
+			expectedError: sql.ErrNoRows,
+		},
+		{
+			description: "should filter the users by score",
+			rankingType: "top4,score>=55",
+			expectedResult: []models.Ranking{
+				{Position: 1, UserID: 1, Score: 70},
+				{Position: 2, UserID: 2, Score: 60},
+			},
+		},
+	}
+	for _, backend := range storeBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			for i, tc := range cases {
+				t.Run(fmt.Sprint(i), func(t *testing.T) {
+					store := backend.newStore(t, &models.Core{})
+					seedStore(t, store, 70, 60, 50, 40, 30)
+
+					query, err := models.ParseRankingQuery(tc.rankingType)
+					assert.NoError(t, err, tc.description)
+					result, err := store.GetRanking(context.Background(), models.DefaultLeaderboard, query)
+					assert.Equal(t, tc.expectedError, err, tc.description)
+					if tc.expectedError == nil {
+						assert.Equal(t, tc.expectedResult, result, tc.description)
+					}
+				})
+			}
+		})
+	}
+}
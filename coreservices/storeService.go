@@ -3,32 +3,70 @@ package coreservices
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"math"
 
 	"github.com/pedrocmart/leaderboard-service/models"
 )
 
+//NewStoreService - will return a store that keeps the rankings in the ql database db. It will also add it to the core
 func NewStoreService(core *models.Core, db *sql.DB) models.StoreService {
+	return newSQLStoreService(core, db, qlDialect)
+}
+
+func newSQLStoreService(core *models.Core, db *sql.DB, dialect sqlDialect) models.StoreService {
 	storeService := BasicStoreService{
-		core: core,
+		core:    core,
+		dialect: dialect,
 	}
 	core.StoreService = &storeService
 	core.DB = db
 	return &storeService
 }
 
+//BasicStoreService keeps the rankings in a SQL database. Its queries are shared by every database, so their
+//$n placeholders must first appear in order: SQLite numbers them as they come, not by their digits
 type BasicStoreService struct {
-	core *models.Core
+	core    *models.Core
+	dialect sqlDialect
+	clock   reachedClock
 }
 
-func (b *BasicStoreService) CreateUser(ctx context.Context, id int, total int) error {
+//begin starts a transaction that writes board, locking the board first on the databases that need it
+func (b *BasicStoreService) begin(ctx context.Context, board string) (*sql.Tx, error) {
 	tx, err := b.core.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if b.dialect.lockBoard != "" {
+		if _, err := tx.ExecContext(ctx, b.dialect.lockBoard, board); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	return tx, nil
+}
+
+func (b *BasicStoreService) CreateLeaderboard(ctx context.Context, name string) error {
+	tx, err := b.begin(ctx, name)
 	if err != nil {
 		return err
 	}
 
-	_, err = tx.ExecContext(ctx, `INSERT INTO users (id, score) VALUES ($1, $2)`,
-		id, total)
+	var existing string
+	err = tx.QueryRowContext(ctx, "SELECT name FROM leaderboards WHERE name = $1", name).Scan(&existing)
+	if err == nil {
+		tx.Rollback()
+		return fmt.Errorf("leaderboard %s already exists", name)
+	}
+	if err != sql.ErrNoRows {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO leaderboards (name) VALUES ($1)`, name)
 	if err != nil {
+		tx.Rollback()
 		return err
 	}
 
@@ -39,16 +77,104 @@ func (b *BasicStoreService) CreateUser(ctx context.Context, id int, total int) e
 	return nil
 }
 
-func (b *BasicStoreService) UpdateRelativeUserScore(ctx context.Context, id int, score int) error {
-	tx, err := b.core.DB.BeginTx(ctx, nil)
+func (b *BasicStoreService) DeleteLeaderboard(ctx context.Context, name string) error {
+	tx, err := b.begin(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM users WHERE board = $1`, name)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM frozen_users WHERE board = $1`, name)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM leaderboards WHERE name = $1`, name)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (b *BasicStoreService) DoesLeaderboardExist(ctx context.Context, name string) (bool, error) {
+	if err := b.core.DB.QueryRowContext(ctx, "SELECT name FROM leaderboards WHERE name = $1", name).Scan(&name); err != nil {
+		if err != sql.ErrNoRows {
+			return false, err
+		}
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (b *BasicStoreService) GetLeaderboards(ctx context.Context) ([]string, error) {
+	rows, err := b.core.DB.QueryContext(ctx, "SELECT name FROM leaderboards ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	leaderboards := make([]string, 0)
+
+	for rows.Next() {
+		var name string
+		err = rows.Scan(&name)
+		if err != nil {
+			return nil, err
+		}
+		leaderboards = append(leaderboards, name)
+	}
+
+	return leaderboards, nil
+}
+
+func (b *BasicStoreService) CreateUser(ctx context.Context, board string, id int, total int) error {
+	tx, err := b.begin(ctx, board)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO users (board, id, score, reached_at) VALUES ($1, $2, $3, $4)`,
+		board, id, total, b.clock.now())
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (b *BasicStoreService) UpdateRelativeUserScore(ctx context.Context, board string, id int, score int) error {
+	//a score that doesn't change keeps the time it was reached
+	if score == 0 {
+		return nil
+	}
+
+	tx, err := b.begin(ctx, board)
 	if err != nil {
 		return err
 	}
 
 	_, err = tx.ExecContext(ctx, `UPDATE users 
-		SET score = score + $1
-		WHERE id = $2`, score, id)
+		SET score = score + $1, reached_at = $2
+		WHERE board = $3 AND id = $4`, score, b.clock.now(), board, id)
 	if err != nil {
+		tx.Rollback()
 		return err
 	}
 
@@ -59,16 +185,17 @@ func (b *BasicStoreService) UpdateRelativeUserScore(ctx context.Context, id int,
 	return err
 }
 
-func (b *BasicStoreService) UpdateAbsoluteUserScore(ctx context.Context, id int, score int) error {
-	tx, err := b.core.DB.BeginTx(ctx, nil)
+func (b *BasicStoreService) UpdateAbsoluteUserScore(ctx context.Context, board string, id int, score int) error {
+	tx, err := b.begin(ctx, board)
 	if err != nil {
 		return err
 	}
 
 	_, err = tx.ExecContext(ctx, `UPDATE users 
-		SET score = $1
-		WHERE id = $2`, score, id)
+		SET score = $1, reached_at = $2
+		WHERE board = $3 AND id = $4 AND score != $1`, score, b.clock.now(), board, id)
 	if err != nil {
+		tx.Rollback()
 		return err
 	}
 
@@ -78,8 +205,106 @@ func (b *BasicStoreService) UpdateAbsoluteUserScore(ctx context.Context, id int,
 	return err
 }
 
-func (b *BasicStoreService) DoesUserExist(ctx context.Context, id int) (bool, error) {
-	if err := b.core.DB.QueryRowContext(ctx, "SELECT id FROM users WHERE id = $1", id).Scan(&id); err != nil {
+func (b *BasicStoreService) UpsertUserScore(ctx context.Context, board string, submission models.ScoreSubmission) (*models.ScoreChange, error) {
+	changes, err := b.SubmitScores(ctx, board, []models.ScoreSubmission{submission})
+	if err != nil {
+		return nil, err
+	}
+
+	return &changes[0], nil
+}
+
+func (b *BasicStoreService) SubmitScores(ctx context.Context, board string, submissions []models.ScoreSubmission) ([]models.ScoreChange, error) {
+	changes, _, err := b.SubmitScoresWithPeriods(ctx, board, nil, submissions)
+	return changes, err
+}
+
+func (b *BasicStoreService) SubmitScoresWithPeriods(ctx context.Context, board string, periods []string, submissions []models.ScoreSubmission) ([]models.ScoreChange, []string, error) {
+	tx, err := b.begin(ctx, board)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changes, err := b.submitScores(ctx, tx, board, submissions)
+	if err != nil {
+		tx.Rollback()
+		return nil, nil, err
+	}
+
+	created := make([]string, 0)
+	for _, period := range periods {
+		var isNew bool
+		isNew, err = b.createPeriodBoard(ctx, tx, period)
+		if err == nil {
+			_, err = b.submitScores(ctx, tx, period, submissions)
+		}
+		if err != nil {
+			tx.Rollback()
+			return nil, nil, err
+		}
+		if isNew {
+			created = append(created, period)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	return changes, created, nil
+}
+
+//createPeriodBoard creates the board of a period within tx, locking it first, unless it already exists.
+//It returns whether the board was created
+func (b *BasicStoreService) createPeriodBoard(ctx context.Context, tx *sql.Tx, board string) (bool, error) {
+	if b.dialect.lockBoard != "" {
+		if _, err := tx.ExecContext(ctx, b.dialect.lockBoard, board); err != nil {
+			return false, err
+		}
+	}
+
+	var existing string
+	err := tx.QueryRowContext(ctx, "SELECT name FROM leaderboards WHERE name = $1", board).Scan(&existing)
+	if err != sql.ErrNoRows {
+		return false, err
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO leaderboards (name) VALUES ($1)`, board)
+	return err == nil, err
+}
+
+//submitScores applies the submissions to board within tx, returning their changes in the same order
+func (b *BasicStoreService) submitScores(ctx context.Context, tx *sql.Tx, board string, submissions []models.ScoreSubmission) ([]models.ScoreChange, error) {
+	changes := make([]models.ScoreChange, len(submissions))
+	for i, submission := range submissions {
+		change := &changes[i]
+		change.UserID = submission.UserID
+		change.Score = submission.Score
+		err := tx.QueryRowContext(ctx, "SELECT score FROM users WHERE board = $1 AND id = $2", board, submission.UserID).Scan(&change.PreviousScore)
+		if err == sql.ErrNoRows {
+			change.Created = true
+			_, err = tx.ExecContext(ctx, `INSERT INTO users (board, id, score, reached_at) VALUES ($1, $2, $3, $4)`,
+				board, submission.UserID, submission.Score, b.clock.now())
+		} else if err == nil {
+			if !submission.Absolute {
+				change.Score, err = relativeScore(submission.UserID, change.PreviousScore, submission.Score)
+			}
+			//a score that doesn't change keeps the time it was reached
+			if err == nil && change.Score != change.PreviousScore {
+				_, err = tx.ExecContext(ctx, `UPDATE users 
+					SET score = $1, reached_at = $2
+					WHERE board = $3 AND id = $4`, change.Score, b.clock.now(), board, submission.UserID)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return changes, nil
+}
+
+func (b *BasicStoreService) DoesUserExist(ctx context.Context, board string, id int) (bool, error) {
+	if err := b.core.DB.QueryRowContext(ctx, "SELECT id FROM users WHERE board = $1 AND id = $2", board, id).Scan(&id); err != nil {
 		if err != sql.ErrNoRows {
 			return false, err
 		}
@@ -89,19 +314,29 @@ func (b *BasicStoreService) DoesUserExist(ctx context.Context, id int) (bool, er
 	return true, nil
 }
 
-func (b *BasicStoreService) GetUsers(ctx context.Context, top int) ([]models.Ranking, error) {
-	rows, err := b.core.DB.QueryContext(ctx, "SELECT id, score FROM users ORDER BY score DESC LIMIT $1", top)
-	defer rows.Close()
+//rankingOrder returns the ORDER BY of the ranking for the tie break policy of the core.
+//ql can only order by selected fields, so the ranking queries also select reached_at
+func (b *BasicStoreService) rankingOrder() string {
+	if b.core.GetTieBreakPolicy() == models.TieBreakFirst {
+		return "ORDER BY -score, reached_at, id"
+	}
+	return "ORDER BY -score, id"
+}
+
+func (b *BasicStoreService) GetUsers(ctx context.Context, board string, top int) ([]models.Ranking, error) {
+	rows, err := b.core.DB.QueryContext(ctx, "SELECT id, score, reached_at FROM users WHERE board = $1 "+b.rankingOrder()+" LIMIT $2", board, top)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 	position := 1
 	ranking := make([]models.Ranking, 0)
 
 	for rows.Next() {
 		var id int
 		var score int
-		err = rows.Scan(&id, &score)
+		var reachedAt int64
+		err = rows.Scan(&id, &score, &reachedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -113,47 +348,33 @@ func (b *BasicStoreService) GetUsers(ctx context.Context, top int) ([]models.Ran
 		position++
 	}
 
+	if err = b.sharePositions(ctx, board, ranking); err != nil {
+		return nil, err
+	}
 	return ranking, nil
 }
 
-func (b *BasicStoreService) GetUsersBetween(ctx context.Context, pos, around int) ([]models.Ranking, error) {
-	/*
-		offset is zero-based, and for this reason -1 is being subtracted
-		eg:
-		pos sent was 100
-		offset:= 100 - 3 - 1
-		offset = 96
-		this way I can start from position 97th (zero-based)
-	*/
-	offset := pos - around - 1
-	if offset < 0 {
-		offset = 0
-	}
-	/*
-		since we need the upper, lower and the exactly positions, I' duplicating the parameter and adding 1
-		eg:
-		positionAround sent was 3
-		positionAround = 3 + 3 + 1
-		and this will limit the 7 close positions to the position that we want
-	*/
-	positionAround := around + around + 1
-	//if offset is 0, means that we gonna get the top 1 from users; this way we need to get only users below his position, including she/he
-	if offset == 0 {
-		positionAround = around + 1
-	}
+func (b *BasicStoreService) GetUsersBetween(ctx context.Context, board string, pos, around int) ([]models.Ranking, error) {
+	offset, positionAround := usersBetweenWindow(pos, around)
+	return b.rankingFrom(ctx, board, offset+1, positionAround)
+}
 
-	rows, err := b.core.DB.QueryContext(ctx, "SELECT id, score FROM users ORDER BY score DESC LIMIT $1 OFFSET $2", positionAround, offset)
-	defer rows.Close()
+//rankingFrom returns up to limit users starting at the 1-based position first
+func (b *BasicStoreService) rankingFrom(ctx context.Context, board string, first, limit int) ([]models.Ranking, error) {
+	offset := first - 1
+	rows, err := b.core.DB.QueryContext(ctx, "SELECT id, score, reached_at FROM users WHERE board = $1 "+b.rankingOrder()+" LIMIT $2 OFFSET $3", board, limit, offset)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 	position := offset + 1 //I'm doing +1 here because offset is zero-based
 	ranking := make([]models.Ranking, 0)
 
 	for rows.Next() {
 		var id int
 		var score int
-		err = rows.Scan(&id, &score)
+		var reachedAt int64
+		err = rows.Scan(&id, &score, &reachedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -165,12 +386,139 @@ func (b *BasicStoreService) GetUsersBetween(ctx context.Context, pos, around int
 		position++
 	}
 
+	if err = b.sharePositions(ctx, board, ranking); err != nil {
+		return nil, err
+	}
 	return ranking, nil
 }
 
-func (b *BasicStoreService) GetUserById(ctx context.Context, id int) (*models.User, error) {
+func (b *BasicStoreService) GetRanking(ctx context.Context, board string, query *models.RankingQuery) ([]models.Ranking, error) {
+	var total, position int
+	var err error
+	if needsTotal(query) {
+		total, err = b.CountUsers(ctx, board)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if query.Kind == models.RankingAroundUser {
+		user, err := b.GetUserById(ctx, board, query.UserID)
+		if err != nil {
+			return nil, err
+		}
+		position, err = b.GetUserPosition(ctx, board, user.UserID, user.Score)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	first, limit := rankingWindow(query, total, position)
+	if limit <= 0 {
+		return []models.Ranking{}, nil
+	}
+	ranking, err := b.rankingFrom(ctx, board, first, limit)
+	if err != nil {
+		return nil, err
+	}
+	return filterRanking(ranking, query), nil
+}
+
+//rankingAfter returns the condition on the users that follow the cursor in the ranking order,
+//the one on the users that go up to it, and the arguments of the cursor they take from $2 on.
+//Only the fields of the cursor the policy ranks by are taken, since Postgres can't type a placeholder that is not used
+func (b *BasicStoreService) rankingAfter(cursor *models.RankingCursor) (after string, upTo string, args []interface{}) {
+	if b.core.GetTieBreakPolicy() == models.TieBreakFirst {
+		return "(score < $2 OR (score = $2 AND (reached_at > $3 OR (reached_at = $3 AND id > $4))))",
+			"(score > $2 OR (score = $2 AND (reached_at < $3 OR (reached_at = $3 AND id <= $4))))",
+			[]interface{}{cursor.Score, cursor.ReachedAt, cursor.UserID}
+	}
+	return "(score < $2 OR (score = $2 AND id > $3))", "(score > $2 OR (score = $2 AND id <= $3))",
+		[]interface{}{cursor.Score, cursor.UserID}
+}
+
+func (b *BasicStoreService) GetUsersAfter(ctx context.Context, board string, after *models.RankingCursor, limit int) ([]models.Ranking, *models.RankingCursor, error) {
+	query := "SELECT id, score, reached_at FROM users WHERE board = $1 " + b.rankingOrder() + " LIMIT $2"
+	args := []interface{}{board, limit}
+	position := 1
+	if after != nil {
+		afterCondition, upToCondition, cursorArgs := b.rankingAfter(after)
+		args = append([]interface{}{board}, cursorArgs...)
+		//the users up to the cursor give the position of the first user of the page, whether the cursor user still exists or not
+		var upTo int
+		err := b.core.DB.QueryRowContext(ctx, "SELECT count(*) FROM users WHERE board = $1 AND "+upToCondition, args...).Scan(&upTo)
+		if err != nil {
+			return nil, nil, err
+		}
+		position = upTo + 1
+		query = fmt.Sprintf("SELECT id, score, reached_at FROM users WHERE board = $1 AND %s %s LIMIT $%d", afterCondition, b.rankingOrder(), len(args)+1)
+		args = append(args, limit)
+	}
+
+	rows, err := b.core.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	ranking := make([]models.Ranking, 0)
+	var cursor *models.RankingCursor
+
+	for rows.Next() {
+		var id int
+		var score int
+		var reachedAt int64
+		err = rows.Scan(&id, &score, &reachedAt)
+		if err != nil {
+			return nil, nil, err
+		}
+		ranking = append(ranking, models.Ranking{
+			Position: position,
+			UserID:   id,
+			Score:    score,
+		})
+		cursor = &models.RankingCursor{Score: score, ReachedAt: reachedAt, UserID: id}
+		position++
+	}
+
+	if err = b.sharePositions(ctx, board, ranking); err != nil {
+		return nil, nil, err
+	}
+	return ranking, cursor, nil
+}
+
+//sharePositions gives users with equal scores the same position when the policy of the core shares them
+func (b *BasicStoreService) sharePositions(ctx context.Context, board string, ranking []models.Ranking) error {
+	policy := b.core.GetTieBreakPolicy()
+	if !policy.SharesPositions() || len(ranking) == 0 {
+		return nil
+	}
+	//the first user of the window may be tied with users before it
+	first, err := b.scorePosition(ctx, board, ranking[0].Score)
+	if err != nil {
+		return err
+	}
+	sharePositions(ranking, policy, first)
+	return nil
+}
+
+//scorePosition returns the position shared by the users with the given score
+func (b *BasicStoreService) scorePosition(ctx context.Context, board string, score int) (int, error) {
+	query := "SELECT count(*) FROM users WHERE board = $1 AND score > $2"
+	if b.core.GetTieBreakPolicy() == models.TieBreakDense {
+		//Postgres needs the subquery to be named
+		query = "SELECT count(*) FROM (SELECT DISTINCT score FROM users WHERE board = $1 AND score > $2) AS scores"
+	}
+
+	var ahead int
+	if err := b.core.DB.QueryRowContext(ctx, query, board, score).Scan(&ahead); err != nil {
+		return 0, err
+	}
+
+	return ahead + 1, nil
+}
+
+func (b *BasicStoreService) GetUserById(ctx context.Context, board string, id int) (*models.User, error) {
 	user := new(models.User)
-	err := b.core.DB.QueryRowContext(ctx, "SELECT id, score FROM users WHERE id = $1", id).Scan(
+	err := b.core.DB.QueryRowContext(ctx, "SELECT id, score FROM users WHERE board = $1 AND id = $2", board, id).Scan(
 		&user.UserID,
 		&user.Score,
 	)
@@ -180,3 +528,324 @@ func (b *BasicStoreService) GetUserById(ctx context.Context, id int) (*models.Us
 
 	return user, nil
 }
+
+func (b *BasicStoreService) GetUserPosition(ctx context.Context, board string, id int, score int) (int, error) {
+	//the position of the user is given by how many users go before him in the ranking
+	var ahead int
+	var err error
+	switch b.core.GetTieBreakPolicy() {
+	case models.TieBreakCompetition, models.TieBreakDense:
+		return b.scorePosition(ctx, board, score)
+	case models.TieBreakLowestID:
+		err = b.core.DB.QueryRowContext(ctx, `SELECT count(*) FROM users 
+			WHERE board = $1 AND (score > $2 OR (score = $2 AND id < $3))`, board, score, id).Scan(&ahead)
+	default:
+		var reachedAt int64
+		err = b.core.DB.QueryRowContext(ctx, "SELECT reached_at FROM users WHERE board = $1 AND id = $2", board, id).Scan(&reachedAt)
+		if err != nil {
+			return 0, err
+		}
+		err = b.core.DB.QueryRowContext(ctx, `SELECT count(*) FROM users 
+			WHERE board = $1 AND (score > $2 OR (score = $2 AND (reached_at < $3 OR (reached_at = $3 AND id < $4))))`,
+			board, score, reachedAt, id).Scan(&ahead)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return ahead + 1, nil
+}
+
+func (b *BasicStoreService) CountUsers(ctx context.Context, board string) (int, error) {
+	var total int
+	err := b.core.DB.QueryRowContext(ctx, "SELECT count(*) FROM users WHERE board = $1", board).Scan(&total)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (b *BasicStoreService) GetScores(ctx context.Context, board string) ([]int, error) {
+	rows, err := b.core.DB.QueryContext(ctx, "SELECT score FROM users WHERE board = $1 ORDER BY score DESC", board)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scores := make([]int, 0)
+	for rows.Next() {
+		var score int
+		if err = rows.Scan(&score); err != nil {
+			return nil, err
+		}
+		scores = append(scores, score)
+	}
+	return scores, rows.Err()
+}
+
+func (b *BasicStoreService) DeleteUser(ctx context.Context, board string, id int) error {
+	tx, err := b.begin(ctx, board)
+	if err != nil {
+		return err
+	}
+
+	var ranked, frozen int
+	err = tx.QueryRowContext(ctx, "SELECT count(*) FROM users WHERE board = $1 AND id = $2", board, id).Scan(&ranked)
+	if err == nil {
+		err = tx.QueryRowContext(ctx, "SELECT count(*) FROM frozen_users WHERE board = $1 AND id = $2", board, id).Scan(&frozen)
+	}
+	if err == nil && ranked == 0 && frozen == 0 {
+		err = sql.ErrNoRows
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM users WHERE board = $1 AND id = $2`, board, id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM frozen_users WHERE board = $1 AND id = $2`, board, id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (b *BasicStoreService) ResetBoard(ctx context.Context, board string) (int, error) {
+	tx, err := b.begin(ctx, board)
+	if err != nil {
+		return 0, err
+	}
+
+	var removed int
+	err = tx.QueryRowContext(ctx, "SELECT count(*) FROM users WHERE board = $1", board).Scan(&removed)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM users WHERE board = $1`, board)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE frozen_users SET score = 0 WHERE board = $1`, board)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+func (b *BasicStoreService) MergeUsers(ctx context.Context, board string, from int, into int, strategy models.MergeStrategy) error {
+	tx, err := b.begin(ctx, board)
+	if err != nil {
+		return err
+	}
+
+	var fromScore, intoScore int
+	err = tx.QueryRowContext(ctx, "SELECT score FROM users WHERE board = $1 AND id = $2", board, from).Scan(&fromScore)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.QueryRowContext(ctx, "SELECT score FROM users WHERE board = $1 AND id = $2", board, into).Scan(&intoScore)
+	if err == sql.ErrNoRows {
+		//a rename keeps the time the score was reached
+		_, err = tx.ExecContext(ctx, `UPDATE users SET id = $1 WHERE board = $2 AND id = $3`, into, board, from)
+	} else if err == nil {
+		//a score that doesn't change keeps the time it was reached
+		if score := strategy.Merge(fromScore, intoScore); score != intoScore {
+			_, err = tx.ExecContext(ctx, `UPDATE users 
+				SET score = $1, reached_at = $2
+				WHERE board = $3 AND id = $4`, score, b.clock.now(), board, into)
+		}
+		if err == nil {
+			_, err = tx.ExecContext(ctx, `DELETE FROM users WHERE board = $1 AND id = $2`, board, from)
+		}
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (b *BasicStoreService) FreezeUser(ctx context.Context, board string, id int, hidden bool) error {
+	tx, err := b.begin(ctx, board)
+	if err != nil {
+		return err
+	}
+
+	var score int
+	err = tx.QueryRowContext(ctx, "SELECT score FROM users WHERE board = $1 AND id = $2", board, id).Scan(&score)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	//only a hidden user needs her/his score back when she/he is unfrozen
+	if !hidden {
+		score = 0
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM frozen_users WHERE board = $1 AND id = $2`, board, id)
+	if err == nil {
+		_, err = tx.ExecContext(ctx, `INSERT INTO frozen_users (board, id, score, hidden) VALUES ($1, $2, $3, $4)`,
+			board, id, score, hidden)
+	}
+	if err == nil && hidden {
+		_, err = tx.ExecContext(ctx, `DELETE FROM users WHERE board = $1 AND id = $2`, board, id)
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (b *BasicStoreService) UnfreezeUser(ctx context.Context, board string, id int) (*models.FrozenUser, error) {
+	tx, err := b.begin(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+
+	frozen := &models.FrozenUser{UserID: id}
+	err = tx.QueryRowContext(ctx, "SELECT score, hidden FROM frozen_users WHERE board = $1 AND id = $2", board, id).Scan(
+		&frozen.Score,
+		&frozen.Hidden,
+	)
+	if err == nil {
+		_, err = tx.ExecContext(ctx, `DELETE FROM frozen_users WHERE board = $1 AND id = $2`, board, id)
+	}
+	if err == nil && frozen.Hidden {
+		_, err = tx.ExecContext(ctx, `INSERT INTO users (board, id, score, reached_at) VALUES ($1, $2, $3, $4)`,
+			board, id, frozen.Score, b.clock.now())
+	}
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return frozen, nil
+}
+
+func (b *BasicStoreService) RestoreFrozenUser(ctx context.Context, board string, user models.FrozenUser) error {
+	tx, err := b.begin(ctx, board)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM frozen_users WHERE board = $1 AND id = $2`, board, user.UserID)
+	if err == nil {
+		_, err = tx.ExecContext(ctx, `INSERT INTO frozen_users (board, id, score, hidden) VALUES ($1, $2, $3, $4)`,
+			board, user.UserID, user.Score, user.Hidden)
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (b *BasicStoreService) GetFrozenUsers(ctx context.Context, board string) ([]models.FrozenUser, error) {
+	rows, err := b.core.DB.QueryContext(ctx, "SELECT id, score, hidden FROM frozen_users WHERE board = $1 ORDER BY id", board)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	frozen := make([]models.FrozenUser, 0)
+	for rows.Next() {
+		var user models.FrozenUser
+		if err = rows.Scan(&user.UserID, &user.Score, &user.Hidden); err != nil {
+			return nil, err
+		}
+		frozen = append(frozen, user)
+	}
+	return frozen, rows.Err()
+}
+
+func (b *BasicStoreService) Ping(ctx context.Context) error {
+	return b.core.DB.PingContext(ctx)
+}
+
+//relativeScore adds the relative score delta to the score of the user, rejecting a sum that doesn't fit an integer
+func relativeScore(id int, score int, delta int) (int, error) {
+	if (delta > 0 && score > math.MaxInt-delta) || (delta < 0 && score < math.MinInt-delta) {
+		return 0, models.NewValidationError(models.CodeInvalidScore, "The score of user %d must stay between %d and %d.", id, math.MinInt, math.MaxInt)
+	}
+	return score + delta, nil
+}
+
+//usersBetweenWindow returns the zero-based offset and the limit of the ranking window
+//holding `around` users above and below pos
+func usersBetweenWindow(pos, around int) (offset int, positionAround int) {
+	/*
+		offset is zero-based, and for this reason -1 is being subtracted
+		eg:
+		pos sent was 100
+		offset:= 100 - 3 - 1
+		offset = 96
+		this way I can start from position 97th (zero-based)
+	*/
+	offset = pos - around - 1
+	if offset < 0 {
+		offset = 0
+	}
+	/*
+		since we need the upper, lower and the exactly positions, I' duplicating the parameter and adding 1
+		eg:
+		positionAround sent was 3
+		positionAround = 3 + 3 + 1
+		and this will limit the 7 close positions to the position that we want
+	*/
+	positionAround = around + around + 1
+	//if offset is 0, means that we gonna get the top 1 from users; this way we need to get only users below his position, including she/he
+	if offset == 0 {
+		positionAround = around + 1
+	}
+
+	return offset, positionAround
+}
+
+//CreateStoreTables creates the tables used by BasicStoreService in the ql database db
+func CreateStoreTables(db *sql.DB) error {
+	return createStoreTables(db, qlDialect)
+}
+
+//createStoreTables creates the tables of the dialect that don't exist yet, so a database that is kept
+//on the disk is opened again as it was
+func createStoreTables(db *sql.DB, dialect sqlDialect) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	for _, statement := range dialect.schema {
+		if _, err := tx.Exec(statement); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -2,6 +2,8 @@ package coreservices
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"regexp"
 	"testing"
@@ -29,7 +31,7 @@ func TestBasicStoreService_CreateUser(t *testing.T) {
 			context:     context.Background(),
 			userId:      1,
 			score:       100,
-			query:       `INSERT INTO users (id, score) VALUES ($1, $2)`,
+			query:       `INSERT INTO users (board, id, score, reached_at) VALUES ($1, $2, $3, $4)`,
 		},
 		{
 			description:   "Should return an error",
@@ -37,7 +39,7 @@ func TestBasicStoreService_CreateUser(t *testing.T) {
 			context:       context.Background(),
 			userId:        1,
 			score:         100,
-			query:         `INSERT INTO users (id, score) VALUES ($1, $2)`,
+			query:         `INSERT INTO users (board, id, score, reached_at) VALUES ($1, $2, $3, $4)`,
 			err:           fmt.Errorf("mock-error"),
 			expectedError: errors.Wrapf(fmt.Errorf("mock-error"), "create user"),
 		},
@@ -52,15 +54,17 @@ func TestBasicStoreService_CreateUser(t *testing.T) {
 		mock.ExpectBegin()
 		mock.ExpectExec(regexp.QuoteMeta(tc.query)).
 			WithArgs(
+				models.DefaultLeaderboard,
 				tc.userId,
 				tc.score,
+				sqlmock.AnyArg(),
 			).
 			WillReturnResult(sqlmock.NewResult(1, 1)).
 			WillReturnError(tc.err)
 
 		basicStore := NewStoreService(tc.core, tc.core.DB)
 		// now we execute our method
-		err = basicStore.CreateUser(tc.context, tc.userId, tc.score)
+		err = basicStore.CreateUser(tc.context, models.DefaultLeaderboard, tc.userId, tc.score)
 		if tc.err != nil {
 			assert.Error(t, err)
 			return
@@ -91,8 +95,8 @@ func TestBasicStoreService_UpdateRelativeUserScore(t *testing.T) {
 			userId:      1,
 			score:       100,
 			query: `UPDATE users 
-			SET score = score + $1
-			WHERE id = $2`,
+		SET score = score + $1, reached_at = $2
+		WHERE board = $3 AND id = $4`,
 		},
 		{
 			description: "Should return an error",
@@ -101,8 +105,8 @@ func TestBasicStoreService_UpdateRelativeUserScore(t *testing.T) {
 			userId:      100,
 			score:       1,
 			query: `UPDATE users 
-			SET score = score + $1
-			WHERE id = $2`,
+		SET score = score + $1, reached_at = $2
+		WHERE board = $3 AND id = $4`,
 			err:           fmt.Errorf("mock-error"),
 			expectedError: errors.Wrapf(fmt.Errorf("mock-error"), "create user"),
 		},
@@ -118,6 +122,8 @@ func TestBasicStoreService_UpdateRelativeUserScore(t *testing.T) {
 		mock.ExpectExec(regexp.QuoteMeta(tc.query)).
 			WithArgs(
 				tc.score,
+				sqlmock.AnyArg(),
+				models.DefaultLeaderboard,
 				tc.userId,
 			).
 			WillReturnResult(sqlmock.NewResult(1, 1)).
@@ -125,7 +131,7 @@ func TestBasicStoreService_UpdateRelativeUserScore(t *testing.T) {
 
 		basicStore := NewStoreService(tc.core, tc.core.DB)
 		// now we execute our method
-		err = basicStore.UpdateRelativeUserScore(tc.context, tc.userId, tc.score)
+		err = basicStore.UpdateRelativeUserScore(tc.context, models.DefaultLeaderboard, tc.userId, tc.score)
 		if tc.err != nil {
 			assert.Error(t, err)
 			return
@@ -156,8 +162,8 @@ func TestBasicStoreService_UpdateAbsoluteUserScore(t *testing.T) {
 			userId:      1,
 			score:       100,
 			query: `UPDATE users 
-			SET score = $1
-			WHERE id = $2`,
+		SET score = $1, reached_at = $2
+		WHERE board = $3 AND id = $4 AND score != $1`,
 		},
 		{
 			description: "Should return an error",
@@ -166,8 +172,8 @@ func TestBasicStoreService_UpdateAbsoluteUserScore(t *testing.T) {
 			userId:      100,
 			score:       1,
 			query: `UPDATE users 
-			SET score = $1
-			WHERE id = $2`,
+		SET score = $1, reached_at = $2
+		WHERE board = $3 AND id = $4 AND score != $1`,
 			err:           fmt.Errorf("mock-error"),
 			expectedError: errors.Wrapf(fmt.Errorf("mock-error"), "create user"),
 		},
@@ -183,6 +189,8 @@ func TestBasicStoreService_UpdateAbsoluteUserScore(t *testing.T) {
 		mock.ExpectExec(regexp.QuoteMeta(tc.query)).
 			WithArgs(
 				tc.score,
+				sqlmock.AnyArg(),
+				models.DefaultLeaderboard,
 				tc.userId,
 			).
 			WillReturnResult(sqlmock.NewResult(1, 1)).
@@ -190,7 +198,7 @@ func TestBasicStoreService_UpdateAbsoluteUserScore(t *testing.T) {
 
 		basicStore := NewStoreService(tc.core, tc.core.DB)
 		// now we execute our method
-		err = basicStore.UpdateAbsoluteUserScore(tc.context, tc.userId, tc.score)
+		err = basicStore.UpdateAbsoluteUserScore(tc.context, models.DefaultLeaderboard, tc.userId, tc.score)
 		if tc.err != nil {
 			assert.Error(t, err)
 			return
@@ -203,6 +211,151 @@ func TestBasicStoreService_UpdateAbsoluteUserScore(t *testing.T) {
 	}
 }
 
+func TestBasicStoreService_SubmitScores(t *testing.T) {
+	cases := []struct {
+		description    string
+		core           *models.Core
+		context        context.Context
+		insertError    error
+		expectedResult []models.ScoreChange
+		expectedError  error
+	}{
+		{
+			description: "Should apply the batch in a single transaction",
+			core:        &models.Core{},
+			context:     context.Background(),
+			expectedResult: []models.ScoreChange{
+				{UserID: 1, Score: 100, Created: true},
+				{UserID: 2, PreviousScore: 10, Score: 15},
+			},
+		},
+		{
+			description:   "Should rollback the batch when a score fails",
+			core:          &models.Core{},
+			context:       context.Background(),
+			insertError:   fmt.Errorf("mock-error"),
+			expectedError: fmt.Errorf("mock-error"),
+		},
+	}
+	for _, tc := range cases {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		defer db.Close()
+		tc.core.DB = db
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT score FROM users WHERE board = $1 AND id = $2")).
+			WithArgs(models.DefaultLeaderboard, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"score"}))
+		insert := mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO users (board, id, score, reached_at) VALUES ($1, $2, $3, $4)`)).
+			WithArgs(models.DefaultLeaderboard, 1, 100, sqlmock.AnyArg())
+		if tc.insertError != nil {
+			insert.WillReturnError(tc.insertError)
+			mock.ExpectRollback()
+		} else {
+			insert.WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT score FROM users WHERE board = $1 AND id = $2")).
+				WithArgs(models.DefaultLeaderboard, 2).
+				WillReturnRows(sqlmock.NewRows([]string{"score"}).AddRow(10))
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE users 
+					SET score = $1, reached_at = $2
+					WHERE board = $3 AND id = $4`)).
+				WithArgs(15, sqlmock.AnyArg(), models.DefaultLeaderboard, 2).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectCommit()
+		}
+
+		basicStore := NewStoreService(tc.core, tc.core.DB)
+		result, err := basicStore.SubmitScores(tc.context, models.DefaultLeaderboard, []models.ScoreSubmission{
+			{UserID: 1, Score: 100, Absolute: true},
+			{UserID: 2, Score: 5},
+		})
+		assert.Equal(t, tc.expectedResult, result, tc.description)
+		assert.Equal(t, tc.expectedError, err, tc.description)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	}
+}
+
+func TestBasicStoreService_SubmitScoresWithPeriods(t *testing.T) {
+	periodBoard := models.PeriodBoard(models.DefaultLeaderboard, models.PeriodDaily, "2026-01-01")
+	cases := []struct {
+		description     string
+		core            *models.Core
+		context         context.Context
+		periodError     error
+		expectedResult  []models.ScoreChange
+		expectedCreated []string
+		expectedError   error
+	}{
+		{
+			description:     "Should apply the scores to the board and to the new board of the period in a single transaction",
+			core:            &models.Core{},
+			context:         context.Background(),
+			expectedResult:  []models.ScoreChange{{UserID: 1, Score: 100, Created: true}},
+			expectedCreated: []string{periodBoard},
+		},
+		{
+			description:   "Should rollback the scores of the board when the board of the period fails",
+			core:          &models.Core{},
+			context:       context.Background(),
+			periodError:   fmt.Errorf("mock-error"),
+			expectedError: fmt.Errorf("mock-error"),
+		},
+	}
+	for _, tc := range cases {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		defer db.Close()
+		tc.core.DB = db
+
+		mock.ExpectBegin()
+		for _, board := range []string{models.DefaultLeaderboard, periodBoard} {
+			if board == periodBoard {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT name FROM leaderboards WHERE name = $1")).
+					WithArgs(periodBoard).
+					WillReturnRows(sqlmock.NewRows([]string{"name"}))
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO leaderboards (name) VALUES ($1)`)).
+					WithArgs(periodBoard).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT score FROM users WHERE board = $1 AND id = $2")).
+				WithArgs(board, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"score"}))
+			insert := mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO users (board, id, score, reached_at) VALUES ($1, $2, $3, $4)`)).
+				WithArgs(board, 1, 100, sqlmock.AnyArg())
+			if board == periodBoard && tc.periodError != nil {
+				insert.WillReturnError(tc.periodError)
+			} else {
+				insert.WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+		}
+		if tc.periodError != nil {
+			mock.ExpectRollback()
+		} else {
+			mock.ExpectCommit()
+		}
+
+		basicStore := NewStoreService(tc.core, tc.core.DB)
+		result, created, err := basicStore.SubmitScoresWithPeriods(tc.context, models.DefaultLeaderboard, []string{periodBoard}, []models.ScoreSubmission{
+			{UserID: 1, Score: 100, Absolute: true},
+		})
+		assert.Equal(t, tc.expectedResult, result, tc.description)
+		assert.Equal(t, tc.expectedCreated, created, tc.description)
+		assert.Equal(t, tc.expectedError, err, tc.description)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	}
+}
+
 func TestBasicStoreService_DoesUserExist(t *testing.T) {
 	cases := []struct {
 		description   string
@@ -219,7 +372,7 @@ func TestBasicStoreService_DoesUserExist(t *testing.T) {
 			core:        &models.Core{},
 			context:     context.Background(),
 			userId:      1,
-			query:       "SELECT id FROM users WHERE id = $1",
+			query:       "SELECT id FROM users WHERE board = $1 AND id = $2",
 			rows: sqlmock.NewRows(([]string{
 				"id",
 			})).AddRow(1),
@@ -232,7 +385,7 @@ func TestBasicStoreService_DoesUserExist(t *testing.T) {
 			rows: sqlmock.NewRows(([]string{
 				"score",
 			})).AddRow("a"),
-			query:         "SELECT id FROM users WHERE id = $1",
+			query:         "SELECT id FROM users WHERE board = $1 AND id = $2",
 			err:           fmt.Errorf("mock-error"),
 			expectedError: errors.Wrapf(fmt.Errorf("mock-error"), "create user"),
 		},
@@ -247,13 +400,14 @@ func TestBasicStoreService_DoesUserExist(t *testing.T) {
 
 		mock.ExpectQuery(regexp.QuoteMeta(tc.query)).
 			WithArgs(
+				models.DefaultLeaderboard,
 				tc.userId,
 			).
 			WillReturnRows(tc.rows)
 
 		basicStore := NewStoreService(tc.core, tc.core.DB)
 		// now we execute our method
-		_, err = basicStore.DoesUserExist(tc.context, tc.userId)
+		_, err = basicStore.DoesUserExist(tc.context, models.DefaultLeaderboard, tc.userId)
 		if tc.err != nil {
 			assert.Error(t, err)
 			return
@@ -283,11 +437,12 @@ func TestBasicStoreService_GetUsers(t *testing.T) {
 			core:        &models.Core{},
 			context:     context.Background(),
 			userId:      1,
-			query:       "SELECT id, score FROM users ORDER BY score DESC LIMIT $1",
+			query:       "SELECT id, score, reached_at FROM users WHERE board = $1 ORDER BY -score, reached_at, id LIMIT $2",
 			rows: sqlmock.NewRows(([]string{
 				"id",
 				"score",
-			})).AddRow(1, 100),
+				"reached_at",
+			})).AddRow(1, 100, 1),
 			expectedResult: []models.Ranking{
 				{
 					Position: 1,
@@ -304,7 +459,7 @@ func TestBasicStoreService_GetUsers(t *testing.T) {
 			rows: sqlmock.NewRows(([]string{
 				"score",
 			})).AddRow("a"),
-			query:         "SELECT id, score FROM users ORDER BY score DESC LIMIT $1",
+			query:         "SELECT id, score, reached_at FROM users WHERE board = $1 ORDER BY -score, reached_at, id LIMIT $2",
 			err:           fmt.Errorf("mock-error"),
 			expectedError: errors.Wrapf(fmt.Errorf("mock-error"), "create user"),
 		},
@@ -319,13 +474,14 @@ func TestBasicStoreService_GetUsers(t *testing.T) {
 
 		mock.ExpectQuery(regexp.QuoteMeta(tc.query)).
 			WithArgs(
+				models.DefaultLeaderboard,
 				tc.userId,
 			).
 			WillReturnRows(tc.rows)
 
 		basicStore := NewStoreService(tc.core, tc.core.DB)
 		// now we execute our method
-		result, err := basicStore.GetUsers(tc.context, tc.userId)
+		result, err := basicStore.GetUsers(tc.context, models.DefaultLeaderboard, tc.userId)
 		if tc.err != nil {
 			assert.Error(t, err)
 			return
@@ -359,11 +515,12 @@ func TestBasicStoreService_GetUsersBetween(t *testing.T) {
 			context:     context.Background(),
 			pos:         7,
 			around:      3,
-			query:       "SELECT id, score FROM users ORDER BY score DESC LIMIT $1 OFFSET $2",
+			query:       "SELECT id, score, reached_at FROM users WHERE board = $1 ORDER BY -score, reached_at, id LIMIT $2 OFFSET $3",
 			rows: sqlmock.NewRows(([]string{
 				"id",
 				"score",
-			})).AddRow(1, 100),
+				"reached_at",
+			})).AddRow(1, 100, 1),
 			expectedResult: []models.Ranking{
 				{
 					Position: 4,
@@ -383,13 +540,14 @@ func TestBasicStoreService_GetUsersBetween(t *testing.T) {
 
 		mock.ExpectQuery(regexp.QuoteMeta(tc.query)).
 			WithArgs(
+				models.DefaultLeaderboard,
 				tc.pos,
 				tc.around,
 			).
 			WillReturnRows(tc.rows)
 		basicStore := NewStoreService(tc.core, tc.core.DB)
 		// now we execute our method
-		result, err := basicStore.GetUsersBetween(tc.context, tc.pos, tc.around)
+		result, err := basicStore.GetUsersBetween(tc.context, models.DefaultLeaderboard, tc.pos, tc.around)
 		if tc.err != nil {
 			assert.Error(t, err)
 			return
@@ -404,6 +562,99 @@ func TestBasicStoreService_GetUsersBetween(t *testing.T) {
 	}
 }
 
+func TestBasicStoreService_GetUsersAfter(t *testing.T) {
+	cases := []struct {
+		description    string
+		core           *models.Core
+		context        context.Context
+		after          *models.RankingCursor
+		limit          int
+		upTo           int
+		query          string
+		rows           *sqlmock.Rows
+		expectedResult []models.Ranking
+		expectedCursor *models.RankingCursor
+	}{
+		{
+			description: "Should get the first page",
+			core:        &models.Core{},
+			context:     context.Background(),
+			limit:       2,
+			query:       "SELECT id, score, reached_at FROM users WHERE board = $1 ORDER BY -score, reached_at, id LIMIT $2",
+			rows: sqlmock.NewRows(([]string{
+				"id",
+				"score",
+				"reached_at",
+			})).AddRow(1, 100, 1).AddRow(2, 90, 3),
+			expectedResult: []models.Ranking{
+				{
+					Position: 1,
+					UserID:   1,
+					Score:    100,
+				},
+				{
+					Position: 2,
+					UserID:   2,
+					Score:    90,
+				},
+			},
+			expectedCursor: &models.RankingCursor{Score: 90, ReachedAt: 3, UserID: 2},
+		},
+		{
+			description: "Should get the page after the cursor",
+			core:        &models.Core{},
+			context:     context.Background(),
+			after:       &models.RankingCursor{Score: 90, ReachedAt: 3, UserID: 2},
+			limit:       2,
+			upTo:        2,
+			query:       "SELECT id, score, reached_at FROM users WHERE board = $1 AND (score < $2 OR (score = $2 AND (reached_at > $3 OR (reached_at = $3 AND id > $4)))) ORDER BY -score, reached_at, id LIMIT $5",
+			rows: sqlmock.NewRows(([]string{
+				"id",
+				"score",
+				"reached_at",
+			})).AddRow(3, 80, 2),
+			expectedResult: []models.Ranking{
+				{
+					Position: 3,
+					UserID:   3,
+					Score:    80,
+				},
+			},
+			expectedCursor: &models.RankingCursor{Score: 80, ReachedAt: 2, UserID: 3},
+		},
+	}
+	for _, tc := range cases {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		defer db.Close()
+		tc.core.DB = db
+
+		if tc.after == nil {
+			mock.ExpectQuery(regexp.QuoteMeta(tc.query)).
+				WithArgs(models.DefaultLeaderboard, tc.limit).
+				WillReturnRows(tc.rows)
+		} else {
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM users WHERE board = $1 AND (score > $2 OR (score = $2 AND (reached_at < $3 OR (reached_at = $3 AND id <= $4))))")).
+				WithArgs(models.DefaultLeaderboard, tc.after.Score, tc.after.ReachedAt, tc.after.UserID).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tc.upTo))
+			mock.ExpectQuery(regexp.QuoteMeta(tc.query)).
+				WithArgs(models.DefaultLeaderboard, tc.after.Score, tc.after.ReachedAt, tc.after.UserID, tc.limit).
+				WillReturnRows(tc.rows)
+		}
+		basicStore := NewStoreService(tc.core, tc.core.DB)
+		result, cursor, err := basicStore.GetUsersAfter(tc.context, models.DefaultLeaderboard, tc.after, tc.limit)
+		assert.NoError(t, err, tc.description)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+		assert.Equal(t, tc.expectedResult, result, tc.description)
+		assert.Equal(t, tc.expectedCursor, cursor, tc.description)
+	}
+}
+
 func TestBasicStoreService_GetUserById(t *testing.T) {
 	cases := []struct {
 		description    string
@@ -423,7 +674,7 @@ func TestBasicStoreService_GetUserById(t *testing.T) {
 			context:     context.Background(),
 			userId:      1,
 			score:       100,
-			query:       "SELECT id, score FROM users WHERE id = $1",
+			query:       "SELECT id, score FROM users WHERE board = $1 AND id = $2",
 			rows: sqlmock.NewRows(([]string{
 				"id",
 				"score",
@@ -441,7 +692,7 @@ func TestBasicStoreService_GetUserById(t *testing.T) {
 			rows: sqlmock.NewRows(([]string{
 				"score",
 			})).AddRow("a"),
-			query:         "SELECT id, score FROM users WHERE id = $1",
+			query:         "SELECT id, score FROM users WHERE board = $1 AND id = $2",
 			err:           fmt.Errorf("mock-error"),
 			expectedError: errors.Wrapf(fmt.Errorf("mock-error"), "create user"),
 		},
@@ -456,13 +707,14 @@ func TestBasicStoreService_GetUserById(t *testing.T) {
 
 		mock.ExpectQuery(regexp.QuoteMeta(tc.query)).
 			WithArgs(
+				models.DefaultLeaderboard,
 				tc.userId,
 			).
 			WillReturnRows(tc.rows)
 
 		basicStore := NewStoreService(tc.core, tc.core.DB)
 		// now we execute our method
-		result, err := basicStore.GetUserById(tc.context, tc.userId)
+		result, err := basicStore.GetUserById(tc.context, models.DefaultLeaderboard, tc.userId)
 		if tc.err != nil {
 			assert.Error(t, err)
 			return
@@ -476,3 +728,548 @@ func TestBasicStoreService_GetUserById(t *testing.T) {
 		assert.Equal(t, tc.err, err, tc.description)
 	}
 }
+
+func TestBasicStoreService_CreateLeaderboard(t *testing.T) {
+	cases := []struct {
+		description string
+		core        *models.Core
+		context     context.Context
+		name        string
+		existing    bool
+		err         error
+	}{
+		{
+			description: "Should create a leaderboard",
+			core:        &models.Core{},
+			context:     context.Background(),
+			name:        "weekly",
+		},
+		{
+			description: "Should return an error when the leaderboard already exists",
+			core:        &models.Core{},
+			context:     context.Background(),
+			name:        "weekly",
+			existing:    true,
+		},
+		{
+			description: "Should return an error",
+			core:        &models.Core{},
+			context:     context.Background(),
+			name:        "weekly",
+			err:         fmt.Errorf("mock-error"),
+		},
+	}
+	for _, tc := range cases {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		defer db.Close()
+		tc.core.DB = db
+
+		rows := sqlmock.NewRows([]string{"name"})
+		if tc.existing {
+			rows.AddRow(tc.name)
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT name FROM leaderboards WHERE name = $1")).
+			WithArgs(tc.name).
+			WillReturnRows(rows)
+		if tc.existing {
+			mock.ExpectRollback()
+		} else {
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO leaderboards (name) VALUES ($1)`)).
+				WithArgs(tc.name).
+				WillReturnResult(sqlmock.NewResult(1, 1)).
+				WillReturnError(tc.err)
+			if tc.err != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+		}
+
+		basicStore := NewStoreService(tc.core, tc.core.DB)
+		err = basicStore.CreateLeaderboard(tc.context, tc.name)
+		if tc.existing || tc.err != nil {
+			assert.Error(t, err, tc.description)
+		} else {
+			assert.NoError(t, err, tc.description)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	}
+}
+
+func TestBasicStoreService_DeleteLeaderboard(t *testing.T) {
+	cases := []struct {
+		description string
+		core        *models.Core
+		context     context.Context
+		name        string
+		err         error
+	}{
+		{
+			description: "Should delete a leaderboard, its users and its frozen users",
+			core:        &models.Core{},
+			context:     context.Background(),
+			name:        "weekly",
+		},
+		{
+			description: "Should return an error",
+			core:        &models.Core{},
+			context:     context.Background(),
+			name:        "weekly",
+			err:         fmt.Errorf("mock-error"),
+		},
+	}
+	for _, tc := range cases {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		defer db.Close()
+		tc.core.DB = db
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM users WHERE board = $1`)).
+			WithArgs(tc.name).
+			WillReturnResult(sqlmock.NewResult(0, 2)).
+			WillReturnError(tc.err)
+		if tc.err != nil {
+			mock.ExpectRollback()
+		} else {
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM frozen_users WHERE board = $1`)).
+				WithArgs(tc.name).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM leaderboards WHERE name = $1`)).
+				WithArgs(tc.name).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+		}
+
+		basicStore := NewStoreService(tc.core, tc.core.DB)
+		err = basicStore.DeleteLeaderboard(tc.context, tc.name)
+		assert.Equal(t, tc.err, err, tc.description)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	}
+}
+
+func TestBasicStoreService_GetLeaderboards(t *testing.T) {
+	cases := []struct {
+		description    string
+		core           *models.Core
+		context        context.Context
+		err            error
+		rows           *sqlmock.Rows
+		expectedResult []string
+	}{
+		{
+			description: "Should get the leaderboards",
+			core:        &models.Core{},
+			context:     context.Background(),
+			rows: sqlmock.NewRows(([]string{
+				"name",
+			})).AddRow("default").AddRow("weekly"),
+			expectedResult: []string{"default", "weekly"},
+		},
+		{
+			description: "Should return an error",
+			core:        &models.Core{},
+			context:     context.Background(),
+			err:         fmt.Errorf("mock-error"),
+		},
+	}
+	for _, tc := range cases {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		defer db.Close()
+		tc.core.DB = db
+
+		expectedQuery := mock.ExpectQuery(regexp.QuoteMeta("SELECT name FROM leaderboards ORDER BY name"))
+		if tc.err != nil {
+			expectedQuery.WillReturnError(tc.err)
+		} else {
+			expectedQuery.WillReturnRows(tc.rows)
+		}
+
+		basicStore := NewStoreService(tc.core, tc.core.DB)
+		result, err := basicStore.GetLeaderboards(tc.context)
+		assert.Equal(t, tc.expectedResult, result, tc.description)
+		assert.Equal(t, tc.err, err, tc.description)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	}
+}
+
+func TestBasicStoreService_GetUserPosition(t *testing.T) {
+	cases := []struct {
+		description    string
+		core           *models.Core
+		context        context.Context
+		userId         int
+		score          int
+		query          string
+		args           []driver.Value
+		err            error
+		expectedResult int
+	}{
+		{
+			description:    "Should get the position from the users with a greater score",
+			core:           &models.Core{TieBreakPolicy: models.TieBreakCompetition},
+			context:        context.Background(),
+			userId:         1,
+			score:          100,
+			query:          "SELECT count(*) FROM users WHERE board = $1 AND score > $2",
+			args:           []driver.Value{models.DefaultLeaderboard, 100},
+			expectedResult: 4,
+		},
+		{
+			description:    "Should get the position from the distinct greater scores",
+			core:           &models.Core{TieBreakPolicy: models.TieBreakDense},
+			context:        context.Background(),
+			userId:         1,
+			score:          100,
+			query:          "SELECT count(*) FROM (SELECT DISTINCT score FROM users WHERE board = $1 AND score > $2)",
+			args:           []driver.Value{models.DefaultLeaderboard, 100},
+			expectedResult: 2,
+		},
+		{
+			description: "Should get the position from the users ahead with the lowest id",
+			core:        &models.Core{TieBreakPolicy: models.TieBreakLowestID},
+			context:     context.Background(),
+			userId:      1,
+			score:       100,
+			query: `SELECT count(*) FROM users 
+			WHERE board = $1 AND (score > $2 OR (score = $2 AND id < $3))`,
+			args:           []driver.Value{models.DefaultLeaderboard, 100, 1},
+			expectedResult: 3,
+		},
+		{
+			description: "Should return an error",
+			core:        &models.Core{TieBreakPolicy: models.TieBreakCompetition},
+			context:     context.Background(),
+			userId:      1,
+			score:       100,
+			query:       "SELECT count(*) FROM users WHERE board = $1 AND score > $2",
+			args:        []driver.Value{models.DefaultLeaderboard, 100},
+			err:         fmt.Errorf("mock-error"),
+		},
+	}
+	for _, tc := range cases {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		defer db.Close()
+		tc.core.DB = db
+
+		expectedQuery := mock.ExpectQuery(regexp.QuoteMeta(tc.query)).
+			WithArgs(tc.args...)
+		if tc.err != nil {
+			expectedQuery.WillReturnError(tc.err)
+		} else {
+			expectedQuery.WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tc.expectedResult - 1))
+		}
+
+		basicStore := NewStoreService(tc.core, tc.core.DB)
+		result, err := basicStore.GetUserPosition(tc.context, models.DefaultLeaderboard, tc.userId, tc.score)
+		assert.Equal(t, tc.expectedResult, result, tc.description)
+		assert.Equal(t, tc.err, err, tc.description)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	}
+}
+
+func TestBasicStoreService_CountUsers(t *testing.T) {
+	cases := []struct {
+		description    string
+		core           *models.Core
+		context        context.Context
+		err            error
+		expectedResult int
+	}{
+		{
+			description:    "Should count the users",
+			core:           &models.Core{},
+			context:        context.Background(),
+			expectedResult: 12,
+		},
+		{
+			description: "Should return an error",
+			core:        &models.Core{},
+			context:     context.Background(),
+			err:         fmt.Errorf("mock-error"),
+		},
+	}
+	for _, tc := range cases {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		defer db.Close()
+		tc.core.DB = db
+
+		expectedQuery := mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM users WHERE board = $1")).
+			WithArgs(models.DefaultLeaderboard)
+		if tc.err != nil {
+			expectedQuery.WillReturnError(tc.err)
+		} else {
+			expectedQuery.WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tc.expectedResult))
+		}
+
+		basicStore := NewStoreService(tc.core, tc.core.DB)
+		result, err := basicStore.CountUsers(tc.context, models.DefaultLeaderboard)
+		assert.Equal(t, tc.expectedResult, result, tc.description)
+		assert.Equal(t, tc.err, err, tc.description)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	}
+}
+
+func TestBasicStoreService_GetScores(t *testing.T) {
+	cases := []struct {
+		description    string
+		core           *models.Core
+		context        context.Context
+		err            error
+		expectedResult []int
+	}{
+		{
+			description:    "Should get the scores",
+			core:           &models.Core{},
+			context:        context.Background(),
+			expectedResult: []int{50, 30, 10},
+		},
+		{
+			description: "Should return an error",
+			core:        &models.Core{},
+			context:     context.Background(),
+			err:         fmt.Errorf("mock-error"),
+		},
+	}
+	for _, tc := range cases {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		defer db.Close()
+		tc.core.DB = db
+
+		expectedQuery := mock.ExpectQuery(regexp.QuoteMeta("SELECT score FROM users WHERE board = $1 ORDER BY score DESC")).
+			WithArgs(models.DefaultLeaderboard)
+		if tc.err != nil {
+			expectedQuery.WillReturnError(tc.err)
+		} else {
+			rows := sqlmock.NewRows([]string{"score"})
+			for _, score := range tc.expectedResult {
+				rows.AddRow(score)
+			}
+			expectedQuery.WillReturnRows(rows)
+		}
+
+		basicStore := NewStoreService(tc.core, tc.core.DB)
+		result, err := basicStore.GetScores(tc.context, models.DefaultLeaderboard)
+		assert.Equal(t, tc.expectedResult, result, tc.description)
+		assert.Equal(t, tc.err, err, tc.description)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	}
+}
+
+func TestBasicStoreService_DeleteUser(t *testing.T) {
+	cases := []struct {
+		description   string
+		core          *models.Core
+		context       context.Context
+		ranked        int
+		frozen        int
+		err           error
+		expectedError error
+	}{
+		{
+			description: "Should delete a user and unfreeze her/him",
+			core:        &models.Core{},
+			context:     context.Background(),
+			ranked:      1,
+		},
+		{
+			description:   "Should not delete a missing user",
+			core:          &models.Core{},
+			context:       context.Background(),
+			expectedError: sql.ErrNoRows,
+		},
+		{
+			description:   "Should return an error",
+			core:          &models.Core{},
+			context:       context.Background(),
+			frozen:        1,
+			err:           fmt.Errorf("mock-error"),
+			expectedError: fmt.Errorf("mock-error"),
+		},
+	}
+	for _, tc := range cases {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		defer db.Close()
+		tc.core.DB = db
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM users WHERE board = $1 AND id = $2")).
+			WithArgs(models.DefaultLeaderboard, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tc.ranked))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM frozen_users WHERE board = $1 AND id = $2")).
+			WithArgs(models.DefaultLeaderboard, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tc.frozen))
+		if tc.expectedError == sql.ErrNoRows {
+			mock.ExpectRollback()
+		} else {
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM users WHERE board = $1 AND id = $2`)).
+				WithArgs(models.DefaultLeaderboard, 1).
+				WillReturnResult(sqlmock.NewResult(0, int64(tc.ranked))).
+				WillReturnError(tc.err)
+			if tc.err != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM frozen_users WHERE board = $1 AND id = $2`)).
+					WithArgs(models.DefaultLeaderboard, 1).
+					WillReturnResult(sqlmock.NewResult(0, int64(tc.frozen)))
+				mock.ExpectCommit()
+			}
+		}
+
+		basicStore := NewStoreService(tc.core, tc.core.DB)
+		err = basicStore.DeleteUser(tc.context, models.DefaultLeaderboard, 1)
+		assert.Equal(t, tc.expectedError, err, tc.description)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	}
+}
+
+func TestBasicStoreService_ResetBoard(t *testing.T) {
+	cases := []struct {
+		description     string
+		core            *models.Core
+		context         context.Context
+		err             error
+		expectedRemoved int
+	}{
+		{
+			description:     "Should remove the users and the scores of the hidden ones",
+			core:            &models.Core{},
+			context:         context.Background(),
+			expectedRemoved: 3,
+		},
+		{
+			description: "Should return an error",
+			core:        &models.Core{},
+			context:     context.Background(),
+			err:         fmt.Errorf("mock-error"),
+		},
+	}
+	for _, tc := range cases {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		defer db.Close()
+		tc.core.DB = db
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM users WHERE board = $1")).
+			WithArgs(models.DefaultLeaderboard).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM users WHERE board = $1`)).
+			WithArgs(models.DefaultLeaderboard).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE frozen_users SET score = 0 WHERE board = $1`)).
+			WithArgs(models.DefaultLeaderboard).
+			WillReturnResult(sqlmock.NewResult(0, 1)).
+			WillReturnError(tc.err)
+		if tc.err != nil {
+			mock.ExpectRollback()
+		} else {
+			mock.ExpectCommit()
+		}
+
+		basicStore := NewStoreService(tc.core, tc.core.DB)
+		removed, err := basicStore.ResetBoard(tc.context, models.DefaultLeaderboard)
+		assert.Equal(t, tc.expectedRemoved, removed, tc.description)
+		assert.Equal(t, tc.err, err, tc.description)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	}
+}
+
+func TestBasicStoreService_GetFrozenUsers(t *testing.T) {
+	cases := []struct {
+		description    string
+		core           *models.Core
+		context        context.Context
+		err            error
+		expectedResult []models.FrozenUser
+	}{
+		{
+			description:    "Should get the frozen users",
+			core:           &models.Core{},
+			context:        context.Background(),
+			expectedResult: []models.FrozenUser{{UserID: 1, Hidden: true, Score: 30}, {UserID: 4}},
+		},
+		{
+			description: "Should return an error",
+			core:        &models.Core{},
+			context:     context.Background(),
+			err:         fmt.Errorf("mock-error"),
+		},
+	}
+	for _, tc := range cases {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		defer db.Close()
+		tc.core.DB = db
+
+		expectedQuery := mock.ExpectQuery(regexp.QuoteMeta("SELECT id, score, hidden FROM frozen_users WHERE board = $1 ORDER BY id")).
+			WithArgs(models.DefaultLeaderboard)
+		if tc.err != nil {
+			expectedQuery.WillReturnError(tc.err)
+		} else {
+			rows := sqlmock.NewRows([]string{"id", "score", "hidden"})
+			for _, user := range tc.expectedResult {
+				rows.AddRow(user.UserID, user.Score, user.Hidden)
+			}
+			expectedQuery.WillReturnRows(rows)
+		}
+
+		basicStore := NewStoreService(tc.core, tc.core.DB)
+		result, err := basicStore.GetFrozenUsers(tc.context, models.DefaultLeaderboard)
+		assert.Equal(t, tc.expectedResult, result, tc.description)
+		assert.Equal(t, tc.err, err, tc.description)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	}
+}
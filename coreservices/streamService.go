@@ -0,0 +1,77 @@
+package coreservices
+
+import (
+	"sync"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+//NewStreamService - will return a StreamService that fans out the changes of the leaderboards to their subscribers.
+//It will also add it to the core
+func NewStreamService(core *models.Core) models.StreamService {
+	streamService := HubStreamService{
+		core:        core,
+		subscribers: make(map[string]map[*models.Subscription]struct{}),
+		done:        make(chan struct{}),
+	}
+	core.Stream = &streamService
+	return &streamService
+}
+
+type HubStreamService struct {
+	core *models.Core
+
+	mu          sync.Mutex
+	subscribers map[string]map[*models.Subscription]struct{}
+	done        chan struct{}
+	closed      bool
+}
+
+func (h *HubStreamService) Subscribe(board string) *models.Subscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subscription := &models.Subscription{Board: board, Notify: make(chan struct{}, 1), Done: h.done}
+	subscribers, ok := h.subscribers[board]
+	if !ok {
+		subscribers = make(map[*models.Subscription]struct{})
+		h.subscribers[board] = subscribers
+	}
+	subscribers[subscription] = struct{}{}
+	return subscription
+}
+
+func (h *HubStreamService) Unsubscribe(subscription *models.Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subscribers := h.subscribers[subscription.Board]
+	delete(subscribers, subscription)
+	if len(subscribers) == 0 {
+		delete(h.subscribers, subscription.Board)
+	}
+}
+
+func (h *HubStreamService) Publish(board string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for subscription := range h.subscribers[board] {
+		//a subscriber that has not handled its last notification yet will see this change too,
+		//so it is skipped instead of stalling the submission
+		select {
+		case subscription.Notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (h *HubStreamService) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.closed {
+		close(h.done)
+		h.closed = true
+	}
+}
@@ -0,0 +1,56 @@
+package coreservices
+
+import (
+	"testing"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+//pending returns how many notifications the subscription has waiting
+func pending(subscription *models.Subscription) int {
+	count := 0
+	for {
+		select {
+		case <-subscription.Notify:
+			count++
+		default:
+			return count
+		}
+	}
+}
+
+func TestHubStreamService_Publish(t *testing.T) {
+	hub := NewStreamService(&models.Core{})
+	weekly := hub.Subscribe("weekly")
+	other := hub.Subscribe(models.DefaultLeaderboard)
+
+	//the subscribers never read, which must not block the publisher
+	for i := 0; i < 100; i++ {
+		hub.Publish("weekly")
+	}
+	assert.Equal(t, 1, pending(weekly), "should coalesce the notifications of a slow subscriber")
+	assert.Equal(t, 0, pending(other), "should only notify the subscribers of the board")
+
+	hub.Unsubscribe(weekly)
+	hub.Publish("weekly")
+	assert.Equal(t, 0, pending(weekly), "should not notify a subscriber that unsubscribed")
+	assert.Empty(t, hub.(*HubStreamService).subscribers["weekly"], "should drop the boards without subscribers")
+}
+
+func TestHubStreamService_Close(t *testing.T) {
+	hub := NewStreamService(&models.Core{})
+	before := hub.Subscribe("weekly")
+
+	hub.Close()
+	hub.Close()
+	after := hub.Subscribe("weekly")
+
+	for _, subscription := range []*models.Subscription{before, after} {
+		select {
+		case <-subscription.Done:
+		default:
+			t.Fatal("the subscription was expected to be done")
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package coreservices
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+//reachedClock hands out the timestamps of when scores are reached.
+//They are strictly increasing, so two scores reached in the same nanosecond still keep their order
+type reachedClock struct {
+	mu   sync.Mutex
+	last int64
+}
+
+func (c *reachedClock) now() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now().UnixNano()
+	if now <= c.last {
+		now = c.last + 1
+	}
+	c.last = now
+	return now
+}
+
+//rankingKeyBefore returns the order of the ranking for the policy: score DESC and then,
+//for equal scores, the first user to reach the score or the user with the lowest id
+func rankingKeyBefore(policy models.TieBreakPolicy) func(a, b rankingKey) bool {
+	if policy == models.TieBreakFirst {
+		return func(a, b rankingKey) bool {
+			if a.score != b.score {
+				return a.score > b.score
+			}
+			if a.reachedAt != b.reachedAt {
+				return a.reachedAt < b.reachedAt
+			}
+			return a.userID < b.userID
+		}
+	}
+	return func(a, b rankingKey) bool {
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		return a.userID < b.userID
+	}
+}
+
+//sharePositions gives users with equal scores the same position, for the policies that share them.
+//The ranking must be ordered and hold sequential positions, and firstPosition is the shared
+//position of the first user, which may be tied with users before the ranking window
+func sharePositions(ranking []models.Ranking, policy models.TieBreakPolicy, firstPosition int) {
+	if !policy.SharesPositions() || len(ranking) == 0 {
+		return
+	}
+	ranking[0].Position = firstPosition
+	for i := 1; i < len(ranking); i++ {
+		if ranking[i].Score == ranking[i-1].Score {
+			ranking[i].Position = ranking[i-1].Position
+		} else if policy == models.TieBreakDense {
+			ranking[i].Position = ranking[i-1].Position + 1
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package coreservices
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"sync"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+const (
+	defaultReviewLimit = 50
+	maxReviewLimit     = 1000
+)
+
+func (bhs *BasicService) HandleGetReviews(ctx context.Context, request *models.GetReviewsRequest) (*models.GetReviewsResponse, error) {
+	query := models.ReviewQuery{Board: request.Board, Verdict: models.ValidationVerdict(request.Verdict), Limit: defaultReviewLimit}
+	if query.Verdict != "" && query.Verdict != models.VerdictRejected && query.Verdict != models.VerdictFlagged {
+		return nil, models.NewValidationError(models.CodeInvalidReviewQuery, "The only verdicts accepted are: %s and %s.", models.VerdictRejected, models.VerdictFlagged)
+	}
+	var err error
+	if request.Limit != "" {
+		query.Limit, err = strconv.Atoi(request.Limit)
+		if err != nil || query.Limit < 1 || query.Limit > maxReviewLimit {
+			return nil, models.NewValidationError(models.CodeInvalidReviewQuery, "Limit must be an integer between 1 and %d.", maxReviewLimit)
+		}
+	}
+	if request.Offset != "" {
+		query.Offset, err = strconv.Atoi(request.Offset)
+		if err != nil || query.Offset < 0 {
+			return nil, models.NewValidationError(models.CodeInvalidReviewQuery, "Offset must be an integer greater than or equal to 0.")
+		}
+	}
+
+	if bhs.Core.Reviews == nil {
+		return nil, models.NewNotFoundError(models.CodeReviewsNotEnabled, "The review queue is not enabled.")
+	}
+
+	reviews, total, err := bhs.Core.Reviews.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GetReviewsResponse{Total: total, Reviews: reviews}, nil
+}
+
+//boardLocks has a lock for each board, which are kept as long as the service since there are only so many boards
+type boardLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func (l *boardLocks) lock(board string) *sync.Mutex {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.locks == nil {
+		l.locks = make(map[string]*sync.Mutex)
+	}
+	lock, ok := l.locks[board]
+	if !ok {
+		lock = &sync.Mutex{}
+		l.locks[board] = lock
+	}
+	return lock
+}
+
+//lockReviews holds back the submissions of board until unlock is called, once the reviewed ones are applied and in the history.
+//Otherwise the validators of concurrent submissions would read the same history, and let through more than they allow together.
+//Without validators nothing is reviewed, so nothing is held back
+func (bhs *BasicService) lockReviews(board string) (unlock func()) {
+	if len(bhs.Core.Validators) == 0 || bhs.reviewLocks == nil {
+		return func() {}
+	}
+	lock := bhs.reviewLocks.lock(board)
+	lock.Lock()
+	return lock.Unlock
+}
+
+//reviewSubmissions runs the submissions through the validators of the core, in order, before they are applied.
+//The score of a user that is submitted more than once follows her/his previous submissions, as it will once they are applied,
+//and the gains of the previous ones are pending, since they are not in the history yet.
+//It returns the review entry of each submission that broke a rule, or nil for the ones that follow all of them
+func (bhs *BasicService) reviewSubmissions(ctx context.Context, board string, source string, submissions []models.ScoreSubmission) ([]*models.ReviewEntry, error) {
+	reviews := make([]*models.ReviewEntry, len(submissions))
+	if len(bhs.Core.Validators) == 0 {
+		return reviews, nil
+	}
+
+	scores := make(map[int]int)
+	gains := make(map[int]int)
+	for i, submission := range submissions {
+		check := models.ScoreCheck{Board: board, Submission: submission, PendingGain: gains[submission.UserID]}
+		if score, ok := scores[submission.UserID]; ok {
+			check.Exists = true
+			check.PreviousScore = score
+		} else {
+			user, err := bhs.Core.StoreService.GetUserById(ctx, board, submission.UserID)
+			if err != nil && err != sql.ErrNoRows {
+				return nil, err
+			}
+			if err == nil {
+				check.Exists = true
+				check.PreviousScore = user.Score
+			}
+		}
+		//users that don't exist are created with the score, relative or not
+		check.Score = submission.Score
+		if !submission.Absolute {
+			check.Score += check.PreviousScore
+		}
+
+		result, err := bhs.validate(ctx, &check)
+		if err != nil {
+			return nil, err
+		}
+		if result != nil {
+			reviews[i] = reviewEntry(&check, result, source)
+		}
+		if result == nil || result.Verdict != models.VerdictRejected {
+			scores[submission.UserID] = check.Score
+			if check.Delta() > 0 {
+				gains[submission.UserID] += check.Delta()
+			}
+		}
+	}
+
+	return reviews, nil
+}
+
+//validate returns the first rule that rejects the check, or else the first one that flags it
+func (bhs *BasicService) validate(ctx context.Context, check *models.ScoreCheck) (*models.ValidationResult, error) {
+	var flagged *models.ValidationResult
+	for _, validator := range bhs.Core.Validators {
+		result, err := validator.Validate(ctx, check)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			continue
+		}
+		if result.Verdict == models.VerdictRejected {
+			return result, nil
+		}
+		if flagged == nil {
+			flagged = result
+		}
+	}
+	return flagged, nil
+}
+
+func reviewEntry(check *models.ScoreCheck, result *models.ValidationResult, source string) *models.ReviewEntry {
+	score := check.Submission.Score
+	entry := &models.ReviewEntry{
+		Board:         check.Board,
+		UserID:        check.Submission.UserID,
+		PreviousScore: check.PreviousScore,
+		Score:         check.Score,
+		Source:        source,
+		Verdict:       result.Verdict,
+		Rule:          result.Rule,
+		Reason:        result.Reason,
+	}
+	if check.Submission.Absolute {
+		entry.Total = &score
+	} else {
+		entry.Delta = &score
+	}
+	return entry
+}
+
+//rejectionError returns the error of a submission rejected by a validator
+func rejectionError(review *models.ReviewEntry) error {
+	return models.NewValidationError(models.CodeScoreRejected, "The score was rejected: %s", review.Reason)
+}
+
+//queueReviews adds the entries to the review queue of the core, when there is one.
+//The submissions are already applied or rejected by then, so a failure is only logged
+func (bhs *BasicService) queueReviews(ctx context.Context, entries []models.ReviewEntry) {
+	if len(entries) == 0 {
+		return
+	}
+	if bhs.Core.Reviews == nil {
+		for _, entry := range entries {
+			bhs.Core.GetLogger().Warn(ctx, "score reviewed", models.LogFields{"verdict": entry.Verdict, "user_id": entry.UserID, "board": entry.Board, "reason": entry.Reason})
+		}
+		return
+	}
+	if err := bhs.Core.Reviews.Add(ctx, entries); err != nil {
+		bhs.Core.GetLogger().Error(ctx, "error while queueing the reviews", models.LogFields{"reviews": len(entries), "error": err.Error()})
+	}
+}
+
+//flaggedReviews returns the entries of the flagged submissions, with the changes they made once applied
+func flaggedReviews(reviews []*models.ReviewEntry, changes []models.ScoreChange) []models.ReviewEntry {
+	var flagged []models.ReviewEntry
+	for j, review := range reviews {
+		if review == nil {
+			continue
+		}
+		review.PreviousScore = changes[j].PreviousScore
+		review.Score = changes[j].Score
+		flagged = append(flagged, *review)
+	}
+	return flagged
+}
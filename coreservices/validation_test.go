@@ -0,0 +1,300 @@
+package coreservices
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pedrocmart/leaderboard-service/mocks"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+//newValidatedService returns a service on a memory store with user 1 at 100, whose submissions go through the validators
+func newValidatedService(t *testing.T, validators ...models.ScoreValidator) *BasicService {
+	core := &models.Core{Validators: validators}
+	store := newTestStore(t, NewMemoryStoreService(core))
+	seedStore(t, store, 100)
+	NewMemoryReviewQueueService(core, 0)
+	return NewCoreService(core).(*BasicService)
+}
+
+func TestBasicService_ValidateSubmitScore(t *testing.T) {
+	flagAll := &mocks.ScoreValidatorMock{
+		ValidateFunc: func(ctx context.Context, check *models.ScoreCheck) (*models.ValidationResult, error) {
+			return &models.ValidationResult{Verdict: models.VerdictFlagged, Rule: "all", Reason: "Flagged."}, nil
+		},
+	}
+	cases := []struct {
+		description      string
+		validators       []models.ScoreValidator
+		request          *models.SubmitScoreRequest
+		expectedResponse *models.SubmitScoreResponse
+		expectedError    error
+		expectedScore    int
+		expectedReviews  []models.ReviewEntry
+	}{
+		{
+			description:      "should apply a score that follows the rules",
+			validators:       []models.ScoreValidator{NewMaxDeltaValidator(50)},
+			request:          &models.SubmitScoreRequest{Score: "+50", Source: "game"},
+			expectedResponse: &models.SubmitScoreResponse{UserID: 1, Score: 150},
+			expectedScore:    150,
+			expectedReviews:  []models.ReviewEntry{},
+		},
+		{
+			description:   "should reject a score that breaks a rule without touching the store",
+			validators:    []models.ScoreValidator{flagAll, NewMaxDeltaValidator(50)},
+			request:       &models.SubmitScoreRequest{Score: "+999999999", Source: "game"},
+			expectedError: models.NewValidationError(models.CodeScoreRejected, "The score was rejected: The score changed by 999999999, while it can change by at most 50 per submission."),
+			expectedScore: 100,
+			expectedReviews: []models.ReviewEntry{{
+				ID:            1,
+				Board:         models.DefaultLeaderboard,
+				UserID:        1,
+				PreviousScore: 100,
+				Score:         1000000099,
+				Delta:         &[]int{999999999}[0],
+				Source:        "game",
+				Verdict:       models.VerdictRejected,
+				Rule:          RuleMaxDelta,
+				Reason:        "The score changed by 999999999, while it can change by at most 50 per submission.",
+			}},
+		},
+		{
+			description:      "should apply a flagged score and queue it",
+			validators:       []models.ScoreValidator{flagAll},
+			request:          &models.SubmitScoreRequest{Total: &[]int{40}[0], Source: "game"},
+			expectedResponse: &models.SubmitScoreResponse{UserID: 1, Score: 40},
+			expectedScore:    40,
+			expectedReviews: []models.ReviewEntry{{
+				ID:            1,
+				Board:         models.DefaultLeaderboard,
+				UserID:        1,
+				PreviousScore: 100,
+				Score:         40,
+				Total:         &[]int{40}[0],
+				Source:        "game",
+				Verdict:       models.VerdictFlagged,
+				Rule:          "all",
+				Reason:        "Flagged.",
+			}},
+		},
+		{
+			description: "should return error when a validator fails",
+			validators: []models.ScoreValidator{&mocks.ScoreValidatorMock{
+				ValidateFunc: func(ctx context.Context, check *models.ScoreCheck) (*models.ValidationResult, error) {
+					return nil, fmt.Errorf("mock-error")
+				},
+			}},
+			request:         &models.SubmitScoreRequest{Score: "+1"},
+			expectedError:   fmt.Errorf("mock-error"),
+			expectedScore:   100,
+			expectedReviews: []models.ReviewEntry{},
+		},
+	}
+	for _, tc := range cases {
+		service := newValidatedService(t, tc.validators...)
+		ctx := context.Background()
+
+		res, err := service.HandleSubmitScore(ctx, models.DefaultLeaderboard, tc.request, "1")
+		assert.Equal(t, tc.expectedResponse, res, tc.description)
+		assert.Equal(t, tc.expectedError, err, tc.description)
+
+		user, err := service.Core.StoreService.GetUserById(ctx, models.DefaultLeaderboard, 1)
+		assert.NoError(t, err, tc.description)
+		assert.Equal(t, tc.expectedScore, user.Score, tc.description)
+
+		reviews, _, err := service.Core.Reviews.List(ctx, models.ReviewQuery{Limit: 10})
+		assert.NoError(t, err, tc.description)
+		for i := range reviews {
+			reviews[i].CreatedAt = tc.expectedReviews[i].CreatedAt
+		}
+		assert.Equal(t, tc.expectedReviews, reviews, tc.description)
+	}
+}
+
+func TestBasicService_ValidateSubmitScores(t *testing.T) {
+	cases := []struct {
+		description      string
+		request          *models.SubmitScoresRequest
+		expectedResponse *models.SubmitScoresResponse
+		expectedScores   map[int]int
+		expectedRules    []string
+	}{
+		{
+			description: "should follow the scores of the users within the batch",
+			request: &models.SubmitScoresRequest{Mode: models.BatchBestEffort, Scores: []models.SubmitScoreRequest{
+				{UserID: 1, Score: "+40"},
+				{UserID: 1, Score: "+40"},
+				{UserID: 2, Total: &[]int{60}[0]},
+			}},
+			expectedResponse: &models.SubmitScoresResponse{Applied: 2, Results: []models.SubmitScoreResult{
+				{UserID: 1, Score: &[]int{140}[0]},
+				{UserID: 1, Error: "The score was rejected: The score would be 180, while it must be between 0 and 150.", Code: models.CodeScoreRejected},
+				{UserID: 2, Score: &[]int{60}[0]},
+			}},
+			expectedScores: map[int]int{1: 140, 2: 60},
+			expectedRules:  []string{RuleScoreRange},
+		},
+		{
+			description: "should not apply an atomic batch with a rejected score",
+			request: &models.SubmitScoresRequest{Scores: []models.SubmitScoreRequest{
+				{UserID: 1, Score: "+40"},
+				{UserID: 2, Total: &[]int{200}[0]},
+			}},
+			expectedResponse: &models.SubmitScoresResponse{Results: []models.SubmitScoreResult{
+				{UserID: 1, Error: "Not applied since other scores of the batch are invalid.", Code: models.CodeBatchNotApplied},
+				{UserID: 2, Error: "The score was rejected: The score would be 200, while it must be between 0 and 150.", Code: models.CodeScoreRejected},
+			}},
+			expectedScores: map[int]int{1: 100},
+			expectedRules:  []string{RuleScoreRange},
+		},
+	}
+	for _, tc := range cases {
+		service := newValidatedService(t, NewScoreRangeValidator(0, 150))
+		ctx := context.Background()
+
+		res, err := service.HandleSubmitScores(ctx, models.DefaultLeaderboard, tc.request)
+		assert.NoError(t, err, tc.description)
+		assert.Equal(t, tc.expectedResponse, res, tc.description)
+
+		for id, score := range tc.expectedScores {
+			user, err := service.Core.StoreService.GetUserById(ctx, models.DefaultLeaderboard, id)
+			assert.NoError(t, err, tc.description)
+			assert.Equal(t, score, user.Score, tc.description)
+		}
+		reviews, _, err := service.Core.Reviews.List(ctx, models.ReviewQuery{Limit: 10})
+		assert.NoError(t, err, tc.description)
+		rules := make([]string, len(reviews))
+		for i, review := range reviews {
+			rules[i] = review.Rule
+		}
+		assert.Equal(t, tc.expectedRules, rules, tc.description)
+	}
+}
+
+//newMaxGainService returns a service like newValidatedService, with a history and a gain of at most 100 within an hour
+func newMaxGainService(t *testing.T) *BasicService {
+	core := &models.Core{}
+	NewMemoryHistoryService(core, time.Hour, 0)
+	core.Validators = []models.ScoreValidator{NewMaxGainValidator(core, 100, time.Hour)}
+	store := newTestStore(t, NewMemoryStoreService(core))
+	seedStore(t, store, 100)
+	NewMemoryReviewQueueService(core, 0)
+	return NewCoreService(core).(*BasicService)
+}
+
+func TestBasicService_ValidateMaxGain(t *testing.T) {
+	ctx := context.Background()
+
+	service := newMaxGainService(t)
+	res, err := service.HandleSubmitScores(ctx, models.DefaultLeaderboard, &models.SubmitScoresRequest{Mode: models.BatchBestEffort, Scores: []models.SubmitScoreRequest{
+		{UserID: 1, Score: "+60"},
+		{UserID: 1, Score: "+60"},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, &models.SubmitScoresResponse{Applied: 1, Results: []models.SubmitScoreResult{
+		{UserID: 1, Score: &[]int{160}[0]},
+		{UserID: 1, Error: "The score was rejected: The score would go up by 120 within 1h0m0s, while it can go up by at most 100.", Code: models.CodeScoreRejected},
+	}}, res, "should count the gains of the earlier scores of the user in the batch")
+
+	//the scores wait for each other once they are validated, so they would both be validated against the same history
+	//if nothing held the second back, which only gives up waiting for it once the first is applied
+	service = newMaxGainService(t)
+	arrived := make(chan struct{}, 2)
+	rendezvous := &mocks.ScoreValidatorMock{
+		ValidateFunc: func(ctx context.Context, check *models.ScoreCheck) (*models.ValidationResult, error) {
+			arrived <- struct{}{}
+			deadline := time.Now().Add(100 * time.Millisecond)
+			for len(arrived) < 2 && time.Now().Before(deadline) {
+				time.Sleep(time.Millisecond)
+			}
+			return nil, nil
+		},
+	}
+	service.Core.Validators = append(service.Core.Validators, rendezvous)
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = service.HandleSubmitScore(ctx, models.DefaultLeaderboard, &models.SubmitScoreRequest{Score: "+60"}, "1")
+		}(i)
+	}
+	wg.Wait()
+	rejectedScores := 0
+	for _, err := range errs {
+		if err != nil {
+			assert.Equal(t, models.CodeScoreRejected, models.ErrorCode(err))
+			rejectedScores++
+		}
+	}
+	assert.Equal(t, 1, rejectedScores, "should not let concurrent scores gain more than the maximum together")
+	user, err := service.Core.StoreService.GetUserById(ctx, models.DefaultLeaderboard, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 160, user.Score, "should not let concurrent scores gain more than the maximum together")
+}
+
+func TestBasicService_HandleGetReviews(t *testing.T) {
+	cases := []struct {
+		description      string
+		request          *models.GetReviewsRequest
+		reviewsDisabled  bool
+		expectedQuery    models.ReviewQuery
+		expectedResponse *models.GetReviewsResponse
+		expectedError    error
+	}{
+		{
+			description:      "should return the reviews with the default limit",
+			request:          &models.GetReviewsRequest{},
+			expectedQuery:    models.ReviewQuery{Limit: defaultReviewLimit},
+			expectedResponse: &models.GetReviewsResponse{Total: 1, Reviews: []models.ReviewEntry{{ID: 1}}},
+		},
+		{
+			description:      "should return the reviews matching the request",
+			request:          &models.GetReviewsRequest{Board: "weekly", Verdict: "flagged", Limit: "10", Offset: "20"},
+			expectedQuery:    models.ReviewQuery{Board: "weekly", Verdict: models.VerdictFlagged, Limit: 10, Offset: 20},
+			expectedResponse: &models.GetReviewsResponse{Total: 1, Reviews: []models.ReviewEntry{{ID: 1}}},
+		},
+		{
+			description:   "should return error when the verdict is unknown",
+			request:       &models.GetReviewsRequest{Verdict: "banned"},
+			expectedError: models.NewValidationError(models.CodeInvalidReviewQuery, "The only verdicts accepted are: rejected and flagged."),
+		},
+		{
+			description:   "should return error when the limit is too big",
+			request:       &models.GetReviewsRequest{Limit: "1001"},
+			expectedError: models.NewValidationError(models.CodeInvalidReviewQuery, "Limit must be an integer between 1 and 1000."),
+		},
+		{
+			description:   "should return error when the offset is negative",
+			request:       &models.GetReviewsRequest{Offset: "-1"},
+			expectedError: models.NewValidationError(models.CodeInvalidReviewQuery, "Offset must be an integer greater than or equal to 0."),
+		},
+		{
+			description:     "should return error when the review queue is not enabled",
+			request:         &models.GetReviewsRequest{},
+			reviewsDisabled: true,
+			expectedError:   models.NewNotFoundError(models.CodeReviewsNotEnabled, "The review queue is not enabled."),
+		},
+	}
+	for _, tc := range cases {
+		basicAPIService := BasicService{Core: &models.Core{}}
+		if !tc.reviewsDisabled {
+			basicAPIService.Core.Reviews = &mocks.ReviewQueueServiceMock{
+				ListFunc: func(ctx context.Context, query models.ReviewQuery) ([]models.ReviewEntry, int, error) {
+					assert.Equal(t, tc.expectedQuery, query, tc.description)
+					return []models.ReviewEntry{{ID: 1}}, 1, nil
+				},
+			}
+		}
+
+		res, err := basicAPIService.HandleGetReviews(context.Background(), tc.request)
+		assert.Equal(t, tc.expectedResponse, res, tc.description)
+		assert.Equal(t, tc.expectedError, err, tc.description)
+	}
+}
@@ -0,0 +1,147 @@
+package coreservices
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+//Rules of the validators, kept in the review queue
+const (
+	RuleMaxDelta    = "max_delta"
+	RuleScoreRange  = "score_range"
+	RuleMonotonic   = "monotonic"
+	RuleMaxGain     = "max_gain"
+	RuleRateAnomaly = "rate_anomaly"
+)
+
+//NewMaxDeltaValidator - will return a validator that rejects the submissions changing a score by more than max
+func NewMaxDeltaValidator(max int) models.ScoreValidator {
+	return &MaxDeltaValidator{max: max}
+}
+
+type MaxDeltaValidator struct {
+	max int
+}
+
+func (v *MaxDeltaValidator) Validate(ctx context.Context, check *models.ScoreCheck) (*models.ValidationResult, error) {
+	delta := check.Delta()
+	if delta <= v.max && -delta <= v.max {
+		return nil, nil
+	}
+	return rejected(RuleMaxDelta, "The score changed by %d, while it can change by at most %d per submission.", delta, v.max), nil
+}
+
+//NewScoreRangeValidator - will return a validator that rejects the submissions leaving a score out of min and max, both included
+func NewScoreRangeValidator(min int, max int) models.ScoreValidator {
+	return &ScoreRangeValidator{min: min, max: max}
+}
+
+type ScoreRangeValidator struct {
+	min int
+	max int
+}
+
+func (v *ScoreRangeValidator) Validate(ctx context.Context, check *models.ScoreCheck) (*models.ValidationResult, error) {
+	if check.Score >= v.min && check.Score <= v.max {
+		return nil, nil
+	}
+	return rejected(RuleScoreRange, "The score would be %d, while it must be between %d and %d.", check.Score, v.min, v.max), nil
+}
+
+//NewMonotonicValidator - will return a validator that rejects the submissions lowering a score in the boards
+func NewMonotonicValidator(boards []string) models.ScoreValidator {
+	validator := MonotonicValidator{boards: make(map[string]bool, len(boards))}
+	for _, board := range boards {
+		validator.boards[board] = true
+	}
+	return &validator
+}
+
+type MonotonicValidator struct {
+	boards map[string]bool
+}
+
+func (v *MonotonicValidator) Validate(ctx context.Context, check *models.ScoreCheck) (*models.ValidationResult, error) {
+	if !v.boards[check.Board] || check.Delta() >= 0 {
+		return nil, nil
+	}
+	return rejected(RuleMonotonic, "The scores of the leaderboard %s can only go up.", check.Board), nil
+}
+
+//NewMaxGainValidator - will return a validator that rejects the submissions making a user gain more than max
+//within the window, counting the gains kept in the score history of the core.
+//Without a history it lets every submission through
+func NewMaxGainValidator(core *models.Core, max int, window time.Duration) models.ScoreValidator {
+	return &MaxGainValidator{core: core, max: max, window: window, now: time.Now}
+}
+
+type MaxGainValidator struct {
+	core   *models.Core
+	max    int
+	window time.Duration
+	now    func() time.Time
+}
+
+func (v *MaxGainValidator) Validate(ctx context.Context, check *models.ScoreCheck) (*models.ValidationResult, error) {
+	if v.core.History == nil || check.Delta() <= 0 {
+		return nil, nil
+	}
+
+	gain := check.Delta() + check.PendingGain
+	history, _, err := v.core.History.GetHistory(ctx, check.Board, check.Submission.UserID, models.HistoryQuery{From: v.now().Add(-v.window), Limit: math.MaxInt32})
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range history {
+		if entry.Score > entry.PreviousScore {
+			gain += entry.Score - entry.PreviousScore
+		}
+	}
+	if gain <= v.max {
+		return nil, nil
+	}
+	return rejected(RuleMaxGain, "The score would go up by %d within %s, while it can go up by at most %d.", gain, v.window, v.max), nil
+}
+
+//NewRateAnomalyValidator - will return a validator that flags the submissions changing a score faster than
+//maxRate points per second since the last change of the user, kept in the score history of the core.
+//Without a history it lets every submission through
+func NewRateAnomalyValidator(core *models.Core, maxRate float64) models.ScoreValidator {
+	return &RateAnomalyValidator{core: core, maxRate: maxRate, now: time.Now}
+}
+
+type RateAnomalyValidator struct {
+	core    *models.Core
+	maxRate float64
+	now     func() time.Time
+}
+
+func (v *RateAnomalyValidator) Validate(ctx context.Context, check *models.ScoreCheck) (*models.ValidationResult, error) {
+	if v.core.History == nil || !check.Exists {
+		return nil, nil
+	}
+
+	history, _, err := v.core.History.GetHistory(ctx, check.Board, check.Submission.UserID, models.HistoryQuery{Limit: 1})
+	if err != nil || len(history) == 0 {
+		return nil, err
+	}
+	//changes within the same second are measured over a whole second
+	elapsed := math.Max(v.now().Sub(history[0].CreatedAt).Seconds(), 1)
+	rate := math.Abs(float64(check.Delta())) / elapsed
+	if rate <= v.maxRate {
+		return nil, nil
+	}
+	return &models.ValidationResult{
+		Verdict: models.VerdictFlagged,
+		Rule:    RuleRateAnomaly,
+		Reason:  fmt.Sprintf("The score changed by %d in %.0fs, faster than %g points per second.", check.Delta(), elapsed, v.maxRate),
+	}, nil
+}
+
+func rejected(rule string, format string, args ...interface{}) *models.ValidationResult {
+	return &models.ValidationResult{Verdict: models.VerdictRejected, Rule: rule, Reason: fmt.Sprintf(format, args...)}
+}
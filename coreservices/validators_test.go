@@ -0,0 +1,150 @@
+package coreservices
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pedrocmart/leaderboard-service/mocks"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoreValidators(t *testing.T) {
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	//history returns a core whose history holds the entries, the most recent first
+	history := func(entries ...models.ScoreHistoryEntry) *models.Core {
+		return &models.Core{
+			History: &mocks.HistoryServiceMock{
+				GetHistoryFunc: func(ctx context.Context, board string, id int, query models.HistoryQuery) ([]models.ScoreHistoryEntry, int, error) {
+					if len(entries) > query.Limit {
+						entries = entries[:query.Limit]
+					}
+					var page []models.ScoreHistoryEntry
+					for _, entry := range entries {
+						if query.From.IsZero() || !entry.CreatedAt.Before(query.From) {
+							page = append(page, entry)
+						}
+					}
+					return page, len(page), nil
+				},
+			},
+		}
+	}
+	maxGain := func(core *models.Core) models.ScoreValidator {
+		validator := NewMaxGainValidator(core, 100, time.Hour).(*MaxGainValidator)
+		validator.now = func() time.Time { return now }
+		return validator
+	}
+	rateAnomaly := func(core *models.Core) models.ScoreValidator {
+		validator := NewRateAnomalyValidator(core, 10).(*RateAnomalyValidator)
+		validator.now = func() time.Time { return now }
+		return validator
+	}
+
+	cases := []struct {
+		description    string
+		validator      models.ScoreValidator
+		check          *models.ScoreCheck
+		expectedResult *models.ValidationResult
+		expectedError  error
+	}{
+		{
+			description: "should accept a delta within the maximum",
+			validator:   NewMaxDeltaValidator(100),
+			check:       &models.ScoreCheck{Exists: true, PreviousScore: 50, Score: 150},
+		},
+		{
+			description:    "should reject a delta above the maximum",
+			validator:      NewMaxDeltaValidator(100),
+			check:          &models.ScoreCheck{Exists: true, PreviousScore: 50, Score: 151},
+			expectedResult: rejected(RuleMaxDelta, "The score changed by 101, while it can change by at most 100 per submission."),
+		},
+		{
+			description:    "should reject a negative delta above the maximum",
+			validator:      NewMaxDeltaValidator(100),
+			check:          &models.ScoreCheck{Exists: true, PreviousScore: 50, Score: -51},
+			expectedResult: rejected(RuleMaxDelta, "The score changed by -101, while it can change by at most 100 per submission."),
+		},
+		{
+			description: "should accept a score within the range",
+			validator:   NewScoreRangeValidator(0, 1000),
+			check:       &models.ScoreCheck{Score: 1000},
+		},
+		{
+			description:    "should reject a score out of the range",
+			validator:      NewScoreRangeValidator(0, 1000),
+			check:          &models.ScoreCheck{Exists: true, PreviousScore: 10, Score: -1},
+			expectedResult: rejected(RuleScoreRange, "The score would be -1, while it must be between 0 and 1000."),
+		},
+		{
+			description: "should accept a lower score in a board that is not monotonic",
+			validator:   NewMonotonicValidator([]string{"weekly"}),
+			check:       &models.ScoreCheck{Board: models.DefaultLeaderboard, Exists: true, PreviousScore: 10, Score: 5},
+		},
+		{
+			description:    "should reject a lower score in a monotonic board",
+			validator:      NewMonotonicValidator([]string{"weekly"}),
+			check:          &models.ScoreCheck{Board: "weekly", Exists: true, PreviousScore: 10, Score: 5},
+			expectedResult: rejected(RuleMonotonic, "The scores of the leaderboard weekly can only go up."),
+		},
+		{
+			description: "should accept a gain within the maximum of the window",
+			validator: maxGain(history(
+				models.ScoreHistoryEntry{PreviousScore: 10, Score: 50, CreatedAt: now.Add(-time.Minute)},
+				models.ScoreHistoryEntry{PreviousScore: 50, Score: 10, CreatedAt: now.Add(-2 * time.Minute)},
+				models.ScoreHistoryEntry{PreviousScore: 0, Score: 500, CreatedAt: now.Add(-2 * time.Hour)},
+			)),
+			check: &models.ScoreCheck{Exists: true, PreviousScore: 50, Score: 110},
+		},
+		{
+			description: "should reject a gain above the maximum of the window",
+			validator: maxGain(history(
+				models.ScoreHistoryEntry{PreviousScore: 10, Score: 50, CreatedAt: now.Add(-time.Minute)},
+			)),
+			check:          &models.ScoreCheck{Exists: true, PreviousScore: 50, Score: 111},
+			expectedResult: rejected(RuleMaxGain, "The score would go up by 101 within 1h0m0s, while it can go up by at most 100."),
+		},
+		{
+			description: "should accept any gain without a history",
+			validator:   maxGain(&models.Core{}),
+			check:       &models.ScoreCheck{Score: 1000},
+		},
+		{
+			description: "should accept a change slower than the rate",
+			validator:   rateAnomaly(history(models.ScoreHistoryEntry{CreatedAt: now.Add(-10 * time.Second)})),
+			check:       &models.ScoreCheck{Exists: true, PreviousScore: 0, Score: 100},
+		},
+		{
+			description: "should flag a change faster than the rate",
+			validator:   rateAnomaly(history(models.ScoreHistoryEntry{CreatedAt: now.Add(-10 * time.Second)})),
+			check:       &models.ScoreCheck{Exists: true, PreviousScore: 0, Score: 101},
+			expectedResult: &models.ValidationResult{
+				Verdict: models.VerdictFlagged,
+				Rule:    RuleRateAnomaly,
+				Reason:  "The score changed by 101 in 10s, faster than 10 points per second.",
+			},
+		},
+		{
+			description: "should accept the first score of a user",
+			validator:   rateAnomaly(history()),
+			check:       &models.ScoreCheck{Score: 1000},
+		},
+		{
+			description: "should return error when GetHistory",
+			validator: rateAnomaly(&models.Core{History: &mocks.HistoryServiceMock{
+				GetHistoryFunc: func(ctx context.Context, board string, id int, query models.HistoryQuery) ([]models.ScoreHistoryEntry, int, error) {
+					return nil, 0, fmt.Errorf("mock-error")
+				},
+			}}),
+			check:         &models.ScoreCheck{Exists: true, Score: 1000},
+			expectedError: fmt.Errorf("mock-error"),
+		},
+	}
+	for _, tc := range cases {
+		result, err := tc.validator.Validate(context.Background(), tc.check)
+		assert.Equal(t, tc.expectedResult, result, tc.description)
+		assert.Equal(t, tc.expectedError, err, tc.description)
+	}
+}
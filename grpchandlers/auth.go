@@ -0,0 +1,67 @@
+package grpchandlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pedrocmart/leaderboard-service/leaderboardpb"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+//methodScopes is the scope of the key each method needs, the same as the HTTP route it matches
+var methodScopes = map[string]models.APIKeyScope{
+	leaderboardpb.Leaderboard_SubmitScore_FullMethodName:  models.ScopeWrite,
+	leaderboardpb.Leaderboard_GetRanking_FullMethodName:   models.ScopeRead,
+	leaderboardpb.Leaderboard_GetUserRank_FullMethodName:  models.ScopeRead,
+	leaderboardpb.Leaderboard_WatchRanking_FullMethodName: models.ScopeRead,
+}
+
+//authenticate only lets through the calls signed by a key whose scope allows their method.
+//The signed request is a POST to the full method, with the deterministic protobuf encoding of the request as its body
+func (api *BasicHandlers) authenticate(ctx context.Context, fullMethod string, request interface{}) (context.Context, error) {
+	message, ok := request.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("the request of %s is not a protobuf message", fullMethod)
+	}
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	key, err := api.core.Auth.Verify(ctx, &models.SignedRequest{
+		KeyID:     metadataValue(md, "x-api-key"),
+		Timestamp: metadataValue(md, "x-timestamp"),
+		Nonce:     metadataValue(md, "x-nonce"),
+		Signature: metadataValue(md, "x-signature"),
+		Method:    http.MethodPost,
+		Path:      fullMethod,
+		Body:      body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !key.Scope.Allows(requiredScope(fullMethod)) {
+		return nil, models.NewForbiddenError(models.CodeInsufficientScope, "The API key %s is not allowed to call %s.", key.ID, fullMethod)
+	}
+	return models.WithAPIKey(ctx, key), nil
+}
+
+//requiredScope returns the scope of the method, admin for the ones that are not known
+func requiredScope(fullMethod string) models.APIKeyScope {
+	if scope, ok := methodScopes[fullMethod]; ok {
+		return scope
+	}
+	return models.ScopeAdmin
+}
+
+func metadataValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
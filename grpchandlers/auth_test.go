@@ -0,0 +1,175 @@
+package grpchandlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pedrocmart/leaderboard-service/leaderboardpb"
+	"github.com/pedrocmart/leaderboard-service/mocks"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+//signedBody returns the body of the signed request of a call
+func signedBody(t *testing.T, request proto.Message) []byte {
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(request)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when encoding %v", err, request)
+	}
+	return body
+}
+
+func TestAuthenticate(t *testing.T) {
+	submitScore := &leaderboardpb.SubmitScoreRequest{UserId: 1, Total: &[]int64{100}[0]}
+	getRanking := &leaderboardpb.GetRankingRequest{Type: "top1"}
+	cases := []struct {
+		description    string
+		call           func(ctx context.Context, client leaderboardpb.LeaderboardClient) error
+		verifiedKey    *models.APIKey
+		verifyError    error
+		expectedSigned *models.SignedRequest
+		expectedCode   codes.Code
+		expectedReason string
+	}{
+		{
+			description: "should let through a score signed by a write key",
+			call: func(ctx context.Context, client leaderboardpb.LeaderboardClient) error {
+				_, err := client.SubmitScore(ctx, submitScore)
+				return err
+			},
+			verifiedKey: &models.APIKey{ID: "server", Scope: models.ScopeWrite},
+			expectedSigned: &models.SignedRequest{
+				KeyID:     "server",
+				Timestamp: "1646136000",
+				Nonce:     "n1",
+				Signature: "abc",
+				Method:    "POST",
+				Path:      leaderboardpb.Leaderboard_SubmitScore_FullMethodName,
+				Body:      signedBody(t, submitScore),
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			description: "should refuse a score signed by a read key",
+			call: func(ctx context.Context, client leaderboardpb.LeaderboardClient) error {
+				_, err := client.SubmitScore(ctx, submitScore)
+				return err
+			},
+			verifiedKey: &models.APIKey{ID: "site", Scope: models.ScopeRead},
+			expectedSigned: &models.SignedRequest{
+				KeyID:     "server",
+				Timestamp: "1646136000",
+				Nonce:     "n1",
+				Signature: "abc",
+				Method:    "POST",
+				Path:      leaderboardpb.Leaderboard_SubmitScore_FullMethodName,
+				Body:      signedBody(t, submitScore),
+			},
+			expectedCode:   codes.PermissionDenied,
+			expectedReason: models.CodeInsufficientScope,
+		},
+		{
+			description: "should refuse a ranking that is not signed",
+			call: func(ctx context.Context, client leaderboardpb.LeaderboardClient) error {
+				_, err := client.GetRanking(ctx, getRanking)
+				return err
+			},
+			verifyError: models.NewUnauthorizedError(models.CodeMissingSignature, "Requests must be signed."),
+			expectedSigned: &models.SignedRequest{
+				KeyID:     "server",
+				Timestamp: "1646136000",
+				Nonce:     "n1",
+				Signature: "abc",
+				Method:    "POST",
+				Path:      leaderboardpb.Leaderboard_GetRanking_FullMethodName,
+				Body:      signedBody(t, getRanking),
+			},
+			expectedCode:   codes.Unauthenticated,
+			expectedReason: models.CodeMissingSignature,
+		},
+		{
+			description: "should let through a stream signed by a read key",
+			call: func(ctx context.Context, client leaderboardpb.LeaderboardClient) error {
+				stream, err := client.WatchRanking(ctx, getRanking)
+				if err != nil {
+					return err
+				}
+				_, err = stream.Recv()
+				return err
+			},
+			verifiedKey: &models.APIKey{ID: "site", Scope: models.ScopeRead},
+			expectedSigned: &models.SignedRequest{
+				KeyID:     "server",
+				Timestamp: "1646136000",
+				Nonce:     "n1",
+				Signature: "abc",
+				Method:    "POST",
+				Path:      leaderboardpb.Leaderboard_WatchRanking_FullMethodName,
+				Body:      signedBody(t, getRanking),
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			description: "should refuse a stream with an invalid signature",
+			call: func(ctx context.Context, client leaderboardpb.LeaderboardClient) error {
+				stream, err := client.WatchRanking(ctx, getRanking)
+				if err != nil {
+					return err
+				}
+				_, err = stream.Recv()
+				return err
+			},
+			verifyError: models.NewUnauthorizedError(models.CodeInvalidSignature, "The signature of the request is not valid."),
+			expectedSigned: &models.SignedRequest{
+				KeyID:     "server",
+				Timestamp: "1646136000",
+				Nonce:     "n1",
+				Signature: "abc",
+				Method:    "POST",
+				Path:      leaderboardpb.Leaderboard_WatchRanking_FullMethodName,
+				Body:      signedBody(t, getRanking),
+			},
+			expectedCode:   codes.Unauthenticated,
+			expectedReason: models.CodeInvalidSignature,
+		},
+	}
+	for _, tc := range cases {
+		//the handlers only run for the calls that are let through, with the key that signed them
+		handled := func(ctx context.Context) {
+			assert.Equal(t, codes.OK, tc.expectedCode, "should not handle a refused call: "+tc.description)
+			assert.Equal(t, tc.verifiedKey, models.APIKeyFromContext(ctx), tc.description)
+		}
+		core := &models.Core{
+			Auth: &mocks.AuthServiceMock{
+				VerifyFunc: func(ctx context.Context, request *models.SignedRequest) (*models.APIKey, error) {
+					assert.Equal(t, tc.expectedSigned, request, tc.description)
+					return tc.verifiedKey, tc.verifyError
+				},
+			},
+			Service: &mocks.ServiceMock{
+				HandleSubmitScoreFunc: func(ctx context.Context, board string, request *models.SubmitScoreRequest, userId string) (*models.SubmitScoreResponse, error) {
+					handled(ctx)
+					return &models.SubmitScoreResponse{UserID: 1, Score: 100}, nil
+				},
+				HandleGetRankingFunc: func(ctx context.Context, board string, request *models.GetRankingRequest) (*models.GetRankingResponse, error) {
+					handled(ctx)
+					return &models.GetRankingResponse{}, nil
+				},
+				HandleStreamRankingFunc: func(ctx context.Context, board string, request *models.GetRankingRequest, send func(*models.RankingUpdate) error) error {
+					handled(ctx)
+					return send(&models.RankingUpdate{Type: models.RankingSnapshot})
+				},
+			},
+		}
+		client := newTestClient(t, core)
+
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "server", "x-timestamp", "1646136000", "x-nonce", "n1", "x-signature", "abc")
+		err := tc.call(ctx, client)
+		assert.Equal(t, tc.expectedCode, status.Code(err), tc.description)
+		assert.Equal(t, tc.expectedReason, errorReason(err), tc.description)
+	}
+}
@@ -0,0 +1,228 @@
+package grpchandlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/pedrocmart/leaderboard-service/leaderboardpb"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+//errorDomain is the domain of the ErrorInfo details of the errors
+const errorDomain = "leaderboard-service"
+
+//BasicHandlers serves the gRPC API with the same models.Service as the HTTP handlers
+type BasicHandlers struct {
+	leaderboardpb.UnimplementedLeaderboardServer
+	core *models.Core
+}
+
+func ConnectBasic(server *grpc.Server, core *models.Core) error {
+	if server == nil {
+		return fmt.Errorf("Could not connect default grpc handlers since server is nil")
+	}
+	leaderboardpb.RegisterLeaderboardServer(server, &BasicHandlers{core: core})
+	return nil
+}
+
+func (api *BasicHandlers) SubmitScore(ctx context.Context, request *leaderboardpb.SubmitScoreRequest) (*leaderboardpb.SubmitScoreResponse, error) {
+	if api.core.Service == nil {
+		return nil, status.Error(codes.Internal, "Service is nil")
+	}
+
+	submitScoreRequest := &models.SubmitScoreRequest{
+		Score:  request.Score,
+		Source: clientSource(ctx),
+	}
+	if request.Total != nil {
+		total := int(*request.Total)
+		submitScoreRequest.Total = &total
+	}
+
+	result, err := api.core.Service.HandleSubmitScore(ctx, boardOrDefault(request.Board), submitScoreRequest, strconv.FormatInt(request.UserId, 10))
+	if err != nil {
+		api.logError(ctx, "error while submiting score", err)
+		return nil, statusError(err)
+	}
+
+	return &leaderboardpb.SubmitScoreResponse{UserId: int64(result.UserID), Score: int64(result.Score)}, nil
+}
+
+func (api *BasicHandlers) GetRanking(ctx context.Context, request *leaderboardpb.GetRankingRequest) (*leaderboardpb.GetRankingResponse, error) {
+	if api.core.Service == nil {
+		return nil, status.Error(codes.Internal, "Service is nil")
+	}
+	if request.Type == "" {
+		return nil, statusError(models.NewValidationError(models.CodeInvalidRankingType, "A type must be included."))
+	}
+
+	result, err := api.core.Service.HandleGetRanking(ctx, boardOrDefault(request.Board), rankingRequest(request))
+	if err != nil {
+		api.logError(ctx, "error while getting ranking", err)
+		return nil, statusError(err)
+	}
+
+	return &leaderboardpb.GetRankingResponse{
+		Period:   string(result.Period),
+		PeriodId: result.PeriodID,
+		Ranking:  rankingMessages(result.Ranking),
+	}, nil
+}
+
+func (api *BasicHandlers) GetUserRank(ctx context.Context, request *leaderboardpb.GetUserRankRequest) (*leaderboardpb.GetUserRankResponse, error) {
+	if api.core.Service == nil {
+		return nil, status.Error(codes.Internal, "Service is nil")
+	}
+
+	result, err := api.core.Service.HandleGetUserRank(ctx, boardOrDefault(request.Board), strconv.FormatInt(request.UserId, 10))
+	if err != nil {
+		api.logError(ctx, "error while getting user rank", err)
+		return nil, statusError(err)
+	}
+
+	return &leaderboardpb.GetUserRankResponse{
+		UserId:     int64(result.UserID),
+		Position:   int64(result.Position),
+		Score:      int64(result.Score),
+		Total:      int64(result.Total),
+		Percentile: result.Percentile,
+	}, nil
+}
+
+func (api *BasicHandlers) WatchRanking(request *leaderboardpb.GetRankingRequest, stream leaderboardpb.Leaderboard_WatchRankingServer) error {
+	if api.core.Service == nil {
+		return status.Error(codes.Internal, "Service is nil")
+	}
+	if request.Type == "" {
+		return statusError(models.NewValidationError(models.CodeInvalidRankingType, "A type must be included."))
+	}
+
+	err := api.core.Service.HandleStreamRanking(stream.Context(), boardOrDefault(request.Board), rankingRequest(request), func(update *models.RankingUpdate) error {
+		//HTTP/2 keeps the idle connections open by itself
+		if update.Type == models.RankingKeepAlive {
+			return nil
+		}
+		return stream.Send(&leaderboardpb.RankingUpdate{
+			Type:     string(update.Type),
+			Period:   string(update.Period),
+			PeriodId: update.PeriodID,
+			Ranking:  rankingMessages(update.Ranking),
+			Changed:  rankingMessages(update.Changed),
+			Removed:  removedMessages(update.Removed),
+		})
+	})
+	if err != nil {
+		api.logError(stream.Context(), "error while streaming ranking", err)
+		return statusError(err)
+	}
+	return nil
+}
+
+//logError logs the error of a call, as a warning when it is an error of the client
+func (api *BasicHandlers) logError(ctx context.Context, message string, err error) {
+	fields := models.LogFields{"code": models.ErrorCode(err), "error": err.Error()}
+	if models.ErrorStatus(err) >= http.StatusInternalServerError {
+		api.core.GetLogger().Error(ctx, message, fields)
+		return
+	}
+	api.core.GetLogger().Warn(ctx, message, fields)
+}
+
+func rankingRequest(request *leaderboardpb.GetRankingRequest) *models.GetRankingRequest {
+	return &models.GetRankingRequest{
+		Type:     request.Type,
+		Period:   request.Period,
+		PeriodID: request.PeriodId,
+	}
+}
+
+func rankingMessages(ranking []models.Ranking) []*leaderboardpb.Ranking {
+	messages := make([]*leaderboardpb.Ranking, len(ranking))
+	for i, user := range ranking {
+		messages[i] = &leaderboardpb.Ranking{
+			Position: int64(user.Position),
+			UserId:   int64(user.UserID),
+			Score:    int64(user.Score),
+		}
+	}
+	return messages
+}
+
+func removedMessages(removed []int) []int64 {
+	messages := make([]int64, len(removed))
+	for i, id := range removed {
+		messages[i] = int64(id)
+	}
+	return messages
+}
+
+func boardOrDefault(board string) string {
+	if board == "" {
+		return models.DefaultLeaderboard
+	}
+	return board
+}
+
+//clientSource returns the client that sent the request, from the x-client-id metadata or else its address
+func clientSource(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if source := md.Get("x-client-id"); len(source) > 0 && source[0] != "" {
+			return source[0]
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+//statusError returns the gRPC status of err, with the code of the error as the reason of its ErrorInfo,
+//and a RetryInfo when the time to wait before calling again is known
+func statusError(err error) error {
+	st := status.New(statusCode(err), models.ErrorMessage(err))
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: models.ErrorCode(err), Domain: errorDomain}}
+	if retryAfter := models.ErrorRetryAfter(err); retryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	}
+	detailed, detailsErr := st.WithDetails(details...)
+	if detailsErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+//statusCode returns the gRPC code of err, Internal if it is not an Error of the service
+func statusCode(err error) codes.Code {
+	var serviceError *models.Error
+	if !errors.As(err, &serviceError) {
+		return codes.Internal
+	}
+	switch serviceError.Kind {
+	case models.ErrorValidation:
+		return codes.InvalidArgument
+	case models.ErrorTooLarge:
+		return codes.ResourceExhausted
+	case models.ErrorNotFound:
+		return codes.NotFound
+	case models.ErrorConflict:
+		return codes.FailedPrecondition
+	case models.ErrorUnauthorized:
+		return codes.Unauthenticated
+	case models.ErrorForbidden:
+		return codes.PermissionDenied
+	case models.ErrorTooManyRequests:
+		return codes.ResourceExhausted
+	}
+	return codes.Internal
+}
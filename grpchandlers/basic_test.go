@@ -0,0 +1,316 @@
+package grpchandlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/pedrocmart/leaderboard-service/leaderboardpb"
+	"github.com/pedrocmart/leaderboard-service/mocks"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+//newTestClient serves the handlers of core on an in-memory connection and returns a client of it
+func newTestClient(t *testing.T, core *models.Core) leaderboardpb.LeaderboardClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(ServerOptions(core)...)
+	assert.NoError(t, ConnectBasic(server, core))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when dialing the server", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return leaderboardpb.NewLeaderboardClient(conn)
+}
+
+//errorReason returns the code of the service carried by the status of err
+func errorReason(err error) string {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+	return ""
+}
+
+func TestConnectBasic(t *testing.T) {
+	err := ConnectBasic(nil, &models.Core{})
+	assert.Error(t, err, "should fail without a server")
+}
+
+func TestSubmitScore(t *testing.T) {
+	cases := []struct {
+		description      string
+		request          *leaderboardpb.SubmitScoreRequest
+		submitScoreError error
+		expectedBoard    string
+		expectedUserId   string
+		expectedRequest  *models.SubmitScoreRequest
+		expectedResponse *leaderboardpb.SubmitScoreResponse
+		expectedCode     codes.Code
+		expectedReason   string
+		expectedMessage  string
+	}{
+		{
+			description:      "should submit an absolute score to the default leaderboard",
+			request:          &leaderboardpb.SubmitScoreRequest{UserId: 1, Total: &[]int64{100}[0]},
+			expectedBoard:    models.DefaultLeaderboard,
+			expectedUserId:   "1",
+			expectedRequest:  &models.SubmitScoreRequest{Total: &[]int{100}[0], Source: "game-1"},
+			expectedResponse: &leaderboardpb.SubmitScoreResponse{UserId: 1, Score: 100},
+			expectedCode:     codes.OK,
+		},
+		{
+			description:      "should submit a relative score to a leaderboard",
+			request:          &leaderboardpb.SubmitScoreRequest{Board: "weekly", UserId: 2, Score: "+10"},
+			expectedBoard:    "weekly",
+			expectedUserId:   "2",
+			expectedRequest:  &models.SubmitScoreRequest{Score: "+10", Source: "game-1"},
+			expectedResponse: &leaderboardpb.SubmitScoreResponse{UserId: 2, Score: 100},
+			expectedCode:     codes.OK,
+		},
+		{
+			description:      "should return the code of a validation error",
+			request:          &leaderboardpb.SubmitScoreRequest{UserId: 1},
+			submitScoreError: models.NewValidationError(models.CodeInvalidScore, "The score must be included."),
+			expectedBoard:    models.DefaultLeaderboard,
+			expectedUserId:   "1",
+			expectedRequest:  &models.SubmitScoreRequest{Source: "game-1"},
+			expectedCode:     codes.InvalidArgument,
+			expectedReason:   models.CodeInvalidScore,
+			expectedMessage:  "The score must be included.",
+		},
+		{
+			description:      "should return an internal error, without its message",
+			request:          &leaderboardpb.SubmitScoreRequest{UserId: 1},
+			submitScoreError: fmt.Errorf("pq: relation \"users\" does not exist"),
+			expectedBoard:    models.DefaultLeaderboard,
+			expectedUserId:   "1",
+			expectedRequest:  &models.SubmitScoreRequest{Source: "game-1"},
+			expectedCode:     codes.Internal,
+			expectedReason:   models.CodeInternal,
+			expectedMessage:  "internal error",
+		},
+	}
+	for _, tc := range cases {
+		core := &models.Core{
+			Service: &mocks.ServiceMock{
+				HandleSubmitScoreFunc: func(ctx context.Context, board string, request *models.SubmitScoreRequest, userId string) (*models.SubmitScoreResponse, error) {
+					assert.Equal(t, tc.expectedBoard, board, tc.description)
+					assert.Equal(t, tc.expectedUserId, userId, tc.description)
+					assert.Equal(t, tc.expectedRequest, request, tc.description)
+					if tc.submitScoreError != nil {
+						return nil, tc.submitScoreError
+					}
+					return &models.SubmitScoreResponse{UserID: int(tc.request.UserId), Score: 100}, nil
+				},
+			},
+		}
+		client := newTestClient(t, core)
+
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "x-client-id", "game-1")
+		response, err := client.SubmitScore(ctx, tc.request)
+		assert.Equal(t, tc.expectedCode, status.Code(err), tc.description)
+		assert.Equal(t, tc.expectedReason, errorReason(err), tc.description)
+		if tc.expectedMessage != "" {
+			assert.Equal(t, tc.expectedMessage, status.Convert(err).Message(), tc.description)
+		}
+		if tc.expectedResponse != nil {
+			assert.Equal(t, tc.expectedResponse.UserId, response.UserId, tc.description)
+			assert.Equal(t, tc.expectedResponse.Score, response.Score, tc.description)
+		}
+	}
+}
+
+func TestGetRanking(t *testing.T) {
+	cases := []struct {
+		description        string
+		request            *leaderboardpb.GetRankingRequest
+		getRankingResponse *models.GetRankingResponse
+		getRankingError    error
+		expectedRequest    *models.GetRankingRequest
+		expectedRanking    []*leaderboardpb.Ranking
+		expectedCode       codes.Code
+		expectedReason     string
+	}{
+		{
+			description: "should get the ranking of a period",
+			request:     &leaderboardpb.GetRankingRequest{Type: "top2", Period: "daily"},
+			getRankingResponse: &models.GetRankingResponse{
+				Period:   models.PeriodDaily,
+				PeriodID: "2022-03-01",
+				Ranking:  []models.Ranking{{Position: 1, UserID: 3, Score: 50}, {Position: 2, UserID: 1, Score: 20}},
+			},
+			expectedRequest: &models.GetRankingRequest{Type: "top2", Period: "daily"},
+			expectedRanking: []*leaderboardpb.Ranking{{Position: 1, UserId: 3, Score: 50}, {Position: 2, UserId: 1, Score: 20}},
+			expectedCode:    codes.OK,
+		},
+		{
+			description:    "should return an error without a type",
+			request:        &leaderboardpb.GetRankingRequest{},
+			expectedCode:   codes.InvalidArgument,
+			expectedReason: models.CodeInvalidRankingType,
+		},
+		{
+			description:     "should return the code of a not found error",
+			request:         &leaderboardpb.GetRankingRequest{Board: "weekly", Type: "top2"},
+			getRankingError: models.NewNotFoundError(models.CodeLeaderboardNotFound, "Leaderboard weekly not found."),
+			expectedRequest: &models.GetRankingRequest{Type: "top2"},
+			expectedCode:    codes.NotFound,
+			expectedReason:  models.CodeLeaderboardNotFound,
+		},
+	}
+	for _, tc := range cases {
+		core := &models.Core{
+			Service: &mocks.ServiceMock{
+				HandleGetRankingFunc: func(ctx context.Context, board string, request *models.GetRankingRequest) (*models.GetRankingResponse, error) {
+					assert.Equal(t, boardOrDefault(tc.request.Board), board, tc.description)
+					assert.Equal(t, tc.expectedRequest, request, tc.description)
+					return tc.getRankingResponse, tc.getRankingError
+				},
+			},
+		}
+		client := newTestClient(t, core)
+
+		response, err := client.GetRanking(context.Background(), tc.request)
+		assert.Equal(t, tc.expectedCode, status.Code(err), tc.description)
+		assert.Equal(t, tc.expectedReason, errorReason(err), tc.description)
+		if tc.expectedRanking != nil {
+			assert.Equal(t, string(tc.getRankingResponse.Period), response.Period, tc.description)
+			assert.Equal(t, tc.getRankingResponse.PeriodID, response.PeriodId, tc.description)
+			assert.Equal(t, len(tc.expectedRanking), len(response.Ranking), tc.description)
+			for i, user := range tc.expectedRanking {
+				assert.Equal(t, user.Position, response.Ranking[i].Position, tc.description)
+				assert.Equal(t, user.UserId, response.Ranking[i].UserId, tc.description)
+				assert.Equal(t, user.Score, response.Ranking[i].Score, tc.description)
+			}
+		}
+	}
+}
+
+func TestGetUserRank(t *testing.T) {
+	cases := []struct {
+		description         string
+		request             *leaderboardpb.GetUserRankRequest
+		getUserRankResponse *models.GetUserRankResponse
+		getUserRankError    error
+		expectedCode        codes.Code
+		expectedReason      string
+	}{
+		{
+			description:         "should get the rank of an user",
+			request:             &leaderboardpb.GetUserRankRequest{UserId: 7},
+			getUserRankResponse: &models.GetUserRankResponse{UserID: 7, Position: 2, Score: 50, Total: 10},
+			expectedCode:        codes.OK,
+		},
+		{
+			description:      "should return the code of a missing user",
+			request:          &leaderboardpb.GetUserRankRequest{UserId: 7},
+			getUserRankError: models.NewNotFoundError(models.CodeUserNotFound, "User 7 not found."),
+			expectedCode:     codes.NotFound,
+			expectedReason:   models.CodeUserNotFound,
+		},
+	}
+	for _, tc := range cases {
+		core := &models.Core{
+			Service: &mocks.ServiceMock{
+				HandleGetUserRankFunc: func(ctx context.Context, board string, userId string) (*models.GetUserRankResponse, error) {
+					assert.Equal(t, models.DefaultLeaderboard, board, tc.description)
+					assert.Equal(t, "7", userId, tc.description)
+					return tc.getUserRankResponse, tc.getUserRankError
+				},
+			},
+		}
+		client := newTestClient(t, core)
+
+		response, err := client.GetUserRank(context.Background(), tc.request)
+		assert.Equal(t, tc.expectedCode, status.Code(err), tc.description)
+		assert.Equal(t, tc.expectedReason, errorReason(err), tc.description)
+		if tc.getUserRankResponse != nil {
+			assert.Equal(t, int64(7), response.UserId, tc.description)
+			assert.Equal(t, int64(2), response.Position, tc.description)
+			assert.Equal(t, int64(50), response.Score, tc.description)
+			assert.Equal(t, int64(10), response.Total, tc.description)
+		}
+	}
+}
+
+func TestWatchRanking(t *testing.T) {
+	updates := []*models.RankingUpdate{
+		{Type: models.RankingSnapshot, Ranking: []models.Ranking{{Position: 1, UserID: 1, Score: 10}}},
+		{Type: models.RankingKeepAlive},
+		{Type: models.RankingDiff, Changed: []models.Ranking{{Position: 1, UserID: 2, Score: 20}}, Removed: []int{1}},
+	}
+	cases := []struct {
+		description    string
+		streamError    error
+		expectedCode   codes.Code
+		expectedReason string
+	}{
+		{
+			description:  "should send the updates without the keepalives",
+			expectedCode: codes.OK,
+		},
+		{
+			description:    "should end the stream with the code of the error",
+			streamError:    models.NewNotFoundError(models.CodeLeaderboardNotFound, "Leaderboard default not found."),
+			expectedCode:   codes.NotFound,
+			expectedReason: models.CodeLeaderboardNotFound,
+		},
+	}
+	for _, tc := range cases {
+		core := &models.Core{
+			Service: &mocks.ServiceMock{
+				HandleStreamRankingFunc: func(ctx context.Context, board string, request *models.GetRankingRequest, send func(*models.RankingUpdate) error) error {
+					for _, update := range updates {
+						if err := send(update); err != nil {
+							return err
+						}
+					}
+					return tc.streamError
+				},
+			},
+		}
+		client := newTestClient(t, core)
+
+		stream, err := client.WatchRanking(context.Background(), &leaderboardpb.GetRankingRequest{Type: "top1"})
+		assert.NoError(t, err, tc.description)
+
+		snapshot, err := stream.Recv()
+		assert.NoError(t, err, tc.description)
+		assert.Equal(t, string(models.RankingSnapshot), snapshot.Type, tc.description)
+		assert.Equal(t, int64(1), snapshot.Ranking[0].UserId, tc.description)
+
+		diff, err := stream.Recv()
+		assert.NoError(t, err, tc.description)
+		assert.Equal(t, string(models.RankingDiff), diff.Type, tc.description)
+		assert.Equal(t, int64(2), diff.Changed[0].UserId, tc.description)
+		assert.Equal(t, []int64{1}, diff.Removed, tc.description)
+
+		_, err = stream.Recv()
+		if tc.streamError == nil {
+			assert.Equal(t, io.EOF, err, tc.description)
+		} else {
+			assert.Equal(t, tc.expectedCode, status.Code(err), tc.description)
+			assert.Equal(t, tc.expectedReason, errorReason(err), tc.description)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package grpchandlers
+
+import (
+	"context"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+	"google.golang.org/grpc"
+)
+
+//callCheck checks a call before it is handled, with the request it carries, and returns the context to handle it with
+type callCheck func(ctx context.Context, fullMethod string, request interface{}) (context.Context, error)
+
+//ServerOptions returns the options of the server ConnectBasic connects to: the calls are bound by the same size as the
+//bodies of the HTTP API, and go through the same authentication and rate limits as its requests
+func ServerOptions(core *models.Core) []grpc.ServerOption {
+	api := &BasicHandlers{core: core}
+	return []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(int(core.GetMaxBodyBytes())),
+		grpc.ChainUnaryInterceptor(api.unaryInterceptor),
+		grpc.ChainStreamInterceptor(api.streamInterceptor),
+	}
+}
+
+//checks returns the checks of the calls in the order of the middlewares of the HTTP API:
+//the calls are authenticated first, so the rate limits can count them by their key
+func (api *BasicHandlers) checks() []callCheck {
+	var checks []callCheck
+	if api.core.Auth != nil {
+		checks = append(checks, api.authenticate)
+	}
+	if api.core.RateLimits != nil {
+		checks = append(checks, api.rateLimit)
+	}
+	return checks
+}
+
+//check runs the checks of the call, logging and returning the status of the first one that refuses it
+func (api *BasicHandlers) check(ctx context.Context, fullMethod string, request interface{}) (context.Context, error) {
+	for _, check := range api.checks() {
+		checked, err := check(ctx, fullMethod, request)
+		if err != nil {
+			api.logError(ctx, "call refused", err)
+			return nil, statusError(err)
+		}
+		ctx = checked
+	}
+	return ctx, nil
+}
+
+func (api *BasicHandlers) unaryInterceptor(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := api.check(ctx, info.FullMethod, request)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, request)
+}
+
+func (api *BasicHandlers) streamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &checkedStream{ServerStream: stream, ctx: stream.Context(), fullMethod: info.FullMethod, api: api})
+}
+
+//checkedStream checks the call of a stream with its first request, since the request is only read by the handler
+type checkedStream struct {
+	grpc.ServerStream
+	ctx        context.Context
+	fullMethod string
+	api        *BasicHandlers
+	checked    bool
+}
+
+func (s *checkedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *checkedStream) RecvMsg(request interface{}) error {
+	if err := s.ServerStream.RecvMsg(request); err != nil || s.checked {
+		return err
+	}
+	ctx, err := s.api.check(s.ctx, s.fullMethod, request)
+	if err != nil {
+		return err
+	}
+	s.ctx, s.checked = ctx, true
+	return nil
+}
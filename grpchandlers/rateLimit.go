@@ -0,0 +1,51 @@
+package grpchandlers
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+	"google.golang.org/grpc/peer"
+)
+
+//userRequest is a request of a single user, whose id the rate limits by user count the calls by
+type userRequest interface {
+	GetUserId() int64
+}
+
+//rateLimit throttles the calls above the rate limits of their method, with the time to wait in a RetryInfo
+func (api *BasicHandlers) rateLimit(ctx context.Context, fullMethod string, request interface{}) (context.Context, error) {
+	wait, err := api.core.RateLimits.Allow(ctx, rateLimitRequest(ctx, fullMethod, request))
+	if err != nil {
+		return nil, err
+	}
+	if wait > 0 {
+		return nil, models.NewTooManyRequestsError(models.CodeRateLimited, wait, "Too many requests, retry in %s.", wait.Round(time.Millisecond))
+	}
+	return ctx, nil
+}
+
+//rateLimitRequest returns the call as the rate limits see it: a POST to the route of its full method
+func rateLimitRequest(ctx context.Context, fullMethod string, request interface{}) *models.RateLimitRequest {
+	rateLimitRequest := &models.RateLimitRequest{
+		Route:  fullMethod,
+		Method: http.MethodPost,
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		rateLimitRequest.IP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(rateLimitRequest.IP); err == nil {
+			rateLimitRequest.IP = host
+		}
+	}
+	if user, ok := request.(userRequest); ok {
+		rateLimitRequest.UserID = strconv.FormatInt(user.GetUserId(), 10)
+	}
+	//only the keys verified by authenticate are trusted
+	if key := models.APIKeyFromContext(ctx); key != nil {
+		rateLimitRequest.APIKey = key.ID
+	}
+	return rateLimitRequest
+}
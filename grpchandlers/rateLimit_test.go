@@ -0,0 +1,119 @@
+package grpchandlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pedrocmart/leaderboard-service/leaderboardpb"
+	"github.com/pedrocmart/leaderboard-service/mocks"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//retryDelay returns the time to wait carried by the status of err
+func retryDelay(err error) time.Duration {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			return info.RetryDelay.AsDuration()
+		}
+	}
+	return 0
+}
+
+func TestRateLimit(t *testing.T) {
+	cases := []struct {
+		description     string
+		call            func(ctx context.Context, client leaderboardpb.LeaderboardClient) error
+		wait            time.Duration
+		expectedRequest *models.RateLimitRequest
+		expectedCode    codes.Code
+		expectedReason  string
+	}{
+		{
+			description: "should let through a score within the rate limits",
+			call: func(ctx context.Context, client leaderboardpb.LeaderboardClient) error {
+				_, err := client.SubmitScore(ctx, &leaderboardpb.SubmitScoreRequest{UserId: 12, Score: "+10"})
+				return err
+			},
+			expectedRequest: &models.RateLimitRequest{Route: leaderboardpb.Leaderboard_SubmitScore_FullMethodName, Method: "POST", IP: "bufconn", UserID: "12"},
+			expectedCode:    codes.OK,
+		},
+		{
+			description: "should throttle a ranking above the rate limits",
+			call: func(ctx context.Context, client leaderboardpb.LeaderboardClient) error {
+				_, err := client.GetRanking(ctx, &leaderboardpb.GetRankingRequest{Type: "top1"})
+				return err
+			},
+			wait:            1500 * time.Millisecond,
+			expectedRequest: &models.RateLimitRequest{Route: leaderboardpb.Leaderboard_GetRanking_FullMethodName, Method: "POST", IP: "bufconn"},
+			expectedCode:    codes.ResourceExhausted,
+			expectedReason:  models.CodeRateLimited,
+		},
+		{
+			description: "should throttle a stream above the rate limits",
+			call: func(ctx context.Context, client leaderboardpb.LeaderboardClient) error {
+				stream, err := client.WatchRanking(ctx, &leaderboardpb.GetRankingRequest{Type: "top1"})
+				if err != nil {
+					return err
+				}
+				_, err = stream.Recv()
+				return err
+			},
+			wait:            time.Second,
+			expectedRequest: &models.RateLimitRequest{Route: leaderboardpb.Leaderboard_WatchRanking_FullMethodName, Method: "POST", IP: "bufconn"},
+			expectedCode:    codes.ResourceExhausted,
+			expectedReason:  models.CodeRateLimited,
+		},
+	}
+	for _, tc := range cases {
+		core := &models.Core{
+			RateLimits: &mocks.RateLimitServiceMock{
+				AllowFunc: func(ctx context.Context, request *models.RateLimitRequest) (time.Duration, error) {
+					assert.Equal(t, tc.expectedRequest, request, tc.description)
+					return tc.wait, nil
+				},
+			},
+			Service: &mocks.ServiceMock{
+				HandleSubmitScoreFunc: func(ctx context.Context, board string, request *models.SubmitScoreRequest, userId string) (*models.SubmitScoreResponse, error) {
+					return &models.SubmitScoreResponse{UserID: 12, Score: 10}, nil
+				},
+			},
+		}
+		client := newTestClient(t, core)
+
+		err := tc.call(context.Background(), client)
+		assert.Equal(t, tc.expectedCode, status.Code(err), tc.description)
+		assert.Equal(t, tc.expectedReason, errorReason(err), tc.description)
+		assert.Equal(t, tc.wait, retryDelay(err), tc.description)
+	}
+}
+
+func TestRateLimit_APIKey(t *testing.T) {
+	core := &models.Core{
+		Auth: &mocks.AuthServiceMock{
+			VerifyFunc: func(ctx context.Context, request *models.SignedRequest) (*models.APIKey, error) {
+				return &models.APIKey{ID: "site", Scope: models.ScopeRead}, nil
+			},
+		},
+		RateLimits: &mocks.RateLimitServiceMock{
+			AllowFunc: func(ctx context.Context, request *models.RateLimitRequest) (time.Duration, error) {
+				assert.Equal(t, "site", request.APIKey, "should count the calls by the key that signed them")
+				return 0, nil
+			},
+		},
+		Service: &mocks.ServiceMock{
+			HandleGetUserRankFunc: func(ctx context.Context, board string, userId string) (*models.GetUserRankResponse, error) {
+				return &models.GetUserRankResponse{UserID: 7, Position: 1}, nil
+			},
+		},
+	}
+	client := newTestClient(t, core)
+
+	_, err := client.GetUserRank(context.Background(), &leaderboardpb.GetUserRankRequest{UserId: 7})
+	assert.NoError(t, err)
+	assert.Len(t, core.RateLimits.(*mocks.RateLimitServiceMock).AllowCalls(), 1, "should rate limit the call once it is authenticated")
+}
@@ -0,0 +1,164 @@
+package http
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+//AdminOnly only lets through the admin requests that carry the admin token as a bearer token.
+//When the requests are signed, Authenticate already checked that their key has the admin scope
+func (api *BasicHandlers) AdminOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/admin/") || api.core.Auth != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if api.core.AdminToken == "" {
+			err := models.NewForbiddenError(models.CodeAdminNotEnabled, "The admin API is not enabled, since there is no admin token nor API keys.")
+			api.core.RequestResponse.HandleError(err, w, r, http.StatusForbidden)
+			return
+		}
+
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(api.core.AdminToken)) != 1 {
+			err := models.NewUnauthorizedError(models.CodeInvalidAdminToken, "The Authorization header must hold the admin token as a bearer token.")
+			api.core.RequestResponse.HandleError(err, w, r, http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (api *BasicHandlers) HandleDeleteUser(w http.ResponseWriter, r *http.Request) {
+	if api.core.Service == nil {
+		err := fmt.Errorf("Service is nil")
+		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
+		return
+	}
+
+	result, err := api.core.Service.HandleDeleteUser(r.Context(), boardFromVars(r), mux.Vars(r)["user_id"])
+	if err != nil {
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
+	api.core.RequestResponse.HandleResponse(result, w, r, http.StatusOK)
+}
+
+func (api *BasicHandlers) HandleResetUser(w http.ResponseWriter, r *http.Request) {
+	if api.core.Service == nil {
+		err := fmt.Errorf("Service is nil")
+		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
+		return
+	}
+
+	result, err := api.core.Service.HandleResetUser(r.Context(), boardFromVars(r), mux.Vars(r)["user_id"])
+	if err != nil {
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
+	api.core.RequestResponse.HandleResponse(result, w, r, http.StatusOK)
+}
+
+func (api *BasicHandlers) HandleFreezeUser(w http.ResponseWriter, r *http.Request) {
+	if api.core.Service == nil {
+		err := fmt.Errorf("Service is nil")
+		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
+		return
+	}
+
+	//the body is optional, and a user is kept in the ranking without one
+	freezeUserRequest := new(models.FreezeUserRequest)
+	if r.ContentLength != 0 {
+		err := api.readBody(r, freezeUserRequest)
+		if err != nil {
+			api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+			return
+		}
+	}
+
+	result, err := api.core.Service.HandleFreezeUser(r.Context(), boardFromVars(r), mux.Vars(r)["user_id"], freezeUserRequest)
+	if err != nil {
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
+	api.core.RequestResponse.HandleResponse(result, w, r, http.StatusOK)
+}
+
+func (api *BasicHandlers) HandleUnfreezeUser(w http.ResponseWriter, r *http.Request) {
+	if api.core.Service == nil {
+		err := fmt.Errorf("Service is nil")
+		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
+		return
+	}
+
+	result, err := api.core.Service.HandleUnfreezeUser(r.Context(), boardFromVars(r), mux.Vars(r)["user_id"])
+	if err != nil {
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
+	api.core.RequestResponse.HandleResponse(result, w, r, http.StatusOK)
+}
+
+func (api *BasicHandlers) HandleGetFrozenUsers(w http.ResponseWriter, r *http.Request) {
+	if api.core.Service == nil {
+		err := fmt.Errorf("Service is nil")
+		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
+		return
+	}
+
+	result, err := api.core.Service.HandleGetFrozenUsers(r.Context(), boardFromVars(r))
+	if err != nil {
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
+	api.core.RequestResponse.HandleResponse(result, w, r, http.StatusOK)
+}
+
+func (api *BasicHandlers) HandleMergeUsers(w http.ResponseWriter, r *http.Request) {
+	if api.core.Service == nil {
+		err := fmt.Errorf("Service is nil")
+		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
+		return
+	}
+
+	mergeUsersRequest := new(models.MergeUsersRequest)
+	err := api.readBody(r, mergeUsersRequest)
+	if err != nil {
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
+	result, err := api.core.Service.HandleMergeUsers(r.Context(), boardFromVars(r), mux.Vars(r)["user_id"], mergeUsersRequest)
+	if err != nil {
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
+	api.core.RequestResponse.HandleResponse(result, w, r, http.StatusOK)
+}
+
+func (api *BasicHandlers) HandleResetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	if api.core.Service == nil {
+		err := fmt.Errorf("Service is nil")
+		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
+		return
+	}
+
+	result, err := api.core.Service.HandleResetLeaderboard(r.Context(), boardFromVars(r))
+	if err != nil {
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
+	api.core.RequestResponse.HandleResponse(result, w, r, http.StatusOK)
+}
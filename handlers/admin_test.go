@@ -0,0 +1,207 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/pedrocmart/leaderboard-service/mocks"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminOnly(t *testing.T) {
+	cases := []struct {
+		description        string
+		core               *models.Core
+		path               string
+		authorization      string
+		expectedStatusCode int
+	}{
+		{
+			description:        "should let through an admin request with the admin token",
+			core:               &models.Core{AdminToken: "secret"},
+			path:               "/admin/reviews",
+			authorization:      "Bearer secret",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "should refuse an admin request with another token",
+			core:               &models.Core{AdminToken: "secret"},
+			path:               "/admin/reviews",
+			authorization:      "Bearer secreT",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			description:        "should refuse an admin request without a token",
+			core:               &models.Core{AdminToken: "secret"},
+			path:               "/admin/reset",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			description:        "should refuse every admin request without an admin token nor API keys",
+			core:               &models.Core{},
+			path:               "/admin/reviews",
+			authorization:      "Bearer ",
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			description:        "should leave the admin requests to the API keys when the requests are signed",
+			core:               &models.Core{Auth: &mocks.AuthServiceMock{}},
+			path:               "/admin/reviews",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "should let through the requests that are not admin ones",
+			core:               &models.Core{AdminToken: "secret"},
+			path:               "/ranking",
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		tc.core.RequestResponse = &mocks.RequestResponseMock{
+			HandleErrorFunc: func(err error, w http.ResponseWriter, r *http.Request, status int) {
+				w.WriteHeader(status)
+			},
+		}
+		basicHandlers := BasicHandlers{core: tc.core}
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		request := httptest.NewRequest("GET", tc.path, nil)
+		if tc.authorization != "" {
+			request.Header.Set("Authorization", tc.authorization)
+		}
+		writer := httptest.NewRecorder()
+		basicHandlers.AdminOnly(next).ServeHTTP(writer, request)
+		assert.Equal(t, tc.expectedStatusCode, writer.Code, tc.description)
+	}
+}
+
+func TestAdminRoutes(t *testing.T) {
+	cases := []struct {
+		description        string
+		method             string
+		path               string
+		body               string
+		expectedCall       string
+		expectedStatusCode int
+	}{
+		{
+			description:        "should delete a user of a leaderboard",
+			method:             "DELETE",
+			path:               "/admin/leaderboards/weekly/users/1",
+			expectedCall:       "delete weekly 1",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "should reset a user of the default leaderboard",
+			method:             "POST",
+			path:               "/admin/users/1/reset",
+			expectedCall:       "reset default 1",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "should freeze a user without a body",
+			method:             "POST",
+			path:               "/admin/users/1/freeze",
+			expectedCall:       "freeze default 1 ",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "should hide a user",
+			method:             "POST",
+			path:               "/admin/leaderboards/weekly/users/1/freeze",
+			body:               `{"mode": "hide"}`,
+			expectedCall:       "freeze weekly 1 hide",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "should unfreeze a user",
+			method:             "DELETE",
+			path:               "/admin/users/1/freeze",
+			expectedCall:       "unfreeze default 1",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "should get the frozen users",
+			method:             "GET",
+			path:               "/admin/leaderboards/weekly/frozen",
+			expectedCall:       "frozen weekly",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "should merge two users",
+			method:             "POST",
+			path:               "/admin/users/1/merge",
+			body:               `{"into": 2, "strategy": "sum"}`,
+			expectedCall:       "merge default 1 2 sum",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "should fail to merge with an invalid body",
+			method:             "POST",
+			path:               "/admin/users/1/merge",
+			body:               `{"into": "2"`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			description:        "should reset a leaderboard",
+			method:             "POST",
+			path:               "/admin/leaderboards/weekly/reset",
+			expectedCall:       "reset weekly",
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		var call string
+		mockedService := mocks.ServiceMock{
+			HandleDeleteUserFunc: func(ctx context.Context, board string, userId string) (*models.User, error) {
+				call = "delete " + board + " " + userId
+				return &models.User{}, nil
+			},
+			HandleResetUserFunc: func(ctx context.Context, board string, userId string) (*models.User, error) {
+				call = "reset " + board + " " + userId
+				return &models.User{}, nil
+			},
+			HandleFreezeUserFunc: func(ctx context.Context, board string, userId string, request *models.FreezeUserRequest) (*models.FrozenUser, error) {
+				call = "freeze " + board + " " + userId + " " + request.Mode
+				return &models.FrozenUser{}, nil
+			},
+			HandleUnfreezeUserFunc: func(ctx context.Context, board string, userId string) (*models.FrozenUser, error) {
+				call = "unfreeze " + board + " " + userId
+				return &models.FrozenUser{}, nil
+			},
+			HandleGetFrozenUsersFunc: func(ctx context.Context, board string) (*models.GetFrozenUsersResponse, error) {
+				call = "frozen " + board
+				return &models.GetFrozenUsersResponse{}, nil
+			},
+			HandleMergeUsersFunc: func(ctx context.Context, board string, userId string, request *models.MergeUsersRequest) (*models.User, error) {
+				call = "merge " + board + " " + userId + " " + fmt.Sprint(*request.Into) + " " + request.Strategy
+				return &models.User{}, nil
+			},
+			HandleResetLeaderboardFunc: func(ctx context.Context, board string) (*models.ResetLeaderboardResponse, error) {
+				call = "reset " + board
+				return &models.ResetLeaderboardResponse{}, nil
+			},
+		}
+		core := &models.Core{Service: &mockedService, AdminToken: "secret"}
+		core.ConnectResponseWriter()
+		router := mux.NewRouter()
+		assert.NoError(t, ConnectBasic(router, core), tc.description)
+
+		request := httptest.NewRequest(tc.method, tc.path, bytes.NewBufferString(tc.body))
+		request.Header.Set("Authorization", "Bearer secret")
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, request)
+		assert.Equal(t, tc.expectedStatusCode, writer.Code, tc.description)
+		assert.Equal(t, tc.expectedCall, call, tc.description)
+	}
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+//Authenticate only lets through the requests signed by a key whose scope allows them:
+//admin requests need an admin key, GET requests a read key, and every other request a write key
+func (api *BasicHandlers) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		//scrapers and probes can't sign their requests, and the metrics and the probes hold no scores
+		if r.URL.Path == metricsPath || probePaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		//the body is signed, so it is read here and handed over to the handler again
+		//it is bound by the same size as the bodies read by the handlers
+		maxBodyBytes := api.core.GetMaxBodyBytes()
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+		if err != nil {
+			err := models.NewTooLargeError(models.CodeBodyTooLarge, "The body must have at most %d bytes.", maxBodyBytes)
+			api.core.RequestResponse.HandleError(err, w, r, http.StatusRequestEntityTooLarge)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		key, err := api.core.Auth.Verify(r.Context(), &models.SignedRequest{
+			KeyID:     r.Header.Get("X-Api-Key"),
+			Timestamp: r.Header.Get("X-Timestamp"),
+			Nonce:     r.Header.Get("X-Nonce"),
+			Signature: r.Header.Get("X-Signature"),
+			Method:    r.Method,
+			Path:      r.URL.RequestURI(),
+			Body:      body,
+		})
+		if err != nil {
+			api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+			return
+		}
+
+		if !key.Scope.Allows(requiredScope(r)) {
+			err := models.NewForbiddenError(models.CodeInsufficientScope, "The API key %s is not allowed to send %s requests.", key.ID, r.Method)
+			api.core.RequestResponse.HandleError(err, w, r, http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(models.WithAPIKey(r.Context(), key)))
+	})
+}
+
+func requiredScope(r *http.Request) models.APIKeyScope {
+	if strings.HasPrefix(r.URL.Path, "/admin/") {
+		return models.ScopeAdmin
+	}
+	if r.Method == http.MethodGet {
+		return models.ScopeRead
+	}
+	return models.ScopeWrite
+}
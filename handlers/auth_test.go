@@ -0,0 +1,120 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pedrocmart/leaderboard-service/mocks"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthenticate(t *testing.T) {
+	cases := []struct {
+		description        string
+		request            *http.Request
+		maxBodyBytes       int64
+		verifiedKey        *models.APIKey
+		verifyError        error
+		expectedSigned     *models.SignedRequest
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			description: "should let through a score signed by a write key",
+			request: func() *http.Request {
+				r := httptest.NewRequest("POST", "/user/1/score?source=game", bytes.NewBufferString(`{"total": 100}`))
+				r.Header.Set("X-Api-Key", "server")
+				r.Header.Set("X-Timestamp", "1646136000")
+				r.Header.Set("X-Nonce", "n1")
+				r.Header.Set("X-Signature", "abc")
+				return r
+			}(),
+			verifiedKey: &models.APIKey{ID: "server", Scope: models.ScopeWrite},
+			expectedSigned: &models.SignedRequest{
+				KeyID:     "server",
+				Timestamp: "1646136000",
+				Nonce:     "n1",
+				Signature: "abc",
+				Method:    "POST",
+				Path:      "/user/1/score?source=game",
+				Body:      []byte(`{"total": 100}`),
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"total": 100}`,
+		},
+		{
+			description:        "should let through a ranking signed by a read key",
+			request:            httptest.NewRequest("GET", "/ranking?type=top10", nil),
+			verifiedKey:        &models.APIKey{ID: "site", Scope: models.ScopeRead},
+			expectedSigned:     &models.SignedRequest{Method: "GET", Path: "/ranking?type=top10", Body: []byte{}},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "should refuse a score signed by a read key",
+			request:            httptest.NewRequest("POST", "/user/1/score", bytes.NewBufferString(`{"total": 100}`)),
+			verifiedKey:        &models.APIKey{ID: "site", Scope: models.ScopeRead},
+			expectedSigned:     &models.SignedRequest{Method: "POST", Path: "/user/1/score", Body: []byte(`{"total": 100}`)},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			description:        "should refuse an admin request signed by a write key",
+			request:            httptest.NewRequest("GET", "/admin/reviews", nil),
+			verifiedKey:        &models.APIKey{ID: "server", Scope: models.ScopeWrite},
+			expectedSigned:     &models.SignedRequest{Method: "GET", Path: "/admin/reviews", Body: []byte{}},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			description:        "should let through an admin request signed by an admin key",
+			request:            httptest.NewRequest("GET", "/admin/reviews", nil),
+			verifiedKey:        &models.APIKey{ID: "ops", Scope: models.ScopeAdmin},
+			expectedSigned:     &models.SignedRequest{Method: "GET", Path: "/admin/reviews", Body: []byte{}},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "should refuse a request that is not signed",
+			request:            httptest.NewRequest("GET", "/ranking?type=top10", nil),
+			verifyError:        models.NewUnauthorizedError(models.CodeMissingSignature, "Requests must be signed with the X-Api-Key, X-Timestamp, X-Nonce and X-Signature headers."),
+			expectedSigned:     &models.SignedRequest{Method: "GET", Path: "/ranking?type=top10", Body: []byte{}},
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			description:        "should refuse a body above the bound before verifying it",
+			request:            httptest.NewRequest("POST", "/user/1/score", bytes.NewBufferString(`{"total": 100}`)),
+			maxBodyBytes:       10,
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tc := range cases {
+		core := &models.Core{
+			MaxBodyBytes: tc.maxBodyBytes,
+			Auth: &mocks.AuthServiceMock{
+				VerifyFunc: func(ctx context.Context, request *models.SignedRequest) (*models.APIKey, error) {
+					assert.Equal(t, tc.expectedSigned, request, tc.description)
+					return tc.verifiedKey, tc.verifyError
+				},
+			},
+			RequestResponse: &mocks.RequestResponseMock{
+				HandleErrorFunc: func(err error, w http.ResponseWriter, r *http.Request, status int) {
+					w.WriteHeader(status)
+				},
+			},
+		}
+		basicHandlers := BasicHandlers{core: core}
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err, tc.description)
+			assert.Equal(t, tc.expectedBody, string(body), "should hand over the body: "+tc.description)
+			w.WriteHeader(http.StatusOK)
+		})
+
+		writer := httptest.NewRecorder()
+		basicHandlers.Authenticate(next).ServeHTTP(writer, tc.request)
+		assert.Equal(t, tc.expectedStatusCode, writer.Code, tc.description)
+	}
+}
@@ -5,8 +5,6 @@ import (
 	"net/http"
 	"strings"
 
-	"log"
-
 	"github.com/gorilla/mux"
 	"github.com/pedrocmart/leaderboard-service/models"
 )
@@ -24,9 +22,66 @@ func ConnectBasic(router *mux.Router, core *models.Core) error {
 		return fmt.Errorf("Could not connect default http mux handlers since router is nil")
 	}
 	basicAPI := BasicHandlers{core: core}
+	//the requests get their id first, then they are logged and instrumented,
+	//so the ones refused by the other middlewares are logged and counted too
+	outer := []mux.MiddlewareFunc{basicAPI.RequestID, basicAPI.LogRequests}
+	if core.Metrics != nil {
+		outer = append(outer, basicAPI.Instrument)
+	}
+	router.Use(outer...)
+	//the requests are authenticated next, so the rate limits can count them by their key
+	if core.Auth != nil {
+		router.Use(basicAPI.Authenticate)
+	}
+	router.Use(basicAPI.AdminOnly)
+	if core.RateLimits != nil {
+		router.Use(basicAPI.RateLimit)
+	}
+	router.HandleFunc("/leaderboards", basicAPI.HandleGetLeaderboards).Methods("GET")
+	router.HandleFunc("/leaderboards", basicAPI.HandleCreateLeaderboard).Methods("POST")
+	router.HandleFunc("/leaderboards/{board}", basicAPI.HandleDeleteLeaderboard).Methods("DELETE")
+	router.HandleFunc("/leaderboards/{board}/user/{user_id}/score", basicAPI.HandleSubmitScore).Methods("POST")
+	router.HandleFunc("/leaderboards/{board}/scores/batch", basicAPI.HandleSubmitScores).Methods("POST")
+	router.HandleFunc("/leaderboards/{board}/ranking", basicAPI.HandleGetRanking).Methods("GET")
+	router.HandleFunc("/leaderboards/{board}/ranking/stream", basicAPI.HandleStreamRanking).Methods("GET")
+	router.HandleFunc("/leaderboards/{board}/ranking/pages", basicAPI.HandleGetRankingPage).Methods("GET")
+	router.HandleFunc("/leaderboards/{board}/ranking/stats", basicAPI.HandleGetRankingStats).Methods("GET")
+	router.HandleFunc("/leaderboards/{board}/user/{user_id}/rank", basicAPI.HandleGetUserRank).Methods("GET")
+	router.HandleFunc("/leaderboards/{board}/user/{user_id}/history", basicAPI.HandleGetUserHistory).Methods("GET")
+	//routes without a board are aliases for the default leaderboard
 	router.HandleFunc("/user/{user_id}/score", basicAPI.HandleSubmitScore).Methods("POST")
+	router.HandleFunc("/scores/batch", basicAPI.HandleSubmitScores).Methods("POST")
 	router.HandleFunc("/ranking", basicAPI.HandleGetRanking).Methods("GET")
+	router.HandleFunc("/ranking/stream", basicAPI.HandleStreamRanking).Methods("GET")
+	router.HandleFunc("/ranking/pages", basicAPI.HandleGetRankingPage).Methods("GET")
+	router.HandleFunc("/ranking/stats", basicAPI.HandleGetRankingStats).Methods("GET")
+	router.HandleFunc("/user/{user_id}/rank", basicAPI.HandleGetUserRank).Methods("GET")
+	router.HandleFunc("/user/{user_id}/history", basicAPI.HandleGetUserHistory).Methods("GET")
+	router.HandleFunc("/admin/reviews", basicAPI.HandleGetReviews).Methods("GET")
+	router.HandleFunc("/admin/rate-limits", basicAPI.HandleGetRateLimits).Methods("GET")
+	router.HandleFunc("/admin/leaderboards/{board}/users/{user_id}", basicAPI.HandleDeleteUser).Methods("DELETE")
+	router.HandleFunc("/admin/leaderboards/{board}/users/{user_id}/reset", basicAPI.HandleResetUser).Methods("POST")
+	router.HandleFunc("/admin/leaderboards/{board}/users/{user_id}/freeze", basicAPI.HandleFreezeUser).Methods("POST")
+	router.HandleFunc("/admin/leaderboards/{board}/users/{user_id}/freeze", basicAPI.HandleUnfreezeUser).Methods("DELETE")
+	router.HandleFunc("/admin/leaderboards/{board}/users/{user_id}/merge", basicAPI.HandleMergeUsers).Methods("POST")
+	router.HandleFunc("/admin/leaderboards/{board}/frozen", basicAPI.HandleGetFrozenUsers).Methods("GET")
+	router.HandleFunc("/admin/leaderboards/{board}/reset", basicAPI.HandleResetLeaderboard).Methods("POST")
+	router.HandleFunc("/admin/users/{user_id}", basicAPI.HandleDeleteUser).Methods("DELETE")
+	router.HandleFunc("/admin/users/{user_id}/reset", basicAPI.HandleResetUser).Methods("POST")
+	router.HandleFunc("/admin/users/{user_id}/freeze", basicAPI.HandleFreezeUser).Methods("POST")
+	router.HandleFunc("/admin/users/{user_id}/freeze", basicAPI.HandleUnfreezeUser).Methods("DELETE")
+	router.HandleFunc("/admin/users/{user_id}/merge", basicAPI.HandleMergeUsers).Methods("POST")
+	router.HandleFunc("/admin/frozen", basicAPI.HandleGetFrozenUsers).Methods("GET")
+	router.HandleFunc("/admin/reset", basicAPI.HandleResetLeaderboard).Methods("POST")
+	router.HandleFunc(metricsPath, basicAPI.HandleMetrics).Methods("GET")
+	router.HandleFunc(healthPath, basicAPI.HandleHealth).Methods("GET")
+	router.HandleFunc(readyPath, basicAPI.HandleReady).Methods("GET")
+	router.HandleFunc(versionPath, basicAPI.HandleVersion).Methods("GET")
+	//the middlewares only run on the routes that matched, so the other requests go through the outer ones here
 	router.NotFoundHandler = http.HandlerFunc(basicAPI.NotFound)
+	for i := len(outer) - 1; i >= 0; i-- {
+		router.NotFoundHandler = outer[i](router.NotFoundHandler)
+	}
 	return nil
 }
 
@@ -45,19 +100,45 @@ func (api *BasicHandlers) HandleSubmitScore(w http.ResponseWriter, r *http.Reque
 	}
 
 	submitScoreRequest := new(models.SubmitScoreRequest)
-	err := api.core.RequestResponse.ReadBodyAsJSON(r, submitScoreRequest)
+	err := api.readBody(r, submitScoreRequest)
 	if err != nil {
-		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
 		return
 	}
 
-	result, err := api.core.Service.HandleSubmitScore(r.Context(), submitScoreRequest, userId)
+	submitScoreRequest.Source = clientSource(r)
+
+	result, err := api.core.Service.HandleSubmitScore(r.Context(), boardFromVars(r), submitScoreRequest, userId)
 	if err != nil {
-		log.Printf("error while submiting score: %s", err.Error())
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
+	api.core.RequestResponse.HandleResponse(result, w, r, http.StatusOK)
+}
+
+func (api *BasicHandlers) HandleSubmitScores(w http.ResponseWriter, r *http.Request) {
+	if api.core.Service == nil {
+		err := fmt.Errorf("Service is nil")
 		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
 		return
 	}
 
+	submitScoresRequest := new(models.SubmitScoresRequest)
+	err := api.readBody(r, submitScoresRequest)
+	if err != nil {
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
+	submitScoresRequest.Source = clientSource(r)
+
+	result, err := api.core.Service.HandleSubmitScores(r.Context(), boardFromVars(r), submitScoresRequest)
+	if err != nil {
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
 	api.core.RequestResponse.HandleResponse(result, w, r, http.StatusOK)
 }
 
@@ -70,27 +151,249 @@ func (api *BasicHandlers) HandleGetRanking(w http.ResponseWriter, r *http.Reques
 
 	rankingType := r.URL.Query().Get("type")
 	if strings.TrimSpace(rankingType) == "" {
-		err := fmt.Errorf("A type must be included.")
+		err := models.NewValidationError(models.CodeInvalidRankingType, "A type must be included.")
+		api.core.RequestResponse.HandleError(err, w, r, http.StatusBadRequest)
+		return
+	}
+
+	getRankingRequest := &models.GetRankingRequest{
+		Type:     rankingType,
+		Period:   r.URL.Query().Get("period"),
+		PeriodID: r.URL.Query().Get("period_id"),
+	}
+
+	result, err := api.core.Service.HandleGetRanking(r.Context(), boardFromVars(r), getRankingRequest)
+	if err != nil {
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
+	api.core.RequestResponse.HandleResponse(result, w, r, http.StatusOK)
+}
+
+func (api *BasicHandlers) HandleGetRankingPage(w http.ResponseWriter, r *http.Request) {
+	if api.core.Service == nil {
+		err := fmt.Errorf("Service is nil")
 		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
 		return
 	}
 
-	result, err := api.core.Service.HandleGetRanking(r.Context(), rankingType)
+	getRankingPageRequest := &models.GetRankingPageRequest{
+		Limit:    r.URL.Query().Get("limit"),
+		Cursor:   r.URL.Query().Get("cursor"),
+		Period:   r.URL.Query().Get("period"),
+		PeriodID: r.URL.Query().Get("period_id"),
+	}
+
+	result, err := api.core.Service.HandleGetRankingPage(r.Context(), boardFromVars(r), getRankingPageRequest)
 	if err != nil {
-		log.Printf("error while getting ranking: %s", err.Error())
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
+	api.core.RequestResponse.HandleResponse(result, w, r, http.StatusOK)
+}
+
+func (api *BasicHandlers) HandleGetRankingStats(w http.ResponseWriter, r *http.Request) {
+	if api.core.Service == nil {
+		err := fmt.Errorf("Service is nil")
 		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
 		return
 	}
 
+	getRankingStatsRequest := &models.GetRankingStatsRequest{
+		Percentiles: r.URL.Query().Get("percentiles"),
+		Buckets:     r.URL.Query().Get("buckets"),
+		Period:      r.URL.Query().Get("period"),
+		PeriodID:    r.URL.Query().Get("period_id"),
+	}
+
+	result, err := api.core.Service.HandleGetRankingStats(r.Context(), boardFromVars(r), getRankingStatsRequest)
+	if err != nil {
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
 	api.core.RequestResponse.HandleResponse(result, w, r, http.StatusOK)
 }
 
-func (api *BasicHandlers) NotFound(w http.ResponseWriter, r *http.Request) {
+func (api *BasicHandlers) HandleGetUserRank(w http.ResponseWriter, r *http.Request) {
+	if api.core.Service == nil {
+		err := fmt.Errorf("Service is nil")
+		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
+		return
+	}
+	vars := mux.Vars(r)
+	userId, ok := vars["user_id"]
+	if !ok {
+		err := fmt.Errorf("user_id is missing in parameters")
+		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
+		return
+	}
+
+	result, err := api.core.Service.HandleGetUserRank(r.Context(), boardFromVars(r), userId)
+	if err != nil {
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
+	api.core.RequestResponse.HandleResponse(result, w, r, http.StatusOK)
+}
+
+func (api *BasicHandlers) HandleGetUserHistory(w http.ResponseWriter, r *http.Request) {
+	if api.core.Service == nil {
+		err := fmt.Errorf("Service is nil")
+		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
+		return
+	}
+	vars := mux.Vars(r)
+	userId, ok := vars["user_id"]
+	if !ok {
+		err := fmt.Errorf("user_id is missing in parameters")
+		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+	getUserHistoryRequest := &models.GetUserHistoryRequest{
+		From:   query.Get("from"),
+		To:     query.Get("to"),
+		Limit:  query.Get("limit"),
+		Offset: query.Get("offset"),
+	}
+
+	result, err := api.core.Service.HandleGetUserHistory(r.Context(), boardFromVars(r), userId, getUserHistoryRequest)
+	if err != nil {
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
+	api.core.RequestResponse.HandleResponse(result, w, r, http.StatusOK)
+}
+
+func (api *BasicHandlers) HandleGetLeaderboards(w http.ResponseWriter, r *http.Request) {
+	if api.core.Service == nil {
+		err := fmt.Errorf("Service is nil")
+		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
+		return
+	}
+
+	result, err := api.core.Service.HandleGetLeaderboards(r.Context())
+	if err != nil {
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
+	api.core.RequestResponse.HandleResponse(result, w, r, http.StatusOK)
+}
+
+func (api *BasicHandlers) HandleCreateLeaderboard(w http.ResponseWriter, r *http.Request) {
+	if api.core.Service == nil {
+		err := fmt.Errorf("Service is nil")
+		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
+		return
+	}
+
+	createLeaderboardRequest := new(models.CreateLeaderboardRequest)
+	err := api.readBody(r, createLeaderboardRequest)
+	if err != nil {
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
+	result, err := api.core.Service.HandleCreateLeaderboard(r.Context(), createLeaderboardRequest)
+	if err != nil {
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
+	api.core.RequestResponse.HandleResponse(result, w, r, http.StatusOK)
+}
+
+func (api *BasicHandlers) HandleDeleteLeaderboard(w http.ResponseWriter, r *http.Request) {
 	if api.core.Service == nil {
 		err := fmt.Errorf("Service is nil")
 		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
 		return
 	}
-	api.core.RequestResponse.HandleError(fmt.Errorf("404 not found"), w, r, http.StatusInternalServerError)
+
+	result, err := api.core.Service.HandleDeleteLeaderboard(r.Context(), boardFromVars(r))
+	if err != nil {
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
+	api.core.RequestResponse.HandleResponse(result, w, r, http.StatusOK)
+}
+
+//boardFromVars returns the leaderboard named in the route, or the default one for the routes without a board
+func boardFromVars(r *http.Request) string {
+	board, ok := mux.Vars(r)["board"]
+	if !ok {
+		return models.DefaultLeaderboard
+	}
+	return board
+}
+
+//clientSource returns the client that sent the request, from the X-Client-Id header or else its address
+func clientSource(r *http.Request) string {
+	if source := r.Header.Get("X-Client-Id"); source != "" {
+		return source
+	}
+	return r.RemoteAddr
+}
+
+//readBody reads the JSON body of the request into dest. A body above the bound keeps its own error,
+//and every other failure is an invalid JSON
+func (api *BasicHandlers) readBody(r *http.Request, dest interface{}) error {
+	err := api.core.RequestResponse.ReadBodyAsJSON(r, dest)
+	if err == nil || models.ErrorCode(err) != models.CodeInternal {
+		return err
+	}
+	return models.NewValidationError(models.CodeInvalidJSON, "The body must be a valid JSON: %s.", err)
+}
+
+func (api *BasicHandlers) HandleGetReviews(w http.ResponseWriter, r *http.Request) {
+	if api.core.Service == nil {
+		err := fmt.Errorf("Service is nil")
+		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
+		return
+	}
+
+	getReviewsRequest := &models.GetReviewsRequest{
+		Board:   r.URL.Query().Get("board"),
+		Verdict: r.URL.Query().Get("verdict"),
+		Limit:   r.URL.Query().Get("limit"),
+		Offset:  r.URL.Query().Get("offset"),
+	}
+
+	result, err := api.core.Service.HandleGetReviews(r.Context(), getReviewsRequest)
+	if err != nil {
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
+	api.core.RequestResponse.HandleResponse(result, w, r, http.StatusOK)
+}
+
+func (api *BasicHandlers) HandleGetRateLimits(w http.ResponseWriter, r *http.Request) {
+	if api.core.Service == nil {
+		err := fmt.Errorf("Service is nil")
+		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
+		return
+	}
+
+	result, err := api.core.Service.HandleGetRateLimits(r.Context())
+	if err != nil {
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
+	api.core.RequestResponse.HandleResponse(result, w, r, http.StatusOK)
+}
+
+func (api *BasicHandlers) NotFound(w http.ResponseWriter, r *http.Request) {
+	err := models.NewNotFoundError(models.CodeRouteNotFound, "404 not found")
+	api.core.RequestResponse.HandleError(err, w, r, http.StatusNotFound)
 	return
 }
@@ -3,6 +3,7 @@ package http
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -129,7 +130,7 @@ func TestHandleSubmitScore(t *testing.T) {
 			description:        "should return an error whilst trying to parse body",
 			expectedResult:     `{"userid":1,"score":100}`,
 			core:               &models.Core{},
-			expectedStatusCode: http.StatusInternalServerError,
+			expectedStatusCode: http.StatusBadRequest,
 			submitScoreResponse: &models.SubmitScoreResponse{
 				UserID: 1,
 				Score:  100,
@@ -174,7 +175,7 @@ func TestHandleSubmitScore(t *testing.T) {
 
 	for _, tc := range cases {
 		mockedService := mocks.ServiceMock{
-			HandleSubmitScoreFunc: func(contextMoqParam context.Context, submitScoreRequest *models.SubmitScoreRequest, s string) (*models.SubmitScoreResponse, error) {
+			HandleSubmitScoreFunc: func(ctx context.Context, board string, submitScoreRequest *models.SubmitScoreRequest, userId string) (*models.SubmitScoreResponse, error) {
 				return tc.submitScoreResponse, tc.submitScoreError
 			},
 		}
@@ -201,6 +202,100 @@ func TestHandleSubmitScore(t *testing.T) {
 	}
 }
 
+func TestHandleSubmitScores(t *testing.T) {
+	cases := []struct {
+		description        string
+		basicHandlers      BasicHandlers
+		submitScoresError  error
+		core               *models.Core
+		service            bool
+		writer             *httptest.ResponseRecorder
+		request            *http.Request
+		vars               map[string]string
+		expectedBoard      string
+		expectedStatusCode int
+		readJsonError      error
+	}{
+		{
+			description:        "should submit the scores to the default leaderboard",
+			core:               &models.Core{},
+			expectedStatusCode: http.StatusOK,
+			service:            true,
+			writer:             httptest.NewRecorder(),
+			request:            httptest.NewRequest("POST", "/scores/batch", bytes.NewReader([]byte(`{"scores":[{"user":1,"total":10}]}`))),
+			expectedBoard:      models.DefaultLeaderboard,
+		},
+		{
+			description:        "should submit the scores to the leaderboard of the route",
+			core:               &models.Core{},
+			expectedStatusCode: http.StatusOK,
+			service:            true,
+			writer:             httptest.NewRecorder(),
+			request:            httptest.NewRequest("POST", "/leaderboards/weekly/scores/batch", bytes.NewReader([]byte(`{"scores":[{"user":1,"total":10}]}`))),
+			vars:               map[string]string{"board": "weekly"},
+			expectedBoard:      "weekly",
+		},
+		{
+			description:        "should fail since the service is nil",
+			expectedStatusCode: http.StatusInternalServerError,
+			core:               &models.Core{},
+			service:            false,
+			writer:             httptest.NewRecorder(),
+			request:            httptest.NewRequest("POST", "/scores/batch", bytes.NewReader([]byte(`{"scores":[]}`))),
+		},
+		{
+			description:        "should return an error whilst trying to parse body",
+			core:               &models.Core{},
+			expectedStatusCode: http.StatusBadRequest,
+			readJsonError:      fmt.Errorf("mock-parse-json-error"),
+			service:            true,
+			writer:             httptest.NewRecorder(),
+			request:            httptest.NewRequest("POST", "/scores/batch", bytes.NewReader([]byte(`{"scores":`))),
+		},
+		{
+			description:        "should return an error submitting the scores",
+			core:               &models.Core{},
+			expectedStatusCode: http.StatusInternalServerError,
+			submitScoresError:  fmt.Errorf("mock-submitScores-error"),
+			service:            true,
+			writer:             httptest.NewRecorder(),
+			request:            httptest.NewRequest("POST", "/scores/batch", bytes.NewReader([]byte(`{"scores":[{"user":1,"total":10}]}`))),
+			expectedBoard:      models.DefaultLeaderboard,
+		},
+	}
+
+	for _, tc := range cases {
+		mockedService := mocks.ServiceMock{
+			HandleSubmitScoresFunc: func(ctx context.Context, board string, request *models.SubmitScoresRequest) (*models.SubmitScoresResponse, error) {
+				assert.Equal(t, tc.expectedBoard, board, tc.description)
+				return &models.SubmitScoresResponse{}, tc.submitScoresError
+			},
+		}
+		if tc.service {
+			tc.core.Service = &mockedService
+		}
+
+		requestResponseService := mocks.RequestResponseMock{
+			HandleErrorFunc: func(err error, w http.ResponseWriter, r *http.Request, status int) {
+				w.WriteHeader(status)
+			},
+			HandleResponseFunc: func(body interface{}, w http.ResponseWriter, r *http.Request, status int) {
+				w.WriteHeader(status)
+			},
+			ReadBodyAsJSONFunc: func(req *http.Request, dest interface{}) error {
+				return tc.readJsonError
+			},
+		}
+		tc.core.RequestResponse = &requestResponseService
+		tc.basicHandlers.core = tc.core
+		if tc.vars != nil {
+			tc.request = mux.SetURLVars(tc.request, tc.vars)
+		}
+		tc.basicHandlers.HandleSubmitScores(tc.writer, tc.request)
+		assert.Equal(t, tc.expectedStatusCode, tc.writer.Code, tc.description)
+	}
+}
+
 func TestHandleGetRanking(t *testing.T) {
 	cases := []struct {
 		description        string
@@ -240,7 +335,7 @@ func TestHandleGetRanking(t *testing.T) {
 			description:        "should return an error without a type",
 			expectedResult:     `{"userid":1,"score":100}`,
 			core:               &models.Core{},
-			expectedStatusCode: http.StatusInternalServerError,
+			expectedStatusCode: http.StatusBadRequest,
 			getRankingResponse: &models.GetRankingResponse{},
 			service:            true,
 			writer:             httptest.NewRecorder(),
@@ -262,7 +357,7 @@ func TestHandleGetRanking(t *testing.T) {
 
 	for _, tc := range cases {
 		mockedService := mocks.ServiceMock{
-			HandleGetRankingFunc: func(contextMoqParam context.Context, s string) (*models.GetRankingResponse, error) {
+			HandleGetRankingFunc: func(ctx context.Context, board string, request *models.GetRankingRequest) (*models.GetRankingResponse, error) {
 				return tc.getRankingResponse, tc.getRankingError
 			},
 		}
@@ -286,42 +381,56 @@ func TestHandleGetRanking(t *testing.T) {
 	}
 }
 
-func TestNotFound(t *testing.T) {
+func TestHandleGetRankingPage(t *testing.T) {
 	cases := []struct {
-		description                  string
-		basicHandlers                BasicHandlers
-		core                         *models.Core
-		writer                       *httptest.ResponseRecorder
-		request                      *http.Request
-		params                       httprouter.Params
-		expectedStatusCode           int
-		expectedNotFoundError        error
-		service                      bool
-		expectedRequestResponseError error
-		requestResponseWriter        http.ResponseWriter
+		description            string
+		basicHandlers          BasicHandlers
+		getRankingPageResponse *models.GetRankingPageResponse
+		getRankingPageError    error
+		core                   *models.Core
+		service                bool
+		writer                 *httptest.ResponseRecorder
+		request                *http.Request
+		expectedStatusCode     int
+		expectedRequest        *models.GetRankingPageRequest
 	}{
+		{
+			description:            "should get a page of the ranking",
+			core:                   &models.Core{},
+			expectedStatusCode:     http.StatusOK,
+			getRankingPageResponse: &models.GetRankingPageResponse{},
+			service:                true,
+			writer:                 httptest.NewRecorder(),
+			request:                httptest.NewRequest("GET", "/ranking/pages?limit=50&cursor=abc&period=daily&period_id=2022-03-01", nil),
+			expectedRequest:        &models.GetRankingPageRequest{Limit: "50", Cursor: "abc", Period: "daily", PeriodID: "2022-03-01"},
+		},
 		{
 			description:        "should fail since the service is nil",
-			params:             nil,
 			expectedStatusCode: http.StatusInternalServerError,
 			core:               &models.Core{},
 			service:            false,
 			writer:             httptest.NewRecorder(),
-			request:            httptest.NewRequest("GET", "/healthz", nil),
+			request:            httptest.NewRequest("GET", "/ranking/pages", nil),
 		},
 		{
-			description:           "should return error if version function errors out",
-			params:                nil,
-			expectedStatusCode:    http.StatusInternalServerError,
-			core:                  &models.Core{},
-			service:               true,
-			writer:                httptest.NewRecorder(),
-			request:               httptest.NewRequest("GET", "/healthz", nil),
-			expectedNotFoundError: errors.New("error"),
+			description:         "should return the status of an invalid cursor",
+			core:                &models.Core{},
+			expectedStatusCode:  http.StatusBadRequest,
+			getRankingPageError: models.NewValidationError(models.CodeInvalidCursor, "The cursor must be the next_cursor of a previous page."),
+			service:             true,
+			writer:              httptest.NewRecorder(),
+			request:             httptest.NewRequest("GET", "/ranking/pages?cursor=abc", nil),
+			expectedRequest:     &models.GetRankingPageRequest{Cursor: "abc"},
 		},
 	}
+
 	for _, tc := range cases {
-		mockedService := mocks.ServiceMock{}
+		mockedService := mocks.ServiceMock{
+			HandleGetRankingPageFunc: func(ctx context.Context, board string, request *models.GetRankingPageRequest) (*models.GetRankingPageResponse, error) {
+				assert.Equal(t, tc.expectedRequest, request, tc.description)
+				return tc.getRankingPageResponse, tc.getRankingPageError
+			},
+		}
 		if tc.service {
 			tc.core.Service = &mockedService
 		}
@@ -336,7 +445,767 @@ func TestNotFound(t *testing.T) {
 		}
 		tc.core.RequestResponse = &requestResponseService
 		tc.basicHandlers.core = tc.core
-		tc.basicHandlers.NotFound(tc.writer, tc.request)
+		tc.basicHandlers.HandleGetRankingPage(tc.writer, tc.request)
+		assert.Equal(t, tc.expectedStatusCode, tc.writer.Code, tc.description)
+	}
+}
+
+func TestHandleGetRankingStats(t *testing.T) {
+	cases := []struct {
+		description             string
+		basicHandlers           BasicHandlers
+		getRankingStatsResponse *models.GetRankingStatsResponse
+		getRankingStatsError    error
+		core                    *models.Core
+		service                 bool
+		writer                  *httptest.ResponseRecorder
+		request                 *http.Request
+		expectedStatusCode      int
+		expectedRequest         *models.GetRankingStatsRequest
+	}{
+		{
+			description:             "should get the stats of the ranking",
+			core:                    &models.Core{},
+			expectedStatusCode:      http.StatusOK,
+			getRankingStatsResponse: &models.GetRankingStatsResponse{},
+			service:                 true,
+			writer:                  httptest.NewRecorder(),
+			request:                 httptest.NewRequest("GET", "/ranking/stats?percentiles=50,75&buckets=5&period=daily&period_id=2022-03-01", nil),
+			expectedRequest:         &models.GetRankingStatsRequest{Percentiles: "50,75", Buckets: "5", Period: "daily", PeriodID: "2022-03-01"},
+		},
+		{
+			description:        "should fail since the service is nil",
+			expectedStatusCode: http.StatusInternalServerError,
+			core:               &models.Core{},
+			service:            false,
+			writer:             httptest.NewRecorder(),
+			request:            httptest.NewRequest("GET", "/ranking/stats", nil),
+		},
+		{
+			description:          "should return the status of invalid buckets",
+			core:                 &models.Core{},
+			expectedStatusCode:   http.StatusBadRequest,
+			getRankingStatsError: models.NewValidationError(models.CodeInvalidStatsQuery, "Buckets must be an integer between 1 and 100."),
+			service:              true,
+			writer:               httptest.NewRecorder(),
+			request:              httptest.NewRequest("GET", "/ranking/stats?buckets=0", nil),
+			expectedRequest:      &models.GetRankingStatsRequest{Buckets: "0"},
+		},
+	}
+
+	for _, tc := range cases {
+		mockedService := mocks.ServiceMock{
+			HandleGetRankingStatsFunc: func(ctx context.Context, board string, request *models.GetRankingStatsRequest) (*models.GetRankingStatsResponse, error) {
+				assert.Equal(t, tc.expectedRequest, request, tc.description)
+				return tc.getRankingStatsResponse, tc.getRankingStatsError
+			},
+		}
+		if tc.service {
+			tc.core.Service = &mockedService
+		}
+
+		requestResponseService := mocks.RequestResponseMock{
+			HandleErrorFunc: func(err error, w http.ResponseWriter, r *http.Request, status int) {
+				w.WriteHeader(status)
+			},
+			HandleResponseFunc: func(body interface{}, w http.ResponseWriter, r *http.Request, status int) {
+				w.WriteHeader(status)
+			},
+		}
+		tc.core.RequestResponse = &requestResponseService
+		tc.basicHandlers.core = tc.core
+		tc.basicHandlers.HandleGetRankingStats(tc.writer, tc.request)
+		assert.Equal(t, tc.expectedStatusCode, tc.writer.Code, tc.description)
+	}
+}
+
+func TestHandleGetUserRank(t *testing.T) {
+	cases := []struct {
+		description        string
+		basicHandlers      BasicHandlers
+		getUserRankError   error
+		core               *models.Core
+		service            bool
+		writer             *httptest.ResponseRecorder
+		request            *http.Request
+		expectedStatusCode int
+		vars               map[string]string
+	}{
+		{
+			description:        "should get the rank of the user",
+			core:               &models.Core{},
+			expectedStatusCode: http.StatusOK,
+			service:            true,
+			writer:             httptest.NewRecorder(),
+			vars:               map[string]string{"user_id": "1"},
+			request:            httptest.NewRequest("GET", "/user/1/rank", nil),
+		},
+		{
+			description:        "should fail since the service is nil",
+			expectedStatusCode: http.StatusInternalServerError,
+			core:               &models.Core{},
+			service:            false,
+			writer:             httptest.NewRecorder(),
+			request:            httptest.NewRequest("GET", "/user/1/rank", nil),
+		},
+		{
+			description:        "should return an error when not sending an user_id",
+			core:               &models.Core{},
+			expectedStatusCode: http.StatusInternalServerError,
+			service:            true,
+			writer:             httptest.NewRecorder(),
+			request:            httptest.NewRequest("GET", "/user/1/rank", nil),
+		},
+		{
+			description:        "should return not found when the user does not exist",
+			core:               &models.Core{},
+			expectedStatusCode: http.StatusNotFound,
+			getUserRankError:   models.NewNotFoundError(models.CodeUserNotFound, "User 1 not found."),
+			service:            true,
+			writer:             httptest.NewRecorder(),
+			vars:               map[string]string{"user_id": "1"},
+			request:            httptest.NewRequest("GET", "/user/1/rank", nil),
+		},
+		{
+			description:        "should return an error getting the rank",
+			core:               &models.Core{},
+			expectedStatusCode: http.StatusInternalServerError,
+			getUserRankError:   fmt.Errorf("mock-getUserRank-error"),
+			service:            true,
+			writer:             httptest.NewRecorder(),
+			vars:               map[string]string{"user_id": "1"},
+			request:            httptest.NewRequest("GET", "/user/1/rank", nil),
+		},
+	}
+
+	for _, tc := range cases {
+		mockedService := mocks.ServiceMock{
+			HandleGetUserRankFunc: func(ctx context.Context, board string, userId string) (*models.GetUserRankResponse, error) {
+				return &models.GetUserRankResponse{}, tc.getUserRankError
+			},
+		}
+		if tc.service {
+			tc.core.Service = &mockedService
+		}
+
+		requestResponseService := mocks.RequestResponseMock{
+			HandleErrorFunc: func(err error, w http.ResponseWriter, r *http.Request, status int) {
+				w.WriteHeader(status)
+			},
+			HandleResponseFunc: func(body interface{}, w http.ResponseWriter, r *http.Request, status int) {
+				w.WriteHeader(status)
+			},
+		}
+		tc.request = mux.SetURLVars(tc.request, tc.vars)
+		tc.core.RequestResponse = &requestResponseService
+		tc.basicHandlers.core = tc.core
+		tc.basicHandlers.HandleGetUserRank(tc.writer, tc.request)
 		assert.Equal(t, tc.expectedStatusCode, tc.writer.Code, tc.description)
 	}
 }
+
+func TestHandleGetUserHistory(t *testing.T) {
+	cases := []struct {
+		description         string
+		basicHandlers       BasicHandlers
+		getUserHistoryError error
+		core                *models.Core
+		service             bool
+		writer              *httptest.ResponseRecorder
+		request             *http.Request
+		expectedStatusCode  int
+		vars                map[string]string
+	}{
+		{
+			description:        "should get the history of the user",
+			core:               &models.Core{},
+			expectedStatusCode: http.StatusOK,
+			service:            true,
+			writer:             httptest.NewRecorder(),
+			vars:               map[string]string{"user_id": "1"},
+			request:            httptest.NewRequest("GET", "/user/1/history?limit=10", nil),
+		},
+		{
+			description:        "should fail since the service is nil",
+			expectedStatusCode: http.StatusInternalServerError,
+			core:               &models.Core{},
+			service:            false,
+			writer:             httptest.NewRecorder(),
+			request:            httptest.NewRequest("GET", "/user/1/history?limit=10", nil),
+		},
+		{
+			description:        "should return an error when not sending an user_id",
+			core:               &models.Core{},
+			expectedStatusCode: http.StatusInternalServerError,
+			service:            true,
+			writer:             httptest.NewRecorder(),
+			request:            httptest.NewRequest("GET", "/user/1/history?limit=10", nil),
+		},
+		{
+			description:         "should return an error getting the history",
+			core:                &models.Core{},
+			expectedStatusCode:  http.StatusInternalServerError,
+			getUserHistoryError: fmt.Errorf("mock-getUserHistory-error"),
+			service:             true,
+			writer:              httptest.NewRecorder(),
+			vars:                map[string]string{"user_id": "1"},
+			request:             httptest.NewRequest("GET", "/user/1/history?limit=10", nil),
+		},
+	}
+
+	for _, tc := range cases {
+		mockedService := mocks.ServiceMock{
+			HandleGetUserHistoryFunc: func(ctx context.Context, board string, userId string, request *models.GetUserHistoryRequest) (*models.GetUserHistoryResponse, error) {
+				return &models.GetUserHistoryResponse{}, tc.getUserHistoryError
+			},
+		}
+		if tc.service {
+			tc.core.Service = &mockedService
+		}
+
+		requestResponseService := mocks.RequestResponseMock{
+			HandleErrorFunc: func(err error, w http.ResponseWriter, r *http.Request, status int) {
+				w.WriteHeader(status)
+			},
+			HandleResponseFunc: func(body interface{}, w http.ResponseWriter, r *http.Request, status int) {
+				w.WriteHeader(status)
+			},
+		}
+		tc.request = mux.SetURLVars(tc.request, tc.vars)
+		tc.core.RequestResponse = &requestResponseService
+		tc.basicHandlers.core = tc.core
+		tc.basicHandlers.HandleGetUserHistory(tc.writer, tc.request)
+		assert.Equal(t, tc.expectedStatusCode, tc.writer.Code, tc.description)
+	}
+}
+
+func TestHandleGetReviews(t *testing.T) {
+	cases := []struct {
+		description        string
+		basicHandlers      BasicHandlers
+		getReviewsResponse *models.GetReviewsResponse
+		getReviewsError    error
+		core               *models.Core
+		service            bool
+		writer             *httptest.ResponseRecorder
+		request            *http.Request
+		expectedStatusCode int
+		expectedRequest    *models.GetReviewsRequest
+	}{
+		{
+			description:        "should get the reviews",
+			core:               &models.Core{},
+			expectedStatusCode: http.StatusOK,
+			getReviewsResponse: &models.GetReviewsResponse{},
+			service:            true,
+			writer:             httptest.NewRecorder(),
+			request:            httptest.NewRequest("GET", "/admin/reviews?board=weekly&verdict=flagged&limit=10&offset=5", nil),
+			expectedRequest:    &models.GetReviewsRequest{Board: "weekly", Verdict: "flagged", Limit: "10", Offset: "5"},
+		},
+		{
+			description:        "should fail since the service is nil",
+			expectedStatusCode: http.StatusInternalServerError,
+			core:               &models.Core{},
+			service:            false,
+			writer:             httptest.NewRecorder(),
+			request:            httptest.NewRequest("GET", "/admin/reviews", nil),
+		},
+		{
+			description:        "should return the status of an invalid verdict",
+			core:               &models.Core{},
+			expectedStatusCode: http.StatusBadRequest,
+			getReviewsError:    models.NewValidationError(models.CodeInvalidReviewQuery, "The only verdicts accepted are: rejected and flagged."),
+			service:            true,
+			writer:             httptest.NewRecorder(),
+			request:            httptest.NewRequest("GET", "/admin/reviews?verdict=banned", nil),
+			expectedRequest:    &models.GetReviewsRequest{Verdict: "banned"},
+		},
+	}
+
+	for _, tc := range cases {
+		mockedService := mocks.ServiceMock{
+			HandleGetReviewsFunc: func(ctx context.Context, request *models.GetReviewsRequest) (*models.GetReviewsResponse, error) {
+				assert.Equal(t, tc.expectedRequest, request, tc.description)
+				return tc.getReviewsResponse, tc.getReviewsError
+			},
+		}
+		if tc.service {
+			tc.core.Service = &mockedService
+		}
+
+		requestResponseService := mocks.RequestResponseMock{
+			HandleErrorFunc: func(err error, w http.ResponseWriter, r *http.Request, status int) {
+				w.WriteHeader(status)
+			},
+			HandleResponseFunc: func(body interface{}, w http.ResponseWriter, r *http.Request, status int) {
+				w.WriteHeader(status)
+			},
+		}
+		tc.core.RequestResponse = &requestResponseService
+		tc.basicHandlers.core = tc.core
+		tc.basicHandlers.HandleGetReviews(tc.writer, tc.request)
+		assert.Equal(t, tc.expectedStatusCode, tc.writer.Code, tc.description)
+	}
+}
+
+func TestHandleGetRateLimits(t *testing.T) {
+	cases := []struct {
+		description        string
+		getRateLimitsError error
+		service            bool
+		expectedStatusCode int
+	}{
+		{
+			description:        "should get the rate limits",
+			service:            true,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "should fail since the service is nil",
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			description:        "should return the status of disabled rate limits",
+			service:            true,
+			getRateLimitsError: models.NewNotFoundError(models.CodeRateLimitsNotEnabled, "The rate limits are not enabled."),
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		core := &models.Core{
+			RequestResponse: &mocks.RequestResponseMock{
+				HandleErrorFunc: func(err error, w http.ResponseWriter, r *http.Request, status int) {
+					w.WriteHeader(status)
+				},
+				HandleResponseFunc: func(body interface{}, w http.ResponseWriter, r *http.Request, status int) {
+					w.WriteHeader(status)
+				},
+			},
+		}
+		if tc.service {
+			core.Service = &mocks.ServiceMock{
+				HandleGetRateLimitsFunc: func(ctx context.Context) (*models.GetRateLimitsResponse, error) {
+					return &models.GetRateLimitsResponse{}, tc.getRateLimitsError
+				},
+			}
+		}
+		basicHandlers := BasicHandlers{core: core}
+		writer := httptest.NewRecorder()
+		basicHandlers.HandleGetRateLimits(writer, httptest.NewRequest("GET", "/admin/rate-limits", nil))
+		assert.Equal(t, tc.expectedStatusCode, writer.Code, tc.description)
+	}
+}
+
+func TestHandleGetLeaderboards(t *testing.T) {
+	cases := []struct {
+		description             string
+		basicHandlers           BasicHandlers
+		getLeaderboardsResponse *models.GetLeaderboardsResponse
+		getLeaderboardsError    error
+		core                    *models.Core
+		service                 bool
+		writer                  *httptest.ResponseRecorder
+		request                 *http.Request
+		expectedStatusCode      int
+	}{
+		{
+			description:             "should get the leaderboards",
+			core:                    &models.Core{},
+			expectedStatusCode:      http.StatusOK,
+			getLeaderboardsResponse: &models.GetLeaderboardsResponse{Leaderboards: []string{"default"}},
+			service:                 true,
+			writer:                  httptest.NewRecorder(),
+			request:                 httptest.NewRequest("GET", "/leaderboards", nil),
+		},
+		{
+			description:        "should fail since the service is nil",
+			expectedStatusCode: http.StatusInternalServerError,
+			core:               &models.Core{},
+			service:            false,
+			writer:             httptest.NewRecorder(),
+			request:            httptest.NewRequest("GET", "/leaderboards", nil),
+		},
+		{
+			description:          "should return an error getting the leaderboards",
+			core:                 &models.Core{},
+			expectedStatusCode:   http.StatusInternalServerError,
+			getLeaderboardsError: fmt.Errorf("mock-getLeaderboards-error"),
+			service:              true,
+			writer:               httptest.NewRecorder(),
+			request:              httptest.NewRequest("GET", "/leaderboards", nil),
+		},
+	}
+
+	for _, tc := range cases {
+		mockedService := mocks.ServiceMock{
+			HandleGetLeaderboardsFunc: func(ctx context.Context) (*models.GetLeaderboardsResponse, error) {
+				return tc.getLeaderboardsResponse, tc.getLeaderboardsError
+			},
+		}
+		if tc.service {
+			tc.core.Service = &mockedService
+		}
+
+		requestResponseService := mocks.RequestResponseMock{
+			HandleErrorFunc: func(err error, w http.ResponseWriter, r *http.Request, status int) {
+				w.WriteHeader(status)
+			},
+			HandleResponseFunc: func(body interface{}, w http.ResponseWriter, r *http.Request, status int) {
+				w.WriteHeader(status)
+			},
+		}
+		tc.core.RequestResponse = &requestResponseService
+		tc.basicHandlers.core = tc.core
+		tc.basicHandlers.HandleGetLeaderboards(tc.writer, tc.request)
+		assert.Equal(t, tc.expectedStatusCode, tc.writer.Code, tc.description)
+	}
+}
+
+func TestHandleCreateLeaderboard(t *testing.T) {
+	cases := []struct {
+		description            string
+		basicHandlers          BasicHandlers
+		createLeaderboardError error
+		core                   *models.Core
+		service                bool
+		writer                 *httptest.ResponseRecorder
+		request                *http.Request
+		expectedStatusCode     int
+		readJsonError          error
+	}{
+		{
+			description:        "should create a leaderboard",
+			core:               &models.Core{},
+			expectedStatusCode: http.StatusOK,
+			service:            true,
+			writer:             httptest.NewRecorder(),
+			request:            httptest.NewRequest("POST", "/leaderboards", bytes.NewReader([]byte(`{"name":"weekly"}`))),
+		},
+		{
+			description:        "should fail since the service is nil",
+			expectedStatusCode: http.StatusInternalServerError,
+			core:               &models.Core{},
+			service:            false,
+			writer:             httptest.NewRecorder(),
+			request:            httptest.NewRequest("POST", "/leaderboards", bytes.NewReader([]byte(`{"name":"weekly"}`))),
+		},
+		{
+			description:        "should return an error whilst trying to parse body",
+			core:               &models.Core{},
+			expectedStatusCode: http.StatusBadRequest,
+			readJsonError:      fmt.Errorf("mock-parse-json-error"),
+			service:            true,
+			writer:             httptest.NewRecorder(),
+			request:            httptest.NewRequest("POST", "/leaderboards", bytes.NewReader([]byte(`{"name":`))),
+		},
+		{
+			description:            "should return a conflict when the leaderboard already exists",
+			core:                   &models.Core{},
+			expectedStatusCode:     http.StatusConflict,
+			createLeaderboardError: models.NewConflictError(models.CodeLeaderboardExists, "Leaderboard weekly already exists."),
+			service:                true,
+			writer:                 httptest.NewRecorder(),
+			request:                httptest.NewRequest("POST", "/leaderboards", bytes.NewReader([]byte(`{"name":"weekly"}`))),
+		},
+		{
+			description:            "should return an error creating the leaderboard",
+			core:                   &models.Core{},
+			expectedStatusCode:     http.StatusInternalServerError,
+			createLeaderboardError: fmt.Errorf("mock-createLeaderboard-error"),
+			service:                true,
+			writer:                 httptest.NewRecorder(),
+			request:                httptest.NewRequest("POST", "/leaderboards", bytes.NewReader([]byte(`{"name":"weekly"}`))),
+		},
+	}
+
+	for _, tc := range cases {
+		mockedService := mocks.ServiceMock{
+			HandleCreateLeaderboardFunc: func(ctx context.Context, request *models.CreateLeaderboardRequest) (*models.LeaderboardResponse, error) {
+				return &models.LeaderboardResponse{Name: request.Name}, tc.createLeaderboardError
+			},
+		}
+		if tc.service {
+			tc.core.Service = &mockedService
+		}
+
+		requestResponseService := mocks.RequestResponseMock{
+			HandleErrorFunc: func(err error, w http.ResponseWriter, r *http.Request, status int) {
+				w.WriteHeader(status)
+			},
+			HandleResponseFunc: func(body interface{}, w http.ResponseWriter, r *http.Request, status int) {
+				w.WriteHeader(status)
+			},
+			ReadBodyAsJSONFunc: func(req *http.Request, dest interface{}) error {
+				return tc.readJsonError
+			},
+		}
+		tc.core.RequestResponse = &requestResponseService
+		tc.basicHandlers.core = tc.core
+		tc.basicHandlers.HandleCreateLeaderboard(tc.writer, tc.request)
+		assert.Equal(t, tc.expectedStatusCode, tc.writer.Code, tc.description)
+	}
+}
+
+func TestHandleDeleteLeaderboard(t *testing.T) {
+	cases := []struct {
+		description            string
+		basicHandlers          BasicHandlers
+		deleteLeaderboardError error
+		core                   *models.Core
+		service                bool
+		writer                 *httptest.ResponseRecorder
+		request                *http.Request
+		expectedStatusCode     int
+		expectedBoard          string
+		vars                   map[string]string
+	}{
+		{
+			description:        "should delete a leaderboard",
+			core:               &models.Core{},
+			expectedStatusCode: http.StatusOK,
+			service:            true,
+			writer:             httptest.NewRecorder(),
+			vars:               map[string]string{"board": "weekly"},
+			expectedBoard:      "weekly",
+			request:            httptest.NewRequest("DELETE", "/leaderboards/weekly", nil),
+		},
+		{
+			description:        "should fail since the service is nil",
+			expectedStatusCode: http.StatusInternalServerError,
+			core:               &models.Core{},
+			service:            false,
+			writer:             httptest.NewRecorder(),
+			request:            httptest.NewRequest("DELETE", "/leaderboards/weekly", nil),
+		},
+		{
+			description:            "should return an error deleting the leaderboard",
+			core:                   &models.Core{},
+			expectedStatusCode:     http.StatusInternalServerError,
+			deleteLeaderboardError: fmt.Errorf("mock-deleteLeaderboard-error"),
+			service:                true,
+			writer:                 httptest.NewRecorder(),
+			vars:                   map[string]string{"board": "weekly"},
+			expectedBoard:          "weekly",
+			request:                httptest.NewRequest("DELETE", "/leaderboards/weekly", nil),
+		},
+	}
+
+	for _, tc := range cases {
+		mockedService := mocks.ServiceMock{
+			HandleDeleteLeaderboardFunc: func(ctx context.Context, board string) (*models.LeaderboardResponse, error) {
+				assert.Equal(t, tc.expectedBoard, board, tc.description)
+				return &models.LeaderboardResponse{Name: board}, tc.deleteLeaderboardError
+			},
+		}
+		if tc.service {
+			tc.core.Service = &mockedService
+		}
+
+		requestResponseService := mocks.RequestResponseMock{
+			HandleErrorFunc: func(err error, w http.ResponseWriter, r *http.Request, status int) {
+				w.WriteHeader(status)
+			},
+			HandleResponseFunc: func(body interface{}, w http.ResponseWriter, r *http.Request, status int) {
+				w.WriteHeader(status)
+			},
+		}
+		tc.request = mux.SetURLVars(tc.request, tc.vars)
+		tc.core.RequestResponse = &requestResponseService
+		tc.basicHandlers.core = tc.core
+		tc.basicHandlers.HandleDeleteLeaderboard(tc.writer, tc.request)
+		assert.Equal(t, tc.expectedStatusCode, tc.writer.Code, tc.description)
+	}
+}
+
+func TestBoardFromVars(t *testing.T) {
+	cases := []struct {
+		description   string
+		vars          map[string]string
+		expectedBoard string
+	}{
+		{
+			description:   "should return the board in the route",
+			vars:          map[string]string{"board": "weekly", "user_id": "1"},
+			expectedBoard: "weekly",
+		},
+		{
+			description:   "should return the default board for routes without a board",
+			vars:          map[string]string{"user_id": "1"},
+			expectedBoard: models.DefaultLeaderboard,
+		},
+	}
+	for _, tc := range cases {
+		request := mux.SetURLVars(httptest.NewRequest("GET", "/ranking", nil), tc.vars)
+		assert.Equal(t, tc.expectedBoard, boardFromVars(request), tc.description)
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	cases := []struct {
+		description                  string
+		basicHandlers                BasicHandlers
+		core                         *models.Core
+		writer                       *httptest.ResponseRecorder
+		request                      *http.Request
+		params                       httprouter.Params
+		expectedStatusCode           int
+		expectedNotFoundError        error
+		service                      bool
+		expectedRequestResponseError error
+		requestResponseWriter        http.ResponseWriter
+	}{
+		{
+			description:        "should return not found without a service",
+			params:             nil,
+			expectedStatusCode: http.StatusNotFound,
+			core:               &models.Core{},
+			service:            false,
+			writer:             httptest.NewRecorder(),
+			request:            httptest.NewRequest("GET", "/health", nil),
+		},
+		{
+			description:           "should return not found for an unknown route",
+			params:                nil,
+			expectedStatusCode:    http.StatusNotFound,
+			core:                  &models.Core{},
+			service:               true,
+			writer:                httptest.NewRecorder(),
+			request:               httptest.NewRequest("GET", "/health", nil),
+			expectedNotFoundError: errors.New("error"),
+		},
+	}
+	for _, tc := range cases {
+		mockedService := mocks.ServiceMock{}
+		if tc.service {
+			tc.core.Service = &mockedService
+		}
+
+		requestResponseService := mocks.RequestResponseMock{
+			HandleErrorFunc: func(err error, w http.ResponseWriter, r *http.Request, status int) {
+				w.WriteHeader(status)
+			},
+			HandleResponseFunc: func(body interface{}, w http.ResponseWriter, r *http.Request, status int) {
+				w.WriteHeader(status)
+			},
+		}
+		tc.core.RequestResponse = &requestResponseService
+		tc.basicHandlers.core = tc.core
+		tc.basicHandlers.NotFound(tc.writer, tc.request)
+		assert.Equal(t, tc.expectedStatusCode, tc.writer.Code, tc.description)
+	}
+}
+
+func TestErrorResponses(t *testing.T) {
+	cases := []struct {
+		description        string
+		serviceError       error
+		request            *http.Request
+		expectedStatusCode int
+		expectedResponse   models.Response
+	}{
+		{
+			description:        "should return bad request for a validation error",
+			serviceError:       models.NewValidationError(models.CodeInvalidRankingType, "The only formats accepted for type are: Top100, At100/3 and User123/3."),
+			request:            httptest.NewRequest("GET", "/ranking?type=bottom10", nil),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   models.Response{Message: "The only formats accepted for type are: Top100, At100/3 and User123/3.", Code: models.CodeInvalidRankingType},
+		},
+		{
+			description:        "should return not found for a missing leaderboard",
+			serviceError:       models.NewNotFoundError(models.CodeLeaderboardNotFound, "Leaderboard weekly not found."),
+			request:            httptest.NewRequest("GET", "/leaderboards/weekly/ranking?type=top10", nil),
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   models.Response{Message: "Leaderboard weekly not found.", Code: models.CodeLeaderboardNotFound},
+		},
+		{
+			description:        "should return internal server error for an untyped error, without its message",
+			serviceError:       fmt.Errorf("pq: relation \"users\" does not exist"),
+			request:            httptest.NewRequest("GET", "/ranking?type=top10", nil),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   models.Response{Message: "internal error", Code: models.CodeInternal},
+		},
+		{
+			description:        "should return the ranking",
+			request:            httptest.NewRequest("GET", "/ranking?type=top10", nil),
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "should return not found for an unknown route",
+			request:            httptest.NewRequest("GET", "/unknown", nil),
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   models.Response{Message: "404 not found", Code: models.CodeRouteNotFound},
+		},
+	}
+	for _, tc := range cases {
+		core := &models.Core{
+			Service: &mocks.ServiceMock{
+				HandleGetRankingFunc: func(ctx context.Context, board string, request *models.GetRankingRequest) (*models.GetRankingResponse, error) {
+					if tc.serviceError != nil {
+						return nil, tc.serviceError
+					}
+					return &models.GetRankingResponse{}, nil
+				},
+			},
+		}
+		core.ConnectResponseWriter()
+		router := mux.NewRouter()
+		ConnectBasic(router, core)
+
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, tc.request)
+		assert.Equal(t, tc.expectedStatusCode, writer.Code, tc.description)
+		if tc.expectedStatusCode != http.StatusOK {
+			response := models.Response{}
+			assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &response), tc.description)
+			assert.Equal(t, tc.expectedResponse, response, tc.description)
+		}
+	}
+}
+
+func TestReadBody(t *testing.T) {
+	cases := []struct {
+		description        string
+		body               string
+		expectedStatusCode int
+		expectedCode       string
+	}{
+		{
+			description:        "should read a body of the bound",
+			body:               `{"name":"weekly"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "should return an error for a body above the bound",
+			body:               `{"name":"monthly"}`,
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+			expectedCode:       models.CodeBodyTooLarge,
+		},
+		{
+			description:        "should return an error for an invalid JSON",
+			body:               `{"name":`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedCode:       models.CodeInvalidJSON,
+		},
+	}
+	for _, tc := range cases {
+		core := &models.Core{
+			Service: &mocks.ServiceMock{
+				HandleCreateLeaderboardFunc: func(ctx context.Context, request *models.CreateLeaderboardRequest) (*models.LeaderboardResponse, error) {
+					return &models.LeaderboardResponse{Name: request.Name}, nil
+				},
+			},
+			MaxBodyBytes: 17,
+		}
+		core.ConnectResponseWriter()
+		router := mux.NewRouter()
+		ConnectBasic(router, core)
+
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, httptest.NewRequest("POST", "/leaderboards", bytes.NewBufferString(tc.body)))
+		assert.Equal(t, tc.expectedStatusCode, writer.Code, tc.description)
+		response := models.Response{}
+		assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &response), tc.description)
+		assert.Equal(t, tc.expectedCode, response.Code, tc.description)
+	}
+}
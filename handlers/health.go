@@ -0,0 +1,53 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+const (
+	healthPath  = "/healthz"
+	readyPath   = "/readyz"
+	versionPath = "/version"
+)
+
+//probePaths are the routes of the probes of the orchestrator and of the deploys, which can't sign their requests,
+//must not be throttled and are sent too often to be logged
+var probePaths = map[string]bool{
+	healthPath:  true,
+	readyPath:   true,
+	versionPath: true,
+}
+
+//HandleHealth tells that the process is alive, without checking anything else, so it is not restarted
+//while the store is unreachable
+func (api *BasicHandlers) HandleHealth(w http.ResponseWriter, r *http.Request) {
+	api.core.RequestResponse.HandleResponse(&models.HealthResponse{Status: "ok"}, w, r, http.StatusOK)
+}
+
+//HandleReady tells whether the requests can be served, with 503 when a dependency failed its check
+func (api *BasicHandlers) HandleReady(w http.ResponseWriter, r *http.Request) {
+	if api.core.Service == nil {
+		err := fmt.Errorf("Service is nil")
+		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
+		return
+	}
+
+	result, err := api.core.Service.HandleGetReadiness(r.Context())
+	if err != nil {
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+
+	status := http.StatusOK
+	if !result.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	api.core.RequestResponse.HandleResponse(result, w, r, status)
+}
+
+func (api *BasicHandlers) HandleVersion(w http.ResponseWriter, r *http.Request) {
+	api.core.RequestResponse.HandleResponse(models.GetBuildInfo(), w, r, http.StatusOK)
+}
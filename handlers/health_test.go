@@ -0,0 +1,142 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pedrocmart/leaderboard-service/mocks"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleProbes(t *testing.T) {
+	cases := []struct {
+		description        string
+		path               string
+		service            bool
+		readiness          *models.ReadinessResponse
+		readinessError     error
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			description:        "should tell that the process is alive without the service",
+			path:               "/healthz",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"status":"ok"}`,
+		},
+		{
+			description:        "should tell that the service is ready",
+			path:               "/readyz",
+			service:            true,
+			readiness:          &models.ReadinessResponse{Ready: true, Checks: map[string]string{"store": models.CheckOK}},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"ready":true,"checks":{"store":"ok"}}`,
+		},
+		{
+			description:        "should tell that the service is not ready, with the checks that failed",
+			path:               "/readyz",
+			service:            true,
+			readiness:          &models.ReadinessResponse{Ready: false, Checks: map[string]string{"store": models.CheckOK, "persistence": "the persistence log is not open"}},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       `{"ready":false,"checks":{"persistence":"the persistence log is not open","store":"ok"}}`,
+		},
+		{
+			description:        "should return error when the readiness can't be checked",
+			path:               "/readyz",
+			service:            true,
+			readinessError:     fmt.Errorf("mock-error"),
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			description:        "should fail since the service is nil",
+			path:               "/readyz",
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range cases {
+		core := &models.Core{}
+		if tc.service {
+			core.Service = &mocks.ServiceMock{
+				HandleGetReadinessFunc: func(ctx context.Context) (*models.ReadinessResponse, error) {
+					return tc.readiness, tc.readinessError
+				},
+			}
+		}
+		core.ConnectResponseWriter()
+		router := mux.NewRouter()
+		ConnectBasic(router, core)
+
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, httptest.NewRequest("GET", tc.path, nil))
+		assert.Equal(t, tc.expectedStatusCode, writer.Code, tc.description)
+		if tc.expectedBody != "" {
+			assert.JSONEq(t, tc.expectedBody, writer.Body.String(), tc.description)
+		}
+	}
+}
+
+func TestHandleVersion(t *testing.T) {
+	defer func(version, commit, buildTime string) {
+		models.Version, models.Commit, models.BuildTime = version, commit, buildTime
+	}(models.Version, models.Commit, models.BuildTime)
+	models.Version, models.Commit, models.BuildTime = "v1.2.0", "4f1c9a0", "2026-10-17T10:00:00Z"
+
+	core := &models.Core{}
+	core.ConnectResponseWriter()
+	router := mux.NewRouter()
+	ConnectBasic(router, core)
+
+	writer := httptest.NewRecorder()
+	router.ServeHTTP(writer, httptest.NewRequest("GET", "/version", nil))
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	info := models.BuildInfo{}
+	assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &info))
+	assert.Equal(t, "v1.2.0", info.Version, "should return the version set at compile time")
+	assert.Equal(t, "4f1c9a0", info.Commit, "should return the commit set at compile time")
+	assert.Equal(t, "2026-10-17T10:00:00Z", info.BuildTime, "should return the build time set at compile time")
+	assert.NotEmpty(t, info.GoVersion, "should return the version of go the binary was built with")
+}
+
+func TestProbesBypass(t *testing.T) {
+	for _, path := range []string{"/healthz", "/readyz", "/version"} {
+		var log bytes.Buffer
+		core := &models.Core{
+			Service: &mocks.ServiceMock{
+				HandleGetReadinessFunc: func(ctx context.Context) (*models.ReadinessResponse, error) {
+					return &models.ReadinessResponse{Ready: true, Checks: map[string]string{}}, nil
+				},
+			},
+			Auth: &mocks.AuthServiceMock{
+				VerifyFunc: func(ctx context.Context, request *models.SignedRequest) (*models.APIKey, error) {
+					t.Fatalf("the probe %s was not expected to be verified", path)
+					return nil, nil
+				},
+			},
+			RateLimits: &mocks.RateLimitServiceMock{
+				AllowFunc: func(ctx context.Context, request *models.RateLimitRequest) (time.Duration, error) {
+					t.Fatalf("the probe %s was not expected to be throttled", path)
+					return 0, nil
+				},
+			},
+			Logger: models.NewJSONLogger(&log, models.LevelInfo),
+		}
+		core.ConnectResponseWriter()
+		router := mux.NewRouter()
+		ConnectBasic(router, core)
+
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, httptest.NewRequest("GET", path, nil))
+		assert.Equal(t, http.StatusOK, writer.Code, "should serve the probe "+path)
+		assert.NotEmpty(t, writer.Header().Get(requestIDHeader), "should give the probe "+path+" an id")
+		assert.Empty(t, log.String(), "should not log the probe "+path)
+	}
+}
@@ -0,0 +1,68 @@
+package http
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+//requestIDHeader carries the id of a request, from the client when it sends one and back in the response
+const requestIDHeader = "X-Request-ID"
+
+//validRequestID are the ids of the clients that are kept, the others are replaced so they can't forge the log
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+//RequestID gives every request an id, the one of its X-Request-ID header when it is valid,
+//which is sent back in the response and carried by the context of the request into the service and the store
+func (api *BasicHandlers) RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(requestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(models.WithRequestID(r.Context(), id)))
+	})
+}
+
+//newRequestID returns a random id of 32 hex characters
+func newRequestID() string {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		//the time is unique enough to follow a request through the log
+		return time.Now().UTC().Format("20060102150405.000000000")
+	}
+	return hex.EncodeToString(bytes)
+}
+
+//LogRequests logs every request once it is served, with its status, size and latency,
+//except for the probes, which would flood the log
+func (api *BasicHandlers) LogRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if probePaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		fields := models.LogFields{
+			"method":      r.Method,
+			"path":        r.URL.Path,
+			"status":      recorder.status,
+			"bytes":       recorder.bytes,
+			"duration_ms": float64(time.Since(start).Microseconds()) / 1000,
+			"remote":      r.RemoteAddr,
+		}
+		if route := mux.CurrentRoute(r); route != nil {
+			fields["route"], _ = route.GetPathTemplate()
+		}
+		api.core.GetLogger().Info(r.Context(), "request", fields)
+	})
+}
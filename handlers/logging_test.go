@@ -0,0 +1,224 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/pedrocmart/leaderboard-service/mocks"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+//logLines returns the messages of a JSON log, by their message
+func logLines(t *testing.T, log *bytes.Buffer) map[string]map[string]interface{} {
+	lines := make(map[string]map[string]interface{})
+	for _, line := range strings.Split(strings.TrimSpace(log.String()), "\n") {
+		fields := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(line), &fields); err != nil {
+			t.Fatalf("an error '%s' was not expected when reading the log line %s", err, line)
+		}
+		lines[fields["message"].(string)] = fields
+	}
+	return lines
+}
+
+func TestRequestID(t *testing.T) {
+	cases := []struct {
+		description string
+		requestID   string
+		expectedID  string
+	}{
+		{
+			description: "should keep the id of the client",
+			requestID:   "game-server.42:7",
+			expectedID:  "game-server.42:7",
+		},
+		{
+			description: "should give an id to a request without one",
+		},
+		{
+			description: "should replace an id that could forge the log",
+			requestID:   "42\"}\n{\"level\":\"error",
+		},
+	}
+	for _, tc := range cases {
+		var serviceID string
+		var log bytes.Buffer
+		core := &models.Core{
+			Service: &mocks.ServiceMock{
+				HandleGetRankingFunc: func(ctx context.Context, board string, request *models.GetRankingRequest) (*models.GetRankingResponse, error) {
+					serviceID = models.RequestIDFromContext(ctx)
+					return &models.GetRankingResponse{}, nil
+				},
+			},
+			Logger: models.NewJSONLogger(&log, models.LevelInfo),
+		}
+		core.ConnectResponseWriter()
+		router := mux.NewRouter()
+		ConnectBasic(router, core)
+
+		request := httptest.NewRequest("GET", "/ranking?type=top10", nil)
+		if tc.requestID != "" {
+			request.Header.Set(requestIDHeader, tc.requestID)
+		}
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, request)
+
+		id := writer.Header().Get(requestIDHeader)
+		if tc.expectedID != "" {
+			assert.Equal(t, tc.expectedID, id, tc.description)
+		} else {
+			assert.Len(t, id, 32, tc.description)
+		}
+		assert.Equal(t, id, serviceID, "should carry the id into the service")
+		assert.Equal(t, id, logLines(t, &log)["request"]["request_id"], "should log the request with its id")
+	}
+}
+
+func TestLogRequests(t *testing.T) {
+	cases := []struct {
+		description      string
+		request          *http.Request
+		expectedMessages []string
+		expectedFields   map[string]interface{}
+	}{
+		{
+			description:      "should log a request by its route",
+			request:          httptest.NewRequest("GET", "/leaderboards/weekly/ranking?type=top10", nil),
+			expectedMessages: []string{"request"},
+			expectedFields: map[string]interface{}{
+				"level":  "info",
+				"method": "GET",
+				"path":   "/leaderboards/weekly/ranking",
+				"route":  "/leaderboards/{board}/ranking",
+				"status": float64(http.StatusOK),
+				"bytes":  float64(len(`{"ranking":null}`)),
+			},
+		},
+		{
+			description:      "should log a request that did not match any route, with its error",
+			request:          httptest.NewRequest("GET", "/unknown", nil),
+			expectedMessages: []string{"request refused", "request"},
+			expectedFields: map[string]interface{}{
+				"level":  "info",
+				"method": "GET",
+				"path":   "/unknown",
+				"status": float64(http.StatusNotFound),
+			},
+		},
+	}
+	for _, tc := range cases {
+		var log bytes.Buffer
+		core := &models.Core{
+			Service: &mocks.ServiceMock{
+				HandleGetRankingFunc: func(ctx context.Context, board string, request *models.GetRankingRequest) (*models.GetRankingResponse, error) {
+					return &models.GetRankingResponse{}, nil
+				},
+			},
+			Logger: models.NewJSONLogger(&log, models.LevelInfo),
+		}
+		core.ConnectResponseWriter()
+		router := mux.NewRouter()
+		ConnectBasic(router, core)
+
+		router.ServeHTTP(httptest.NewRecorder(), tc.request)
+		lines := logLines(t, &log)
+		assert.Len(t, lines, len(tc.expectedMessages), tc.description)
+		for _, message := range tc.expectedMessages {
+			assert.Contains(t, lines, message, tc.description)
+		}
+		for name, value := range tc.expectedFields {
+			assert.Equal(t, value, lines["request"][name], tc.description+": "+name)
+		}
+	}
+}
+
+func TestLogBodies(t *testing.T) {
+	cases := []struct {
+		description    string
+		level          models.LogLevel
+		logBodyBytes   int
+		expectedBodies map[string]interface{}
+	}{
+		{
+			description:    "should log the bodies at the debug level, cut at the bound",
+			level:          models.LevelDebug,
+			logBodyBytes:   10,
+			expectedBodies: map[string]interface{}{"request body": `{"name":"w`, "response body": `{"name":"w`},
+		},
+		{
+			description:    "should log the whole bodies below the bound",
+			level:          models.LevelDebug,
+			logBodyBytes:   1024,
+			expectedBodies: map[string]interface{}{"request body": `{"name":"weekly"}`, "response body": `{"name":"weekly"}`},
+		},
+		{
+			description:  "should not log the bodies without a bound",
+			level:        models.LevelDebug,
+			logBodyBytes: 0,
+		},
+		{
+			description:  "should not log the bodies above the debug level",
+			level:        models.LevelInfo,
+			logBodyBytes: 1024,
+		},
+	}
+	for _, tc := range cases {
+		var log bytes.Buffer
+		core := &models.Core{
+			Service: &mocks.ServiceMock{
+				HandleCreateLeaderboardFunc: func(ctx context.Context, request *models.CreateLeaderboardRequest) (*models.LeaderboardResponse, error) {
+					return &models.LeaderboardResponse{Name: request.Name}, nil
+				},
+			},
+			Logger:       models.NewJSONLogger(&log, tc.level),
+			LogBodyBytes: tc.logBodyBytes,
+		}
+		core.ConnectResponseWriter()
+		router := mux.NewRouter()
+		ConnectBasic(router, core)
+
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/leaderboards", strings.NewReader(`{"name":"weekly"}`)))
+		lines := logLines(t, &log)
+		for _, message := range []string{"request body", "response body"} {
+			expected, ok := tc.expectedBodies[message]
+			if !ok {
+				assert.NotContains(t, lines, message, tc.description)
+				continue
+			}
+			assert.Equal(t, expected, lines[message]["body"], tc.description)
+			assert.Equal(t, float64(len(`{"name":"weekly"}`)), lines[message]["bytes"], tc.description)
+			assert.Equal(t, len(expected.(string)) < len(`{"name":"weekly"}`), lines[message]["truncated"] == true, tc.description)
+		}
+	}
+}
+
+func TestLogInternalErrors(t *testing.T) {
+	var log bytes.Buffer
+	core := &models.Core{
+		Service: &mocks.ServiceMock{
+			HandleGetRankingFunc: func(ctx context.Context, board string, request *models.GetRankingRequest) (*models.GetRankingResponse, error) {
+				return nil, errors.New("pq: relation \"users\" does not exist")
+			},
+		},
+		Logger: models.NewJSONLogger(&log, models.LevelInfo),
+	}
+	core.ConnectResponseWriter()
+	router := mux.NewRouter()
+	ConnectBasic(router, core)
+
+	writer := httptest.NewRecorder()
+	router.ServeHTTP(writer, httptest.NewRequest("GET", "/ranking?type=top10", nil))
+	assert.NotContains(t, writer.Body.String(), "relation", "should not tell the client the error of the database")
+
+	failed := logLines(t, &log)["request failed"]
+	assert.Equal(t, "pq: relation \"users\" does not exist", failed["error"], "should log the error of the database")
+	assert.Equal(t, writer.Header().Get(requestIDHeader), failed["request_id"], "should log the error with the id of the request")
+}
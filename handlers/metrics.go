@@ -0,0 +1,45 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+//metricsPath is the route of the metrics, which scrapers read without signing their requests
+const metricsPath = "/metrics"
+
+//unmatchedRoute is the route of the metrics of the requests that did not match any route
+const unmatchedRoute = "unmatched"
+
+//Instrument records the status and the latency of every request in the metrics, by the route it matched
+func (api *BasicHandlers) Instrument(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		route := unmatchedRoute
+		if current := mux.CurrentRoute(r); current != nil {
+			route, _ = current.GetPathTemplate()
+		}
+		api.core.Metrics.ObserveRequest(route, r.Method, recorder.status, time.Since(start))
+	})
+}
+
+func (api *BasicHandlers) HandleMetrics(w http.ResponseWriter, r *http.Request) {
+	if api.core.Metrics == nil {
+		err := fmt.Errorf("Metrics is nil")
+		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	err := api.core.Metrics.WriteMetrics(r.Context(), w)
+	if err != nil {
+		//the metrics of the core are read before anything is written, so the status can still be set
+		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,136 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pedrocmart/leaderboard-service/mocks"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstrument(t *testing.T) {
+	cases := []struct {
+		description        string
+		request            *http.Request
+		rankingError       error
+		expectedRoute      string
+		expectedStatusCode int
+	}{
+		{
+			description:        "should observe a request by the template of its route",
+			request:            httptest.NewRequest("GET", "/leaderboards/weekly/ranking?type=top10", nil),
+			expectedRoute:      "/leaderboards/{board}/ranking",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "should observe the status of a failed request",
+			request:            httptest.NewRequest("GET", "/ranking?type=top10", nil),
+			rankingError:       models.NewNotFoundError(models.CodeLeaderboardNotFound, "Leaderboard default not found."),
+			expectedRoute:      "/ranking",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description:        "should observe a request that did not match any route",
+			request:            httptest.NewRequest("GET", "/unknown", nil),
+			expectedRoute:      unmatchedRoute,
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+	for _, tc := range cases {
+		observed := 0
+		core := &models.Core{
+			Service: &mocks.ServiceMock{
+				HandleGetRankingFunc: func(ctx context.Context, board string, request *models.GetRankingRequest) (*models.GetRankingResponse, error) {
+					return &models.GetRankingResponse{}, tc.rankingError
+				},
+			},
+			Metrics: &mocks.MetricsServiceMock{
+				ObserveRequestFunc: func(route string, method string, status int, duration time.Duration) {
+					observed++
+					assert.Equal(t, tc.expectedRoute, route, tc.description)
+					assert.Equal(t, "GET", method, tc.description)
+					assert.Equal(t, tc.expectedStatusCode, status, tc.description)
+				},
+			},
+		}
+		core.ConnectResponseWriter()
+		router := mux.NewRouter()
+		ConnectBasic(router, core)
+
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, tc.request)
+		assert.Equal(t, tc.expectedStatusCode, writer.Code, tc.description)
+		assert.Equal(t, 1, observed, tc.description)
+	}
+}
+
+func TestHandleMetrics(t *testing.T) {
+	cases := []struct {
+		description         string
+		metricsError        error
+		signed              bool
+		expectedStatusCode  int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			description:         "should write the metrics",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/plain; version=0.0.4; charset=utf-8",
+			expectedBody:        "go_goroutines 8\n",
+		},
+		{
+			description:         "should write the metrics without a signature when the requests are signed",
+			signed:              true,
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/plain; version=0.0.4; charset=utf-8",
+			expectedBody:        "go_goroutines 8\n",
+		},
+		{
+			description:         "should return error when the metrics can't be read",
+			metricsError:        fmt.Errorf("mock-error"),
+			expectedStatusCode:  http.StatusInternalServerError,
+			expectedContentType: "application/json",
+		},
+	}
+	for _, tc := range cases {
+		core := &models.Core{
+			Metrics: &mocks.MetricsServiceMock{
+				ObserveRequestFunc: func(route string, method string, status int, duration time.Duration) {},
+				WriteMetricsFunc: func(ctx context.Context, w io.Writer) error {
+					if tc.metricsError != nil {
+						return tc.metricsError
+					}
+					_, err := io.WriteString(w, "go_goroutines 8\n")
+					return err
+				},
+			},
+		}
+		if tc.signed {
+			core.Auth = &mocks.AuthServiceMock{
+				VerifyFunc: func(ctx context.Context, request *models.SignedRequest) (*models.APIKey, error) {
+					t.Fatal("the metrics were not expected to be verified")
+					return nil, nil
+				},
+			}
+		}
+		core.ConnectResponseWriter()
+		router := mux.NewRouter()
+		ConnectBasic(router, core)
+
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, httptest.NewRequest("GET", "/metrics", nil))
+		assert.Equal(t, tc.expectedStatusCode, writer.Code, tc.description)
+		assert.Equal(t, tc.expectedContentType, writer.Header().Get("Content-Type"), tc.description)
+		if tc.expectedBody != "" {
+			assert.Equal(t, tc.expectedBody, writer.Body.String(), tc.description)
+		}
+	}
+}
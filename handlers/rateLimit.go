@@ -0,0 +1,52 @@
+package http
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+//RateLimit throttles the requests above the rate limits of their route, with 429 and the time to wait in Retry-After
+func (api *BasicHandlers) RateLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		//a throttled probe would take the service out of rotation
+		if probePaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		wait, err := api.core.RateLimits.Allow(r.Context(), rateLimitRequest(r))
+		if err != nil {
+			api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
+			return
+		}
+		if wait > 0 {
+			err := models.NewTooManyRequestsError(models.CodeRateLimited, wait, "Too many requests, retry in %s.", wait.Round(time.Millisecond))
+			api.core.RequestResponse.HandleError(err, w, r, http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+//rateLimitRequest returns the request as the rate limits see it, by the route it matched
+func rateLimitRequest(r *http.Request) *models.RateLimitRequest {
+	request := &models.RateLimitRequest{
+		Method: r.Method,
+		IP:     r.RemoteAddr,
+		UserID: mux.Vars(r)["user_id"],
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		request.IP = host
+	}
+	if route := mux.CurrentRoute(r); route != nil {
+		request.Route, _ = route.GetPathTemplate()
+	}
+	//only the keys verified by Authenticate are trusted
+	if key := models.APIKeyFromContext(r.Context()); key != nil {
+		request.APIKey = key.ID
+	}
+	return request
+}
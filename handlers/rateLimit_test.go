@@ -0,0 +1,73 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pedrocmart/leaderboard-service/mocks"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit(t *testing.T) {
+	cases := []struct {
+		description        string
+		request            *http.Request
+		wait               time.Duration
+		expectedRequest    *models.RateLimitRequest
+		expectedStatusCode int
+		expectedRetryAfter string
+	}{
+		{
+			description: "should let through a request within the rate limits",
+			request: func() *http.Request {
+				r := httptest.NewRequest("POST", "/user/12/score", nil)
+				r.RemoteAddr = "10.0.0.1:51234"
+				return r.WithContext(models.WithAPIKey(r.Context(), &models.APIKey{ID: "server"}))
+			}(),
+			expectedRequest:    &models.RateLimitRequest{Route: "/user/{user_id}/score", Method: "POST", APIKey: "server", IP: "10.0.0.1", UserID: "12"},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "should throttle a request above the rate limits",
+			request: func() *http.Request {
+				r := httptest.NewRequest("GET", "/ranking", nil)
+				r.RemoteAddr = "10.0.0.2:51234"
+				return r
+			}(),
+			wait:               1500 * time.Millisecond,
+			expectedRequest:    &models.RateLimitRequest{Route: "/ranking", Method: "GET", IP: "10.0.0.2"},
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedRetryAfter: "2",
+		},
+	}
+
+	for _, tc := range cases {
+		core := &models.Core{
+			RateLimits: &mocks.RateLimitServiceMock{
+				AllowFunc: func(ctx context.Context, request *models.RateLimitRequest) (time.Duration, error) {
+					assert.Equal(t, tc.expectedRequest, request, tc.description)
+					return tc.wait, nil
+				},
+			},
+			RequestResponse: models.BasicRequestResponse{},
+		}
+		basicHandlers := BasicHandlers{core: core}
+		ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		router := mux.NewRouter()
+		router.Use(basicHandlers.RateLimit)
+		router.Handle("/user/{user_id}/score", ok)
+		router.Handle("/ranking", ok)
+
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, tc.request)
+		assert.Equal(t, tc.expectedStatusCode, writer.Code, tc.description)
+		assert.Equal(t, tc.expectedRetryAfter, writer.Header().Get("Retry-After"), tc.description)
+	}
+}
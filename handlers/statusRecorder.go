@@ -0,0 +1,52 @@
+package http
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+//statusRecorder keeps the status written to the response, and still lets the streams flush and hijack it
+type statusRecorder struct {
+	http.ResponseWriter
+	status  int
+	written bool
+	//bytes counts the bytes of the body written
+	bytes int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	if !s.written {
+		s.status = status
+		s.written = true
+	}
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func (s *statusRecorder) Write(bytes []byte) (int, error) {
+	s.written = true
+	n, err := s.ResponseWriter.Write(bytes)
+	s.bytes += n
+	return n, err
+}
+
+func (s *statusRecorder) Flush() {
+	if flusher, ok := s.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+//Hijack hands the connection over to a WebSocket, which is recorded as switching protocols
+func (s *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := s.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("The response writer does not support hijacking")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err == nil {
+		s.status = http.StatusSwitchingProtocols
+		s.written = true
+	}
+	return conn, rw, err
+}
@@ -0,0 +1,198 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pedrocmart/leaderboard-service/models"
+)
+
+//streamWriteTimeout is how long a write to a stream may take before the client is considered gone
+const streamWriteTimeout = 10 * time.Second
+
+var upgrader = websocket.Upgrader{}
+
+type connContextKey struct{}
+
+//ConnContext keeps the connection of the requests in their context, so the streams can lift the deadlines
+//of the server from it. It is meant to be the ConnContext of the http.Server
+func ConnContext(ctx context.Context, conn net.Conn) context.Context {
+	return context.WithValue(ctx, connContextKey{}, conn)
+}
+
+//setStreamDeadline lifts the read deadline of the server from the connection of the request, which would
+//cancel a stream once it is over, and sets its write deadline to the one of the next write of the stream
+func setStreamDeadline(r *http.Request) {
+	conn, ok := r.Context().Value(connContextKey{}).(net.Conn)
+	if !ok {
+		return
+	}
+	conn.SetReadDeadline(time.Time{})
+	conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+}
+
+//HandleStreamRanking streams the ranking over a WebSocket when the request asks for an upgrade,
+//or else as Server-Sent Events
+func (api *BasicHandlers) HandleStreamRanking(w http.ResponseWriter, r *http.Request) {
+	if api.core.Service == nil {
+		err := fmt.Errorf("Service is nil")
+		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
+		return
+	}
+
+	rankingType := r.URL.Query().Get("type")
+	if strings.TrimSpace(rankingType) == "" {
+		err := models.NewValidationError(models.CodeInvalidRankingType, "A type must be included.")
+		api.core.RequestResponse.HandleError(err, w, r, http.StatusBadRequest)
+		return
+	}
+
+	getRankingRequest := &models.GetRankingRequest{
+		Type:     rankingType,
+		Period:   r.URL.Query().Get("period"),
+		PeriodID: r.URL.Query().Get("period_id"),
+	}
+
+	if websocket.IsWebSocketUpgrade(r) {
+		api.streamWebSocket(w, r, getRankingRequest)
+		return
+	}
+	api.streamEvents(w, r, getRankingRequest)
+}
+
+//streamEvents sends the updates of the ranking as Server-Sent Events
+func (api *BasicHandlers) streamEvents(w http.ResponseWriter, r *http.Request, request *models.GetRankingRequest) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		err := fmt.Errorf("The response writer does not support streaming")
+		api.core.RequestResponse.HandleError(err, w, r, http.StatusInternalServerError)
+		return
+	}
+
+	//the headers are only written with the first update, so the errors found before it get their status
+	started := false
+	err := api.core.Service.HandleStreamRanking(r.Context(), boardFromVars(r), request, func(update *models.RankingUpdate) error {
+		if !started {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Header().Set("Connection", "keep-alive")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+
+		setStreamDeadline(r)
+		var err error
+		if update.Type == models.RankingKeepAlive {
+			_, err = fmt.Fprint(w, ": keepalive\n\n")
+		} else {
+			err = writeEvent(w, string(update.Type), update)
+		}
+		if err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	})
+	if err == nil {
+		return
+	}
+
+	if !started {
+		api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		return
+	}
+	api.logStreamError(r, err)
+	setStreamDeadline(r)
+	if writeEvent(w, "error", errorResponse(err)) == nil {
+		flusher.Flush()
+	}
+}
+
+//writeEvent writes body as the data of a Server-Sent Event
+func writeEvent(w http.ResponseWriter, event string, body interface{}) error {
+	bytes, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, bytes)
+	return err
+}
+
+//streamWebSocket sends the updates of the ranking as JSON messages of a WebSocket
+func (api *BasicHandlers) streamWebSocket(w http.ResponseWriter, r *http.Request, request *models.GetRankingRequest) {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	//the connection is only upgraded with the first update, so the errors found before it get their status
+	var conn *websocket.Conn
+	var upgradeErr error
+	err := api.core.Service.HandleStreamRanking(ctx, boardFromVars(r), request, func(update *models.RankingUpdate) error {
+		if conn == nil {
+			//the upgrader lifts the deadlines of the server from the connection it takes over
+			conn, upgradeErr = upgrader.Upgrade(w, r, nil)
+			if upgradeErr != nil {
+				return upgradeErr
+			}
+			go discardMessages(conn, cancel)
+		}
+
+		conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		if update.Type == models.RankingKeepAlive {
+			return conn.WriteMessage(websocket.PingMessage, nil)
+		}
+		return conn.WriteJSON(update)
+	})
+	if upgradeErr != nil {
+		//the upgrader already replied with the error
+		api.core.GetLogger().Warn(r.Context(), "ranking stream not upgraded", models.LogFields{"path": r.URL.Path, "error": upgradeErr.Error()})
+		return
+	}
+	if conn == nil {
+		if err != nil {
+			api.core.RequestResponse.HandleError(err, w, r, models.ErrorStatus(err))
+		}
+		return
+	}
+	defer conn.Close()
+
+	if err != nil && ctx.Err() == nil {
+		api.logStreamError(r, err)
+		conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		conn.WriteJSON(errorResponse(err))
+	}
+	closeMessage := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(streamWriteTimeout))
+}
+
+//discardMessages reads the connection until it is closed, which handles its control messages,
+//and then cancels the stream
+func discardMessages(conn *websocket.Conn, cancel context.CancelFunc) {
+	defer cancel()
+	for {
+		if _, _, err := conn.NextReader(); err != nil {
+			return
+		}
+	}
+}
+
+//logStreamError logs the error that ended a stream after it started, which is sent on the stream
+//instead of going through the response writer
+func (api *BasicHandlers) logStreamError(r *http.Request, err error) {
+	fields := models.LogFields{"path": r.URL.Path, "code": models.ErrorCode(err), "error": err.Error()}
+	if models.ErrorStatus(err) >= http.StatusInternalServerError {
+		api.core.GetLogger().Error(r.Context(), "ranking stream failed", fields)
+		return
+	}
+	api.core.GetLogger().Warn(r.Context(), "ranking stream ended", fields)
+}
+
+//errorResponse returns the body of the errors sent on a stream
+func errorResponse(err error) models.Response {
+	return models.Response{Message: err.Error(), Code: models.ErrorCode(err), Success: false}
+}
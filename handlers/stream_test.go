@@ -0,0 +1,161 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/pedrocmart/leaderboard-service/mocks"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var streamUpdates = []*models.RankingUpdate{
+	{Type: models.RankingSnapshot, Ranking: []models.Ranking{{Position: 1, UserID: 1, Score: 10}}},
+	{Type: models.RankingKeepAlive},
+	{Type: models.RankingDiff, Changed: []models.Ranking{{Position: 1, UserID: 2, Score: 20}}, Removed: []int{1}},
+}
+
+//newStreamRouter returns a router whose service sends the updates and then fails with streamError.
+//The requests are instrumented, so the streams are flushed and hijacked through the recorder of the metrics
+func newStreamRouter(updates []*models.RankingUpdate, streamError error) *mux.Router {
+	core := &models.Core{
+		Metrics: &mocks.MetricsServiceMock{
+			ObserveRequestFunc: func(route string, method string, status int, duration time.Duration) {},
+		},
+		Service: &mocks.ServiceMock{
+			HandleStreamRankingFunc: func(ctx context.Context, board string, request *models.GetRankingRequest, send func(*models.RankingUpdate) error) error {
+				for _, update := range updates {
+					if err := send(update); err != nil {
+						return err
+					}
+				}
+				return streamError
+			},
+		},
+	}
+	core.ConnectResponseWriter()
+	router := mux.NewRouter()
+	ConnectBasic(router, core)
+	return router
+}
+
+func TestHandleStreamRanking(t *testing.T) {
+	cases := []struct {
+		description        string
+		updates            []*models.RankingUpdate
+		streamError        error
+		url                string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			description:        "should stream the updates as events",
+			updates:            streamUpdates,
+			url:                "/ranking/stream?type=top1",
+			expectedStatusCode: http.StatusOK,
+			expectedBody: "event: snapshot\ndata: {\"type\":\"snapshot\",\"ranking\":[{\"position\":1,\"user_id\":1,\"score\":10}]}\n\n" +
+				": keepalive\n\n" +
+				"event: diff\ndata: {\"type\":\"diff\",\"changed\":[{\"position\":1,\"user_id\":2,\"score\":20}],\"removed\":[1]}\n\n",
+		},
+		{
+			description:        "should send an error event when the stream fails",
+			updates:            streamUpdates[:1],
+			streamError:        models.NewNotFoundError(models.CodeLeaderboardNotFound, "Leaderboard weekly not found."),
+			url:                "/leaderboards/weekly/ranking/stream?type=top1",
+			expectedStatusCode: http.StatusOK,
+			expectedBody: "event: snapshot\ndata: {\"type\":\"snapshot\",\"ranking\":[{\"position\":1,\"user_id\":1,\"score\":10}]}\n\n" +
+				"event: error\ndata: {\"message\":\"Leaderboard weekly not found.\",\"code\":\"leaderboard_not_found\",\"success\":false,\"data\":null}\n\n",
+		},
+		{
+			description:        "should return the status of an error before the stream starts",
+			streamError:        models.NewNotFoundError(models.CodeLeaderboardNotFound, "Leaderboard weekly not found."),
+			url:                "/leaderboards/weekly/ranking/stream?type=top1",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description:        "should return an error without a type",
+			url:                "/ranking/stream",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range cases {
+		writer := httptest.NewRecorder()
+		newStreamRouter(tc.updates, tc.streamError).ServeHTTP(writer, httptest.NewRequest("GET", tc.url, nil))
+		assert.Equal(t, tc.expectedStatusCode, writer.Code, tc.description)
+		if tc.expectedStatusCode == http.StatusOK {
+			assert.Equal(t, "text/event-stream", writer.Header().Get("Content-Type"), tc.description)
+			assert.Equal(t, tc.expectedBody, writer.Body.String(), tc.description)
+		}
+	}
+}
+
+func TestHandleStreamRankingWebSocket(t *testing.T) {
+	server := httptest.NewServer(newStreamRouter(streamUpdates, fmt.Errorf("mock-error")))
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ranking/stream?type=top1"
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when dialing the stream", err)
+	}
+	defer conn.Close()
+
+	for _, expected := range []*models.RankingUpdate{streamUpdates[0], streamUpdates[2]} {
+		update := new(models.RankingUpdate)
+		assert.NoError(t, conn.ReadJSON(update))
+		assert.Equal(t, expected, update, "should send the updates as messages")
+	}
+	response := models.Response{}
+	assert.NoError(t, conn.ReadJSON(&response))
+	assert.Equal(t, models.Response{Message: "mock-error", Code: models.CodeInternal}, response, "should send the error that ended the stream")
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), "should close the connection")
+
+	_, resp, err := websocket.DefaultDialer.Dial(strings.TrimSuffix(url, "?type=top1"), nil)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "should not upgrade a request without a type")
+}
+
+func TestHandleStreamRankingDeadlines(t *testing.T) {
+	core := &models.Core{
+		Service: &mocks.ServiceMock{
+			HandleStreamRankingFunc: func(ctx context.Context, board string, request *models.GetRankingRequest, send func(*models.RankingUpdate) error) error {
+				if err := send(streamUpdates[0]); err != nil {
+					return err
+				}
+				//outlives the timeouts of the server
+				time.Sleep(200 * time.Millisecond)
+				return send(streamUpdates[2])
+			},
+		},
+	}
+	core.ConnectResponseWriter()
+	router := mux.NewRouter()
+	ConnectBasic(router, core)
+
+	server := httptest.NewUnstartedServer(router)
+	server.Config.ReadTimeout = 50 * time.Millisecond
+	server.Config.WriteTimeout = 50 * time.Millisecond
+	server.Config.ConnContext = ConnContext
+	server.Start()
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/ranking/stream?type=top1")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when requesting the stream", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "should not cut the stream at the timeouts of the server")
+	assert.Equal(t, "event: snapshot\ndata: {\"type\":\"snapshot\",\"ranking\":[{\"position\":1,\"user_id\":1,\"score\":10}]}\n\n"+
+		"event: diff\ndata: {\"type\":\"diff\",\"changed\":[{\"position\":1,\"user_id\":2,\"score\":20}],\"removed\":[1]}\n\n", string(body),
+		"should send the updates after the timeouts of the server")
+}
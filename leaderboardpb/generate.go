@@ -0,0 +1,4 @@
+//Package leaderboardpb holds the gRPC API of the service, generated from leaderboard.proto
+package leaderboardpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative leaderboard.proto
@@ -0,0 +1,250 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.3
+// source: leaderboard.proto
+
+package leaderboardpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Leaderboard_SubmitScore_FullMethodName  = "/leaderboard.v1.Leaderboard/SubmitScore"
+	Leaderboard_GetRanking_FullMethodName   = "/leaderboard.v1.Leaderboard/GetRanking"
+	Leaderboard_GetUserRank_FullMethodName  = "/leaderboard.v1.Leaderboard/GetUserRank"
+	Leaderboard_WatchRanking_FullMethodName = "/leaderboard.v1.Leaderboard/WatchRanking"
+)
+
+// LeaderboardClient is the client API for Leaderboard service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LeaderboardClient interface {
+	SubmitScore(ctx context.Context, in *SubmitScoreRequest, opts ...grpc.CallOption) (*SubmitScoreResponse, error)
+	GetRanking(ctx context.Context, in *GetRankingRequest, opts ...grpc.CallOption) (*GetRankingResponse, error)
+	GetUserRank(ctx context.Context, in *GetUserRankRequest, opts ...grpc.CallOption) (*GetUserRankResponse, error)
+	//WatchRanking sends the ranking and then its changes, until the client cancels the call
+	WatchRanking(ctx context.Context, in *GetRankingRequest, opts ...grpc.CallOption) (Leaderboard_WatchRankingClient, error)
+}
+
+type leaderboardClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLeaderboardClient(cc grpc.ClientConnInterface) LeaderboardClient {
+	return &leaderboardClient{cc}
+}
+
+func (c *leaderboardClient) SubmitScore(ctx context.Context, in *SubmitScoreRequest, opts ...grpc.CallOption) (*SubmitScoreResponse, error) {
+	out := new(SubmitScoreResponse)
+	err := c.cc.Invoke(ctx, Leaderboard_SubmitScore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) GetRanking(ctx context.Context, in *GetRankingRequest, opts ...grpc.CallOption) (*GetRankingResponse, error) {
+	out := new(GetRankingResponse)
+	err := c.cc.Invoke(ctx, Leaderboard_GetRanking_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) GetUserRank(ctx context.Context, in *GetUserRankRequest, opts ...grpc.CallOption) (*GetUserRankResponse, error) {
+	out := new(GetUserRankResponse)
+	err := c.cc.Invoke(ctx, Leaderboard_GetUserRank_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) WatchRanking(ctx context.Context, in *GetRankingRequest, opts ...grpc.CallOption) (Leaderboard_WatchRankingClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Leaderboard_ServiceDesc.Streams[0], Leaderboard_WatchRanking_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &leaderboardWatchRankingClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Leaderboard_WatchRankingClient interface {
+	Recv() (*RankingUpdate, error)
+	grpc.ClientStream
+}
+
+type leaderboardWatchRankingClient struct {
+	grpc.ClientStream
+}
+
+func (x *leaderboardWatchRankingClient) Recv() (*RankingUpdate, error) {
+	m := new(RankingUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// LeaderboardServer is the server API for Leaderboard service.
+// All implementations must embed UnimplementedLeaderboardServer
+// for forward compatibility
+type LeaderboardServer interface {
+	SubmitScore(context.Context, *SubmitScoreRequest) (*SubmitScoreResponse, error)
+	GetRanking(context.Context, *GetRankingRequest) (*GetRankingResponse, error)
+	GetUserRank(context.Context, *GetUserRankRequest) (*GetUserRankResponse, error)
+	//WatchRanking sends the ranking and then its changes, until the client cancels the call
+	WatchRanking(*GetRankingRequest, Leaderboard_WatchRankingServer) error
+	mustEmbedUnimplementedLeaderboardServer()
+}
+
+// UnimplementedLeaderboardServer must be embedded to have forward compatible implementations.
+type UnimplementedLeaderboardServer struct {
+}
+
+func (UnimplementedLeaderboardServer) SubmitScore(context.Context, *SubmitScoreRequest) (*SubmitScoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitScore not implemented")
+}
+func (UnimplementedLeaderboardServer) GetRanking(context.Context, *GetRankingRequest) (*GetRankingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRanking not implemented")
+}
+func (UnimplementedLeaderboardServer) GetUserRank(context.Context, *GetUserRankRequest) (*GetUserRankResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserRank not implemented")
+}
+func (UnimplementedLeaderboardServer) WatchRanking(*GetRankingRequest, Leaderboard_WatchRankingServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchRanking not implemented")
+}
+func (UnimplementedLeaderboardServer) mustEmbedUnimplementedLeaderboardServer() {}
+
+// UnsafeLeaderboardServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LeaderboardServer will
+// result in compilation errors.
+type UnsafeLeaderboardServer interface {
+	mustEmbedUnimplementedLeaderboardServer()
+}
+
+func RegisterLeaderboardServer(s grpc.ServiceRegistrar, srv LeaderboardServer) {
+	s.RegisterService(&Leaderboard_ServiceDesc, srv)
+}
+
+func _Leaderboard_SubmitScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).SubmitScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_SubmitScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).SubmitScore(ctx, req.(*SubmitScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_GetRanking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRankingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).GetRanking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_GetRanking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).GetRanking(ctx, req.(*GetRankingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_GetUserRank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRankRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).GetUserRank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_GetUserRank_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).GetUserRank(ctx, req.(*GetUserRankRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_WatchRanking_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetRankingRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LeaderboardServer).WatchRanking(m, &leaderboardWatchRankingServer{stream})
+}
+
+type Leaderboard_WatchRankingServer interface {
+	Send(*RankingUpdate) error
+	grpc.ServerStream
+}
+
+type leaderboardWatchRankingServer struct {
+	grpc.ServerStream
+}
+
+func (x *leaderboardWatchRankingServer) Send(m *RankingUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Leaderboard_ServiceDesc is the grpc.ServiceDesc for Leaderboard service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Leaderboard_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leaderboard.v1.Leaderboard",
+	HandlerType: (*LeaderboardServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SubmitScore",
+			Handler:    _Leaderboard_SubmitScore_Handler,
+		},
+		{
+			MethodName: "GetRanking",
+			Handler:    _Leaderboard_GetRanking_Handler,
+		},
+		{
+			MethodName: "GetUserRank",
+			Handler:    _Leaderboard_GetUserRank_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchRanking",
+			Handler:       _Leaderboard_WatchRanking_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "leaderboard.proto",
+}
@@ -1,60 +1,337 @@
 package main
 
 import (
-	"database/sql"
-	"fmt"
+	"context"
+	"errors"
+	"flag"
 	"log"
+	"math"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/pedrocmart/leaderboard-service/config"
 	"github.com/pedrocmart/leaderboard-service/coreservices"
+	grpcHandlers "github.com/pedrocmart/leaderboard-service/grpchandlers"
 	httpHandlers "github.com/pedrocmart/leaderboard-service/handlers"
 	"github.com/pedrocmart/leaderboard-service/models"
-	"github.com/pedrocmart/leaderboard-service/utils"
-
-	_ "modernc.org/ql/driver"
+	"google.golang.org/grpc"
 )
 
 func main() {
+	cfg, options, err := config.Load(os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if options.PrintConfig {
+		if err := config.Write(os.Stdout, cfg); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	//first we will initialize core that needs a response writer attatched to it
 	core = coreservices.InitCore()
+	core.Config = cfg
+	connectLogger()
+	if options.File != "" {
+		core.Logger.Info(context.Background(), "using config file", models.LogFields{"file": options.File})
+	}
+	setMaxBodyBytes()
 	core.ConnectResponseWriter()
 
 	coreservices.NewCoreService(core)
+	coreservices.NewStreamService(core)
+
+	setTieBreakPolicy()
+	setPeriods()
+	connectStore()
+	connectMetrics()
+	connectPersistence()
+	connectHistory()
+	connectValidation()
+	connectAuth()
+	connectAdmin()
+	connectRateLimits()
+	grpcServer := prepareConnectGRPC()
+	httpServer := prepareConnectHTTP()
+	shutdown(httpServer, grpcServer)
+}
+
+//prepareConnectGRPC serves the gRPC API on its own port, when there is one
+func prepareConnectGRPC() *grpc.Server {
+	host, grpcPort := core.Config.Server.Host, string(core.Config.Server.GRPCPort)
+	if grpcPort == "" {
+		return nil
+	}
 
-	createsInMemoryDB()
-	prepareConnectHTTP()
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, grpcPort))
+	if err != nil {
+		log.Fatal(err)
+	}
+	//the calls are authenticated and rate limited as the requests of the HTTP API
+	server := grpc.NewServer(grpcHandlers.ServerOptions(core)...)
+	if err := grpcHandlers.ConnectBasic(server, core); err != nil {
+		log.Fatal(err)
+	}
+
+	core.Logger.Info(context.Background(), "listening and serving gRPC", models.LogFields{"host": host, "port": grpcPort})
+	go func() {
+		//Serve only returns nil once the server is stopped on shutdown
+		if err := server.Serve(listener); err != nil {
+			log.Fatal(err)
+		}
+	}()
+	return server
 }
 
-func prepareConnectHTTP() {
+//prepareConnectHTTP serves the HTTP API, with the timeouts lifted from the streams once they start
+func prepareConnectHTTP() *http.Server {
 	httpHandlers.ConnectBasic(router, core)
 
-	fmt.Printf("Listening and serving on Host: %s, Port: %s\n", host, port)
-	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%s", port), router))
+	host, port := core.Config.Server.Host, string(core.Config.Server.Port)
+	server := &http.Server{
+		Addr:         net.JoinHostPort(host, port),
+		Handler:      router,
+		ReadTimeout:  time.Duration(core.Config.Server.ReadTimeout),
+		WriteTimeout: time.Duration(core.Config.Server.WriteTimeout),
+		IdleTimeout:  time.Duration(core.Config.Server.IdleTimeout),
+		ConnContext:  httpHandlers.ConnContext,
+	}
+
+	core.Logger.Info(context.Background(), "listening and serving HTTP", models.LogFields{"host": host, "port": port})
+	go func() {
+		if err := server.ListenAndServe(); err != http.ErrServerClosed {
+			log.Fatal(err)
+		}
+	}()
+	return server
 }
 
-func createsInMemoryDB() {
-	//defining in memory database
-	mdb, err := sql.Open("ql-mem", "memory://mem.db")
+//shutdown waits for SIGINT or SIGTERM, and then stops the servers within SHUTDOWN_TIMEOUT: the streams are
+//ended first, so the servers don't wait for them, then the requests in flight are drained and at last
+//the persistence log is flushed and closed, and so is the store
+func shutdown(httpServer *http.Server, grpcServer *grpc.Server) {
+	timeout := time.Duration(core.Config.Server.ShutdownTimeout)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	received := <-signals
+	core.Logger.Info(context.Background(), "shutting down, waiting for the requests in flight", models.LogFields{"signal": received.String(), "timeout": timeout.String()})
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	core.Stream.Close()
+	if err := httpServer.Shutdown(ctx); err != nil {
+		core.Logger.Error(context.Background(), "HTTP shutdown failed", models.LogFields{"error": err.Error()})
+	}
+	if grpcServer != nil {
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			grpcServer.Stop()
+		}
+	}
+	if core.Persistence != nil {
+		if err := core.Persistence.Close(); err != nil {
+			core.Logger.Error(context.Background(), "closing the persistence log failed", models.LogFields{"error": err.Error()})
+		}
+	}
+	//the databases kept on the disk write what they still hold once they are closed
+	if core.DB != nil {
+		if err := coreservices.CloseDB(core.DB); err != nil {
+			core.Logger.Error(context.Background(), "closing the store failed", models.LogFields{"error": err.Error()})
+		}
+	}
+	core.Logger.Info(context.Background(), "shut down", nil)
+}
+
+//connectLogger sets the logger of the core, which writes the messages of the log level and above as JSON to stderr,
+//and how much of the bodies it logs at the debug level
+func connectLogger() {
+	core.Logger = models.NewJSONLogger(os.Stderr, core.Config.Log.Level)
+	core.LogBodyBytes = core.Config.Log.BodyMaxBytes
+}
+
+//setMaxBodyBytes bounds the bodies of the requests, before the response writer that reads them is connected
+func setMaxBodyBytes() {
+	core.MaxBodyBytes = core.Config.Server.MaxBodyBytes
+}
+
+func setTieBreakPolicy() {
+	core.TieBreakPolicy = core.Config.TieBreak
+	core.Logger.Info(context.Background(), "using tie break policy", models.LogFields{"policy": core.TieBreakPolicy})
+}
+
+//setPeriods sets the time windowed periods that every submission is also ranked in
+func setPeriods() {
+	calendar, err := core.Config.Periods.Calendar()
 	if err != nil {
 		log.Fatal(err)
 	}
-	coreservices.NewStoreService(core, mdb)
-	tx, err := mdb.Begin()
+	core.Periods = calendar
+	core.Logger.Info(context.Background(), "ranking the periods", models.LogFields{"periods": calendar.Periods})
+}
+
+//connectStore opens the store of STORE_BACKEND, with the default leaderboard, which the backends kept
+//on the disk already have once they are opened again
+func connectStore() {
+	storeBackend := core.Config.Store.Backend
+	if _, err := coreservices.OpenStoreService(core, core.Config.Store); err != nil {
+		log.Fatal(err)
+	}
+	core.Logger.Info(context.Background(), "using store backend", models.LogFields{"backend": storeBackend})
+	//every call to the store is timed in the metrics and logged at the debug level
+	coreservices.NewInstrumentedStoreService(core)
+
+	exists, err := core.StoreService.DoesLeaderboardExist(context.Background(), models.DefaultLeaderboard)
 	if err != nil {
+		log.Fatal(err)
+	}
+	if exists {
+		return
+	}
+	if err := core.StoreService.CreateLeaderboard(context.Background(), models.DefaultLeaderboard); err != nil {
+		log.Fatal(err)
+	}
+}
+
+//connectMetrics serves the metrics of the requests, the submissions and the store on /metrics, when they are enabled
+func connectMetrics() {
+	if !core.Config.Metrics.Enabled {
 		return
 	}
 
-	if _, err := tx.Exec("CREATE TABLE users (id INT, score INT);"); err != nil {
+	coreservices.NewMetricsService(core)
+	core.Logger.Info(context.Background(), "serving the metrics on /metrics", nil)
+}
+
+//connectPersistence recovers the store from the persistence directory, when there is one,
+//and compacts the log into a snapshot at every interval
+func connectPersistence() {
+	settings := core.Config.Persistence
+	if settings.Dir == "" {
+		return
+	}
+
+	persistence, err := coreservices.NewPersistenceService(core, settings.Dir, settings.Fsync)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := persistence.Recover(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+	core.Logger.Info(context.Background(), "persisting the store", models.LogFields{"dir": settings.Dir})
+
+	go func() {
+		for range time.Tick(time.Duration(settings.SnapshotInterval)) {
+			if err := persistence.Snapshot(context.Background()); err != nil {
+				core.Logger.Error(context.Background(), "snapshot failed", models.LogFields{"error": err.Error()})
+			}
+		}
+	}()
+}
+
+//connectHistory keeps the history of the scores, pruning the entries older than the retention every minute
+func connectHistory() {
+	settings := core.Config.History
+	history := coreservices.NewMemoryHistoryService(core, time.Duration(settings.Retention), settings.MaxEntries)
+	go func() {
+		for range time.Tick(time.Minute) {
+			history.Prune(context.Background())
+		}
+	}()
+}
+
+//connectValidation sets the validators that every submission goes through, in order,
+//and the review queue of the submissions they reject or flag
+func connectValidation() {
+	settings := core.Config.Validation
+	coreservices.NewMemoryReviewQueueService(core, settings.ReviewMaxEntries)
+
+	if settings.MinScore != nil || settings.MaxScore != nil {
+		//a range without one of its ends is left open on that end
+		min, max := math.MinInt, math.MaxInt
+		if settings.MinScore != nil {
+			min = *settings.MinScore
+		}
+		if settings.MaxScore != nil {
+			max = *settings.MaxScore
+		}
+		core.Validators = append(core.Validators, coreservices.NewScoreRangeValidator(min, max))
+	}
+	if len(settings.MonotonicBoards) > 0 {
+		core.Validators = append(core.Validators, coreservices.NewMonotonicValidator(settings.MonotonicBoards))
+	}
+	if settings.MaxDelta != nil {
+		core.Validators = append(core.Validators, coreservices.NewMaxDeltaValidator(*settings.MaxDelta))
+	}
+	if settings.MaxGain != nil {
+		core.Validators = append(core.Validators, coreservices.NewMaxGainValidator(core, *settings.MaxGain, time.Duration(settings.GainWindow)))
+	}
+	if settings.FlagRate != nil {
+		core.Validators = append(core.Validators, coreservices.NewRateAnomalyValidator(core, *settings.FlagRate))
+	}
+	core.Logger.Info(context.Background(), "validating the submissions", models.LogFields{"rules": len(core.Validators)})
+}
+
+//connectAuth makes the HTTP API only serve the requests signed by the keys of the keys file, when there is one
+func connectAuth() {
+	settings := core.Config.Auth
+	if settings.KeysFile == "" {
 		return
 	}
 
-	if err = tx.Commit(); err != nil {
+	keys, err := coreservices.LoadAPIKeys(settings.KeysFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := coreservices.NewHMACAuthService(core, keys, time.Duration(settings.MaxSkew)); err != nil {
+		log.Fatal(err)
+	}
+	core.Logger.Info(context.Background(), "verifying the signed requests", models.LogFields{"keys": len(keys)})
+}
+
+//connectAdmin sets the bearer token of the admin routes, which only take it when the requests are not signed
+func connectAdmin() {
+	core.AdminToken = core.Config.Admin.Token
+	switch {
+	case core.Auth != nil:
+		core.Logger.Info(context.Background(), "serving the admin routes to the API keys with the admin scope", nil)
+	case core.AdminToken != "":
+		core.Logger.Info(context.Background(), "serving the admin routes to the admin token", nil)
+	default:
+		core.Logger.Warn(context.Background(), "not serving the admin routes, since neither ADMIN_TOKEN nor AUTH_KEYS_FILE is set", nil)
+	}
+}
+
+//connectRateLimits throttles the HTTP requests above the rate limits of the rate limits file, when there is one
+func connectRateLimits() {
+	if core.Config.RateLimits.File == "" {
 		return
 	}
+
+	rules, err := coreservices.LoadRateLimits(core.Config.RateLimits.File)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := coreservices.NewTokenBucketRateLimitService(core, rules); err != nil {
+		log.Fatal(err)
+	}
+	core.Logger.Info(context.Background(), "throttling the requests", models.LogFields{"rate_limits": len(rules)})
 }
 
 var core *models.Core
 var router = mux.NewRouter()
-var host = utils.GetEnvOrDefault("HOST", "0.0.0.0")
-var port = utils.GetEnvOrDefault("PORT", "8894")
@@ -0,0 +1,82 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"sync"
+)
+
+// Ensure, that AuthServiceMock does implement models.AuthService.
+// If this is not the case, regenerate this file with moq.
+var _ models.AuthService = &AuthServiceMock{}
+
+// AuthServiceMock is a mock implementation of models.AuthService.
+//
+//	func TestSomethingThatUsesAuthService(t *testing.T) {
+//
+//		// make and configure a mocked models.AuthService
+//		mockedAuthService := &AuthServiceMock{
+//			VerifyFunc: func(ctx context.Context, request *models.SignedRequest) (*models.APIKey, error) {
+//				panic("mock out the Verify method")
+//			},
+//		}
+//
+//		// use mockedAuthService in code that requires models.AuthService
+//		// and then make assertions.
+//
+//	}
+type AuthServiceMock struct {
+	// VerifyFunc mocks the Verify method.
+	VerifyFunc func(ctx context.Context, request *models.SignedRequest) (*models.APIKey, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Verify holds details about calls to the Verify method.
+		Verify []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Request is the request argument value.
+			Request *models.SignedRequest
+		}
+	}
+	lockVerify sync.RWMutex
+}
+
+// Verify calls VerifyFunc.
+func (mock *AuthServiceMock) Verify(ctx context.Context, request *models.SignedRequest) (*models.APIKey, error) {
+	if mock.VerifyFunc == nil {
+		panic("AuthServiceMock.VerifyFunc: method is nil but AuthService.Verify was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Request *models.SignedRequest
+	}{
+		Ctx:     ctx,
+		Request: request,
+	}
+	mock.lockVerify.Lock()
+	mock.calls.Verify = append(mock.calls.Verify, callInfo)
+	mock.lockVerify.Unlock()
+	return mock.VerifyFunc(ctx, request)
+}
+
+// VerifyCalls gets all the calls that were made to Verify.
+// Check the length with:
+//
+//	len(mockedAuthService.VerifyCalls())
+func (mock *AuthServiceMock) VerifyCalls() []struct {
+	Ctx     context.Context
+	Request *models.SignedRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Request *models.SignedRequest
+	}
+	mock.lockVerify.RLock()
+	calls = mock.calls.Verify
+	mock.lockVerify.RUnlock()
+	return calls
+}
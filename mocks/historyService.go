@@ -0,0 +1,306 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"sync"
+)
+
+// Ensure, that HistoryServiceMock does implement models.HistoryService.
+// If this is not the case, regenerate this file with moq.
+var _ models.HistoryService = &HistoryServiceMock{}
+
+// HistoryServiceMock is a mock implementation of models.HistoryService.
+//
+//	func TestSomethingThatUsesHistoryService(t *testing.T) {
+//
+//		// make and configure a mocked models.HistoryService
+//		mockedHistoryService := &HistoryServiceMock{
+//			DeleteLeaderboardFunc: func(ctx context.Context, board string) error {
+//				panic("mock out the DeleteLeaderboard method")
+//			},
+//			GetHistoryFunc: func(ctx context.Context, board string, id int, query models.HistoryQuery) ([]models.ScoreHistoryEntry, int, error) {
+//				panic("mock out the GetHistory method")
+//			},
+//			MergeUsersFunc: func(ctx context.Context, board string, from int, into int) error {
+//				panic("mock out the MergeUsers method")
+//			},
+//			PruneFunc: func(ctx context.Context) error {
+//				panic("mock out the Prune method")
+//			},
+//			RecordFunc: func(ctx context.Context, board string, entries []models.ScoreHistoryEntry) error {
+//				panic("mock out the Record method")
+//			},
+//		}
+//
+//		// use mockedHistoryService in code that requires models.HistoryService
+//		// and then make assertions.
+//
+//	}
+type HistoryServiceMock struct {
+	// DeleteLeaderboardFunc mocks the DeleteLeaderboard method.
+	DeleteLeaderboardFunc func(ctx context.Context, board string) error
+
+	// GetHistoryFunc mocks the GetHistory method.
+	GetHistoryFunc func(ctx context.Context, board string, id int, query models.HistoryQuery) ([]models.ScoreHistoryEntry, int, error)
+
+	// MergeUsersFunc mocks the MergeUsers method.
+	MergeUsersFunc func(ctx context.Context, board string, from int, into int) error
+
+	// PruneFunc mocks the Prune method.
+	PruneFunc func(ctx context.Context) error
+
+	// RecordFunc mocks the Record method.
+	RecordFunc func(ctx context.Context, board string, entries []models.ScoreHistoryEntry) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeleteLeaderboard holds details about calls to the DeleteLeaderboard method.
+		DeleteLeaderboard []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+		}
+		// GetHistory holds details about calls to the GetHistory method.
+		GetHistory []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// ID is the id argument value.
+			ID int
+			// Query is the query argument value.
+			Query models.HistoryQuery
+		}
+		// MergeUsers holds details about calls to the MergeUsers method.
+		MergeUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// From is the from argument value.
+			From int
+			// Into is the into argument value.
+			Into int
+		}
+		// Prune holds details about calls to the Prune method.
+		Prune []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Record holds details about calls to the Record method.
+		Record []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// Entries is the entries argument value.
+			Entries []models.ScoreHistoryEntry
+		}
+	}
+	lockDeleteLeaderboard sync.RWMutex
+	lockGetHistory        sync.RWMutex
+	lockMergeUsers        sync.RWMutex
+	lockPrune             sync.RWMutex
+	lockRecord            sync.RWMutex
+}
+
+// DeleteLeaderboard calls DeleteLeaderboardFunc.
+func (mock *HistoryServiceMock) DeleteLeaderboard(ctx context.Context, board string) error {
+	if mock.DeleteLeaderboardFunc == nil {
+		panic("HistoryServiceMock.DeleteLeaderboardFunc: method is nil but HistoryService.DeleteLeaderboard was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Board string
+	}{
+		Ctx:   ctx,
+		Board: board,
+	}
+	mock.lockDeleteLeaderboard.Lock()
+	mock.calls.DeleteLeaderboard = append(mock.calls.DeleteLeaderboard, callInfo)
+	mock.lockDeleteLeaderboard.Unlock()
+	return mock.DeleteLeaderboardFunc(ctx, board)
+}
+
+// DeleteLeaderboardCalls gets all the calls that were made to DeleteLeaderboard.
+// Check the length with:
+//
+//	len(mockedHistoryService.DeleteLeaderboardCalls())
+func (mock *HistoryServiceMock) DeleteLeaderboardCalls() []struct {
+	Ctx   context.Context
+	Board string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Board string
+	}
+	mock.lockDeleteLeaderboard.RLock()
+	calls = mock.calls.DeleteLeaderboard
+	mock.lockDeleteLeaderboard.RUnlock()
+	return calls
+}
+
+// GetHistory calls GetHistoryFunc.
+func (mock *HistoryServiceMock) GetHistory(ctx context.Context, board string, id int, query models.HistoryQuery) ([]models.ScoreHistoryEntry, int, error) {
+	if mock.GetHistoryFunc == nil {
+		panic("HistoryServiceMock.GetHistoryFunc: method is nil but HistoryService.GetHistory was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Board string
+		ID    int
+		Query models.HistoryQuery
+	}{
+		Ctx:   ctx,
+		Board: board,
+		ID:    id,
+		Query: query,
+	}
+	mock.lockGetHistory.Lock()
+	mock.calls.GetHistory = append(mock.calls.GetHistory, callInfo)
+	mock.lockGetHistory.Unlock()
+	return mock.GetHistoryFunc(ctx, board, id, query)
+}
+
+// GetHistoryCalls gets all the calls that were made to GetHistory.
+// Check the length with:
+//
+//	len(mockedHistoryService.GetHistoryCalls())
+func (mock *HistoryServiceMock) GetHistoryCalls() []struct {
+	Ctx   context.Context
+	Board string
+	ID    int
+	Query models.HistoryQuery
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Board string
+		ID    int
+		Query models.HistoryQuery
+	}
+	mock.lockGetHistory.RLock()
+	calls = mock.calls.GetHistory
+	mock.lockGetHistory.RUnlock()
+	return calls
+}
+
+// MergeUsers calls MergeUsersFunc.
+func (mock *HistoryServiceMock) MergeUsers(ctx context.Context, board string, from int, into int) error {
+	if mock.MergeUsersFunc == nil {
+		panic("HistoryServiceMock.MergeUsersFunc: method is nil but HistoryService.MergeUsers was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Board string
+		From  int
+		Into  int
+	}{
+		Ctx:   ctx,
+		Board: board,
+		From:  from,
+		Into:  into,
+	}
+	mock.lockMergeUsers.Lock()
+	mock.calls.MergeUsers = append(mock.calls.MergeUsers, callInfo)
+	mock.lockMergeUsers.Unlock()
+	return mock.MergeUsersFunc(ctx, board, from, into)
+}
+
+// MergeUsersCalls gets all the calls that were made to MergeUsers.
+// Check the length with:
+//
+//	len(mockedHistoryService.MergeUsersCalls())
+func (mock *HistoryServiceMock) MergeUsersCalls() []struct {
+	Ctx   context.Context
+	Board string
+	From  int
+	Into  int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Board string
+		From  int
+		Into  int
+	}
+	mock.lockMergeUsers.RLock()
+	calls = mock.calls.MergeUsers
+	mock.lockMergeUsers.RUnlock()
+	return calls
+}
+
+// Prune calls PruneFunc.
+func (mock *HistoryServiceMock) Prune(ctx context.Context) error {
+	if mock.PruneFunc == nil {
+		panic("HistoryServiceMock.PruneFunc: method is nil but HistoryService.Prune was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPrune.Lock()
+	mock.calls.Prune = append(mock.calls.Prune, callInfo)
+	mock.lockPrune.Unlock()
+	return mock.PruneFunc(ctx)
+}
+
+// PruneCalls gets all the calls that were made to Prune.
+// Check the length with:
+//
+//	len(mockedHistoryService.PruneCalls())
+func (mock *HistoryServiceMock) PruneCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockPrune.RLock()
+	calls = mock.calls.Prune
+	mock.lockPrune.RUnlock()
+	return calls
+}
+
+// Record calls RecordFunc.
+func (mock *HistoryServiceMock) Record(ctx context.Context, board string, entries []models.ScoreHistoryEntry) error {
+	if mock.RecordFunc == nil {
+		panic("HistoryServiceMock.RecordFunc: method is nil but HistoryService.Record was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Board   string
+		Entries []models.ScoreHistoryEntry
+	}{
+		Ctx:     ctx,
+		Board:   board,
+		Entries: entries,
+	}
+	mock.lockRecord.Lock()
+	mock.calls.Record = append(mock.calls.Record, callInfo)
+	mock.lockRecord.Unlock()
+	return mock.RecordFunc(ctx, board, entries)
+}
+
+// RecordCalls gets all the calls that were made to Record.
+// Check the length with:
+//
+//	len(mockedHistoryService.RecordCalls())
+func (mock *HistoryServiceMock) RecordCalls() []struct {
+	Ctx     context.Context
+	Board   string
+	Entries []models.ScoreHistoryEntry
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Board   string
+		Entries []models.ScoreHistoryEntry
+	}
+	mock.lockRecord.RLock()
+	calls = mock.calls.Record
+	mock.lockRecord.RUnlock()
+	return calls
+}
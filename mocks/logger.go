@@ -0,0 +1,300 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"sync"
+)
+
+// Ensure, that LoggerMock does implement models.Logger.
+// If this is not the case, regenerate this file with moq.
+var _ models.Logger = &LoggerMock{}
+
+// LoggerMock is a mock implementation of models.Logger.
+//
+//	func TestSomethingThatUsesLogger(t *testing.T) {
+//
+//		// make and configure a mocked models.Logger
+//		mockedLogger := &LoggerMock{
+//			DebugFunc: func(ctx context.Context, message string, fields models.LogFields)  {
+//				panic("mock out the Debug method")
+//			},
+//			EnabledFunc: func(level models.LogLevel) bool {
+//				panic("mock out the Enabled method")
+//			},
+//			ErrorFunc: func(ctx context.Context, message string, fields models.LogFields)  {
+//				panic("mock out the Error method")
+//			},
+//			InfoFunc: func(ctx context.Context, message string, fields models.LogFields)  {
+//				panic("mock out the Info method")
+//			},
+//			WarnFunc: func(ctx context.Context, message string, fields models.LogFields)  {
+//				panic("mock out the Warn method")
+//			},
+//		}
+//
+//		// use mockedLogger in code that requires models.Logger
+//		// and then make assertions.
+//
+//	}
+type LoggerMock struct {
+	// DebugFunc mocks the Debug method.
+	DebugFunc func(ctx context.Context, message string, fields models.LogFields)
+
+	// EnabledFunc mocks the Enabled method.
+	EnabledFunc func(level models.LogLevel) bool
+
+	// ErrorFunc mocks the Error method.
+	ErrorFunc func(ctx context.Context, message string, fields models.LogFields)
+
+	// InfoFunc mocks the Info method.
+	InfoFunc func(ctx context.Context, message string, fields models.LogFields)
+
+	// WarnFunc mocks the Warn method.
+	WarnFunc func(ctx context.Context, message string, fields models.LogFields)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Debug holds details about calls to the Debug method.
+		Debug []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Message is the message argument value.
+			Message string
+			// Fields is the fields argument value.
+			Fields models.LogFields
+		}
+		// Enabled holds details about calls to the Enabled method.
+		Enabled []struct {
+			// Level is the level argument value.
+			Level models.LogLevel
+		}
+		// Error holds details about calls to the Error method.
+		Error []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Message is the message argument value.
+			Message string
+			// Fields is the fields argument value.
+			Fields models.LogFields
+		}
+		// Info holds details about calls to the Info method.
+		Info []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Message is the message argument value.
+			Message string
+			// Fields is the fields argument value.
+			Fields models.LogFields
+		}
+		// Warn holds details about calls to the Warn method.
+		Warn []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Message is the message argument value.
+			Message string
+			// Fields is the fields argument value.
+			Fields models.LogFields
+		}
+	}
+	lockDebug   sync.RWMutex
+	lockEnabled sync.RWMutex
+	lockError   sync.RWMutex
+	lockInfo    sync.RWMutex
+	lockWarn    sync.RWMutex
+}
+
+// Debug calls DebugFunc.
+func (mock *LoggerMock) Debug(ctx context.Context, message string, fields models.LogFields) {
+	if mock.DebugFunc == nil {
+		panic("LoggerMock.DebugFunc: method is nil but Logger.Debug was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Message string
+		Fields  models.LogFields
+	}{
+		Ctx:     ctx,
+		Message: message,
+		Fields:  fields,
+	}
+	mock.lockDebug.Lock()
+	mock.calls.Debug = append(mock.calls.Debug, callInfo)
+	mock.lockDebug.Unlock()
+	mock.DebugFunc(ctx, message, fields)
+}
+
+// DebugCalls gets all the calls that were made to Debug.
+// Check the length with:
+//
+//	len(mockedLogger.DebugCalls())
+func (mock *LoggerMock) DebugCalls() []struct {
+	Ctx     context.Context
+	Message string
+	Fields  models.LogFields
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Message string
+		Fields  models.LogFields
+	}
+	mock.lockDebug.RLock()
+	calls = mock.calls.Debug
+	mock.lockDebug.RUnlock()
+	return calls
+}
+
+// Enabled calls EnabledFunc.
+func (mock *LoggerMock) Enabled(level models.LogLevel) bool {
+	if mock.EnabledFunc == nil {
+		panic("LoggerMock.EnabledFunc: method is nil but Logger.Enabled was just called")
+	}
+	callInfo := struct {
+		Level models.LogLevel
+	}{
+		Level: level,
+	}
+	mock.lockEnabled.Lock()
+	mock.calls.Enabled = append(mock.calls.Enabled, callInfo)
+	mock.lockEnabled.Unlock()
+	return mock.EnabledFunc(level)
+}
+
+// EnabledCalls gets all the calls that were made to Enabled.
+// Check the length with:
+//
+//	len(mockedLogger.EnabledCalls())
+func (mock *LoggerMock) EnabledCalls() []struct {
+	Level models.LogLevel
+} {
+	var calls []struct {
+		Level models.LogLevel
+	}
+	mock.lockEnabled.RLock()
+	calls = mock.calls.Enabled
+	mock.lockEnabled.RUnlock()
+	return calls
+}
+
+// Error calls ErrorFunc.
+func (mock *LoggerMock) Error(ctx context.Context, message string, fields models.LogFields) {
+	if mock.ErrorFunc == nil {
+		panic("LoggerMock.ErrorFunc: method is nil but Logger.Error was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Message string
+		Fields  models.LogFields
+	}{
+		Ctx:     ctx,
+		Message: message,
+		Fields:  fields,
+	}
+	mock.lockError.Lock()
+	mock.calls.Error = append(mock.calls.Error, callInfo)
+	mock.lockError.Unlock()
+	mock.ErrorFunc(ctx, message, fields)
+}
+
+// ErrorCalls gets all the calls that were made to Error.
+// Check the length with:
+//
+//	len(mockedLogger.ErrorCalls())
+func (mock *LoggerMock) ErrorCalls() []struct {
+	Ctx     context.Context
+	Message string
+	Fields  models.LogFields
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Message string
+		Fields  models.LogFields
+	}
+	mock.lockError.RLock()
+	calls = mock.calls.Error
+	mock.lockError.RUnlock()
+	return calls
+}
+
+// Info calls InfoFunc.
+func (mock *LoggerMock) Info(ctx context.Context, message string, fields models.LogFields) {
+	if mock.InfoFunc == nil {
+		panic("LoggerMock.InfoFunc: method is nil but Logger.Info was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Message string
+		Fields  models.LogFields
+	}{
+		Ctx:     ctx,
+		Message: message,
+		Fields:  fields,
+	}
+	mock.lockInfo.Lock()
+	mock.calls.Info = append(mock.calls.Info, callInfo)
+	mock.lockInfo.Unlock()
+	mock.InfoFunc(ctx, message, fields)
+}
+
+// InfoCalls gets all the calls that were made to Info.
+// Check the length with:
+//
+//	len(mockedLogger.InfoCalls())
+func (mock *LoggerMock) InfoCalls() []struct {
+	Ctx     context.Context
+	Message string
+	Fields  models.LogFields
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Message string
+		Fields  models.LogFields
+	}
+	mock.lockInfo.RLock()
+	calls = mock.calls.Info
+	mock.lockInfo.RUnlock()
+	return calls
+}
+
+// Warn calls WarnFunc.
+func (mock *LoggerMock) Warn(ctx context.Context, message string, fields models.LogFields) {
+	if mock.WarnFunc == nil {
+		panic("LoggerMock.WarnFunc: method is nil but Logger.Warn was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Message string
+		Fields  models.LogFields
+	}{
+		Ctx:     ctx,
+		Message: message,
+		Fields:  fields,
+	}
+	mock.lockWarn.Lock()
+	mock.calls.Warn = append(mock.calls.Warn, callInfo)
+	mock.lockWarn.Unlock()
+	mock.WarnFunc(ctx, message, fields)
+}
+
+// WarnCalls gets all the calls that were made to Warn.
+// Check the length with:
+//
+//	len(mockedLogger.WarnCalls())
+func (mock *LoggerMock) WarnCalls() []struct {
+	Ctx     context.Context
+	Message string
+	Fields  models.LogFields
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Message string
+		Fields  models.LogFields
+	}
+	mock.lockWarn.RLock()
+	calls = mock.calls.Warn
+	mock.lockWarn.RUnlock()
+	return calls
+}
@@ -0,0 +1,258 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"io"
+	"sync"
+	"time"
+)
+
+// Ensure, that MetricsServiceMock does implement models.MetricsService.
+// If this is not the case, regenerate this file with moq.
+var _ models.MetricsService = &MetricsServiceMock{}
+
+// MetricsServiceMock is a mock implementation of models.MetricsService.
+//
+//	func TestSomethingThatUsesMetricsService(t *testing.T) {
+//
+//		// make and configure a mocked models.MetricsService
+//		mockedMetricsService := &MetricsServiceMock{
+//			ObserveRequestFunc: func(route string, method string, status int, duration time.Duration)  {
+//				panic("mock out the ObserveRequest method")
+//			},
+//			ObserveStoreFunc: func(method string, duration time.Duration, err error)  {
+//				panic("mock out the ObserveStore method")
+//			},
+//			ObserveSubmissionsFunc: func(board string, submissions []models.ScoreSubmission, changes []models.ScoreChange)  {
+//				panic("mock out the ObserveSubmissions method")
+//			},
+//			WriteMetricsFunc: func(ctx context.Context, w io.Writer) error {
+//				panic("mock out the WriteMetrics method")
+//			},
+//		}
+//
+//		// use mockedMetricsService in code that requires models.MetricsService
+//		// and then make assertions.
+//
+//	}
+type MetricsServiceMock struct {
+	// ObserveRequestFunc mocks the ObserveRequest method.
+	ObserveRequestFunc func(route string, method string, status int, duration time.Duration)
+
+	// ObserveStoreFunc mocks the ObserveStore method.
+	ObserveStoreFunc func(method string, duration time.Duration, err error)
+
+	// ObserveSubmissionsFunc mocks the ObserveSubmissions method.
+	ObserveSubmissionsFunc func(board string, submissions []models.ScoreSubmission, changes []models.ScoreChange)
+
+	// WriteMetricsFunc mocks the WriteMetrics method.
+	WriteMetricsFunc func(ctx context.Context, w io.Writer) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ObserveRequest holds details about calls to the ObserveRequest method.
+		ObserveRequest []struct {
+			// Route is the route argument value.
+			Route string
+			// Method is the method argument value.
+			Method string
+			// Status is the status argument value.
+			Status int
+			// Duration is the duration argument value.
+			Duration time.Duration
+		}
+		// ObserveStore holds details about calls to the ObserveStore method.
+		ObserveStore []struct {
+			// Method is the method argument value.
+			Method string
+			// Duration is the duration argument value.
+			Duration time.Duration
+			// Err is the err argument value.
+			Err error
+		}
+		// ObserveSubmissions holds details about calls to the ObserveSubmissions method.
+		ObserveSubmissions []struct {
+			// Board is the board argument value.
+			Board string
+			// Submissions is the submissions argument value.
+			Submissions []models.ScoreSubmission
+			// Changes is the changes argument value.
+			Changes []models.ScoreChange
+		}
+		// WriteMetrics holds details about calls to the WriteMetrics method.
+		WriteMetrics []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// W is the w argument value.
+			W io.Writer
+		}
+	}
+	lockObserveRequest     sync.RWMutex
+	lockObserveStore       sync.RWMutex
+	lockObserveSubmissions sync.RWMutex
+	lockWriteMetrics       sync.RWMutex
+}
+
+// ObserveRequest calls ObserveRequestFunc.
+func (mock *MetricsServiceMock) ObserveRequest(route string, method string, status int, duration time.Duration) {
+	if mock.ObserveRequestFunc == nil {
+		panic("MetricsServiceMock.ObserveRequestFunc: method is nil but MetricsService.ObserveRequest was just called")
+	}
+	callInfo := struct {
+		Route    string
+		Method   string
+		Status   int
+		Duration time.Duration
+	}{
+		Route:    route,
+		Method:   method,
+		Status:   status,
+		Duration: duration,
+	}
+	mock.lockObserveRequest.Lock()
+	mock.calls.ObserveRequest = append(mock.calls.ObserveRequest, callInfo)
+	mock.lockObserveRequest.Unlock()
+	mock.ObserveRequestFunc(route, method, status, duration)
+}
+
+// ObserveRequestCalls gets all the calls that were made to ObserveRequest.
+// Check the length with:
+//
+//	len(mockedMetricsService.ObserveRequestCalls())
+func (mock *MetricsServiceMock) ObserveRequestCalls() []struct {
+	Route    string
+	Method   string
+	Status   int
+	Duration time.Duration
+} {
+	var calls []struct {
+		Route    string
+		Method   string
+		Status   int
+		Duration time.Duration
+	}
+	mock.lockObserveRequest.RLock()
+	calls = mock.calls.ObserveRequest
+	mock.lockObserveRequest.RUnlock()
+	return calls
+}
+
+// ObserveStore calls ObserveStoreFunc.
+func (mock *MetricsServiceMock) ObserveStore(method string, duration time.Duration, err error) {
+	if mock.ObserveStoreFunc == nil {
+		panic("MetricsServiceMock.ObserveStoreFunc: method is nil but MetricsService.ObserveStore was just called")
+	}
+	callInfo := struct {
+		Method   string
+		Duration time.Duration
+		Err      error
+	}{
+		Method:   method,
+		Duration: duration,
+		Err:      err,
+	}
+	mock.lockObserveStore.Lock()
+	mock.calls.ObserveStore = append(mock.calls.ObserveStore, callInfo)
+	mock.lockObserveStore.Unlock()
+	mock.ObserveStoreFunc(method, duration, err)
+}
+
+// ObserveStoreCalls gets all the calls that were made to ObserveStore.
+// Check the length with:
+//
+//	len(mockedMetricsService.ObserveStoreCalls())
+func (mock *MetricsServiceMock) ObserveStoreCalls() []struct {
+	Method   string
+	Duration time.Duration
+	Err      error
+} {
+	var calls []struct {
+		Method   string
+		Duration time.Duration
+		Err      error
+	}
+	mock.lockObserveStore.RLock()
+	calls = mock.calls.ObserveStore
+	mock.lockObserveStore.RUnlock()
+	return calls
+}
+
+// ObserveSubmissions calls ObserveSubmissionsFunc.
+func (mock *MetricsServiceMock) ObserveSubmissions(board string, submissions []models.ScoreSubmission, changes []models.ScoreChange) {
+	if mock.ObserveSubmissionsFunc == nil {
+		panic("MetricsServiceMock.ObserveSubmissionsFunc: method is nil but MetricsService.ObserveSubmissions was just called")
+	}
+	callInfo := struct {
+		Board       string
+		Submissions []models.ScoreSubmission
+		Changes     []models.ScoreChange
+	}{
+		Board:       board,
+		Submissions: submissions,
+		Changes:     changes,
+	}
+	mock.lockObserveSubmissions.Lock()
+	mock.calls.ObserveSubmissions = append(mock.calls.ObserveSubmissions, callInfo)
+	mock.lockObserveSubmissions.Unlock()
+	mock.ObserveSubmissionsFunc(board, submissions, changes)
+}
+
+// ObserveSubmissionsCalls gets all the calls that were made to ObserveSubmissions.
+// Check the length with:
+//
+//	len(mockedMetricsService.ObserveSubmissionsCalls())
+func (mock *MetricsServiceMock) ObserveSubmissionsCalls() []struct {
+	Board       string
+	Submissions []models.ScoreSubmission
+	Changes     []models.ScoreChange
+} {
+	var calls []struct {
+		Board       string
+		Submissions []models.ScoreSubmission
+		Changes     []models.ScoreChange
+	}
+	mock.lockObserveSubmissions.RLock()
+	calls = mock.calls.ObserveSubmissions
+	mock.lockObserveSubmissions.RUnlock()
+	return calls
+}
+
+// WriteMetrics calls WriteMetricsFunc.
+func (mock *MetricsServiceMock) WriteMetrics(ctx context.Context, w io.Writer) error {
+	if mock.WriteMetricsFunc == nil {
+		panic("MetricsServiceMock.WriteMetricsFunc: method is nil but MetricsService.WriteMetrics was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		W   io.Writer
+	}{
+		Ctx: ctx,
+		W:   w,
+	}
+	mock.lockWriteMetrics.Lock()
+	mock.calls.WriteMetrics = append(mock.calls.WriteMetrics, callInfo)
+	mock.lockWriteMetrics.Unlock()
+	return mock.WriteMetricsFunc(ctx, w)
+}
+
+// WriteMetricsCalls gets all the calls that were made to WriteMetrics.
+// Check the length with:
+//
+//	len(mockedMetricsService.WriteMetricsCalls())
+func (mock *MetricsServiceMock) WriteMetricsCalls() []struct {
+	Ctx context.Context
+	W   io.Writer
+} {
+	var calls []struct {
+		Ctx context.Context
+		W   io.Writer
+	}
+	mock.lockWriteMetrics.RLock()
+	calls = mock.calls.WriteMetrics
+	mock.lockWriteMetrics.RUnlock()
+	return calls
+}
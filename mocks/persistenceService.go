@@ -0,0 +1,250 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"sync"
+)
+
+// Ensure, that PersistenceServiceMock does implement models.PersistenceService.
+// If this is not the case, regenerate this file with moq.
+var _ models.PersistenceService = &PersistenceServiceMock{}
+
+// PersistenceServiceMock is a mock implementation of models.PersistenceService.
+//
+//	func TestSomethingThatUsesPersistenceService(t *testing.T) {
+//
+//		// make and configure a mocked models.PersistenceService
+//		mockedPersistenceService := &PersistenceServiceMock{
+//			CloseFunc: func() error {
+//				panic("mock out the Close method")
+//			},
+//			LogFunc: func(ctx context.Context, mutation models.Mutation, apply func() error) error {
+//				panic("mock out the Log method")
+//			},
+//			ReadyFunc: func() error {
+//				panic("mock out the Ready method")
+//			},
+//			RecoverFunc: func(ctx context.Context) error {
+//				panic("mock out the Recover method")
+//			},
+//			SnapshotFunc: func(ctx context.Context) error {
+//				panic("mock out the Snapshot method")
+//			},
+//		}
+//
+//		// use mockedPersistenceService in code that requires models.PersistenceService
+//		// and then make assertions.
+//
+//	}
+type PersistenceServiceMock struct {
+	// CloseFunc mocks the Close method.
+	CloseFunc func() error
+
+	// LogFunc mocks the Log method.
+	LogFunc func(ctx context.Context, mutation models.Mutation, apply func() error) error
+
+	// ReadyFunc mocks the Ready method.
+	ReadyFunc func() error
+
+	// RecoverFunc mocks the Recover method.
+	RecoverFunc func(ctx context.Context) error
+
+	// SnapshotFunc mocks the Snapshot method.
+	SnapshotFunc func(ctx context.Context) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Close holds details about calls to the Close method.
+		Close []struct {
+		}
+		// Log holds details about calls to the Log method.
+		Log []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Mutation is the mutation argument value.
+			Mutation models.Mutation
+			// Apply is the apply argument value.
+			Apply func() error
+		}
+		// Ready holds details about calls to the Ready method.
+		Ready []struct {
+		}
+		// Recover holds details about calls to the Recover method.
+		Recover []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Snapshot holds details about calls to the Snapshot method.
+		Snapshot []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockClose    sync.RWMutex
+	lockLog      sync.RWMutex
+	lockReady    sync.RWMutex
+	lockRecover  sync.RWMutex
+	lockSnapshot sync.RWMutex
+}
+
+// Close calls CloseFunc.
+func (mock *PersistenceServiceMock) Close() error {
+	if mock.CloseFunc == nil {
+		panic("PersistenceServiceMock.CloseFunc: method is nil but PersistenceService.Close was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockClose.Lock()
+	mock.calls.Close = append(mock.calls.Close, callInfo)
+	mock.lockClose.Unlock()
+	return mock.CloseFunc()
+}
+
+// CloseCalls gets all the calls that were made to Close.
+// Check the length with:
+//
+//	len(mockedPersistenceService.CloseCalls())
+func (mock *PersistenceServiceMock) CloseCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockClose.RLock()
+	calls = mock.calls.Close
+	mock.lockClose.RUnlock()
+	return calls
+}
+
+// Log calls LogFunc.
+func (mock *PersistenceServiceMock) Log(ctx context.Context, mutation models.Mutation, apply func() error) error {
+	if mock.LogFunc == nil {
+		panic("PersistenceServiceMock.LogFunc: method is nil but PersistenceService.Log was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Mutation models.Mutation
+		Apply    func() error
+	}{
+		Ctx:      ctx,
+		Mutation: mutation,
+		Apply:    apply,
+	}
+	mock.lockLog.Lock()
+	mock.calls.Log = append(mock.calls.Log, callInfo)
+	mock.lockLog.Unlock()
+	return mock.LogFunc(ctx, mutation, apply)
+}
+
+// LogCalls gets all the calls that were made to Log.
+// Check the length with:
+//
+//	len(mockedPersistenceService.LogCalls())
+func (mock *PersistenceServiceMock) LogCalls() []struct {
+	Ctx      context.Context
+	Mutation models.Mutation
+	Apply    func() error
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Mutation models.Mutation
+		Apply    func() error
+	}
+	mock.lockLog.RLock()
+	calls = mock.calls.Log
+	mock.lockLog.RUnlock()
+	return calls
+}
+
+// Ready calls ReadyFunc.
+func (mock *PersistenceServiceMock) Ready() error {
+	if mock.ReadyFunc == nil {
+		panic("PersistenceServiceMock.ReadyFunc: method is nil but PersistenceService.Ready was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockReady.Lock()
+	mock.calls.Ready = append(mock.calls.Ready, callInfo)
+	mock.lockReady.Unlock()
+	return mock.ReadyFunc()
+}
+
+// ReadyCalls gets all the calls that were made to Ready.
+// Check the length with:
+//
+//	len(mockedPersistenceService.ReadyCalls())
+func (mock *PersistenceServiceMock) ReadyCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockReady.RLock()
+	calls = mock.calls.Ready
+	mock.lockReady.RUnlock()
+	return calls
+}
+
+// Recover calls RecoverFunc.
+func (mock *PersistenceServiceMock) Recover(ctx context.Context) error {
+	if mock.RecoverFunc == nil {
+		panic("PersistenceServiceMock.RecoverFunc: method is nil but PersistenceService.Recover was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockRecover.Lock()
+	mock.calls.Recover = append(mock.calls.Recover, callInfo)
+	mock.lockRecover.Unlock()
+	return mock.RecoverFunc(ctx)
+}
+
+// RecoverCalls gets all the calls that were made to Recover.
+// Check the length with:
+//
+//	len(mockedPersistenceService.RecoverCalls())
+func (mock *PersistenceServiceMock) RecoverCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockRecover.RLock()
+	calls = mock.calls.Recover
+	mock.lockRecover.RUnlock()
+	return calls
+}
+
+// Snapshot calls SnapshotFunc.
+func (mock *PersistenceServiceMock) Snapshot(ctx context.Context) error {
+	if mock.SnapshotFunc == nil {
+		panic("PersistenceServiceMock.SnapshotFunc: method is nil but PersistenceService.Snapshot was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockSnapshot.Lock()
+	mock.calls.Snapshot = append(mock.calls.Snapshot, callInfo)
+	mock.lockSnapshot.Unlock()
+	return mock.SnapshotFunc(ctx)
+}
+
+// SnapshotCalls gets all the calls that were made to Snapshot.
+// Check the length with:
+//
+//	len(mockedPersistenceService.SnapshotCalls())
+func (mock *PersistenceServiceMock) SnapshotCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockSnapshot.RLock()
+	calls = mock.calls.Snapshot
+	mock.lockSnapshot.RUnlock()
+	return calls
+}
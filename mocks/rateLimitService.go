@@ -0,0 +1,127 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"sync"
+	"time"
+)
+
+// Ensure, that RateLimitServiceMock does implement models.RateLimitService.
+// If this is not the case, regenerate this file with moq.
+var _ models.RateLimitService = &RateLimitServiceMock{}
+
+// RateLimitServiceMock is a mock implementation of models.RateLimitService.
+//
+//	func TestSomethingThatUsesRateLimitService(t *testing.T) {
+//
+//		// make and configure a mocked models.RateLimitService
+//		mockedRateLimitService := &RateLimitServiceMock{
+//			AllowFunc: func(ctx context.Context, request *models.RateLimitRequest) (time.Duration, error) {
+//				panic("mock out the Allow method")
+//			},
+//			StatusFunc: func(ctx context.Context) ([]models.RateLimitStatus, error) {
+//				panic("mock out the Status method")
+//			},
+//		}
+//
+//		// use mockedRateLimitService in code that requires models.RateLimitService
+//		// and then make assertions.
+//
+//	}
+type RateLimitServiceMock struct {
+	// AllowFunc mocks the Allow method.
+	AllowFunc func(ctx context.Context, request *models.RateLimitRequest) (time.Duration, error)
+
+	// StatusFunc mocks the Status method.
+	StatusFunc func(ctx context.Context) ([]models.RateLimitStatus, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Allow holds details about calls to the Allow method.
+		Allow []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Request is the request argument value.
+			Request *models.RateLimitRequest
+		}
+		// Status holds details about calls to the Status method.
+		Status []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockAllow  sync.RWMutex
+	lockStatus sync.RWMutex
+}
+
+// Allow calls AllowFunc.
+func (mock *RateLimitServiceMock) Allow(ctx context.Context, request *models.RateLimitRequest) (time.Duration, error) {
+	if mock.AllowFunc == nil {
+		panic("RateLimitServiceMock.AllowFunc: method is nil but RateLimitService.Allow was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Request *models.RateLimitRequest
+	}{
+		Ctx:     ctx,
+		Request: request,
+	}
+	mock.lockAllow.Lock()
+	mock.calls.Allow = append(mock.calls.Allow, callInfo)
+	mock.lockAllow.Unlock()
+	return mock.AllowFunc(ctx, request)
+}
+
+// AllowCalls gets all the calls that were made to Allow.
+// Check the length with:
+//
+//	len(mockedRateLimitService.AllowCalls())
+func (mock *RateLimitServiceMock) AllowCalls() []struct {
+	Ctx     context.Context
+	Request *models.RateLimitRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Request *models.RateLimitRequest
+	}
+	mock.lockAllow.RLock()
+	calls = mock.calls.Allow
+	mock.lockAllow.RUnlock()
+	return calls
+}
+
+// Status calls StatusFunc.
+func (mock *RateLimitServiceMock) Status(ctx context.Context) ([]models.RateLimitStatus, error) {
+	if mock.StatusFunc == nil {
+		panic("RateLimitServiceMock.StatusFunc: method is nil but RateLimitService.Status was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockStatus.Lock()
+	mock.calls.Status = append(mock.calls.Status, callInfo)
+	mock.lockStatus.Unlock()
+	return mock.StatusFunc(ctx)
+}
+
+// StatusCalls gets all the calls that were made to Status.
+// Check the length with:
+//
+//	len(mockedRateLimitService.StatusCalls())
+func (mock *RateLimitServiceMock) StatusCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockStatus.RLock()
+	calls = mock.calls.Status
+	mock.lockStatus.RUnlock()
+	return calls
+}
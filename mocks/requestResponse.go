@@ -15,25 +15,25 @@ var _ models.RequestResponse = &RequestResponseMock{}
 
 // RequestResponseMock is a mock implementation of models.RequestResponse.
 //
-// 	func TestSomethingThatUsesRequestResponse(t *testing.T) {
+//	func TestSomethingThatUsesRequestResponse(t *testing.T) {
 //
-// 		// make and configure a mocked models.RequestResponse
-// 		mockedRequestResponse := &RequestResponseMock{
-// 			HandleErrorFunc: func(err error, w http.ResponseWriter, r *http.Request, status int)  {
-// 				panic("mock out the HandleError method")
-// 			},
-// 			HandleResponseFunc: func(body interface{}, w http.ResponseWriter, r *http.Request, status int)  {
-// 				panic("mock out the HandleResponse method")
-// 			},
-// 			ReadBodyAsJSONFunc: func(req *http.Request, dest interface{}) error {
-// 				panic("mock out the ReadBodyAsJSON method")
-// 			},
-// 		}
+//		// make and configure a mocked models.RequestResponse
+//		mockedRequestResponse := &RequestResponseMock{
+//			HandleErrorFunc: func(err error, w http.ResponseWriter, r *http.Request, status int)  {
+//				panic("mock out the HandleError method")
+//			},
+//			HandleResponseFunc: func(body interface{}, w http.ResponseWriter, r *http.Request, status int)  {
+//				panic("mock out the HandleResponse method")
+//			},
+//			ReadBodyAsJSONFunc: func(req *http.Request, dest interface{}) error {
+//				panic("mock out the ReadBodyAsJSON method")
+//			},
+//		}
 //
-// 		// use mockedRequestResponse in code that requires models.RequestResponse
-// 		// and then make assertions.
+//		// use mockedRequestResponse in code that requires models.RequestResponse
+//		// and then make assertions.
 //
-// 	}
+//	}
 type RequestResponseMock struct {
 	// HandleErrorFunc mocks the HandleError method.
 	HandleErrorFunc func(err error, w http.ResponseWriter, r *http.Request, status int)
@@ -105,7 +105,8 @@ func (mock *RequestResponseMock) HandleError(err error, w http.ResponseWriter, r
 
 // HandleErrorCalls gets all the calls that were made to HandleError.
 // Check the length with:
-//     len(mockedRequestResponse.HandleErrorCalls())
+//
+//	len(mockedRequestResponse.HandleErrorCalls())
 func (mock *RequestResponseMock) HandleErrorCalls() []struct {
 	Err    error
 	W      http.ResponseWriter
@@ -148,7 +149,8 @@ func (mock *RequestResponseMock) HandleResponse(body interface{}, w http.Respons
 
 // HandleResponseCalls gets all the calls that were made to HandleResponse.
 // Check the length with:
-//     len(mockedRequestResponse.HandleResponseCalls())
+//
+//	len(mockedRequestResponse.HandleResponseCalls())
 func (mock *RequestResponseMock) HandleResponseCalls() []struct {
 	Body   interface{}
 	W      http.ResponseWriter
@@ -187,7 +189,8 @@ func (mock *RequestResponseMock) ReadBodyAsJSON(req *http.Request, dest interfac
 
 // ReadBodyAsJSONCalls gets all the calls that were made to ReadBodyAsJSON.
 // Check the length with:
-//     len(mockedRequestResponse.ReadBodyAsJSONCalls())
+//
+//	len(mockedRequestResponse.ReadBodyAsJSONCalls())
 func (mock *RequestResponseMock) ReadBodyAsJSONCalls() []struct {
 	Req  *http.Request
 	Dest interface{}
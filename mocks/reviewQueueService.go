@@ -0,0 +1,132 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"sync"
+)
+
+// Ensure, that ReviewQueueServiceMock does implement models.ReviewQueueService.
+// If this is not the case, regenerate this file with moq.
+var _ models.ReviewQueueService = &ReviewQueueServiceMock{}
+
+// ReviewQueueServiceMock is a mock implementation of models.ReviewQueueService.
+//
+//	func TestSomethingThatUsesReviewQueueService(t *testing.T) {
+//
+//		// make and configure a mocked models.ReviewQueueService
+//		mockedReviewQueueService := &ReviewQueueServiceMock{
+//			AddFunc: func(ctx context.Context, entries []models.ReviewEntry) error {
+//				panic("mock out the Add method")
+//			},
+//			ListFunc: func(ctx context.Context, query models.ReviewQuery) ([]models.ReviewEntry, int, error) {
+//				panic("mock out the List method")
+//			},
+//		}
+//
+//		// use mockedReviewQueueService in code that requires models.ReviewQueueService
+//		// and then make assertions.
+//
+//	}
+type ReviewQueueServiceMock struct {
+	// AddFunc mocks the Add method.
+	AddFunc func(ctx context.Context, entries []models.ReviewEntry) error
+
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context, query models.ReviewQuery) ([]models.ReviewEntry, int, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Add holds details about calls to the Add method.
+		Add []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entries is the entries argument value.
+			Entries []models.ReviewEntry
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Query is the query argument value.
+			Query models.ReviewQuery
+		}
+	}
+	lockAdd  sync.RWMutex
+	lockList sync.RWMutex
+}
+
+// Add calls AddFunc.
+func (mock *ReviewQueueServiceMock) Add(ctx context.Context, entries []models.ReviewEntry) error {
+	if mock.AddFunc == nil {
+		panic("ReviewQueueServiceMock.AddFunc: method is nil but ReviewQueueService.Add was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Entries []models.ReviewEntry
+	}{
+		Ctx:     ctx,
+		Entries: entries,
+	}
+	mock.lockAdd.Lock()
+	mock.calls.Add = append(mock.calls.Add, callInfo)
+	mock.lockAdd.Unlock()
+	return mock.AddFunc(ctx, entries)
+}
+
+// AddCalls gets all the calls that were made to Add.
+// Check the length with:
+//
+//	len(mockedReviewQueueService.AddCalls())
+func (mock *ReviewQueueServiceMock) AddCalls() []struct {
+	Ctx     context.Context
+	Entries []models.ReviewEntry
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Entries []models.ReviewEntry
+	}
+	mock.lockAdd.RLock()
+	calls = mock.calls.Add
+	mock.lockAdd.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *ReviewQueueServiceMock) List(ctx context.Context, query models.ReviewQuery) ([]models.ReviewEntry, int, error) {
+	if mock.ListFunc == nil {
+		panic("ReviewQueueServiceMock.ListFunc: method is nil but ReviewQueueService.List was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Query models.ReviewQuery
+	}{
+		Ctx:   ctx,
+		Query: query,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx, query)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedReviewQueueService.ListCalls())
+func (mock *ReviewQueueServiceMock) ListCalls() []struct {
+	Ctx   context.Context
+	Query models.ReviewQuery
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Query models.ReviewQuery
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
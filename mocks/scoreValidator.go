@@ -0,0 +1,82 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"github.com/pedrocmart/leaderboard-service/models"
+	"sync"
+)
+
+// Ensure, that ScoreValidatorMock does implement models.ScoreValidator.
+// If this is not the case, regenerate this file with moq.
+var _ models.ScoreValidator = &ScoreValidatorMock{}
+
+// ScoreValidatorMock is a mock implementation of models.ScoreValidator.
+//
+//	func TestSomethingThatUsesScoreValidator(t *testing.T) {
+//
+//		// make and configure a mocked models.ScoreValidator
+//		mockedScoreValidator := &ScoreValidatorMock{
+//			ValidateFunc: func(ctx context.Context, check *models.ScoreCheck) (*models.ValidationResult, error) {
+//				panic("mock out the Validate method")
+//			},
+//		}
+//
+//		// use mockedScoreValidator in code that requires models.ScoreValidator
+//		// and then make assertions.
+//
+//	}
+type ScoreValidatorMock struct {
+	// ValidateFunc mocks the Validate method.
+	ValidateFunc func(ctx context.Context, check *models.ScoreCheck) (*models.ValidationResult, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Validate holds details about calls to the Validate method.
+		Validate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Check is the check argument value.
+			Check *models.ScoreCheck
+		}
+	}
+	lockValidate sync.RWMutex
+}
+
+// Validate calls ValidateFunc.
+func (mock *ScoreValidatorMock) Validate(ctx context.Context, check *models.ScoreCheck) (*models.ValidationResult, error) {
+	if mock.ValidateFunc == nil {
+		panic("ScoreValidatorMock.ValidateFunc: method is nil but ScoreValidator.Validate was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Check *models.ScoreCheck
+	}{
+		Ctx:   ctx,
+		Check: check,
+	}
+	mock.lockValidate.Lock()
+	mock.calls.Validate = append(mock.calls.Validate, callInfo)
+	mock.lockValidate.Unlock()
+	return mock.ValidateFunc(ctx, check)
+}
+
+// ValidateCalls gets all the calls that were made to Validate.
+// Check the length with:
+//
+//	len(mockedScoreValidator.ValidateCalls())
+func (mock *ScoreValidatorMock) ValidateCalls() []struct {
+	Ctx   context.Context
+	Check *models.ScoreCheck
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Check *models.ScoreCheck
+	}
+	mock.lockValidate.RLock()
+	calls = mock.calls.Validate
+	mock.lockValidate.RUnlock()
+	return calls
+}
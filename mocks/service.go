@@ -15,80 +15,603 @@ var _ models.Service = &ServiceMock{}
 
 // ServiceMock is a mock implementation of models.Service.
 //
-// 	func TestSomethingThatUsesService(t *testing.T) {
-//
-// 		// make and configure a mocked models.Service
-// 		mockedService := &ServiceMock{
-// 			HandleGetRankingFunc: func(contextMoqParam context.Context, s string) (*models.GetRankingResponse, error) {
-// 				panic("mock out the HandleGetRanking method")
-// 			},
-// 			HandleSubmitScoreFunc: func(contextMoqParam context.Context, submitScoreRequest *models.SubmitScoreRequest, s string) (*models.SubmitScoreResponse, error) {
-// 				panic("mock out the HandleSubmitScore method")
-// 			},
-// 		}
-//
-// 		// use mockedService in code that requires models.Service
-// 		// and then make assertions.
-//
-// 	}
+//	func TestSomethingThatUsesService(t *testing.T) {
+//
+//		// make and configure a mocked models.Service
+//		mockedService := &ServiceMock{
+//			HandleCreateLeaderboardFunc: func(ctx context.Context, request *models.CreateLeaderboardRequest) (*models.LeaderboardResponse, error) {
+//				panic("mock out the HandleCreateLeaderboard method")
+//			},
+//			HandleDeleteLeaderboardFunc: func(ctx context.Context, board string) (*models.LeaderboardResponse, error) {
+//				panic("mock out the HandleDeleteLeaderboard method")
+//			},
+//			HandleDeleteUserFunc: func(ctx context.Context, board string, userId string) (*models.User, error) {
+//				panic("mock out the HandleDeleteUser method")
+//			},
+//			HandleFreezeUserFunc: func(ctx context.Context, board string, userId string, request *models.FreezeUserRequest) (*models.FrozenUser, error) {
+//				panic("mock out the HandleFreezeUser method")
+//			},
+//			HandleGetFrozenUsersFunc: func(ctx context.Context, board string) (*models.GetFrozenUsersResponse, error) {
+//				panic("mock out the HandleGetFrozenUsers method")
+//			},
+//			HandleGetLeaderboardsFunc: func(ctx context.Context) (*models.GetLeaderboardsResponse, error) {
+//				panic("mock out the HandleGetLeaderboards method")
+//			},
+//			HandleGetRankingFunc: func(ctx context.Context, board string, request *models.GetRankingRequest) (*models.GetRankingResponse, error) {
+//				panic("mock out the HandleGetRanking method")
+//			},
+//			HandleGetRankingPageFunc: func(ctx context.Context, board string, request *models.GetRankingPageRequest) (*models.GetRankingPageResponse, error) {
+//				panic("mock out the HandleGetRankingPage method")
+//			},
+//			HandleGetRankingStatsFunc: func(ctx context.Context, board string, request *models.GetRankingStatsRequest) (*models.GetRankingStatsResponse, error) {
+//				panic("mock out the HandleGetRankingStats method")
+//			},
+//			HandleGetRateLimitsFunc: func(ctx context.Context) (*models.GetRateLimitsResponse, error) {
+//				panic("mock out the HandleGetRateLimits method")
+//			},
+//			HandleGetReadinessFunc: func(ctx context.Context) (*models.ReadinessResponse, error) {
+//				panic("mock out the HandleGetReadiness method")
+//			},
+//			HandleGetReviewsFunc: func(ctx context.Context, request *models.GetReviewsRequest) (*models.GetReviewsResponse, error) {
+//				panic("mock out the HandleGetReviews method")
+//			},
+//			HandleGetUserHistoryFunc: func(ctx context.Context, board string, userId string, request *models.GetUserHistoryRequest) (*models.GetUserHistoryResponse, error) {
+//				panic("mock out the HandleGetUserHistory method")
+//			},
+//			HandleGetUserRankFunc: func(ctx context.Context, board string, userId string) (*models.GetUserRankResponse, error) {
+//				panic("mock out the HandleGetUserRank method")
+//			},
+//			HandleMergeUsersFunc: func(ctx context.Context, board string, userId string, request *models.MergeUsersRequest) (*models.User, error) {
+//				panic("mock out the HandleMergeUsers method")
+//			},
+//			HandleResetLeaderboardFunc: func(ctx context.Context, board string) (*models.ResetLeaderboardResponse, error) {
+//				panic("mock out the HandleResetLeaderboard method")
+//			},
+//			HandleResetUserFunc: func(ctx context.Context, board string, userId string) (*models.User, error) {
+//				panic("mock out the HandleResetUser method")
+//			},
+//			HandleStreamRankingFunc: func(ctx context.Context, board string, request *models.GetRankingRequest, send func(*models.RankingUpdate) error) error {
+//				panic("mock out the HandleStreamRanking method")
+//			},
+//			HandleSubmitScoreFunc: func(ctx context.Context, board string, request *models.SubmitScoreRequest, userId string) (*models.SubmitScoreResponse, error) {
+//				panic("mock out the HandleSubmitScore method")
+//			},
+//			HandleSubmitScoresFunc: func(ctx context.Context, board string, request *models.SubmitScoresRequest) (*models.SubmitScoresResponse, error) {
+//				panic("mock out the HandleSubmitScores method")
+//			},
+//			HandleUnfreezeUserFunc: func(ctx context.Context, board string, userId string) (*models.FrozenUser, error) {
+//				panic("mock out the HandleUnfreezeUser method")
+//			},
+//		}
+//
+//		// use mockedService in code that requires models.Service
+//		// and then make assertions.
+//
+//	}
 type ServiceMock struct {
+	// HandleCreateLeaderboardFunc mocks the HandleCreateLeaderboard method.
+	HandleCreateLeaderboardFunc func(ctx context.Context, request *models.CreateLeaderboardRequest) (*models.LeaderboardResponse, error)
+
+	// HandleDeleteLeaderboardFunc mocks the HandleDeleteLeaderboard method.
+	HandleDeleteLeaderboardFunc func(ctx context.Context, board string) (*models.LeaderboardResponse, error)
+
+	// HandleDeleteUserFunc mocks the HandleDeleteUser method.
+	HandleDeleteUserFunc func(ctx context.Context, board string, userId string) (*models.User, error)
+
+	// HandleFreezeUserFunc mocks the HandleFreezeUser method.
+	HandleFreezeUserFunc func(ctx context.Context, board string, userId string, request *models.FreezeUserRequest) (*models.FrozenUser, error)
+
+	// HandleGetFrozenUsersFunc mocks the HandleGetFrozenUsers method.
+	HandleGetFrozenUsersFunc func(ctx context.Context, board string) (*models.GetFrozenUsersResponse, error)
+
+	// HandleGetLeaderboardsFunc mocks the HandleGetLeaderboards method.
+	HandleGetLeaderboardsFunc func(ctx context.Context) (*models.GetLeaderboardsResponse, error)
+
 	// HandleGetRankingFunc mocks the HandleGetRanking method.
-	HandleGetRankingFunc func(contextMoqParam context.Context, s string) (*models.GetRankingResponse, error)
+	HandleGetRankingFunc func(ctx context.Context, board string, request *models.GetRankingRequest) (*models.GetRankingResponse, error)
+
+	// HandleGetRankingPageFunc mocks the HandleGetRankingPage method.
+	HandleGetRankingPageFunc func(ctx context.Context, board string, request *models.GetRankingPageRequest) (*models.GetRankingPageResponse, error)
+
+	// HandleGetRankingStatsFunc mocks the HandleGetRankingStats method.
+	HandleGetRankingStatsFunc func(ctx context.Context, board string, request *models.GetRankingStatsRequest) (*models.GetRankingStatsResponse, error)
+
+	// HandleGetRateLimitsFunc mocks the HandleGetRateLimits method.
+	HandleGetRateLimitsFunc func(ctx context.Context) (*models.GetRateLimitsResponse, error)
+
+	// HandleGetReadinessFunc mocks the HandleGetReadiness method.
+	HandleGetReadinessFunc func(ctx context.Context) (*models.ReadinessResponse, error)
+
+	// HandleGetReviewsFunc mocks the HandleGetReviews method.
+	HandleGetReviewsFunc func(ctx context.Context, request *models.GetReviewsRequest) (*models.GetReviewsResponse, error)
+
+	// HandleGetUserHistoryFunc mocks the HandleGetUserHistory method.
+	HandleGetUserHistoryFunc func(ctx context.Context, board string, userId string, request *models.GetUserHistoryRequest) (*models.GetUserHistoryResponse, error)
+
+	// HandleGetUserRankFunc mocks the HandleGetUserRank method.
+	HandleGetUserRankFunc func(ctx context.Context, board string, userId string) (*models.GetUserRankResponse, error)
+
+	// HandleMergeUsersFunc mocks the HandleMergeUsers method.
+	HandleMergeUsersFunc func(ctx context.Context, board string, userId string, request *models.MergeUsersRequest) (*models.User, error)
+
+	// HandleResetLeaderboardFunc mocks the HandleResetLeaderboard method.
+	HandleResetLeaderboardFunc func(ctx context.Context, board string) (*models.ResetLeaderboardResponse, error)
+
+	// HandleResetUserFunc mocks the HandleResetUser method.
+	HandleResetUserFunc func(ctx context.Context, board string, userId string) (*models.User, error)
+
+	// HandleStreamRankingFunc mocks the HandleStreamRanking method.
+	HandleStreamRankingFunc func(ctx context.Context, board string, request *models.GetRankingRequest, send func(*models.RankingUpdate) error) error
 
 	// HandleSubmitScoreFunc mocks the HandleSubmitScore method.
-	HandleSubmitScoreFunc func(contextMoqParam context.Context, submitScoreRequest *models.SubmitScoreRequest, s string) (*models.SubmitScoreResponse, error)
+	HandleSubmitScoreFunc func(ctx context.Context, board string, request *models.SubmitScoreRequest, userId string) (*models.SubmitScoreResponse, error)
+
+	// HandleSubmitScoresFunc mocks the HandleSubmitScores method.
+	HandleSubmitScoresFunc func(ctx context.Context, board string, request *models.SubmitScoresRequest) (*models.SubmitScoresResponse, error)
+
+	// HandleUnfreezeUserFunc mocks the HandleUnfreezeUser method.
+	HandleUnfreezeUserFunc func(ctx context.Context, board string, userId string) (*models.FrozenUser, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// HandleCreateLeaderboard holds details about calls to the HandleCreateLeaderboard method.
+		HandleCreateLeaderboard []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Request is the request argument value.
+			Request *models.CreateLeaderboardRequest
+		}
+		// HandleDeleteLeaderboard holds details about calls to the HandleDeleteLeaderboard method.
+		HandleDeleteLeaderboard []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+		}
+		// HandleDeleteUser holds details about calls to the HandleDeleteUser method.
+		HandleDeleteUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// UserId is the userId argument value.
+			UserId string
+		}
+		// HandleFreezeUser holds details about calls to the HandleFreezeUser method.
+		HandleFreezeUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// UserId is the userId argument value.
+			UserId string
+			// Request is the request argument value.
+			Request *models.FreezeUserRequest
+		}
+		// HandleGetFrozenUsers holds details about calls to the HandleGetFrozenUsers method.
+		HandleGetFrozenUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+		}
+		// HandleGetLeaderboards holds details about calls to the HandleGetLeaderboards method.
+		HandleGetLeaderboards []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// HandleGetRanking holds details about calls to the HandleGetRanking method.
 		HandleGetRanking []struct {
-			// ContextMoqParam is the contextMoqParam argument value.
-			ContextMoqParam context.Context
-			// S is the s argument value.
-			S string
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// Request is the request argument value.
+			Request *models.GetRankingRequest
+		}
+		// HandleGetRankingPage holds details about calls to the HandleGetRankingPage method.
+		HandleGetRankingPage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// Request is the request argument value.
+			Request *models.GetRankingPageRequest
+		}
+		// HandleGetRankingStats holds details about calls to the HandleGetRankingStats method.
+		HandleGetRankingStats []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// Request is the request argument value.
+			Request *models.GetRankingStatsRequest
+		}
+		// HandleGetRateLimits holds details about calls to the HandleGetRateLimits method.
+		HandleGetRateLimits []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// HandleGetReadiness holds details about calls to the HandleGetReadiness method.
+		HandleGetReadiness []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// HandleGetReviews holds details about calls to the HandleGetReviews method.
+		HandleGetReviews []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Request is the request argument value.
+			Request *models.GetReviewsRequest
+		}
+		// HandleGetUserHistory holds details about calls to the HandleGetUserHistory method.
+		HandleGetUserHistory []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// UserId is the userId argument value.
+			UserId string
+			// Request is the request argument value.
+			Request *models.GetUserHistoryRequest
+		}
+		// HandleGetUserRank holds details about calls to the HandleGetUserRank method.
+		HandleGetUserRank []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// UserId is the userId argument value.
+			UserId string
+		}
+		// HandleMergeUsers holds details about calls to the HandleMergeUsers method.
+		HandleMergeUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// UserId is the userId argument value.
+			UserId string
+			// Request is the request argument value.
+			Request *models.MergeUsersRequest
+		}
+		// HandleResetLeaderboard holds details about calls to the HandleResetLeaderboard method.
+		HandleResetLeaderboard []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+		}
+		// HandleResetUser holds details about calls to the HandleResetUser method.
+		HandleResetUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// UserId is the userId argument value.
+			UserId string
+		}
+		// HandleStreamRanking holds details about calls to the HandleStreamRanking method.
+		HandleStreamRanking []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// Request is the request argument value.
+			Request *models.GetRankingRequest
+			// Send is the send argument value.
+			Send func(*models.RankingUpdate) error
 		}
 		// HandleSubmitScore holds details about calls to the HandleSubmitScore method.
 		HandleSubmitScore []struct {
-			// ContextMoqParam is the contextMoqParam argument value.
-			ContextMoqParam context.Context
-			// SubmitScoreRequest is the submitScoreRequest argument value.
-			SubmitScoreRequest *models.SubmitScoreRequest
-			// S is the s argument value.
-			S string
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// Request is the request argument value.
+			Request *models.SubmitScoreRequest
+			// UserId is the userId argument value.
+			UserId string
 		}
+		// HandleSubmitScores holds details about calls to the HandleSubmitScores method.
+		HandleSubmitScores []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// Request is the request argument value.
+			Request *models.SubmitScoresRequest
+		}
+		// HandleUnfreezeUser holds details about calls to the HandleUnfreezeUser method.
+		HandleUnfreezeUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// UserId is the userId argument value.
+			UserId string
+		}
+	}
+	lockHandleCreateLeaderboard sync.RWMutex
+	lockHandleDeleteLeaderboard sync.RWMutex
+	lockHandleDeleteUser        sync.RWMutex
+	lockHandleFreezeUser        sync.RWMutex
+	lockHandleGetFrozenUsers    sync.RWMutex
+	lockHandleGetLeaderboards   sync.RWMutex
+	lockHandleGetRanking        sync.RWMutex
+	lockHandleGetRankingPage    sync.RWMutex
+	lockHandleGetRankingStats   sync.RWMutex
+	lockHandleGetRateLimits     sync.RWMutex
+	lockHandleGetReadiness      sync.RWMutex
+	lockHandleGetReviews        sync.RWMutex
+	lockHandleGetUserHistory    sync.RWMutex
+	lockHandleGetUserRank       sync.RWMutex
+	lockHandleMergeUsers        sync.RWMutex
+	lockHandleResetLeaderboard  sync.RWMutex
+	lockHandleResetUser         sync.RWMutex
+	lockHandleStreamRanking     sync.RWMutex
+	lockHandleSubmitScore       sync.RWMutex
+	lockHandleSubmitScores      sync.RWMutex
+	lockHandleUnfreezeUser      sync.RWMutex
+}
+
+// HandleCreateLeaderboard calls HandleCreateLeaderboardFunc.
+func (mock *ServiceMock) HandleCreateLeaderboard(ctx context.Context, request *models.CreateLeaderboardRequest) (*models.LeaderboardResponse, error) {
+	if mock.HandleCreateLeaderboardFunc == nil {
+		panic("ServiceMock.HandleCreateLeaderboardFunc: method is nil but Service.HandleCreateLeaderboard was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Request *models.CreateLeaderboardRequest
+	}{
+		Ctx:     ctx,
+		Request: request,
+	}
+	mock.lockHandleCreateLeaderboard.Lock()
+	mock.calls.HandleCreateLeaderboard = append(mock.calls.HandleCreateLeaderboard, callInfo)
+	mock.lockHandleCreateLeaderboard.Unlock()
+	return mock.HandleCreateLeaderboardFunc(ctx, request)
+}
+
+// HandleCreateLeaderboardCalls gets all the calls that were made to HandleCreateLeaderboard.
+// Check the length with:
+//
+//	len(mockedService.HandleCreateLeaderboardCalls())
+func (mock *ServiceMock) HandleCreateLeaderboardCalls() []struct {
+	Ctx     context.Context
+	Request *models.CreateLeaderboardRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Request *models.CreateLeaderboardRequest
 	}
-	lockHandleGetRanking  sync.RWMutex
-	lockHandleSubmitScore sync.RWMutex
+	mock.lockHandleCreateLeaderboard.RLock()
+	calls = mock.calls.HandleCreateLeaderboard
+	mock.lockHandleCreateLeaderboard.RUnlock()
+	return calls
+}
+
+// HandleDeleteLeaderboard calls HandleDeleteLeaderboardFunc.
+func (mock *ServiceMock) HandleDeleteLeaderboard(ctx context.Context, board string) (*models.LeaderboardResponse, error) {
+	if mock.HandleDeleteLeaderboardFunc == nil {
+		panic("ServiceMock.HandleDeleteLeaderboardFunc: method is nil but Service.HandleDeleteLeaderboard was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Board string
+	}{
+		Ctx:   ctx,
+		Board: board,
+	}
+	mock.lockHandleDeleteLeaderboard.Lock()
+	mock.calls.HandleDeleteLeaderboard = append(mock.calls.HandleDeleteLeaderboard, callInfo)
+	mock.lockHandleDeleteLeaderboard.Unlock()
+	return mock.HandleDeleteLeaderboardFunc(ctx, board)
+}
+
+// HandleDeleteLeaderboardCalls gets all the calls that were made to HandleDeleteLeaderboard.
+// Check the length with:
+//
+//	len(mockedService.HandleDeleteLeaderboardCalls())
+func (mock *ServiceMock) HandleDeleteLeaderboardCalls() []struct {
+	Ctx   context.Context
+	Board string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Board string
+	}
+	mock.lockHandleDeleteLeaderboard.RLock()
+	calls = mock.calls.HandleDeleteLeaderboard
+	mock.lockHandleDeleteLeaderboard.RUnlock()
+	return calls
+}
+
+// HandleDeleteUser calls HandleDeleteUserFunc.
+func (mock *ServiceMock) HandleDeleteUser(ctx context.Context, board string, userId string) (*models.User, error) {
+	if mock.HandleDeleteUserFunc == nil {
+		panic("ServiceMock.HandleDeleteUserFunc: method is nil but Service.HandleDeleteUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Board  string
+		UserId string
+	}{
+		Ctx:    ctx,
+		Board:  board,
+		UserId: userId,
+	}
+	mock.lockHandleDeleteUser.Lock()
+	mock.calls.HandleDeleteUser = append(mock.calls.HandleDeleteUser, callInfo)
+	mock.lockHandleDeleteUser.Unlock()
+	return mock.HandleDeleteUserFunc(ctx, board, userId)
+}
+
+// HandleDeleteUserCalls gets all the calls that were made to HandleDeleteUser.
+// Check the length with:
+//
+//	len(mockedService.HandleDeleteUserCalls())
+func (mock *ServiceMock) HandleDeleteUserCalls() []struct {
+	Ctx    context.Context
+	Board  string
+	UserId string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Board  string
+		UserId string
+	}
+	mock.lockHandleDeleteUser.RLock()
+	calls = mock.calls.HandleDeleteUser
+	mock.lockHandleDeleteUser.RUnlock()
+	return calls
+}
+
+// HandleFreezeUser calls HandleFreezeUserFunc.
+func (mock *ServiceMock) HandleFreezeUser(ctx context.Context, board string, userId string, request *models.FreezeUserRequest) (*models.FrozenUser, error) {
+	if mock.HandleFreezeUserFunc == nil {
+		panic("ServiceMock.HandleFreezeUserFunc: method is nil but Service.HandleFreezeUser was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Board   string
+		UserId  string
+		Request *models.FreezeUserRequest
+	}{
+		Ctx:     ctx,
+		Board:   board,
+		UserId:  userId,
+		Request: request,
+	}
+	mock.lockHandleFreezeUser.Lock()
+	mock.calls.HandleFreezeUser = append(mock.calls.HandleFreezeUser, callInfo)
+	mock.lockHandleFreezeUser.Unlock()
+	return mock.HandleFreezeUserFunc(ctx, board, userId, request)
+}
+
+// HandleFreezeUserCalls gets all the calls that were made to HandleFreezeUser.
+// Check the length with:
+//
+//	len(mockedService.HandleFreezeUserCalls())
+func (mock *ServiceMock) HandleFreezeUserCalls() []struct {
+	Ctx     context.Context
+	Board   string
+	UserId  string
+	Request *models.FreezeUserRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Board   string
+		UserId  string
+		Request *models.FreezeUserRequest
+	}
+	mock.lockHandleFreezeUser.RLock()
+	calls = mock.calls.HandleFreezeUser
+	mock.lockHandleFreezeUser.RUnlock()
+	return calls
+}
+
+// HandleGetFrozenUsers calls HandleGetFrozenUsersFunc.
+func (mock *ServiceMock) HandleGetFrozenUsers(ctx context.Context, board string) (*models.GetFrozenUsersResponse, error) {
+	if mock.HandleGetFrozenUsersFunc == nil {
+		panic("ServiceMock.HandleGetFrozenUsersFunc: method is nil but Service.HandleGetFrozenUsers was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Board string
+	}{
+		Ctx:   ctx,
+		Board: board,
+	}
+	mock.lockHandleGetFrozenUsers.Lock()
+	mock.calls.HandleGetFrozenUsers = append(mock.calls.HandleGetFrozenUsers, callInfo)
+	mock.lockHandleGetFrozenUsers.Unlock()
+	return mock.HandleGetFrozenUsersFunc(ctx, board)
+}
+
+// HandleGetFrozenUsersCalls gets all the calls that were made to HandleGetFrozenUsers.
+// Check the length with:
+//
+//	len(mockedService.HandleGetFrozenUsersCalls())
+func (mock *ServiceMock) HandleGetFrozenUsersCalls() []struct {
+	Ctx   context.Context
+	Board string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Board string
+	}
+	mock.lockHandleGetFrozenUsers.RLock()
+	calls = mock.calls.HandleGetFrozenUsers
+	mock.lockHandleGetFrozenUsers.RUnlock()
+	return calls
+}
+
+// HandleGetLeaderboards calls HandleGetLeaderboardsFunc.
+func (mock *ServiceMock) HandleGetLeaderboards(ctx context.Context) (*models.GetLeaderboardsResponse, error) {
+	if mock.HandleGetLeaderboardsFunc == nil {
+		panic("ServiceMock.HandleGetLeaderboardsFunc: method is nil but Service.HandleGetLeaderboards was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockHandleGetLeaderboards.Lock()
+	mock.calls.HandleGetLeaderboards = append(mock.calls.HandleGetLeaderboards, callInfo)
+	mock.lockHandleGetLeaderboards.Unlock()
+	return mock.HandleGetLeaderboardsFunc(ctx)
+}
+
+// HandleGetLeaderboardsCalls gets all the calls that were made to HandleGetLeaderboards.
+// Check the length with:
+//
+//	len(mockedService.HandleGetLeaderboardsCalls())
+func (mock *ServiceMock) HandleGetLeaderboardsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockHandleGetLeaderboards.RLock()
+	calls = mock.calls.HandleGetLeaderboards
+	mock.lockHandleGetLeaderboards.RUnlock()
+	return calls
 }
 
 // HandleGetRanking calls HandleGetRankingFunc.
-func (mock *ServiceMock) HandleGetRanking(contextMoqParam context.Context, s string) (*models.GetRankingResponse, error) {
+func (mock *ServiceMock) HandleGetRanking(ctx context.Context, board string, request *models.GetRankingRequest) (*models.GetRankingResponse, error) {
 	if mock.HandleGetRankingFunc == nil {
 		panic("ServiceMock.HandleGetRankingFunc: method is nil but Service.HandleGetRanking was just called")
 	}
 	callInfo := struct {
-		ContextMoqParam context.Context
-		S               string
+		Ctx     context.Context
+		Board   string
+		Request *models.GetRankingRequest
 	}{
-		ContextMoqParam: contextMoqParam,
-		S:               s,
+		Ctx:     ctx,
+		Board:   board,
+		Request: request,
 	}
 	mock.lockHandleGetRanking.Lock()
 	mock.calls.HandleGetRanking = append(mock.calls.HandleGetRanking, callInfo)
 	mock.lockHandleGetRanking.Unlock()
-	return mock.HandleGetRankingFunc(contextMoqParam, s)
+	return mock.HandleGetRankingFunc(ctx, board, request)
 }
 
 // HandleGetRankingCalls gets all the calls that were made to HandleGetRanking.
 // Check the length with:
-//     len(mockedService.HandleGetRankingCalls())
+//
+//	len(mockedService.HandleGetRankingCalls())
 func (mock *ServiceMock) HandleGetRankingCalls() []struct {
-	ContextMoqParam context.Context
-	S               string
+	Ctx     context.Context
+	Board   string
+	Request *models.GetRankingRequest
 } {
 	var calls []struct {
-		ContextMoqParam context.Context
-		S               string
+		Ctx     context.Context
+		Board   string
+		Request *models.GetRankingRequest
 	}
 	mock.lockHandleGetRanking.RLock()
 	calls = mock.calls.HandleGetRanking
@@ -96,41 +619,554 @@ func (mock *ServiceMock) HandleGetRankingCalls() []struct {
 	return calls
 }
 
+// HandleGetRankingPage calls HandleGetRankingPageFunc.
+func (mock *ServiceMock) HandleGetRankingPage(ctx context.Context, board string, request *models.GetRankingPageRequest) (*models.GetRankingPageResponse, error) {
+	if mock.HandleGetRankingPageFunc == nil {
+		panic("ServiceMock.HandleGetRankingPageFunc: method is nil but Service.HandleGetRankingPage was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Board   string
+		Request *models.GetRankingPageRequest
+	}{
+		Ctx:     ctx,
+		Board:   board,
+		Request: request,
+	}
+	mock.lockHandleGetRankingPage.Lock()
+	mock.calls.HandleGetRankingPage = append(mock.calls.HandleGetRankingPage, callInfo)
+	mock.lockHandleGetRankingPage.Unlock()
+	return mock.HandleGetRankingPageFunc(ctx, board, request)
+}
+
+// HandleGetRankingPageCalls gets all the calls that were made to HandleGetRankingPage.
+// Check the length with:
+//
+//	len(mockedService.HandleGetRankingPageCalls())
+func (mock *ServiceMock) HandleGetRankingPageCalls() []struct {
+	Ctx     context.Context
+	Board   string
+	Request *models.GetRankingPageRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Board   string
+		Request *models.GetRankingPageRequest
+	}
+	mock.lockHandleGetRankingPage.RLock()
+	calls = mock.calls.HandleGetRankingPage
+	mock.lockHandleGetRankingPage.RUnlock()
+	return calls
+}
+
+// HandleGetRankingStats calls HandleGetRankingStatsFunc.
+func (mock *ServiceMock) HandleGetRankingStats(ctx context.Context, board string, request *models.GetRankingStatsRequest) (*models.GetRankingStatsResponse, error) {
+	if mock.HandleGetRankingStatsFunc == nil {
+		panic("ServiceMock.HandleGetRankingStatsFunc: method is nil but Service.HandleGetRankingStats was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Board   string
+		Request *models.GetRankingStatsRequest
+	}{
+		Ctx:     ctx,
+		Board:   board,
+		Request: request,
+	}
+	mock.lockHandleGetRankingStats.Lock()
+	mock.calls.HandleGetRankingStats = append(mock.calls.HandleGetRankingStats, callInfo)
+	mock.lockHandleGetRankingStats.Unlock()
+	return mock.HandleGetRankingStatsFunc(ctx, board, request)
+}
+
+// HandleGetRankingStatsCalls gets all the calls that were made to HandleGetRankingStats.
+// Check the length with:
+//
+//	len(mockedService.HandleGetRankingStatsCalls())
+func (mock *ServiceMock) HandleGetRankingStatsCalls() []struct {
+	Ctx     context.Context
+	Board   string
+	Request *models.GetRankingStatsRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Board   string
+		Request *models.GetRankingStatsRequest
+	}
+	mock.lockHandleGetRankingStats.RLock()
+	calls = mock.calls.HandleGetRankingStats
+	mock.lockHandleGetRankingStats.RUnlock()
+	return calls
+}
+
+// HandleGetRateLimits calls HandleGetRateLimitsFunc.
+func (mock *ServiceMock) HandleGetRateLimits(ctx context.Context) (*models.GetRateLimitsResponse, error) {
+	if mock.HandleGetRateLimitsFunc == nil {
+		panic("ServiceMock.HandleGetRateLimitsFunc: method is nil but Service.HandleGetRateLimits was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockHandleGetRateLimits.Lock()
+	mock.calls.HandleGetRateLimits = append(mock.calls.HandleGetRateLimits, callInfo)
+	mock.lockHandleGetRateLimits.Unlock()
+	return mock.HandleGetRateLimitsFunc(ctx)
+}
+
+// HandleGetRateLimitsCalls gets all the calls that were made to HandleGetRateLimits.
+// Check the length with:
+//
+//	len(mockedService.HandleGetRateLimitsCalls())
+func (mock *ServiceMock) HandleGetRateLimitsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockHandleGetRateLimits.RLock()
+	calls = mock.calls.HandleGetRateLimits
+	mock.lockHandleGetRateLimits.RUnlock()
+	return calls
+}
+
+// HandleGetReadiness calls HandleGetReadinessFunc.
+func (mock *ServiceMock) HandleGetReadiness(ctx context.Context) (*models.ReadinessResponse, error) {
+	if mock.HandleGetReadinessFunc == nil {
+		panic("ServiceMock.HandleGetReadinessFunc: method is nil but Service.HandleGetReadiness was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockHandleGetReadiness.Lock()
+	mock.calls.HandleGetReadiness = append(mock.calls.HandleGetReadiness, callInfo)
+	mock.lockHandleGetReadiness.Unlock()
+	return mock.HandleGetReadinessFunc(ctx)
+}
+
+// HandleGetReadinessCalls gets all the calls that were made to HandleGetReadiness.
+// Check the length with:
+//
+//	len(mockedService.HandleGetReadinessCalls())
+func (mock *ServiceMock) HandleGetReadinessCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockHandleGetReadiness.RLock()
+	calls = mock.calls.HandleGetReadiness
+	mock.lockHandleGetReadiness.RUnlock()
+	return calls
+}
+
+// HandleGetReviews calls HandleGetReviewsFunc.
+func (mock *ServiceMock) HandleGetReviews(ctx context.Context, request *models.GetReviewsRequest) (*models.GetReviewsResponse, error) {
+	if mock.HandleGetReviewsFunc == nil {
+		panic("ServiceMock.HandleGetReviewsFunc: method is nil but Service.HandleGetReviews was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Request *models.GetReviewsRequest
+	}{
+		Ctx:     ctx,
+		Request: request,
+	}
+	mock.lockHandleGetReviews.Lock()
+	mock.calls.HandleGetReviews = append(mock.calls.HandleGetReviews, callInfo)
+	mock.lockHandleGetReviews.Unlock()
+	return mock.HandleGetReviewsFunc(ctx, request)
+}
+
+// HandleGetReviewsCalls gets all the calls that were made to HandleGetReviews.
+// Check the length with:
+//
+//	len(mockedService.HandleGetReviewsCalls())
+func (mock *ServiceMock) HandleGetReviewsCalls() []struct {
+	Ctx     context.Context
+	Request *models.GetReviewsRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Request *models.GetReviewsRequest
+	}
+	mock.lockHandleGetReviews.RLock()
+	calls = mock.calls.HandleGetReviews
+	mock.lockHandleGetReviews.RUnlock()
+	return calls
+}
+
+// HandleGetUserHistory calls HandleGetUserHistoryFunc.
+func (mock *ServiceMock) HandleGetUserHistory(ctx context.Context, board string, userId string, request *models.GetUserHistoryRequest) (*models.GetUserHistoryResponse, error) {
+	if mock.HandleGetUserHistoryFunc == nil {
+		panic("ServiceMock.HandleGetUserHistoryFunc: method is nil but Service.HandleGetUserHistory was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Board   string
+		UserId  string
+		Request *models.GetUserHistoryRequest
+	}{
+		Ctx:     ctx,
+		Board:   board,
+		UserId:  userId,
+		Request: request,
+	}
+	mock.lockHandleGetUserHistory.Lock()
+	mock.calls.HandleGetUserHistory = append(mock.calls.HandleGetUserHistory, callInfo)
+	mock.lockHandleGetUserHistory.Unlock()
+	return mock.HandleGetUserHistoryFunc(ctx, board, userId, request)
+}
+
+// HandleGetUserHistoryCalls gets all the calls that were made to HandleGetUserHistory.
+// Check the length with:
+//
+//	len(mockedService.HandleGetUserHistoryCalls())
+func (mock *ServiceMock) HandleGetUserHistoryCalls() []struct {
+	Ctx     context.Context
+	Board   string
+	UserId  string
+	Request *models.GetUserHistoryRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Board   string
+		UserId  string
+		Request *models.GetUserHistoryRequest
+	}
+	mock.lockHandleGetUserHistory.RLock()
+	calls = mock.calls.HandleGetUserHistory
+	mock.lockHandleGetUserHistory.RUnlock()
+	return calls
+}
+
+// HandleGetUserRank calls HandleGetUserRankFunc.
+func (mock *ServiceMock) HandleGetUserRank(ctx context.Context, board string, userId string) (*models.GetUserRankResponse, error) {
+	if mock.HandleGetUserRankFunc == nil {
+		panic("ServiceMock.HandleGetUserRankFunc: method is nil but Service.HandleGetUserRank was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Board  string
+		UserId string
+	}{
+		Ctx:    ctx,
+		Board:  board,
+		UserId: userId,
+	}
+	mock.lockHandleGetUserRank.Lock()
+	mock.calls.HandleGetUserRank = append(mock.calls.HandleGetUserRank, callInfo)
+	mock.lockHandleGetUserRank.Unlock()
+	return mock.HandleGetUserRankFunc(ctx, board, userId)
+}
+
+// HandleGetUserRankCalls gets all the calls that were made to HandleGetUserRank.
+// Check the length with:
+//
+//	len(mockedService.HandleGetUserRankCalls())
+func (mock *ServiceMock) HandleGetUserRankCalls() []struct {
+	Ctx    context.Context
+	Board  string
+	UserId string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Board  string
+		UserId string
+	}
+	mock.lockHandleGetUserRank.RLock()
+	calls = mock.calls.HandleGetUserRank
+	mock.lockHandleGetUserRank.RUnlock()
+	return calls
+}
+
+// HandleMergeUsers calls HandleMergeUsersFunc.
+func (mock *ServiceMock) HandleMergeUsers(ctx context.Context, board string, userId string, request *models.MergeUsersRequest) (*models.User, error) {
+	if mock.HandleMergeUsersFunc == nil {
+		panic("ServiceMock.HandleMergeUsersFunc: method is nil but Service.HandleMergeUsers was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Board   string
+		UserId  string
+		Request *models.MergeUsersRequest
+	}{
+		Ctx:     ctx,
+		Board:   board,
+		UserId:  userId,
+		Request: request,
+	}
+	mock.lockHandleMergeUsers.Lock()
+	mock.calls.HandleMergeUsers = append(mock.calls.HandleMergeUsers, callInfo)
+	mock.lockHandleMergeUsers.Unlock()
+	return mock.HandleMergeUsersFunc(ctx, board, userId, request)
+}
+
+// HandleMergeUsersCalls gets all the calls that were made to HandleMergeUsers.
+// Check the length with:
+//
+//	len(mockedService.HandleMergeUsersCalls())
+func (mock *ServiceMock) HandleMergeUsersCalls() []struct {
+	Ctx     context.Context
+	Board   string
+	UserId  string
+	Request *models.MergeUsersRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Board   string
+		UserId  string
+		Request *models.MergeUsersRequest
+	}
+	mock.lockHandleMergeUsers.RLock()
+	calls = mock.calls.HandleMergeUsers
+	mock.lockHandleMergeUsers.RUnlock()
+	return calls
+}
+
+// HandleResetLeaderboard calls HandleResetLeaderboardFunc.
+func (mock *ServiceMock) HandleResetLeaderboard(ctx context.Context, board string) (*models.ResetLeaderboardResponse, error) {
+	if mock.HandleResetLeaderboardFunc == nil {
+		panic("ServiceMock.HandleResetLeaderboardFunc: method is nil but Service.HandleResetLeaderboard was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Board string
+	}{
+		Ctx:   ctx,
+		Board: board,
+	}
+	mock.lockHandleResetLeaderboard.Lock()
+	mock.calls.HandleResetLeaderboard = append(mock.calls.HandleResetLeaderboard, callInfo)
+	mock.lockHandleResetLeaderboard.Unlock()
+	return mock.HandleResetLeaderboardFunc(ctx, board)
+}
+
+// HandleResetLeaderboardCalls gets all the calls that were made to HandleResetLeaderboard.
+// Check the length with:
+//
+//	len(mockedService.HandleResetLeaderboardCalls())
+func (mock *ServiceMock) HandleResetLeaderboardCalls() []struct {
+	Ctx   context.Context
+	Board string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Board string
+	}
+	mock.lockHandleResetLeaderboard.RLock()
+	calls = mock.calls.HandleResetLeaderboard
+	mock.lockHandleResetLeaderboard.RUnlock()
+	return calls
+}
+
+// HandleResetUser calls HandleResetUserFunc.
+func (mock *ServiceMock) HandleResetUser(ctx context.Context, board string, userId string) (*models.User, error) {
+	if mock.HandleResetUserFunc == nil {
+		panic("ServiceMock.HandleResetUserFunc: method is nil but Service.HandleResetUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Board  string
+		UserId string
+	}{
+		Ctx:    ctx,
+		Board:  board,
+		UserId: userId,
+	}
+	mock.lockHandleResetUser.Lock()
+	mock.calls.HandleResetUser = append(mock.calls.HandleResetUser, callInfo)
+	mock.lockHandleResetUser.Unlock()
+	return mock.HandleResetUserFunc(ctx, board, userId)
+}
+
+// HandleResetUserCalls gets all the calls that were made to HandleResetUser.
+// Check the length with:
+//
+//	len(mockedService.HandleResetUserCalls())
+func (mock *ServiceMock) HandleResetUserCalls() []struct {
+	Ctx    context.Context
+	Board  string
+	UserId string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Board  string
+		UserId string
+	}
+	mock.lockHandleResetUser.RLock()
+	calls = mock.calls.HandleResetUser
+	mock.lockHandleResetUser.RUnlock()
+	return calls
+}
+
+// HandleStreamRanking calls HandleStreamRankingFunc.
+func (mock *ServiceMock) HandleStreamRanking(ctx context.Context, board string, request *models.GetRankingRequest, send func(*models.RankingUpdate) error) error {
+	if mock.HandleStreamRankingFunc == nil {
+		panic("ServiceMock.HandleStreamRankingFunc: method is nil but Service.HandleStreamRanking was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Board   string
+		Request *models.GetRankingRequest
+		Send    func(*models.RankingUpdate) error
+	}{
+		Ctx:     ctx,
+		Board:   board,
+		Request: request,
+		Send:    send,
+	}
+	mock.lockHandleStreamRanking.Lock()
+	mock.calls.HandleStreamRanking = append(mock.calls.HandleStreamRanking, callInfo)
+	mock.lockHandleStreamRanking.Unlock()
+	return mock.HandleStreamRankingFunc(ctx, board, request, send)
+}
+
+// HandleStreamRankingCalls gets all the calls that were made to HandleStreamRanking.
+// Check the length with:
+//
+//	len(mockedService.HandleStreamRankingCalls())
+func (mock *ServiceMock) HandleStreamRankingCalls() []struct {
+	Ctx     context.Context
+	Board   string
+	Request *models.GetRankingRequest
+	Send    func(*models.RankingUpdate) error
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Board   string
+		Request *models.GetRankingRequest
+		Send    func(*models.RankingUpdate) error
+	}
+	mock.lockHandleStreamRanking.RLock()
+	calls = mock.calls.HandleStreamRanking
+	mock.lockHandleStreamRanking.RUnlock()
+	return calls
+}
+
 // HandleSubmitScore calls HandleSubmitScoreFunc.
-func (mock *ServiceMock) HandleSubmitScore(contextMoqParam context.Context, submitScoreRequest *models.SubmitScoreRequest, s string) (*models.SubmitScoreResponse, error) {
+func (mock *ServiceMock) HandleSubmitScore(ctx context.Context, board string, request *models.SubmitScoreRequest, userId string) (*models.SubmitScoreResponse, error) {
 	if mock.HandleSubmitScoreFunc == nil {
 		panic("ServiceMock.HandleSubmitScoreFunc: method is nil but Service.HandleSubmitScore was just called")
 	}
 	callInfo := struct {
-		ContextMoqParam    context.Context
-		SubmitScoreRequest *models.SubmitScoreRequest
-		S                  string
+		Ctx     context.Context
+		Board   string
+		Request *models.SubmitScoreRequest
+		UserId  string
 	}{
-		ContextMoqParam:    contextMoqParam,
-		SubmitScoreRequest: submitScoreRequest,
-		S:                  s,
+		Ctx:     ctx,
+		Board:   board,
+		Request: request,
+		UserId:  userId,
 	}
 	mock.lockHandleSubmitScore.Lock()
 	mock.calls.HandleSubmitScore = append(mock.calls.HandleSubmitScore, callInfo)
 	mock.lockHandleSubmitScore.Unlock()
-	return mock.HandleSubmitScoreFunc(contextMoqParam, submitScoreRequest, s)
+	return mock.HandleSubmitScoreFunc(ctx, board, request, userId)
 }
 
 // HandleSubmitScoreCalls gets all the calls that were made to HandleSubmitScore.
 // Check the length with:
-//     len(mockedService.HandleSubmitScoreCalls())
+//
+//	len(mockedService.HandleSubmitScoreCalls())
 func (mock *ServiceMock) HandleSubmitScoreCalls() []struct {
-	ContextMoqParam    context.Context
-	SubmitScoreRequest *models.SubmitScoreRequest
-	S                  string
+	Ctx     context.Context
+	Board   string
+	Request *models.SubmitScoreRequest
+	UserId  string
 } {
 	var calls []struct {
-		ContextMoqParam    context.Context
-		SubmitScoreRequest *models.SubmitScoreRequest
-		S                  string
+		Ctx     context.Context
+		Board   string
+		Request *models.SubmitScoreRequest
+		UserId  string
 	}
 	mock.lockHandleSubmitScore.RLock()
 	calls = mock.calls.HandleSubmitScore
 	mock.lockHandleSubmitScore.RUnlock()
 	return calls
 }
+
+// HandleSubmitScores calls HandleSubmitScoresFunc.
+func (mock *ServiceMock) HandleSubmitScores(ctx context.Context, board string, request *models.SubmitScoresRequest) (*models.SubmitScoresResponse, error) {
+	if mock.HandleSubmitScoresFunc == nil {
+		panic("ServiceMock.HandleSubmitScoresFunc: method is nil but Service.HandleSubmitScores was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Board   string
+		Request *models.SubmitScoresRequest
+	}{
+		Ctx:     ctx,
+		Board:   board,
+		Request: request,
+	}
+	mock.lockHandleSubmitScores.Lock()
+	mock.calls.HandleSubmitScores = append(mock.calls.HandleSubmitScores, callInfo)
+	mock.lockHandleSubmitScores.Unlock()
+	return mock.HandleSubmitScoresFunc(ctx, board, request)
+}
+
+// HandleSubmitScoresCalls gets all the calls that were made to HandleSubmitScores.
+// Check the length with:
+//
+//	len(mockedService.HandleSubmitScoresCalls())
+func (mock *ServiceMock) HandleSubmitScoresCalls() []struct {
+	Ctx     context.Context
+	Board   string
+	Request *models.SubmitScoresRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Board   string
+		Request *models.SubmitScoresRequest
+	}
+	mock.lockHandleSubmitScores.RLock()
+	calls = mock.calls.HandleSubmitScores
+	mock.lockHandleSubmitScores.RUnlock()
+	return calls
+}
+
+// HandleUnfreezeUser calls HandleUnfreezeUserFunc.
+func (mock *ServiceMock) HandleUnfreezeUser(ctx context.Context, board string, userId string) (*models.FrozenUser, error) {
+	if mock.HandleUnfreezeUserFunc == nil {
+		panic("ServiceMock.HandleUnfreezeUserFunc: method is nil but Service.HandleUnfreezeUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Board  string
+		UserId string
+	}{
+		Ctx:    ctx,
+		Board:  board,
+		UserId: userId,
+	}
+	mock.lockHandleUnfreezeUser.Lock()
+	mock.calls.HandleUnfreezeUser = append(mock.calls.HandleUnfreezeUser, callInfo)
+	mock.lockHandleUnfreezeUser.Unlock()
+	return mock.HandleUnfreezeUserFunc(ctx, board, userId)
+}
+
+// HandleUnfreezeUserCalls gets all the calls that were made to HandleUnfreezeUser.
+// Check the length with:
+//
+//	len(mockedService.HandleUnfreezeUserCalls())
+func (mock *ServiceMock) HandleUnfreezeUserCalls() []struct {
+	Ctx    context.Context
+	Board  string
+	UserId string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Board  string
+		UserId string
+	}
+	mock.lockHandleUnfreezeUser.RLock()
+	calls = mock.calls.HandleUnfreezeUser
+	mock.lockHandleUnfreezeUser.RUnlock()
+	return calls
+}
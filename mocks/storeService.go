@@ -15,104 +15,397 @@ var _ models.StoreService = &StoreServiceMock{}
 
 // StoreServiceMock is a mock implementation of models.StoreService.
 //
-// 	func TestSomethingThatUsesStoreService(t *testing.T) {
+//	func TestSomethingThatUsesStoreService(t *testing.T) {
 //
-// 		// make and configure a mocked models.StoreService
-// 		mockedStoreService := &StoreServiceMock{
-// 			CreateUserFunc: func(ctx context.Context, id int, total int) error {
-// 				panic("mock out the CreateUser method")
-// 			},
-// 			DoesUserExistFunc: func(ctx context.Context, id int) (bool, error) {
-// 				panic("mock out the DoesUserExist method")
-// 			},
-// 			GetUserByIdFunc: func(ctx context.Context, id int) (*models.User, error) {
-// 				panic("mock out the GetUserById method")
-// 			},
-// 			GetUsersFunc: func(ctx context.Context, top int) ([]models.Ranking, error) {
-// 				panic("mock out the GetUsers method")
-// 			},
-// 			GetUsersBetweenFunc: func(ctx context.Context, lower int, upper int) ([]models.Ranking, error) {
-// 				panic("mock out the GetUsersBetween method")
-// 			},
-// 			UpdateAbsoluteUserScoreFunc: func(ctx context.Context, id int, score int) error {
-// 				panic("mock out the UpdateAbsoluteUserScore method")
-// 			},
-// 			UpdateRelativeUserScoreFunc: func(ctx context.Context, id int, score int) error {
-// 				panic("mock out the UpdateRelativeUserScore method")
-// 			},
-// 		}
+//		// make and configure a mocked models.StoreService
+//		mockedStoreService := &StoreServiceMock{
+//			CountUsersFunc: func(ctx context.Context, board string) (int, error) {
+//				panic("mock out the CountUsers method")
+//			},
+//			CreateLeaderboardFunc: func(ctx context.Context, name string) error {
+//				panic("mock out the CreateLeaderboard method")
+//			},
+//			CreateUserFunc: func(ctx context.Context, board string, id int, total int) error {
+//				panic("mock out the CreateUser method")
+//			},
+//			DeleteLeaderboardFunc: func(ctx context.Context, name string) error {
+//				panic("mock out the DeleteLeaderboard method")
+//			},
+//			DeleteUserFunc: func(ctx context.Context, board string, id int) error {
+//				panic("mock out the DeleteUser method")
+//			},
+//			DoesLeaderboardExistFunc: func(ctx context.Context, name string) (bool, error) {
+//				panic("mock out the DoesLeaderboardExist method")
+//			},
+//			DoesUserExistFunc: func(ctx context.Context, board string, id int) (bool, error) {
+//				panic("mock out the DoesUserExist method")
+//			},
+//			FreezeUserFunc: func(ctx context.Context, board string, id int, hidden bool) error {
+//				panic("mock out the FreezeUser method")
+//			},
+//			GetFrozenUsersFunc: func(ctx context.Context, board string) ([]models.FrozenUser, error) {
+//				panic("mock out the GetFrozenUsers method")
+//			},
+//			GetLeaderboardsFunc: func(ctx context.Context) ([]string, error) {
+//				panic("mock out the GetLeaderboards method")
+//			},
+//			GetRankingFunc: func(ctx context.Context, board string, query *models.RankingQuery) ([]models.Ranking, error) {
+//				panic("mock out the GetRanking method")
+//			},
+//			GetScoresFunc: func(ctx context.Context, board string) ([]int, error) {
+//				panic("mock out the GetScores method")
+//			},
+//			GetUserByIdFunc: func(ctx context.Context, board string, id int) (*models.User, error) {
+//				panic("mock out the GetUserById method")
+//			},
+//			GetUserPositionFunc: func(ctx context.Context, board string, id int, score int) (int, error) {
+//				panic("mock out the GetUserPosition method")
+//			},
+//			GetUsersFunc: func(ctx context.Context, board string, top int) ([]models.Ranking, error) {
+//				panic("mock out the GetUsers method")
+//			},
+//			GetUsersAfterFunc: func(ctx context.Context, board string, after *models.RankingCursor, limit int) ([]models.Ranking, *models.RankingCursor, error) {
+//				panic("mock out the GetUsersAfter method")
+//			},
+//			GetUsersBetweenFunc: func(ctx context.Context, board string, lower int, upper int) ([]models.Ranking, error) {
+//				panic("mock out the GetUsersBetween method")
+//			},
+//			MergeUsersFunc: func(ctx context.Context, board string, from int, into int, strategy models.MergeStrategy) error {
+//				panic("mock out the MergeUsers method")
+//			},
+//			PingFunc: func(ctx context.Context) error {
+//				panic("mock out the Ping method")
+//			},
+//			ResetBoardFunc: func(ctx context.Context, board string) (int, error) {
+//				panic("mock out the ResetBoard method")
+//			},
+//			RestoreFrozenUserFunc: func(ctx context.Context, board string, user models.FrozenUser) error {
+//				panic("mock out the RestoreFrozenUser method")
+//			},
+//			SubmitScoresFunc: func(ctx context.Context, board string, submissions []models.ScoreSubmission) ([]models.ScoreChange, error) {
+//				panic("mock out the SubmitScores method")
+//			},
+//			SubmitScoresWithPeriodsFunc: func(ctx context.Context, board string, periods []string, submissions []models.ScoreSubmission) ([]models.ScoreChange, []string, error) {
+//				panic("mock out the SubmitScoresWithPeriods method")
+//			},
+//			UnfreezeUserFunc: func(ctx context.Context, board string, id int) (*models.FrozenUser, error) {
+//				panic("mock out the UnfreezeUser method")
+//			},
+//			UpdateAbsoluteUserScoreFunc: func(ctx context.Context, board string, id int, score int) error {
+//				panic("mock out the UpdateAbsoluteUserScore method")
+//			},
+//			UpdateRelativeUserScoreFunc: func(ctx context.Context, board string, id int, score int) error {
+//				panic("mock out the UpdateRelativeUserScore method")
+//			},
+//			UpsertUserScoreFunc: func(ctx context.Context, board string, submission models.ScoreSubmission) (*models.ScoreChange, error) {
+//				panic("mock out the UpsertUserScore method")
+//			},
+//		}
 //
-// 		// use mockedStoreService in code that requires models.StoreService
-// 		// and then make assertions.
+//		// use mockedStoreService in code that requires models.StoreService
+//		// and then make assertions.
 //
-// 	}
+//	}
 type StoreServiceMock struct {
+	// CountUsersFunc mocks the CountUsers method.
+	CountUsersFunc func(ctx context.Context, board string) (int, error)
+
+	// CreateLeaderboardFunc mocks the CreateLeaderboard method.
+	CreateLeaderboardFunc func(ctx context.Context, name string) error
+
 	// CreateUserFunc mocks the CreateUser method.
-	CreateUserFunc func(ctx context.Context, id int, total int) error
+	CreateUserFunc func(ctx context.Context, board string, id int, total int) error
+
+	// DeleteLeaderboardFunc mocks the DeleteLeaderboard method.
+	DeleteLeaderboardFunc func(ctx context.Context, name string) error
+
+	// DeleteUserFunc mocks the DeleteUser method.
+	DeleteUserFunc func(ctx context.Context, board string, id int) error
+
+	// DoesLeaderboardExistFunc mocks the DoesLeaderboardExist method.
+	DoesLeaderboardExistFunc func(ctx context.Context, name string) (bool, error)
 
 	// DoesUserExistFunc mocks the DoesUserExist method.
-	DoesUserExistFunc func(ctx context.Context, id int) (bool, error)
+	DoesUserExistFunc func(ctx context.Context, board string, id int) (bool, error)
+
+	// FreezeUserFunc mocks the FreezeUser method.
+	FreezeUserFunc func(ctx context.Context, board string, id int, hidden bool) error
+
+	// GetFrozenUsersFunc mocks the GetFrozenUsers method.
+	GetFrozenUsersFunc func(ctx context.Context, board string) ([]models.FrozenUser, error)
+
+	// GetLeaderboardsFunc mocks the GetLeaderboards method.
+	GetLeaderboardsFunc func(ctx context.Context) ([]string, error)
+
+	// GetRankingFunc mocks the GetRanking method.
+	GetRankingFunc func(ctx context.Context, board string, query *models.RankingQuery) ([]models.Ranking, error)
+
+	// GetScoresFunc mocks the GetScores method.
+	GetScoresFunc func(ctx context.Context, board string) ([]int, error)
 
 	// GetUserByIdFunc mocks the GetUserById method.
-	GetUserByIdFunc func(ctx context.Context, id int) (*models.User, error)
+	GetUserByIdFunc func(ctx context.Context, board string, id int) (*models.User, error)
+
+	// GetUserPositionFunc mocks the GetUserPosition method.
+	GetUserPositionFunc func(ctx context.Context, board string, id int, score int) (int, error)
 
 	// GetUsersFunc mocks the GetUsers method.
-	GetUsersFunc func(ctx context.Context, top int) ([]models.Ranking, error)
+	GetUsersFunc func(ctx context.Context, board string, top int) ([]models.Ranking, error)
+
+	// GetUsersAfterFunc mocks the GetUsersAfter method.
+	GetUsersAfterFunc func(ctx context.Context, board string, after *models.RankingCursor, limit int) ([]models.Ranking, *models.RankingCursor, error)
 
 	// GetUsersBetweenFunc mocks the GetUsersBetween method.
-	GetUsersBetweenFunc func(ctx context.Context, lower int, upper int) ([]models.Ranking, error)
+	GetUsersBetweenFunc func(ctx context.Context, board string, lower int, upper int) ([]models.Ranking, error)
+
+	// MergeUsersFunc mocks the MergeUsers method.
+	MergeUsersFunc func(ctx context.Context, board string, from int, into int, strategy models.MergeStrategy) error
+
+	// PingFunc mocks the Ping method.
+	PingFunc func(ctx context.Context) error
+
+	// ResetBoardFunc mocks the ResetBoard method.
+	ResetBoardFunc func(ctx context.Context, board string) (int, error)
+
+	// RestoreFrozenUserFunc mocks the RestoreFrozenUser method.
+	RestoreFrozenUserFunc func(ctx context.Context, board string, user models.FrozenUser) error
+
+	// SubmitScoresFunc mocks the SubmitScores method.
+	SubmitScoresFunc func(ctx context.Context, board string, submissions []models.ScoreSubmission) ([]models.ScoreChange, error)
+
+	// SubmitScoresWithPeriodsFunc mocks the SubmitScoresWithPeriods method.
+	SubmitScoresWithPeriodsFunc func(ctx context.Context, board string, periods []string, submissions []models.ScoreSubmission) ([]models.ScoreChange, []string, error)
+
+	// UnfreezeUserFunc mocks the UnfreezeUser method.
+	UnfreezeUserFunc func(ctx context.Context, board string, id int) (*models.FrozenUser, error)
 
 	// UpdateAbsoluteUserScoreFunc mocks the UpdateAbsoluteUserScore method.
-	UpdateAbsoluteUserScoreFunc func(ctx context.Context, id int, score int) error
+	UpdateAbsoluteUserScoreFunc func(ctx context.Context, board string, id int, score int) error
 
 	// UpdateRelativeUserScoreFunc mocks the UpdateRelativeUserScore method.
-	UpdateRelativeUserScoreFunc func(ctx context.Context, id int, score int) error
+	UpdateRelativeUserScoreFunc func(ctx context.Context, board string, id int, score int) error
+
+	// UpsertUserScoreFunc mocks the UpsertUserScore method.
+	UpsertUserScoreFunc func(ctx context.Context, board string, submission models.ScoreSubmission) (*models.ScoreChange, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// CountUsers holds details about calls to the CountUsers method.
+		CountUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+		}
+		// CreateLeaderboard holds details about calls to the CreateLeaderboard method.
+		CreateLeaderboard []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
 		// CreateUser holds details about calls to the CreateUser method.
 		CreateUser []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Board is the board argument value.
+			Board string
 			// ID is the id argument value.
 			ID int
 			// Total is the total argument value.
 			Total int
 		}
+		// DeleteLeaderboard holds details about calls to the DeleteLeaderboard method.
+		DeleteLeaderboard []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
+		// DeleteUser holds details about calls to the DeleteUser method.
+		DeleteUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// ID is the id argument value.
+			ID int
+		}
+		// DoesLeaderboardExist holds details about calls to the DoesLeaderboardExist method.
+		DoesLeaderboardExist []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
 		// DoesUserExist holds details about calls to the DoesUserExist method.
 		DoesUserExist []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Board is the board argument value.
+			Board string
 			// ID is the id argument value.
 			ID int
 		}
+		// FreezeUser holds details about calls to the FreezeUser method.
+		FreezeUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// ID is the id argument value.
+			ID int
+			// Hidden is the hidden argument value.
+			Hidden bool
+		}
+		// GetFrozenUsers holds details about calls to the GetFrozenUsers method.
+		GetFrozenUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+		}
+		// GetLeaderboards holds details about calls to the GetLeaderboards method.
+		GetLeaderboards []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetRanking holds details about calls to the GetRanking method.
+		GetRanking []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// Query is the query argument value.
+			Query *models.RankingQuery
+		}
+		// GetScores holds details about calls to the GetScores method.
+		GetScores []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+		}
 		// GetUserById holds details about calls to the GetUserById method.
 		GetUserById []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Board is the board argument value.
+			Board string
 			// ID is the id argument value.
 			ID int
 		}
+		// GetUserPosition holds details about calls to the GetUserPosition method.
+		GetUserPosition []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// ID is the id argument value.
+			ID int
+			// Score is the score argument value.
+			Score int
+		}
 		// GetUsers holds details about calls to the GetUsers method.
 		GetUsers []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Board is the board argument value.
+			Board string
 			// Top is the top argument value.
 			Top int
 		}
+		// GetUsersAfter holds details about calls to the GetUsersAfter method.
+		GetUsersAfter []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// After is the after argument value.
+			After *models.RankingCursor
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetUsersBetween holds details about calls to the GetUsersBetween method.
 		GetUsersBetween []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Board is the board argument value.
+			Board string
 			// Lower is the lower argument value.
 			Lower int
 			// Upper is the upper argument value.
 			Upper int
 		}
+		// MergeUsers holds details about calls to the MergeUsers method.
+		MergeUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// From is the from argument value.
+			From int
+			// Into is the into argument value.
+			Into int
+			// Strategy is the strategy argument value.
+			Strategy models.MergeStrategy
+		}
+		// Ping holds details about calls to the Ping method.
+		Ping []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ResetBoard holds details about calls to the ResetBoard method.
+		ResetBoard []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+		}
+		// RestoreFrozenUser holds details about calls to the RestoreFrozenUser method.
+		RestoreFrozenUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// User is the user argument value.
+			User models.FrozenUser
+		}
+		// SubmitScores holds details about calls to the SubmitScores method.
+		SubmitScores []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// Submissions is the submissions argument value.
+			Submissions []models.ScoreSubmission
+		}
+		// SubmitScoresWithPeriods holds details about calls to the SubmitScoresWithPeriods method.
+		SubmitScoresWithPeriods []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// Periods is the periods argument value.
+			Periods []string
+			// Submissions is the submissions argument value.
+			Submissions []models.ScoreSubmission
+		}
+		// UnfreezeUser holds details about calls to the UnfreezeUser method.
+		UnfreezeUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// ID is the id argument value.
+			ID int
+		}
 		// UpdateAbsoluteUserScore holds details about calls to the UpdateAbsoluteUserScore method.
 		UpdateAbsoluteUserScore []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Board is the board argument value.
+			Board string
 			// ID is the id argument value.
 			ID int
 			// Score is the score argument value.
@@ -122,51 +415,159 @@ type StoreServiceMock struct {
 		UpdateRelativeUserScore []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Board is the board argument value.
+			Board string
 			// ID is the id argument value.
 			ID int
 			// Score is the score argument value.
 			Score int
 		}
+		// UpsertUserScore holds details about calls to the UpsertUserScore method.
+		UpsertUserScore []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Board is the board argument value.
+			Board string
+			// Submission is the submission argument value.
+			Submission models.ScoreSubmission
+		}
 	}
+	lockCountUsers              sync.RWMutex
+	lockCreateLeaderboard       sync.RWMutex
 	lockCreateUser              sync.RWMutex
+	lockDeleteLeaderboard       sync.RWMutex
+	lockDeleteUser              sync.RWMutex
+	lockDoesLeaderboardExist    sync.RWMutex
 	lockDoesUserExist           sync.RWMutex
+	lockFreezeUser              sync.RWMutex
+	lockGetFrozenUsers          sync.RWMutex
+	lockGetLeaderboards         sync.RWMutex
+	lockGetRanking              sync.RWMutex
+	lockGetScores               sync.RWMutex
 	lockGetUserById             sync.RWMutex
+	lockGetUserPosition         sync.RWMutex
 	lockGetUsers                sync.RWMutex
+	lockGetUsersAfter           sync.RWMutex
 	lockGetUsersBetween         sync.RWMutex
+	lockMergeUsers              sync.RWMutex
+	lockPing                    sync.RWMutex
+	lockResetBoard              sync.RWMutex
+	lockRestoreFrozenUser       sync.RWMutex
+	lockSubmitScores            sync.RWMutex
+	lockSubmitScoresWithPeriods sync.RWMutex
+	lockUnfreezeUser            sync.RWMutex
 	lockUpdateAbsoluteUserScore sync.RWMutex
 	lockUpdateRelativeUserScore sync.RWMutex
+	lockUpsertUserScore         sync.RWMutex
+}
+
+// CountUsers calls CountUsersFunc.
+func (mock *StoreServiceMock) CountUsers(ctx context.Context, board string) (int, error) {
+	if mock.CountUsersFunc == nil {
+		panic("StoreServiceMock.CountUsersFunc: method is nil but StoreService.CountUsers was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Board string
+	}{
+		Ctx:   ctx,
+		Board: board,
+	}
+	mock.lockCountUsers.Lock()
+	mock.calls.CountUsers = append(mock.calls.CountUsers, callInfo)
+	mock.lockCountUsers.Unlock()
+	return mock.CountUsersFunc(ctx, board)
+}
+
+// CountUsersCalls gets all the calls that were made to CountUsers.
+// Check the length with:
+//
+//	len(mockedStoreService.CountUsersCalls())
+func (mock *StoreServiceMock) CountUsersCalls() []struct {
+	Ctx   context.Context
+	Board string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Board string
+	}
+	mock.lockCountUsers.RLock()
+	calls = mock.calls.CountUsers
+	mock.lockCountUsers.RUnlock()
+	return calls
+}
+
+// CreateLeaderboard calls CreateLeaderboardFunc.
+func (mock *StoreServiceMock) CreateLeaderboard(ctx context.Context, name string) error {
+	if mock.CreateLeaderboardFunc == nil {
+		panic("StoreServiceMock.CreateLeaderboardFunc: method is nil but StoreService.CreateLeaderboard was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockCreateLeaderboard.Lock()
+	mock.calls.CreateLeaderboard = append(mock.calls.CreateLeaderboard, callInfo)
+	mock.lockCreateLeaderboard.Unlock()
+	return mock.CreateLeaderboardFunc(ctx, name)
+}
+
+// CreateLeaderboardCalls gets all the calls that were made to CreateLeaderboard.
+// Check the length with:
+//
+//	len(mockedStoreService.CreateLeaderboardCalls())
+func (mock *StoreServiceMock) CreateLeaderboardCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockCreateLeaderboard.RLock()
+	calls = mock.calls.CreateLeaderboard
+	mock.lockCreateLeaderboard.RUnlock()
+	return calls
 }
 
 // CreateUser calls CreateUserFunc.
-func (mock *StoreServiceMock) CreateUser(ctx context.Context, id int, total int) error {
+func (mock *StoreServiceMock) CreateUser(ctx context.Context, board string, id int, total int) error {
 	if mock.CreateUserFunc == nil {
 		panic("StoreServiceMock.CreateUserFunc: method is nil but StoreService.CreateUser was just called")
 	}
 	callInfo := struct {
 		Ctx   context.Context
+		Board string
 		ID    int
 		Total int
 	}{
 		Ctx:   ctx,
+		Board: board,
 		ID:    id,
 		Total: total,
 	}
 	mock.lockCreateUser.Lock()
 	mock.calls.CreateUser = append(mock.calls.CreateUser, callInfo)
 	mock.lockCreateUser.Unlock()
-	return mock.CreateUserFunc(ctx, id, total)
+	return mock.CreateUserFunc(ctx, board, id, total)
 }
 
 // CreateUserCalls gets all the calls that were made to CreateUser.
 // Check the length with:
-//     len(mockedStoreService.CreateUserCalls())
+//
+//	len(mockedStoreService.CreateUserCalls())
 func (mock *StoreServiceMock) CreateUserCalls() []struct {
 	Ctx   context.Context
+	Board string
 	ID    int
 	Total int
 } {
 	var calls []struct {
 		Ctx   context.Context
+		Board string
 		ID    int
 		Total int
 	}
@@ -176,34 +577,151 @@ func (mock *StoreServiceMock) CreateUserCalls() []struct {
 	return calls
 }
 
+// DeleteLeaderboard calls DeleteLeaderboardFunc.
+func (mock *StoreServiceMock) DeleteLeaderboard(ctx context.Context, name string) error {
+	if mock.DeleteLeaderboardFunc == nil {
+		panic("StoreServiceMock.DeleteLeaderboardFunc: method is nil but StoreService.DeleteLeaderboard was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockDeleteLeaderboard.Lock()
+	mock.calls.DeleteLeaderboard = append(mock.calls.DeleteLeaderboard, callInfo)
+	mock.lockDeleteLeaderboard.Unlock()
+	return mock.DeleteLeaderboardFunc(ctx, name)
+}
+
+// DeleteLeaderboardCalls gets all the calls that were made to DeleteLeaderboard.
+// Check the length with:
+//
+//	len(mockedStoreService.DeleteLeaderboardCalls())
+func (mock *StoreServiceMock) DeleteLeaderboardCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockDeleteLeaderboard.RLock()
+	calls = mock.calls.DeleteLeaderboard
+	mock.lockDeleteLeaderboard.RUnlock()
+	return calls
+}
+
+// DeleteUser calls DeleteUserFunc.
+func (mock *StoreServiceMock) DeleteUser(ctx context.Context, board string, id int) error {
+	if mock.DeleteUserFunc == nil {
+		panic("StoreServiceMock.DeleteUserFunc: method is nil but StoreService.DeleteUser was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Board string
+		ID    int
+	}{
+		Ctx:   ctx,
+		Board: board,
+		ID:    id,
+	}
+	mock.lockDeleteUser.Lock()
+	mock.calls.DeleteUser = append(mock.calls.DeleteUser, callInfo)
+	mock.lockDeleteUser.Unlock()
+	return mock.DeleteUserFunc(ctx, board, id)
+}
+
+// DeleteUserCalls gets all the calls that were made to DeleteUser.
+// Check the length with:
+//
+//	len(mockedStoreService.DeleteUserCalls())
+func (mock *StoreServiceMock) DeleteUserCalls() []struct {
+	Ctx   context.Context
+	Board string
+	ID    int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Board string
+		ID    int
+	}
+	mock.lockDeleteUser.RLock()
+	calls = mock.calls.DeleteUser
+	mock.lockDeleteUser.RUnlock()
+	return calls
+}
+
+// DoesLeaderboardExist calls DoesLeaderboardExistFunc.
+func (mock *StoreServiceMock) DoesLeaderboardExist(ctx context.Context, name string) (bool, error) {
+	if mock.DoesLeaderboardExistFunc == nil {
+		panic("StoreServiceMock.DoesLeaderboardExistFunc: method is nil but StoreService.DoesLeaderboardExist was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockDoesLeaderboardExist.Lock()
+	mock.calls.DoesLeaderboardExist = append(mock.calls.DoesLeaderboardExist, callInfo)
+	mock.lockDoesLeaderboardExist.Unlock()
+	return mock.DoesLeaderboardExistFunc(ctx, name)
+}
+
+// DoesLeaderboardExistCalls gets all the calls that were made to DoesLeaderboardExist.
+// Check the length with:
+//
+//	len(mockedStoreService.DoesLeaderboardExistCalls())
+func (mock *StoreServiceMock) DoesLeaderboardExistCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockDoesLeaderboardExist.RLock()
+	calls = mock.calls.DoesLeaderboardExist
+	mock.lockDoesLeaderboardExist.RUnlock()
+	return calls
+}
+
 // DoesUserExist calls DoesUserExistFunc.
-func (mock *StoreServiceMock) DoesUserExist(ctx context.Context, id int) (bool, error) {
+func (mock *StoreServiceMock) DoesUserExist(ctx context.Context, board string, id int) (bool, error) {
 	if mock.DoesUserExistFunc == nil {
 		panic("StoreServiceMock.DoesUserExistFunc: method is nil but StoreService.DoesUserExist was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
-		ID  int
+		Ctx   context.Context
+		Board string
+		ID    int
 	}{
-		Ctx: ctx,
-		ID:  id,
+		Ctx:   ctx,
+		Board: board,
+		ID:    id,
 	}
 	mock.lockDoesUserExist.Lock()
 	mock.calls.DoesUserExist = append(mock.calls.DoesUserExist, callInfo)
 	mock.lockDoesUserExist.Unlock()
-	return mock.DoesUserExistFunc(ctx, id)
+	return mock.DoesUserExistFunc(ctx, board, id)
 }
 
 // DoesUserExistCalls gets all the calls that were made to DoesUserExist.
 // Check the length with:
-//     len(mockedStoreService.DoesUserExistCalls())
+//
+//	len(mockedStoreService.DoesUserExistCalls())
 func (mock *StoreServiceMock) DoesUserExistCalls() []struct {
-	Ctx context.Context
-	ID  int
+	Ctx   context.Context
+	Board string
+	ID    int
 } {
 	var calls []struct {
-		Ctx context.Context
-		ID  int
+		Ctx   context.Context
+		Board string
+		ID    int
 	}
 	mock.lockDoesUserExist.RLock()
 	calls = mock.calls.DoesUserExist
@@ -211,34 +729,227 @@ func (mock *StoreServiceMock) DoesUserExistCalls() []struct {
 	return calls
 }
 
+// FreezeUser calls FreezeUserFunc.
+func (mock *StoreServiceMock) FreezeUser(ctx context.Context, board string, id int, hidden bool) error {
+	if mock.FreezeUserFunc == nil {
+		panic("StoreServiceMock.FreezeUserFunc: method is nil but StoreService.FreezeUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Board  string
+		ID     int
+		Hidden bool
+	}{
+		Ctx:    ctx,
+		Board:  board,
+		ID:     id,
+		Hidden: hidden,
+	}
+	mock.lockFreezeUser.Lock()
+	mock.calls.FreezeUser = append(mock.calls.FreezeUser, callInfo)
+	mock.lockFreezeUser.Unlock()
+	return mock.FreezeUserFunc(ctx, board, id, hidden)
+}
+
+// FreezeUserCalls gets all the calls that were made to FreezeUser.
+// Check the length with:
+//
+//	len(mockedStoreService.FreezeUserCalls())
+func (mock *StoreServiceMock) FreezeUserCalls() []struct {
+	Ctx    context.Context
+	Board  string
+	ID     int
+	Hidden bool
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Board  string
+		ID     int
+		Hidden bool
+	}
+	mock.lockFreezeUser.RLock()
+	calls = mock.calls.FreezeUser
+	mock.lockFreezeUser.RUnlock()
+	return calls
+}
+
+// GetFrozenUsers calls GetFrozenUsersFunc.
+func (mock *StoreServiceMock) GetFrozenUsers(ctx context.Context, board string) ([]models.FrozenUser, error) {
+	if mock.GetFrozenUsersFunc == nil {
+		panic("StoreServiceMock.GetFrozenUsersFunc: method is nil but StoreService.GetFrozenUsers was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Board string
+	}{
+		Ctx:   ctx,
+		Board: board,
+	}
+	mock.lockGetFrozenUsers.Lock()
+	mock.calls.GetFrozenUsers = append(mock.calls.GetFrozenUsers, callInfo)
+	mock.lockGetFrozenUsers.Unlock()
+	return mock.GetFrozenUsersFunc(ctx, board)
+}
+
+// GetFrozenUsersCalls gets all the calls that were made to GetFrozenUsers.
+// Check the length with:
+//
+//	len(mockedStoreService.GetFrozenUsersCalls())
+func (mock *StoreServiceMock) GetFrozenUsersCalls() []struct {
+	Ctx   context.Context
+	Board string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Board string
+	}
+	mock.lockGetFrozenUsers.RLock()
+	calls = mock.calls.GetFrozenUsers
+	mock.lockGetFrozenUsers.RUnlock()
+	return calls
+}
+
+// GetLeaderboards calls GetLeaderboardsFunc.
+func (mock *StoreServiceMock) GetLeaderboards(ctx context.Context) ([]string, error) {
+	if mock.GetLeaderboardsFunc == nil {
+		panic("StoreServiceMock.GetLeaderboardsFunc: method is nil but StoreService.GetLeaderboards was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetLeaderboards.Lock()
+	mock.calls.GetLeaderboards = append(mock.calls.GetLeaderboards, callInfo)
+	mock.lockGetLeaderboards.Unlock()
+	return mock.GetLeaderboardsFunc(ctx)
+}
+
+// GetLeaderboardsCalls gets all the calls that were made to GetLeaderboards.
+// Check the length with:
+//
+//	len(mockedStoreService.GetLeaderboardsCalls())
+func (mock *StoreServiceMock) GetLeaderboardsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetLeaderboards.RLock()
+	calls = mock.calls.GetLeaderboards
+	mock.lockGetLeaderboards.RUnlock()
+	return calls
+}
+
+// GetRanking calls GetRankingFunc.
+func (mock *StoreServiceMock) GetRanking(ctx context.Context, board string, query *models.RankingQuery) ([]models.Ranking, error) {
+	if mock.GetRankingFunc == nil {
+		panic("StoreServiceMock.GetRankingFunc: method is nil but StoreService.GetRanking was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Board string
+		Query *models.RankingQuery
+	}{
+		Ctx:   ctx,
+		Board: board,
+		Query: query,
+	}
+	mock.lockGetRanking.Lock()
+	mock.calls.GetRanking = append(mock.calls.GetRanking, callInfo)
+	mock.lockGetRanking.Unlock()
+	return mock.GetRankingFunc(ctx, board, query)
+}
+
+// GetRankingCalls gets all the calls that were made to GetRanking.
+// Check the length with:
+//
+//	len(mockedStoreService.GetRankingCalls())
+func (mock *StoreServiceMock) GetRankingCalls() []struct {
+	Ctx   context.Context
+	Board string
+	Query *models.RankingQuery
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Board string
+		Query *models.RankingQuery
+	}
+	mock.lockGetRanking.RLock()
+	calls = mock.calls.GetRanking
+	mock.lockGetRanking.RUnlock()
+	return calls
+}
+
+// GetScores calls GetScoresFunc.
+func (mock *StoreServiceMock) GetScores(ctx context.Context, board string) ([]int, error) {
+	if mock.GetScoresFunc == nil {
+		panic("StoreServiceMock.GetScoresFunc: method is nil but StoreService.GetScores was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Board string
+	}{
+		Ctx:   ctx,
+		Board: board,
+	}
+	mock.lockGetScores.Lock()
+	mock.calls.GetScores = append(mock.calls.GetScores, callInfo)
+	mock.lockGetScores.Unlock()
+	return mock.GetScoresFunc(ctx, board)
+}
+
+// GetScoresCalls gets all the calls that were made to GetScores.
+// Check the length with:
+//
+//	len(mockedStoreService.GetScoresCalls())
+func (mock *StoreServiceMock) GetScoresCalls() []struct {
+	Ctx   context.Context
+	Board string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Board string
+	}
+	mock.lockGetScores.RLock()
+	calls = mock.calls.GetScores
+	mock.lockGetScores.RUnlock()
+	return calls
+}
+
 // GetUserById calls GetUserByIdFunc.
-func (mock *StoreServiceMock) GetUserById(ctx context.Context, id int) (*models.User, error) {
+func (mock *StoreServiceMock) GetUserById(ctx context.Context, board string, id int) (*models.User, error) {
 	if mock.GetUserByIdFunc == nil {
 		panic("StoreServiceMock.GetUserByIdFunc: method is nil but StoreService.GetUserById was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
-		ID  int
+		Ctx   context.Context
+		Board string
+		ID    int
 	}{
-		Ctx: ctx,
-		ID:  id,
+		Ctx:   ctx,
+		Board: board,
+		ID:    id,
 	}
 	mock.lockGetUserById.Lock()
 	mock.calls.GetUserById = append(mock.calls.GetUserById, callInfo)
 	mock.lockGetUserById.Unlock()
-	return mock.GetUserByIdFunc(ctx, id)
+	return mock.GetUserByIdFunc(ctx, board, id)
 }
 
 // GetUserByIdCalls gets all the calls that were made to GetUserById.
 // Check the length with:
-//     len(mockedStoreService.GetUserByIdCalls())
+//
+//	len(mockedStoreService.GetUserByIdCalls())
 func (mock *StoreServiceMock) GetUserByIdCalls() []struct {
-	Ctx context.Context
-	ID  int
+	Ctx   context.Context
+	Board string
+	ID    int
 } {
 	var calls []struct {
-		Ctx context.Context
-		ID  int
+		Ctx   context.Context
+		Board string
+		ID    int
 	}
 	mock.lockGetUserById.RLock()
 	calls = mock.calls.GetUserById
@@ -246,34 +957,83 @@ func (mock *StoreServiceMock) GetUserByIdCalls() []struct {
 	return calls
 }
 
+// GetUserPosition calls GetUserPositionFunc.
+func (mock *StoreServiceMock) GetUserPosition(ctx context.Context, board string, id int, score int) (int, error) {
+	if mock.GetUserPositionFunc == nil {
+		panic("StoreServiceMock.GetUserPositionFunc: method is nil but StoreService.GetUserPosition was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Board string
+		ID    int
+		Score int
+	}{
+		Ctx:   ctx,
+		Board: board,
+		ID:    id,
+		Score: score,
+	}
+	mock.lockGetUserPosition.Lock()
+	mock.calls.GetUserPosition = append(mock.calls.GetUserPosition, callInfo)
+	mock.lockGetUserPosition.Unlock()
+	return mock.GetUserPositionFunc(ctx, board, id, score)
+}
+
+// GetUserPositionCalls gets all the calls that were made to GetUserPosition.
+// Check the length with:
+//
+//	len(mockedStoreService.GetUserPositionCalls())
+func (mock *StoreServiceMock) GetUserPositionCalls() []struct {
+	Ctx   context.Context
+	Board string
+	ID    int
+	Score int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Board string
+		ID    int
+		Score int
+	}
+	mock.lockGetUserPosition.RLock()
+	calls = mock.calls.GetUserPosition
+	mock.lockGetUserPosition.RUnlock()
+	return calls
+}
+
 // GetUsers calls GetUsersFunc.
-func (mock *StoreServiceMock) GetUsers(ctx context.Context, top int) ([]models.Ranking, error) {
+func (mock *StoreServiceMock) GetUsers(ctx context.Context, board string, top int) ([]models.Ranking, error) {
 	if mock.GetUsersFunc == nil {
 		panic("StoreServiceMock.GetUsersFunc: method is nil but StoreService.GetUsers was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
-		Top int
+		Ctx   context.Context
+		Board string
+		Top   int
 	}{
-		Ctx: ctx,
-		Top: top,
+		Ctx:   ctx,
+		Board: board,
+		Top:   top,
 	}
 	mock.lockGetUsers.Lock()
 	mock.calls.GetUsers = append(mock.calls.GetUsers, callInfo)
 	mock.lockGetUsers.Unlock()
-	return mock.GetUsersFunc(ctx, top)
+	return mock.GetUsersFunc(ctx, board, top)
 }
 
 // GetUsersCalls gets all the calls that were made to GetUsers.
 // Check the length with:
-//     len(mockedStoreService.GetUsersCalls())
+//
+//	len(mockedStoreService.GetUsersCalls())
 func (mock *StoreServiceMock) GetUsersCalls() []struct {
-	Ctx context.Context
-	Top int
+	Ctx   context.Context
+	Board string
+	Top   int
 } {
 	var calls []struct {
-		Ctx context.Context
-		Top int
+		Ctx   context.Context
+		Board string
+		Top   int
 	}
 	mock.lockGetUsers.RLock()
 	calls = mock.calls.GetUsers
@@ -281,36 +1041,85 @@ func (mock *StoreServiceMock) GetUsersCalls() []struct {
 	return calls
 }
 
+// GetUsersAfter calls GetUsersAfterFunc.
+func (mock *StoreServiceMock) GetUsersAfter(ctx context.Context, board string, after *models.RankingCursor, limit int) ([]models.Ranking, *models.RankingCursor, error) {
+	if mock.GetUsersAfterFunc == nil {
+		panic("StoreServiceMock.GetUsersAfterFunc: method is nil but StoreService.GetUsersAfter was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Board string
+		After *models.RankingCursor
+		Limit int
+	}{
+		Ctx:   ctx,
+		Board: board,
+		After: after,
+		Limit: limit,
+	}
+	mock.lockGetUsersAfter.Lock()
+	mock.calls.GetUsersAfter = append(mock.calls.GetUsersAfter, callInfo)
+	mock.lockGetUsersAfter.Unlock()
+	return mock.GetUsersAfterFunc(ctx, board, after, limit)
+}
+
+// GetUsersAfterCalls gets all the calls that were made to GetUsersAfter.
+// Check the length with:
+//
+//	len(mockedStoreService.GetUsersAfterCalls())
+func (mock *StoreServiceMock) GetUsersAfterCalls() []struct {
+	Ctx   context.Context
+	Board string
+	After *models.RankingCursor
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Board string
+		After *models.RankingCursor
+		Limit int
+	}
+	mock.lockGetUsersAfter.RLock()
+	calls = mock.calls.GetUsersAfter
+	mock.lockGetUsersAfter.RUnlock()
+	return calls
+}
+
 // GetUsersBetween calls GetUsersBetweenFunc.
-func (mock *StoreServiceMock) GetUsersBetween(ctx context.Context, lower int, upper int) ([]models.Ranking, error) {
+func (mock *StoreServiceMock) GetUsersBetween(ctx context.Context, board string, lower int, upper int) ([]models.Ranking, error) {
 	if mock.GetUsersBetweenFunc == nil {
 		panic("StoreServiceMock.GetUsersBetweenFunc: method is nil but StoreService.GetUsersBetween was just called")
 	}
 	callInfo := struct {
 		Ctx   context.Context
+		Board string
 		Lower int
 		Upper int
 	}{
 		Ctx:   ctx,
+		Board: board,
 		Lower: lower,
 		Upper: upper,
 	}
 	mock.lockGetUsersBetween.Lock()
 	mock.calls.GetUsersBetween = append(mock.calls.GetUsersBetween, callInfo)
 	mock.lockGetUsersBetween.Unlock()
-	return mock.GetUsersBetweenFunc(ctx, lower, upper)
+	return mock.GetUsersBetweenFunc(ctx, board, lower, upper)
 }
 
 // GetUsersBetweenCalls gets all the calls that were made to GetUsersBetween.
 // Check the length with:
-//     len(mockedStoreService.GetUsersBetweenCalls())
+//
+//	len(mockedStoreService.GetUsersBetweenCalls())
 func (mock *StoreServiceMock) GetUsersBetweenCalls() []struct {
 	Ctx   context.Context
+	Board string
 	Lower int
 	Upper int
 } {
 	var calls []struct {
 		Ctx   context.Context
+		Board string
 		Lower int
 		Upper int
 	}
@@ -320,36 +1129,321 @@ func (mock *StoreServiceMock) GetUsersBetweenCalls() []struct {
 	return calls
 }
 
+// MergeUsers calls MergeUsersFunc.
+func (mock *StoreServiceMock) MergeUsers(ctx context.Context, board string, from int, into int, strategy models.MergeStrategy) error {
+	if mock.MergeUsersFunc == nil {
+		panic("StoreServiceMock.MergeUsersFunc: method is nil but StoreService.MergeUsers was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Board    string
+		From     int
+		Into     int
+		Strategy models.MergeStrategy
+	}{
+		Ctx:      ctx,
+		Board:    board,
+		From:     from,
+		Into:     into,
+		Strategy: strategy,
+	}
+	mock.lockMergeUsers.Lock()
+	mock.calls.MergeUsers = append(mock.calls.MergeUsers, callInfo)
+	mock.lockMergeUsers.Unlock()
+	return mock.MergeUsersFunc(ctx, board, from, into, strategy)
+}
+
+// MergeUsersCalls gets all the calls that were made to MergeUsers.
+// Check the length with:
+//
+//	len(mockedStoreService.MergeUsersCalls())
+func (mock *StoreServiceMock) MergeUsersCalls() []struct {
+	Ctx      context.Context
+	Board    string
+	From     int
+	Into     int
+	Strategy models.MergeStrategy
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Board    string
+		From     int
+		Into     int
+		Strategy models.MergeStrategy
+	}
+	mock.lockMergeUsers.RLock()
+	calls = mock.calls.MergeUsers
+	mock.lockMergeUsers.RUnlock()
+	return calls
+}
+
+// Ping calls PingFunc.
+func (mock *StoreServiceMock) Ping(ctx context.Context) error {
+	if mock.PingFunc == nil {
+		panic("StoreServiceMock.PingFunc: method is nil but StoreService.Ping was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPing.Lock()
+	mock.calls.Ping = append(mock.calls.Ping, callInfo)
+	mock.lockPing.Unlock()
+	return mock.PingFunc(ctx)
+}
+
+// PingCalls gets all the calls that were made to Ping.
+// Check the length with:
+//
+//	len(mockedStoreService.PingCalls())
+func (mock *StoreServiceMock) PingCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockPing.RLock()
+	calls = mock.calls.Ping
+	mock.lockPing.RUnlock()
+	return calls
+}
+
+// ResetBoard calls ResetBoardFunc.
+func (mock *StoreServiceMock) ResetBoard(ctx context.Context, board string) (int, error) {
+	if mock.ResetBoardFunc == nil {
+		panic("StoreServiceMock.ResetBoardFunc: method is nil but StoreService.ResetBoard was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Board string
+	}{
+		Ctx:   ctx,
+		Board: board,
+	}
+	mock.lockResetBoard.Lock()
+	mock.calls.ResetBoard = append(mock.calls.ResetBoard, callInfo)
+	mock.lockResetBoard.Unlock()
+	return mock.ResetBoardFunc(ctx, board)
+}
+
+// ResetBoardCalls gets all the calls that were made to ResetBoard.
+// Check the length with:
+//
+//	len(mockedStoreService.ResetBoardCalls())
+func (mock *StoreServiceMock) ResetBoardCalls() []struct {
+	Ctx   context.Context
+	Board string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Board string
+	}
+	mock.lockResetBoard.RLock()
+	calls = mock.calls.ResetBoard
+	mock.lockResetBoard.RUnlock()
+	return calls
+}
+
+// RestoreFrozenUser calls RestoreFrozenUserFunc.
+func (mock *StoreServiceMock) RestoreFrozenUser(ctx context.Context, board string, user models.FrozenUser) error {
+	if mock.RestoreFrozenUserFunc == nil {
+		panic("StoreServiceMock.RestoreFrozenUserFunc: method is nil but StoreService.RestoreFrozenUser was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Board string
+		User  models.FrozenUser
+	}{
+		Ctx:   ctx,
+		Board: board,
+		User:  user,
+	}
+	mock.lockRestoreFrozenUser.Lock()
+	mock.calls.RestoreFrozenUser = append(mock.calls.RestoreFrozenUser, callInfo)
+	mock.lockRestoreFrozenUser.Unlock()
+	return mock.RestoreFrozenUserFunc(ctx, board, user)
+}
+
+// RestoreFrozenUserCalls gets all the calls that were made to RestoreFrozenUser.
+// Check the length with:
+//
+//	len(mockedStoreService.RestoreFrozenUserCalls())
+func (mock *StoreServiceMock) RestoreFrozenUserCalls() []struct {
+	Ctx   context.Context
+	Board string
+	User  models.FrozenUser
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Board string
+		User  models.FrozenUser
+	}
+	mock.lockRestoreFrozenUser.RLock()
+	calls = mock.calls.RestoreFrozenUser
+	mock.lockRestoreFrozenUser.RUnlock()
+	return calls
+}
+
+// SubmitScores calls SubmitScoresFunc.
+func (mock *StoreServiceMock) SubmitScores(ctx context.Context, board string, submissions []models.ScoreSubmission) ([]models.ScoreChange, error) {
+	if mock.SubmitScoresFunc == nil {
+		panic("StoreServiceMock.SubmitScoresFunc: method is nil but StoreService.SubmitScores was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		Board       string
+		Submissions []models.ScoreSubmission
+	}{
+		Ctx:         ctx,
+		Board:       board,
+		Submissions: submissions,
+	}
+	mock.lockSubmitScores.Lock()
+	mock.calls.SubmitScores = append(mock.calls.SubmitScores, callInfo)
+	mock.lockSubmitScores.Unlock()
+	return mock.SubmitScoresFunc(ctx, board, submissions)
+}
+
+// SubmitScoresCalls gets all the calls that were made to SubmitScores.
+// Check the length with:
+//
+//	len(mockedStoreService.SubmitScoresCalls())
+func (mock *StoreServiceMock) SubmitScoresCalls() []struct {
+	Ctx         context.Context
+	Board       string
+	Submissions []models.ScoreSubmission
+} {
+	var calls []struct {
+		Ctx         context.Context
+		Board       string
+		Submissions []models.ScoreSubmission
+	}
+	mock.lockSubmitScores.RLock()
+	calls = mock.calls.SubmitScores
+	mock.lockSubmitScores.RUnlock()
+	return calls
+}
+
+// SubmitScoresWithPeriods calls SubmitScoresWithPeriodsFunc.
+func (mock *StoreServiceMock) SubmitScoresWithPeriods(ctx context.Context, board string, periods []string, submissions []models.ScoreSubmission) ([]models.ScoreChange, []string, error) {
+	if mock.SubmitScoresWithPeriodsFunc == nil {
+		panic("StoreServiceMock.SubmitScoresWithPeriodsFunc: method is nil but StoreService.SubmitScoresWithPeriods was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		Board       string
+		Periods     []string
+		Submissions []models.ScoreSubmission
+	}{
+		Ctx:         ctx,
+		Board:       board,
+		Periods:     periods,
+		Submissions: submissions,
+	}
+	mock.lockSubmitScoresWithPeriods.Lock()
+	mock.calls.SubmitScoresWithPeriods = append(mock.calls.SubmitScoresWithPeriods, callInfo)
+	mock.lockSubmitScoresWithPeriods.Unlock()
+	return mock.SubmitScoresWithPeriodsFunc(ctx, board, periods, submissions)
+}
+
+// SubmitScoresWithPeriodsCalls gets all the calls that were made to SubmitScoresWithPeriods.
+// Check the length with:
+//
+//	len(mockedStoreService.SubmitScoresWithPeriodsCalls())
+func (mock *StoreServiceMock) SubmitScoresWithPeriodsCalls() []struct {
+	Ctx         context.Context
+	Board       string
+	Periods     []string
+	Submissions []models.ScoreSubmission
+} {
+	var calls []struct {
+		Ctx         context.Context
+		Board       string
+		Periods     []string
+		Submissions []models.ScoreSubmission
+	}
+	mock.lockSubmitScoresWithPeriods.RLock()
+	calls = mock.calls.SubmitScoresWithPeriods
+	mock.lockSubmitScoresWithPeriods.RUnlock()
+	return calls
+}
+
+// UnfreezeUser calls UnfreezeUserFunc.
+func (mock *StoreServiceMock) UnfreezeUser(ctx context.Context, board string, id int) (*models.FrozenUser, error) {
+	if mock.UnfreezeUserFunc == nil {
+		panic("StoreServiceMock.UnfreezeUserFunc: method is nil but StoreService.UnfreezeUser was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Board string
+		ID    int
+	}{
+		Ctx:   ctx,
+		Board: board,
+		ID:    id,
+	}
+	mock.lockUnfreezeUser.Lock()
+	mock.calls.UnfreezeUser = append(mock.calls.UnfreezeUser, callInfo)
+	mock.lockUnfreezeUser.Unlock()
+	return mock.UnfreezeUserFunc(ctx, board, id)
+}
+
+// UnfreezeUserCalls gets all the calls that were made to UnfreezeUser.
+// Check the length with:
+//
+//	len(mockedStoreService.UnfreezeUserCalls())
+func (mock *StoreServiceMock) UnfreezeUserCalls() []struct {
+	Ctx   context.Context
+	Board string
+	ID    int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Board string
+		ID    int
+	}
+	mock.lockUnfreezeUser.RLock()
+	calls = mock.calls.UnfreezeUser
+	mock.lockUnfreezeUser.RUnlock()
+	return calls
+}
+
 // UpdateAbsoluteUserScore calls UpdateAbsoluteUserScoreFunc.
-func (mock *StoreServiceMock) UpdateAbsoluteUserScore(ctx context.Context, id int, score int) error {
+func (mock *StoreServiceMock) UpdateAbsoluteUserScore(ctx context.Context, board string, id int, score int) error {
 	if mock.UpdateAbsoluteUserScoreFunc == nil {
 		panic("StoreServiceMock.UpdateAbsoluteUserScoreFunc: method is nil but StoreService.UpdateAbsoluteUserScore was just called")
 	}
 	callInfo := struct {
 		Ctx   context.Context
+		Board string
 		ID    int
 		Score int
 	}{
 		Ctx:   ctx,
+		Board: board,
 		ID:    id,
 		Score: score,
 	}
 	mock.lockUpdateAbsoluteUserScore.Lock()
 	mock.calls.UpdateAbsoluteUserScore = append(mock.calls.UpdateAbsoluteUserScore, callInfo)
 	mock.lockUpdateAbsoluteUserScore.Unlock()
-	return mock.UpdateAbsoluteUserScoreFunc(ctx, id, score)
+	return mock.UpdateAbsoluteUserScoreFunc(ctx, board, id, score)
 }
 
 // UpdateAbsoluteUserScoreCalls gets all the calls that were made to UpdateAbsoluteUserScore.
 // Check the length with:
-//     len(mockedStoreService.UpdateAbsoluteUserScoreCalls())
+//
+//	len(mockedStoreService.UpdateAbsoluteUserScoreCalls())
 func (mock *StoreServiceMock) UpdateAbsoluteUserScoreCalls() []struct {
 	Ctx   context.Context
+	Board string
 	ID    int
 	Score int
 } {
 	var calls []struct {
 		Ctx   context.Context
+		Board string
 		ID    int
 		Score int
 	}
@@ -360,35 +1454,40 @@ func (mock *StoreServiceMock) UpdateAbsoluteUserScoreCalls() []struct {
 }
 
 // UpdateRelativeUserScore calls UpdateRelativeUserScoreFunc.
-func (mock *StoreServiceMock) UpdateRelativeUserScore(ctx context.Context, id int, score int) error {
+func (mock *StoreServiceMock) UpdateRelativeUserScore(ctx context.Context, board string, id int, score int) error {
 	if mock.UpdateRelativeUserScoreFunc == nil {
 		panic("StoreServiceMock.UpdateRelativeUserScoreFunc: method is nil but StoreService.UpdateRelativeUserScore was just called")
 	}
 	callInfo := struct {
 		Ctx   context.Context
+		Board string
 		ID    int
 		Score int
 	}{
 		Ctx:   ctx,
+		Board: board,
 		ID:    id,
 		Score: score,
 	}
 	mock.lockUpdateRelativeUserScore.Lock()
 	mock.calls.UpdateRelativeUserScore = append(mock.calls.UpdateRelativeUserScore, callInfo)
 	mock.lockUpdateRelativeUserScore.Unlock()
-	return mock.UpdateRelativeUserScoreFunc(ctx, id, score)
+	return mock.UpdateRelativeUserScoreFunc(ctx, board, id, score)
 }
 
 // UpdateRelativeUserScoreCalls gets all the calls that were made to UpdateRelativeUserScore.
 // Check the length with:
-//     len(mockedStoreService.UpdateRelativeUserScoreCalls())
+//
+//	len(mockedStoreService.UpdateRelativeUserScoreCalls())
 func (mock *StoreServiceMock) UpdateRelativeUserScoreCalls() []struct {
 	Ctx   context.Context
+	Board string
 	ID    int
 	Score int
 } {
 	var calls []struct {
 		Ctx   context.Context
+		Board string
 		ID    int
 		Score int
 	}
@@ -397,3 +1496,43 @@ func (mock *StoreServiceMock) UpdateRelativeUserScoreCalls() []struct {
 	mock.lockUpdateRelativeUserScore.RUnlock()
 	return calls
 }
+
+// UpsertUserScore calls UpsertUserScoreFunc.
+func (mock *StoreServiceMock) UpsertUserScore(ctx context.Context, board string, submission models.ScoreSubmission) (*models.ScoreChange, error) {
+	if mock.UpsertUserScoreFunc == nil {
+		panic("StoreServiceMock.UpsertUserScoreFunc: method is nil but StoreService.UpsertUserScore was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Board      string
+		Submission models.ScoreSubmission
+	}{
+		Ctx:        ctx,
+		Board:      board,
+		Submission: submission,
+	}
+	mock.lockUpsertUserScore.Lock()
+	mock.calls.UpsertUserScore = append(mock.calls.UpsertUserScore, callInfo)
+	mock.lockUpsertUserScore.Unlock()
+	return mock.UpsertUserScoreFunc(ctx, board, submission)
+}
+
+// UpsertUserScoreCalls gets all the calls that were made to UpsertUserScore.
+// Check the length with:
+//
+//	len(mockedStoreService.UpsertUserScoreCalls())
+func (mock *StoreServiceMock) UpsertUserScoreCalls() []struct {
+	Ctx        context.Context
+	Board      string
+	Submission models.ScoreSubmission
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Board      string
+		Submission models.ScoreSubmission
+	}
+	mock.lockUpsertUserScore.RLock()
+	calls = mock.calls.UpsertUserScore
+	mock.lockUpsertUserScore.RUnlock()
+	return calls
+}
@@ -0,0 +1,200 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"github.com/pedrocmart/leaderboard-service/models"
+	"sync"
+)
+
+// Ensure, that StreamServiceMock does implement models.StreamService.
+// If this is not the case, regenerate this file with moq.
+var _ models.StreamService = &StreamServiceMock{}
+
+// StreamServiceMock is a mock implementation of models.StreamService.
+//
+//	func TestSomethingThatUsesStreamService(t *testing.T) {
+//
+//		// make and configure a mocked models.StreamService
+//		mockedStreamService := &StreamServiceMock{
+//			CloseFunc: func()  {
+//				panic("mock out the Close method")
+//			},
+//			PublishFunc: func(board string)  {
+//				panic("mock out the Publish method")
+//			},
+//			SubscribeFunc: func(board string) *models.Subscription {
+//				panic("mock out the Subscribe method")
+//			},
+//			UnsubscribeFunc: func(subscription *models.Subscription)  {
+//				panic("mock out the Unsubscribe method")
+//			},
+//		}
+//
+//		// use mockedStreamService in code that requires models.StreamService
+//		// and then make assertions.
+//
+//	}
+type StreamServiceMock struct {
+	// CloseFunc mocks the Close method.
+	CloseFunc func()
+
+	// PublishFunc mocks the Publish method.
+	PublishFunc func(board string)
+
+	// SubscribeFunc mocks the Subscribe method.
+	SubscribeFunc func(board string) *models.Subscription
+
+	// UnsubscribeFunc mocks the Unsubscribe method.
+	UnsubscribeFunc func(subscription *models.Subscription)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Close holds details about calls to the Close method.
+		Close []struct {
+		}
+		// Publish holds details about calls to the Publish method.
+		Publish []struct {
+			// Board is the board argument value.
+			Board string
+		}
+		// Subscribe holds details about calls to the Subscribe method.
+		Subscribe []struct {
+			// Board is the board argument value.
+			Board string
+		}
+		// Unsubscribe holds details about calls to the Unsubscribe method.
+		Unsubscribe []struct {
+			// Subscription is the subscription argument value.
+			Subscription *models.Subscription
+		}
+	}
+	lockClose       sync.RWMutex
+	lockPublish     sync.RWMutex
+	lockSubscribe   sync.RWMutex
+	lockUnsubscribe sync.RWMutex
+}
+
+// Close calls CloseFunc.
+func (mock *StreamServiceMock) Close() {
+	if mock.CloseFunc == nil {
+		panic("StreamServiceMock.CloseFunc: method is nil but StreamService.Close was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockClose.Lock()
+	mock.calls.Close = append(mock.calls.Close, callInfo)
+	mock.lockClose.Unlock()
+	mock.CloseFunc()
+}
+
+// CloseCalls gets all the calls that were made to Close.
+// Check the length with:
+//
+//	len(mockedStreamService.CloseCalls())
+func (mock *StreamServiceMock) CloseCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockClose.RLock()
+	calls = mock.calls.Close
+	mock.lockClose.RUnlock()
+	return calls
+}
+
+// Publish calls PublishFunc.
+func (mock *StreamServiceMock) Publish(board string) {
+	if mock.PublishFunc == nil {
+		panic("StreamServiceMock.PublishFunc: method is nil but StreamService.Publish was just called")
+	}
+	callInfo := struct {
+		Board string
+	}{
+		Board: board,
+	}
+	mock.lockPublish.Lock()
+	mock.calls.Publish = append(mock.calls.Publish, callInfo)
+	mock.lockPublish.Unlock()
+	mock.PublishFunc(board)
+}
+
+// PublishCalls gets all the calls that were made to Publish.
+// Check the length with:
+//
+//	len(mockedStreamService.PublishCalls())
+func (mock *StreamServiceMock) PublishCalls() []struct {
+	Board string
+} {
+	var calls []struct {
+		Board string
+	}
+	mock.lockPublish.RLock()
+	calls = mock.calls.Publish
+	mock.lockPublish.RUnlock()
+	return calls
+}
+
+// Subscribe calls SubscribeFunc.
+func (mock *StreamServiceMock) Subscribe(board string) *models.Subscription {
+	if mock.SubscribeFunc == nil {
+		panic("StreamServiceMock.SubscribeFunc: method is nil but StreamService.Subscribe was just called")
+	}
+	callInfo := struct {
+		Board string
+	}{
+		Board: board,
+	}
+	mock.lockSubscribe.Lock()
+	mock.calls.Subscribe = append(mock.calls.Subscribe, callInfo)
+	mock.lockSubscribe.Unlock()
+	return mock.SubscribeFunc(board)
+}
+
+// SubscribeCalls gets all the calls that were made to Subscribe.
+// Check the length with:
+//
+//	len(mockedStreamService.SubscribeCalls())
+func (mock *StreamServiceMock) SubscribeCalls() []struct {
+	Board string
+} {
+	var calls []struct {
+		Board string
+	}
+	mock.lockSubscribe.RLock()
+	calls = mock.calls.Subscribe
+	mock.lockSubscribe.RUnlock()
+	return calls
+}
+
+// Unsubscribe calls UnsubscribeFunc.
+func (mock *StreamServiceMock) Unsubscribe(subscription *models.Subscription) {
+	if mock.UnsubscribeFunc == nil {
+		panic("StreamServiceMock.UnsubscribeFunc: method is nil but StreamService.Unsubscribe was just called")
+	}
+	callInfo := struct {
+		Subscription *models.Subscription
+	}{
+		Subscription: subscription,
+	}
+	mock.lockUnsubscribe.Lock()
+	mock.calls.Unsubscribe = append(mock.calls.Unsubscribe, callInfo)
+	mock.lockUnsubscribe.Unlock()
+	mock.UnsubscribeFunc(subscription)
+}
+
+// UnsubscribeCalls gets all the calls that were made to Unsubscribe.
+// Check the length with:
+//
+//	len(mockedStreamService.UnsubscribeCalls())
+func (mock *StreamServiceMock) UnsubscribeCalls() []struct {
+	Subscription *models.Subscription
+} {
+	var calls []struct {
+		Subscription *models.Subscription
+	}
+	mock.lockUnsubscribe.RLock()
+	calls = mock.calls.Unsubscribe
+	mock.lockUnsubscribe.RUnlock()
+	return calls
+}
@@ -0,0 +1,83 @@
+package models
+
+import "fmt"
+
+//FreezeMode defines whether a frozen user keeps her/his place in the ranking
+type FreezeMode string
+
+const (
+	//FreezeKeep leaves the frozen user in the ranking with the score she/he had
+	FreezeKeep FreezeMode = "keep"
+	//FreezeHide takes the frozen user out of the ranking until she/he is unfrozen
+	FreezeHide FreezeMode = "hide"
+)
+
+//ParseFreezeMode returns the freeze mode named by mode, or FreezeKeep if it is empty
+func ParseFreezeMode(mode string) (FreezeMode, error) {
+	switch FreezeMode(mode) {
+	case "":
+		return FreezeKeep, nil
+	case FreezeKeep, FreezeHide:
+		return FreezeMode(mode), nil
+	}
+	return "", fmt.Errorf("unknown freeze mode %q", mode)
+}
+
+//MergeStrategy defines the score of a user that another one is merged into
+type MergeStrategy string
+
+const (
+	//MergeMax keeps the highest of the two scores
+	MergeMax MergeStrategy = "max"
+	//MergeSum adds the two scores
+	MergeSum MergeStrategy = "sum"
+)
+
+//ParseMergeStrategy returns the merge strategy named by strategy, or MergeMax if it is empty
+func ParseMergeStrategy(strategy string) (MergeStrategy, error) {
+	switch MergeStrategy(strategy) {
+	case "":
+		return MergeMax, nil
+	case MergeMax, MergeSum:
+		return MergeStrategy(strategy), nil
+	}
+	return "", fmt.Errorf("unknown merge strategy %q", strategy)
+}
+
+//Merge returns the score of the user merged into, from the scores of both users
+func (s MergeStrategy) Merge(from, into int) int {
+	if s == MergeSum {
+		return from + into
+	}
+	if from > into {
+		return from
+	}
+	return into
+}
+
+//FrozenUser is a user whose submissions are rejected
+type FrozenUser struct {
+	UserID int  `json:"user_id"`
+	Hidden bool `json:"hidden"`
+	//Score is the score of a hidden user, kept aside until she/he is unfrozen
+	Score int `json:"score"`
+}
+
+type FreezeUserRequest struct {
+	Mode string `json:"mode,omitempty"`
+}
+
+type GetFrozenUsersResponse struct {
+	Frozen []FrozenUser `json:"frozen"`
+}
+
+type MergeUsersRequest struct {
+	Into     *int   `json:"into"`
+	Strategy string `json:"strategy,omitempty"`
+}
+
+type ResetLeaderboardResponse struct {
+	Name string `json:"name"`
+	//Removed is how many users were taken out of the ranking
+	Removed int `json:"removed"`
+}
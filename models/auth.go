@@ -0,0 +1,83 @@
+package models
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+//APIKeyScope is what the requests signed with an APIKey are allowed to do
+type APIKeyScope string
+
+const (
+	//ScopeRead allows the GET requests
+	ScopeRead APIKeyScope = "read"
+	//ScopeWrite allows every request but the admin ones, including the submission of scores
+	ScopeWrite APIKeyScope = "write"
+	//ScopeAdmin allows every request
+	ScopeAdmin APIKeyScope = "admin"
+)
+
+//Allows tells whether the scope allows the requests that need the required scope
+func (s APIKeyScope) Allows(required APIKeyScope) bool {
+	switch s {
+	case ScopeAdmin:
+		return true
+	case ScopeWrite:
+		return required != ScopeAdmin
+	}
+	return s == required
+}
+
+//APIKey is the key of a client, which signs its requests with the secret
+type APIKey struct {
+	ID     string      `json:"id"`
+	Secret string      `json:"secret"`
+	Scope  APIKeyScope `json:"scope"`
+}
+
+//Validate checks that the key can be used to verify requests
+func (k APIKey) Validate() error {
+	if k.ID == "" || k.Secret == "" {
+		return fmt.Errorf("API keys must have an id and a secret")
+	}
+	if k.Scope != ScopeRead && k.Scope != ScopeWrite && k.Scope != ScopeAdmin {
+		return fmt.Errorf("invalid scope %q of the API key %s, expected one of: %s, %s, %s", k.Scope, k.ID, ScopeRead, ScopeWrite, ScopeAdmin)
+	}
+	return nil
+}
+
+//SignedRequest is what a client signs, with the signature it sent.
+//Timestamp is in unix seconds and Nonce must not be reused while the timestamp is accepted
+type SignedRequest struct {
+	KeyID     string
+	Timestamp string
+	Nonce     string
+	Signature string
+	Method    string
+	//Path is the path of the request with its query, eg: /ranking?type=top10
+	Path string
+	Body []byte
+}
+
+//Payload is the message that is signed: the method, the path, the timestamp, the nonce
+//and the hex SHA-256 of the body, separated by new lines
+func (r *SignedRequest) Payload() []byte {
+	body := sha256.Sum256(r.Body)
+	return []byte(strings.Join([]string{r.Method, r.Path, r.Timestamp, r.Nonce, hex.EncodeToString(body[:])}, "\n"))
+}
+
+type apiKeyContextKey struct{}
+
+//WithAPIKey returns a context holding the key that signed the request
+func WithAPIKey(ctx context.Context, key *APIKey) context.Context {
+	return context.WithValue(ctx, apiKeyContextKey{}, key)
+}
+
+//APIKeyFromContext returns the key that signed the request, or nil when it was not signed
+func APIKeyFromContext(ctx context.Context) *APIKey {
+	key, _ := ctx.Value(apiKeyContextKey{}).(*APIKey)
+	return key
+}
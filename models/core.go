@@ -3,13 +3,58 @@ package models
 import "database/sql"
 
 type Core struct {
-	Service         Service
-	StoreService    StoreService
+	//Config holds the settings the services of the core were connected with
+	Config       *Config
+	Service      Service
+	StoreService StoreService
+	Persistence  PersistenceService
+	History      HistoryService
+	Stream       StreamService
+	Auth         AuthService
+	RateLimits   RateLimitService
+	Metrics      MetricsService
+	Logger       Logger
+	//Validators run in order on every submission before it is applied
+	Validators      []ScoreValidator
+	Reviews         ReviewQueueService
 	DB              *sql.DB
 	RequestResponse RequestResponse
+	TieBreakPolicy  TieBreakPolicy
+	Periods         *PeriodCalendar
+	//AdminToken is the bearer token of the admin routes, when the requests are not signed
+	AdminToken string
+	//MaxBodyBytes bounds the bodies of the requests, DefaultMaxBodyBytes when it is not set
+	MaxBodyBytes int64
+	//LogBodyBytes is how much of the bodies of the requests and responses is logged at the debug level,
+	//0 leaves them out
+	LogBodyBytes int
+}
+
+//GetLogger returns the logger of the core, or one that writes the info messages to stderr if it is not set
+func (c *Core) GetLogger() Logger {
+	if c.Logger == nil {
+		return defaultLogger
+	}
+	return c.Logger
+}
+
+//GetTieBreakPolicy returns the tie break policy of the core, or the default one if it is not set
+func (c *Core) GetTieBreakPolicy() TieBreakPolicy {
+	if c.TieBreakPolicy == "" {
+		return DefaultTieBreakPolicy
+	}
+	return c.TieBreakPolicy
+}
+
+//GetMaxBodyBytes returns the most bytes a request body may have, or the default if it is not set
+func (c *Core) GetMaxBodyBytes() int64 {
+	if c.MaxBodyBytes <= 0 {
+		return DefaultMaxBodyBytes
+	}
+	return c.MaxBodyBytes
 }
 
 func (c *Core) ConnectResponseWriter() {
-	rw := &BasicRequestResponse{}
+	rw := &BasicRequestResponse{MaxBodyBytes: c.GetMaxBodyBytes(), Logger: c.GetLogger(), LogBodyBytes: c.LogBodyBytes}
 	c.RequestResponse = rw
 }
@@ -0,0 +1,179 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+//ErrorKind classifies the errors of the service by what the client can do about them
+type ErrorKind string
+
+const (
+	//ErrorValidation is a request that can't be served as it is sent
+	ErrorValidation ErrorKind = "validation"
+	//ErrorTooLarge is a request whose body is above the bound
+	ErrorTooLarge ErrorKind = "too_large"
+	//ErrorNotFound is a request for a leaderboard, user or period that does not exist
+	ErrorNotFound ErrorKind = "not_found"
+	//ErrorConflict is a request that the current state of the service does not allow
+	ErrorConflict ErrorKind = "conflict"
+	//ErrorUnauthorized is a request that is not signed by a known client
+	ErrorUnauthorized ErrorKind = "unauthorized"
+	//ErrorForbidden is a request of a client that is not allowed to send it
+	ErrorForbidden ErrorKind = "forbidden"
+	//ErrorTooManyRequests is a request of a client that sent more requests than it is allowed to
+	ErrorTooManyRequests ErrorKind = "too_many_requests"
+	//ErrorInternal is a failure of the service itself
+	ErrorInternal ErrorKind = "internal"
+)
+
+//Codes of the errors, which are kept stable so the clients can branch on them
+const (
+	CodeInvalidJSON            = "invalid_json"
+	CodeBodyTooLarge           = "body_too_large"
+	CodeInvalidUserID          = "invalid_user_id"
+	CodeInvalidScore           = "invalid_score"
+	CodeInvalidRankingType     = "invalid_ranking_type"
+	CodeInvalidPosition        = "invalid_position"
+	CodeInvalidLeaderboardName = "invalid_leaderboard_name"
+	CodeInvalidBatch           = "invalid_batch"
+	CodeBatchNotApplied        = "batch_not_applied"
+	CodeInvalidPeriod          = "invalid_period"
+	CodeInvalidPeriodID        = "invalid_period_id"
+	CodeInvalidHistoryQuery    = "invalid_history_query"
+	CodeInvalidCursor          = "invalid_cursor"
+	CodeInvalidLimit           = "invalid_limit"
+	CodeInvalidStatsQuery      = "invalid_stats_query"
+	CodeInvalidReviewQuery     = "invalid_review_query"
+	CodeScoreRejected          = "score_rejected"
+	CodeInvalidFreezeMode      = "invalid_freeze_mode"
+	CodeInvalidMerge           = "invalid_merge"
+	CodeUserFrozen             = "user_frozen"
+	CodeUserNotFrozen          = "user_not_frozen"
+	CodeRouteNotFound          = "route_not_found"
+	CodeLeaderboardNotFound    = "leaderboard_not_found"
+	CodeUserNotFound           = "user_not_found"
+	CodePeriodNotFound         = "period_not_found"
+	CodePeriodNotEnabled       = "period_not_enabled"
+	CodeHistoryNotEnabled      = "history_not_enabled"
+	CodeStreamNotEnabled       = "stream_not_enabled"
+	CodeReviewsNotEnabled      = "reviews_not_enabled"
+	CodeRateLimitsNotEnabled   = "rate_limits_not_enabled"
+	CodeLeaderboardExists      = "leaderboard_exists"
+	CodeDefaultLeaderboard     = "default_leaderboard"
+	CodeMissingSignature       = "missing_signature"
+	CodeInvalidSignature       = "invalid_signature"
+	CodeStaleRequest           = "stale_request"
+	CodeReplayedRequest        = "replayed_request"
+	CodeInsufficientScope      = "insufficient_scope"
+	CodeRateLimited            = "rate_limited"
+	CodeInvalidAdminToken      = "invalid_admin_token"
+	CodeAdminNotEnabled        = "admin_not_enabled"
+	CodeInternal               = "internal_error"
+)
+
+//Error is an error of the service that the client is told about, with its kind and code
+type Error struct {
+	Kind    ErrorKind
+	Code    string
+	Message string
+	//RetryAfter is how long the client should wait before sending the request again, when it is known
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+//NewValidationError returns an error of a request that can't be served as it is sent
+func NewValidationError(code string, format string, args ...interface{}) *Error {
+	return &Error{Kind: ErrorValidation, Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+//NewTooLargeError returns an error of a request whose body is above the bound
+func NewTooLargeError(code string, format string, args ...interface{}) *Error {
+	return &Error{Kind: ErrorTooLarge, Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+//NewNotFoundError returns an error of a request for something that does not exist
+func NewNotFoundError(code string, format string, args ...interface{}) *Error {
+	return &Error{Kind: ErrorNotFound, Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+//NewConflictError returns an error of a request that the state of the service does not allow
+func NewConflictError(code string, format string, args ...interface{}) *Error {
+	return &Error{Kind: ErrorConflict, Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+//NewUnauthorizedError returns an error of a request that is not signed by a known client
+func NewUnauthorizedError(code string, format string, args ...interface{}) *Error {
+	return &Error{Kind: ErrorUnauthorized, Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+//NewForbiddenError returns an error of a request that the client is not allowed to send
+func NewForbiddenError(code string, format string, args ...interface{}) *Error {
+	return &Error{Kind: ErrorForbidden, Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+//NewTooManyRequestsError returns an error of a request that can be sent again after retryAfter
+func NewTooManyRequestsError(code string, retryAfter time.Duration, format string, args ...interface{}) *Error {
+	return &Error{Kind: ErrorTooManyRequests, Code: code, Message: fmt.Sprintf(format, args...), RetryAfter: retryAfter}
+}
+
+//ErrorRetryAfter returns how long the client should wait before sending the request of err again, 0 if it is not known
+func ErrorRetryAfter(err error) time.Duration {
+	var serviceError *Error
+	if errors.As(err, &serviceError) {
+		return serviceError.RetryAfter
+	}
+	return 0
+}
+
+//ErrorCode returns the code of err, or the internal one if it is not an Error of the service
+func ErrorCode(err error) string {
+	var serviceError *Error
+	if errors.As(err, &serviceError) {
+		return serviceError.Code
+	}
+	return CodeInternal
+}
+
+//internalErrorMessage is what the clients are told of the errors that are not Errors of the service,
+//whose messages may hold the details of the database or the files, which are only logged
+const internalErrorMessage = "internal error"
+
+//ErrorMessage returns the message of err that the client is told, a fixed one if it is not an Error of the service
+func ErrorMessage(err error) string {
+	var serviceError *Error
+	if errors.As(err, &serviceError) {
+		return serviceError.Error()
+	}
+	return internalErrorMessage
+}
+
+//ErrorStatus returns the HTTP status of err, 500 if it is not an Error of the service
+func ErrorStatus(err error) int {
+	var serviceError *Error
+	if !errors.As(err, &serviceError) {
+		return http.StatusInternalServerError
+	}
+	switch serviceError.Kind {
+	case ErrorValidation:
+		return http.StatusBadRequest
+	case ErrorTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case ErrorNotFound:
+		return http.StatusNotFound
+	case ErrorConflict:
+		return http.StatusConflict
+	case ErrorUnauthorized:
+		return http.StatusUnauthorized
+	case ErrorForbidden:
+		return http.StatusForbidden
+	case ErrorTooManyRequests:
+		return http.StatusTooManyRequests
+	}
+	return http.StatusInternalServerError
+}
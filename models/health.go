@@ -0,0 +1,72 @@
+package models
+
+import (
+	"runtime/debug"
+)
+
+//Build info of the binary, set at compile time with:
+//go build -ldflags "-X github.com/pedrocmart/leaderboard-service/models.Version=v1.2.0 -X github.com/pedrocmart/leaderboard-service/models.Commit=$(git rev-parse HEAD)"
+//The ones that are not set are read from the build info that the go toolchain embeds in the binary
+var (
+	Version   = ""
+	Commit    = ""
+	BuildTime = ""
+)
+
+//CheckOK is the result of a check of the readiness that passed, the ones that failed have their error instead
+const CheckOK = "ok"
+
+type ReadinessResponse struct {
+	Ready bool `json:"ready"`
+	//Checks has the result of every dependency that was checked, by its name
+	Checks map[string]string `json:"checks"`
+}
+
+type HealthResponse struct {
+	Status string `json:"status"`
+}
+
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"build_time,omitempty"`
+	//CommitTime is the time of the commit the binary was built from
+	CommitTime string `json:"commit_time,omitempty"`
+	//Modified tells whether the binary was built with changes that were not committed
+	Modified  bool   `json:"modified,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+//GetBuildInfo returns the build info set at compile time, completed with the one embedded by the go toolchain
+func GetBuildInfo() BuildInfo {
+	info := BuildInfo{Version: Version, Commit: Commit, BuildTime: BuildTime}
+	embedded, ok := debug.ReadBuildInfo()
+	if !ok {
+		if info.Version == "" {
+			info.Version = "unknown"
+		}
+		return info
+	}
+
+	info.GoVersion = embedded.GoVersion
+	//the main module has no version when it is built from its own directory
+	if info.Version == "" && embedded.Main.Version != "" && embedded.Main.Version != "(devel)" {
+		info.Version = embedded.Main.Version
+	}
+	for _, setting := range embedded.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if info.Commit == "" {
+				info.Commit = setting.Value
+			}
+		case "vcs.time":
+			info.CommitTime = setting.Value
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+	if info.Version == "" {
+		info.Version = "devel"
+	}
+	return info
+}
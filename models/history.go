@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+//ScoreHistoryEntry records a submission that changed, or tried to change, the score of an user
+type ScoreHistoryEntry struct {
+	UserID        int `json:"user_id"`
+	PreviousScore int `json:"previous_score"`
+	Score         int `json:"score"`
+	//Delta is the relative score submitted, and Total the absolute one
+	Delta     *int      `json:"delta,omitempty"`
+	Total     *int      `json:"total,omitempty"`
+	Source    string    `json:"source,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+//Sources of the entries that the admin routes record, with the new score of the user as their Total
+const (
+	SourceAdminResetUser        = "admin:reset_user"
+	SourceAdminDeleteUser       = "admin:delete_user"
+	SourceAdminMergeUsers       = "admin:merge_users"
+	SourceAdminResetLeaderboard = "admin:reset_leaderboard"
+)
+
+//HistoryQuery selects a page of the history of an user, from the most recent entries.
+//Zero From or To leave the time range open
+type HistoryQuery struct {
+	From   time.Time
+	To     time.Time
+	Limit  int
+	Offset int
+}
+
+type GetUserHistoryRequest struct {
+	From   string
+	To     string
+	Limit  string
+	Offset string
+}
+
+type GetUserHistoryResponse struct {
+	UserID  int                 `json:"user_id"`
+	Total   int                 `json:"total"`
+	History []ScoreHistoryEntry `json:"history"`
+}
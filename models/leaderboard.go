@@ -1,6 +1,21 @@
 package models
 
+//DefaultLeaderboard is the board used by the routes that don't name a leaderboard
+const DefaultLeaderboard = "default"
+
 type Leaderboard struct {
 	Id    int
 	Score int
 }
+
+type CreateLeaderboardRequest struct {
+	Name string `json:"name"`
+}
+
+type LeaderboardResponse struct {
+	Name string `json:"name"`
+}
+
+type GetLeaderboardsResponse struct {
+	Leaderboards []string `json:"leaderboards"`
+}
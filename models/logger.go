@@ -0,0 +1,186 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+//LogLevel is how important a message of the log is, the messages below the level of the logger are dropped
+type LogLevel int
+
+const (
+	LevelDebug LogLevel = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+//DefaultLogLevel is the level of the logger when no other one is set
+const DefaultLogLevel = LevelInfo
+
+var logLevelNames = map[LogLevel]string{
+	LevelDebug: "debug",
+	LevelInfo:  "info",
+	LevelWarn:  "warn",
+	LevelError: "error",
+}
+
+func (l LogLevel) String() string {
+	if name, ok := logLevelNames[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("level(%d)", int(l))
+}
+
+func (l LogLevel) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+func (l *LogLevel) UnmarshalText(text []byte) error {
+	level, err := ParseLogLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
+//ParseLogLevel returns the level of its name, or the default one if it is empty
+func ParseLogLevel(value string) (LogLevel, error) {
+	if strings.TrimSpace(value) == "" {
+		return DefaultLogLevel, nil
+	}
+	for level, name := range logLevelNames {
+		if strings.EqualFold(strings.TrimSpace(value), name) {
+			return level, nil
+		}
+	}
+	return DefaultLogLevel, fmt.Errorf("invalid log level %q, expected one of: debug, info, warn, error", value)
+}
+
+//LogFields are the fields of a message of the log, besides its time, level and message
+type LogFields map[string]interface{}
+
+//NewJSONLogger - will return a Logger that writes one JSON object per message to w,
+//dropping the messages below level
+func NewJSONLogger(w io.Writer, level LogLevel) *JSONLogger {
+	return &JSONLogger{out: w, level: level, now: time.Now}
+}
+
+//defaultLogger is the logger of the cores without one
+var defaultLogger = NewJSONLogger(os.Stderr, DefaultLogLevel)
+
+//JSONLogger writes every message as a JSON object on its own line, with the request id of its context
+type JSONLogger struct {
+	level LogLevel
+	now   func() time.Time
+
+	//mu keeps the lines of concurrent messages from being interleaved
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func (l *JSONLogger) Debug(ctx context.Context, message string, fields LogFields) {
+	l.log(ctx, LevelDebug, message, fields)
+}
+
+func (l *JSONLogger) Info(ctx context.Context, message string, fields LogFields) {
+	l.log(ctx, LevelInfo, message, fields)
+}
+
+func (l *JSONLogger) Warn(ctx context.Context, message string, fields LogFields) {
+	l.log(ctx, LevelWarn, message, fields)
+}
+
+func (l *JSONLogger) Error(ctx context.Context, message string, fields LogFields) {
+	l.log(ctx, LevelError, message, fields)
+}
+
+func (l *JSONLogger) Enabled(level LogLevel) bool {
+	return level >= l.level
+}
+
+//log writes the time, the level, the message and the request id first, and then the fields by name
+func (l *JSONLogger) log(ctx context.Context, level LogLevel, message string, fields LogFields) {
+	if !l.Enabled(level) {
+		return
+	}
+
+	var line strings.Builder
+	line.WriteString("{")
+	writeLogField(&line, "time", l.now().UTC().Format(time.RFC3339Nano), true)
+	writeLogField(&line, "level", level.String(), false)
+	writeLogField(&line, "message", message, false)
+	if ctx != nil {
+		if id := RequestIDFromContext(ctx); id != "" {
+			writeLogField(&line, "request_id", id, false)
+		}
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		switch name {
+		case "time", "level", "message", "request_id":
+			//the fields can't take the place of the ones of every message
+		default:
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		writeLogField(&line, name, fields[name], false)
+	}
+	line.WriteString("}\n")
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	io.WriteString(l.out, line.String())
+}
+
+//writeLogField writes the field as JSON, or the text of its value when it can't be written as JSON
+func writeLogField(line *strings.Builder, name string, value interface{}, first bool) {
+	if err, ok := value.(error); ok {
+		value = err.Error()
+	}
+	if !first {
+		line.WriteString(",")
+	}
+	writeLogValue(line, name)
+	line.WriteString(":")
+	if err := writeLogValue(line, value); err != nil {
+		writeLogValue(line, fmt.Sprintf("%+v", value))
+	}
+}
+
+//writeLogValue writes the value as JSON, leaving the characters of HTML as they are so the log reads as it was
+func writeLogValue(line *strings.Builder, value interface{}) error {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	//the encoder ends every value with a new line
+	line.Write(bytes.TrimSuffix(buffer.Bytes(), []byte("\n")))
+	return nil
+}
+
+type requestIDContextKey struct{}
+
+//WithRequestID returns a context holding the id of the request, which every message logged with it carries
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+//RequestIDFromContext returns the id of the request, or an empty one when the context has none
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
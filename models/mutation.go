@@ -0,0 +1,45 @@
+package models
+
+//MutationType identifies a change to the store that is kept in the persistence log
+type MutationType string
+
+const (
+	MutationCreateLeaderboard MutationType = "create_leaderboard"
+	MutationDeleteLeaderboard MutationType = "delete_leaderboard"
+	//MutationAbsoluteScore sets the score of the user, creating her/him if needed
+	MutationAbsoluteScore MutationType = "absolute_score"
+	//MutationRelativeScore adds to the score of the user, creating her/him with it if needed
+	MutationRelativeScore MutationType = "relative_score"
+	//MutationBatch applies the score mutations it holds in a single transaction
+	MutationBatch MutationType = "batch"
+	//MutationDeleteUser removes the user from the ranking, frozen or not
+	MutationDeleteUser MutationType = "delete_user"
+	//MutationResetUser sets the score of the user to 0, only if she/he exists
+	MutationResetUser MutationType = "reset_user"
+	//MutationMergeUsers merges the user into the user Into, with the score given by Strategy
+	MutationMergeUsers MutationType = "merge_users"
+	//MutationFreezeUser rejects the submissions of the user, taking her/him out of the ranking if Hidden
+	MutationFreezeUser   MutationType = "freeze_user"
+	MutationUnfreezeUser MutationType = "unfreeze_user"
+	//MutationRestoreFrozenUser marks the user as frozen with Score and Hidden as they were, kept in the snapshots,
+	//since a user frozen in the ranking may not be in it anymore once her/his board is reset
+	MutationRestoreFrozenUser MutationType = "restore_frozen_user"
+	//MutationResetBoard removes every user from the ranking
+	MutationResetBoard MutationType = "reset_board"
+)
+
+//Mutation is a record of the persistence log
+type Mutation struct {
+	Type   MutationType `json:"type"`
+	Board  string       `json:"board"`
+	UserID int          `json:"user_id,omitempty"`
+	Score  int          `json:"score,omitempty"`
+	//Mutations are the score mutations of a batch
+	Mutations []Mutation `json:"mutations,omitempty"`
+	//Periods are the boards of the periods that the scores are also applied to
+	Periods []string `json:"periods,omitempty"`
+	//Into and Strategy are the user merged into and how, for a merge
+	Into     int           `json:"into,omitempty"`
+	Strategy MergeStrategy `json:"strategy,omitempty"`
+	Hidden   bool          `json:"hidden,omitempty"`
+}
@@ -0,0 +1,164 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+//Period is the time window of a ranking
+type Period string
+
+const (
+	//PeriodAllTime ranks every score ever submitted, it is the leaderboard itself
+	PeriodAllTime Period = "all_time"
+	PeriodDaily   Period = "daily"
+	PeriodWeekly  Period = "weekly"
+	PeriodMonthly Period = "monthly"
+)
+
+//periodSeparator joins a board, a period and its id in the name of the board of the period.
+//Leaderboard names can't have it, so the boards of the periods never clash with them
+const periodSeparator = "@"
+
+//ParsePeriod returns the period with the given name
+func ParsePeriod(period string) (Period, error) {
+	switch p := Period(period); p {
+	case PeriodAllTime, PeriodDaily, PeriodWeekly, PeriodMonthly:
+		return p, nil
+	}
+	return "", fmt.Errorf("unknown period %q, expected one of: all_time, daily, weekly, monthly", period)
+}
+
+//ParsePeriods returns the time windowed periods of a comma separated list, eg: daily,weekly
+func ParsePeriods(periods string) ([]Period, error) {
+	parsed := make([]Period, 0)
+	for _, name := range strings.Split(periods, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		period, err := ParsePeriod(name)
+		if err != nil {
+			return nil, err
+		}
+		if period == PeriodAllTime {
+			continue
+		}
+		parsed = append(parsed, period)
+	}
+	return parsed, nil
+}
+
+//ParseWeekday returns the weekday with the given english name, eg: monday
+func ParseWeekday(weekday string) (time.Weekday, error) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), weekday) {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday %q", weekday)
+}
+
+//PeriodCalendar splits the time in the periods the submissions are ranked in
+type PeriodCalendar struct {
+	//Periods are the time windowed periods fed by every submission, besides the leaderboard itself
+	Periods []Period
+	//Location is the timezone of the boundaries of the periods
+	Location *time.Location
+	//DayStart is the time after midnight at which the days start, eg: 6h
+	DayStart time.Duration
+	//WeekStart is the first day of the weeks
+	WeekStart time.Weekday
+	//Archives is how many past periods of each kind are kept, 0 keeps all of them
+	Archives int
+	//Clock returns the current time, time.Now when it is nil
+	Clock func() time.Time
+}
+
+//Now returns the current time of the calendar
+func (c *PeriodCalendar) Now() time.Time {
+	if c.Clock == nil {
+		return time.Now()
+	}
+	return c.Clock()
+}
+
+//IsEnabled reports whether the submissions are ranked in the period
+func (c *PeriodCalendar) IsEnabled(period Period) bool {
+	if period == PeriodAllTime {
+		return true
+	}
+	for _, p := range c.Periods {
+		if p == period {
+			return true
+		}
+	}
+	return false
+}
+
+//ID returns the id of the period that t is in: the date it starts at, eg: 2026-10-12, or the month for monthly periods, eg: 2026-10
+func (c *PeriodCalendar) ID(period Period, t time.Time) string {
+	day := c.day(t)
+	switch period {
+	case PeriodDaily:
+		return day.Format(dayFormat)
+	case PeriodWeekly:
+		offset := (int(day.Weekday()) - int(c.WeekStart) + 7) % 7
+		return day.AddDate(0, 0, -offset).Format(dayFormat)
+	case PeriodMonthly:
+		return day.Format(monthFormat)
+	}
+	return ""
+}
+
+//ValidateID returns an error if id is not the id of a period
+func (c *PeriodCalendar) ValidateID(period Period, id string) error {
+	format := dayFormat
+	if period == PeriodMonthly {
+		format = monthFormat
+	}
+	start, err := time.ParseInLocation(format, id, c.location())
+	if err != nil || c.ID(period, start.Add(c.DayStart)) != id {
+		return fmt.Errorf("invalid %s period id %q", period, id)
+	}
+	return nil
+}
+
+//day returns the midnight of the day that t is in, which starts DayStart after midnight
+func (c *PeriodCalendar) day(t time.Time) time.Time {
+	local := t.In(c.location()).Add(-c.DayStart)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, c.location())
+}
+
+func (c *PeriodCalendar) location() *time.Location {
+	if c.Location == nil {
+		return time.UTC
+	}
+	return c.Location
+}
+
+const (
+	dayFormat   = "2006-01-02"
+	monthFormat = "2006-01"
+)
+
+//PeriodBoard returns the name of the board that keeps the ranking of board in the period with the given id
+func PeriodBoard(board string, period Period, id string) string {
+	return strings.Join([]string{board, string(period), id}, periodSeparator)
+}
+
+//ParsePeriodBoard returns the board, the period and the id of the period of the board named name,
+//ok is false if it is not the board of a period
+func ParsePeriodBoard(name string) (board string, period Period, id string, ok bool) {
+	parts := strings.Split(name, periodSeparator)
+	if len(parts) != 3 {
+		return "", "", "", false
+	}
+	return parts[0], Period(parts[1]), parts[2], true
+}
+
+//IsPeriodBoard reports whether name is the board of a period
+func IsPeriodBoard(name string) bool {
+	return strings.Contains(name, periodSeparator)
+}
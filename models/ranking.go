@@ -1,7 +1,17 @@
 package models
 
+type GetRankingRequest struct {
+	Type string
+	//Period is the time window of the ranking, all_time when it is empty
+	Period string
+	//PeriodID selects a past period, the current one when it is empty
+	PeriodID string
+}
+
 type GetRankingResponse struct {
-	Ranking []Ranking `json:"ranking"`
+	Period   Period    `json:"period,omitempty"`
+	PeriodID string    `json:"period_id,omitempty"`
+	Ranking  []Ranking `json:"ranking"`
 }
 
 type Ranking struct {
@@ -9,3 +19,40 @@ type Ranking struct {
 	UserID   int `json:"user_id"`
 	Score    int `json:"score"`
 }
+
+type GetUserRankResponse struct {
+	UserID   int `json:"user_id"`
+	Position int `json:"position"`
+	Score    int `json:"score"`
+	Total    int `json:"total"`
+	//Percentile is the percentage of the players ranked below the user, eg: 99 is in the top 1%
+	Percentile float64 `json:"percentile"`
+}
+
+type GetRankingPageRequest struct {
+	//Limit is the maximum number of users of the page, the default one when it is empty
+	Limit string
+	//Cursor is the next_cursor of the previous page, the page starts at the top when it is empty
+	Cursor string
+	//Period is the time window of the ranking, all_time when it is empty
+	Period string
+	//PeriodID selects a past period, the current one when it is empty
+	PeriodID string
+}
+
+type GetRankingPageResponse struct {
+	Period   Period    `json:"period,omitempty"`
+	PeriodID string    `json:"period_id,omitempty"`
+	Ranking  []Ranking `json:"ranking"`
+	//NextCursor fetches the page that follows, it is empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+//RankingCursor is the key of a user in the ranking order, a page of the ranking starts right after it.
+//Since it holds the key and not a position, the pages don't skip or repeat users when scores change
+type RankingCursor struct {
+	Score int `json:"s"`
+	//ReachedAt is when the user reached the score, it only orders the users under the first policy
+	ReachedAt int64 `json:"r,omitempty"`
+	UserID    int   `json:"u"`
+}
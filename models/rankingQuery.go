@@ -0,0 +1,255 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+//RankingQueryKind is the selection of users of a ranking query
+type RankingQueryKind string
+
+const (
+	//RankingTop selects the first Count users
+	RankingTop RankingQueryKind = "top"
+	//RankingBottom selects the last Count users
+	RankingBottom RankingQueryKind = "bottom"
+	//RankingAt selects Around users above and below Position
+	RankingAt RankingQueryKind = "at"
+	//RankingRange selects the users from position From to To
+	RankingRange RankingQueryKind = "range"
+	//RankingAroundUser selects Around users above and below the user UserID
+	RankingAroundUser RankingQueryKind = "user"
+	//RankingPercentile selects the users at or above Percentile, eg: percentile90 is the top 10%
+	RankingPercentile RankingQueryKind = "percentile"
+)
+
+//RankingQuery is a parsed ranking type, which the StoreService executes
+type RankingQuery struct {
+	Kind RankingQueryKind
+	//Count is the number of users of top and bottom
+	Count int
+	//Position is the center of at
+	Position int
+	//From and To are the first and the last positions of range
+	From int
+	To   int
+	//UserID is the center of user
+	UserID int
+	//Around is the number of users above and below the center of at and user
+	Around int
+	//Percentile is between 0 and 100
+	Percentile float64
+	//MinScore keeps only the users with at least this score, when it is not nil
+	MinScore *int
+}
+
+//rankingQuerySyntax is the grammar of the ranking types, shown when one can't be parsed
+const rankingQuerySyntax = "top{n}, bottom{n}, at{position}/{n}, range{from}-{to}, user{id}/{n} or percentile{p}, optionally followed by ,score>={x}"
+
+//ParseRankingQuery parses a ranking type, which is a selection of users followed by filters, eg: top100,score>=500.
+//Keywords are case insensitive and the positions of the errors are 1-based
+func ParseRankingQuery(rankingType string) (*RankingQuery, error) {
+	p := &rankingQueryParser{input: rankingType}
+	query, err := p.parseSelection()
+	if err != nil {
+		return nil, err
+	}
+	for !p.done() {
+		if err := p.expect(',', "the end of the type or a ,score>={x} filter"); err != nil {
+			return nil, err
+		}
+		if err := p.parseFilter(query); err != nil {
+			return nil, err
+		}
+	}
+	return query, nil
+}
+
+//rankingQueryParser is a recursive descent parser of the ranking types, pos is the byte being read
+type rankingQueryParser struct {
+	input string
+	pos   int
+}
+
+func (p *rankingQueryParser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *rankingQueryParser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+//unexpected returns the error of the byte being read, which is not what the grammar expects
+func (p *rankingQueryParser) unexpected(expected string) error {
+	if p.done() {
+		return NewValidationError(CodeInvalidRankingType, "Unexpected end of the type at position %d, expected %s.", p.pos+1, expected)
+	}
+	return NewValidationError(CodeInvalidRankingType, "Unexpected %q at position %d of the type, expected %s.", p.input[p.pos], p.pos+1, expected)
+}
+
+func (p *rankingQueryParser) expect(c byte, expected string) error {
+	if p.peek() != c {
+		return p.unexpected(expected)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *rankingQueryParser) word() string {
+	start := p.pos
+	for !p.done() && isLetter(p.peek()) {
+		p.pos++
+	}
+	return strings.ToLower(p.input[start:p.pos])
+}
+
+func (p *rankingQueryParser) digits() string {
+	start := p.pos
+	for !p.done() && isDigit(p.peek()) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+//number parses an unsigned integer
+func (p *rankingQueryParser) number(expected string) (int, error) {
+	start := p.pos
+	digits := p.digits()
+	if digits == "" {
+		return 0, p.unexpected(expected)
+	}
+	n, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0, NewValidationError(CodeInvalidPosition, "The number at position %d of the type is too big.", start+1)
+	}
+	return n, nil
+}
+
+func (p *rankingQueryParser) parseSelection() (*RankingQuery, error) {
+	keyword := p.word()
+	query := &RankingQuery{Kind: RankingQueryKind(keyword)}
+	var err error
+	switch query.Kind {
+	case RankingTop, RankingBottom:
+		query.Count, err = p.number("the number of users")
+		if err == nil && query.Count <= 0 {
+			err = NewValidationError(CodeInvalidPosition, "The position must be greater than 0.")
+		}
+	case RankingAt:
+		query.Position, query.Around, err = p.parsePair("the position", "the number of users around it")
+	case RankingAroundUser:
+		query.UserID, query.Around, err = p.parsePair("the user id", "the number of users around the user")
+	case RankingRange:
+		err = p.parseRange(query)
+	case RankingPercentile:
+		err = p.parsePercentile(query)
+	case "":
+		err = p.unexpected("a ranking type: " + rankingQuerySyntax)
+	default:
+		err = NewValidationError(CodeInvalidRankingType, "Unknown ranking type %q at position 1, expected: %s.", keyword, rankingQuerySyntax)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return query, nil
+}
+
+//parsePair parses the {n}/{m} that follows at and user, which must be greater than 0
+func (p *rankingQueryParser) parsePair(first string, second string) (int, int, error) {
+	a, err := p.number(first)
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := p.expect('/', "a digit or '/'"); err != nil {
+		return 0, 0, err
+	}
+	b, err := p.number(second)
+	if err != nil {
+		return 0, 0, err
+	}
+	if a <= 0 || b <= 0 {
+		return 0, 0, NewValidationError(CodeInvalidPosition, "The positions must be greater than 0.")
+	}
+	return a, b, nil
+}
+
+func (p *rankingQueryParser) parseRange(query *RankingQuery) error {
+	var err error
+	query.From, err = p.number("the first position of the range")
+	if err != nil {
+		return err
+	}
+	if err := p.expect('-', "a digit or '-'"); err != nil {
+		return err
+	}
+	query.To, err = p.number("the last position of the range")
+	if err != nil {
+		return err
+	}
+	if query.From <= 0 || query.To < query.From {
+		return NewValidationError(CodeInvalidPosition, "The range must start at a position greater than 0 and end at or after it.")
+	}
+	return nil
+}
+
+//parsePercentile parses the only decimal number of the grammar, eg: percentile99.5
+func (p *rankingQueryParser) parsePercentile(query *RankingQuery) error {
+	start := p.pos
+	if p.digits() == "" {
+		return p.unexpected("the percentile")
+	}
+	if p.peek() == '.' {
+		p.pos++
+		if p.digits() == "" {
+			return p.unexpected("the decimals of the percentile")
+		}
+	}
+	percentile, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+	if err != nil || percentile >= 100 {
+		return NewValidationError(CodeInvalidPosition, "The percentile must be at least 0 and less than 100.")
+	}
+	query.Percentile = percentile
+	return nil
+}
+
+func (p *rankingQueryParser) parseFilter(query *RankingQuery) error {
+	start := p.pos
+	if field := p.word(); field != "score" {
+		p.pos = start
+		return p.unexpected("a score>={x} filter")
+	}
+	if err := p.expect('>', "'>='"); err != nil {
+		return err
+	}
+	if err := p.expect('=', "'>='"); err != nil {
+		return err
+	}
+	if query.MinScore != nil {
+		return NewValidationError(CodeInvalidRankingType, "The score filter at position %d of the type is repeated.", start+1)
+	}
+
+	negative := p.peek() == '-'
+	if negative {
+		p.pos++
+	}
+	score, err := p.number("the minimum score")
+	if err != nil {
+		return err
+	}
+	if negative {
+		score = -score
+	}
+	query.MinScore = &score
+	return nil
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+//RateLimitKey is what the requests of a rate limit are counted by
+type RateLimitKey string
+
+const (
+	//RateLimitByAPIKey counts the requests of each API key, and the ones that are not signed by their IP
+	RateLimitByAPIKey RateLimitKey = "api_key"
+	//RateLimitByIP counts the requests of each client address
+	RateLimitByIP RateLimitKey = "ip"
+	//RateLimitByUser counts the requests for each user id of the route, and leaves out the routes without one
+	RateLimitByUser RateLimitKey = "user"
+)
+
+//AllRoutes is the route of the rate limits of every route
+const AllRoutes = "*"
+
+//RateLimitRule is a token bucket for each key of the requests of a route: it holds up to Burst requests,
+//and is refilled with Rate requests per second
+type RateLimitRule struct {
+	//Route is the path template of the route, eg: /user/{user_id}/score, or * for every route
+	Route string `json:"route"`
+	//Method is the method of the requests, every method when it is empty
+	Method string       `json:"method,omitempty"`
+	By     RateLimitKey `json:"by"`
+	Rate   float64      `json:"rate"`
+	Burst  int          `json:"burst"`
+}
+
+//Validate checks that the rule can limit requests
+func (r RateLimitRule) Validate() error {
+	if r.Route == "" {
+		return fmt.Errorf("rate limits must have a route, or %s for every route", AllRoutes)
+	}
+	if r.By != RateLimitByAPIKey && r.By != RateLimitByIP && r.By != RateLimitByUser {
+		return fmt.Errorf("invalid key %q of the rate limit of %s, expected one of: %s, %s, %s", r.By, r.Route, RateLimitByAPIKey, RateLimitByIP, RateLimitByUser)
+	}
+	if r.Rate <= 0 || r.Burst < 1 {
+		return fmt.Errorf("the rate limit of %s must have a positive rate and a burst of at least 1", r.Route)
+	}
+	return nil
+}
+
+//Matches tells whether the rule limits the request
+func (r RateLimitRule) Matches(request *RateLimitRequest) bool {
+	if r.Route != AllRoutes && r.Route != request.Route {
+		return false
+	}
+	return r.Method == "" || strings.EqualFold(r.Method, request.Method)
+}
+
+//RateLimitRequest is a request as the rate limits see it
+type RateLimitRequest struct {
+	Route  string
+	Method string
+	//APIKey is the id of the key that signed the request, empty when it was not signed
+	APIKey string
+	IP     string
+	//UserID is the user of the route, empty when it has none
+	UserID string
+}
+
+//Key returns the key of the request that the rule counts it by, empty when the rule leaves it out
+func (r *RateLimitRequest) Key(by RateLimitKey) string {
+	switch by {
+	case RateLimitByAPIKey:
+		if r.APIKey != "" {
+			return "key:" + r.APIKey
+		}
+		return "ip:" + r.IP
+	case RateLimitByIP:
+		return "ip:" + r.IP
+	case RateLimitByUser:
+		if r.UserID != "" {
+			return "user:" + r.UserID
+		}
+	}
+	return ""
+}
+
+//RateLimitStatus is a rule and the number of requests it throttled
+type RateLimitStatus struct {
+	RateLimitRule
+	Throttled int64 `json:"throttled"`
+}
+
+type GetRateLimitsResponse struct {
+	RateLimits []RateLimitStatus `json:"rate_limits"`
+}
@@ -2,66 +2,147 @@ package models
 
 import (
 	"encoding/json"
+	"io"
+	"math"
 	"net/http"
-
-	"log"
+	"strconv"
 )
 
+//DefaultMaxBodyBytes is the most bytes a request body may have, when no other bound is set
+const DefaultMaxBodyBytes int64 = 1 << 20
+
 type BasicRequestResponse struct {
+	//MaxBodyBytes bounds the bodies read, DefaultMaxBodyBytes when it is not set
+	MaxBodyBytes int64
+	//Logger logs the errors, and the bodies at the debug level. The default logger is used when it is not set
+	Logger Logger
+	//LogBodyBytes is how much of each body is logged, 0 leaves them out
+	LogBodyBytes int
+}
+
+func (brr BasicRequestResponse) logger() Logger {
+	if brr.Logger == nil {
+		return defaultLogger
+	}
+	return brr.Logger
+}
+
+//logsBodies tells whether the bodies are logged, which they are only at the debug level
+func (brr BasicRequestResponse) logsBodies() bool {
+	return brr.LogBodyBytes > 0 && brr.logger().Enabled(LevelDebug)
 }
 
-func (brr BasicRequestResponse) log(endpoint string, message interface{}) {
-	log.Printf("%s - %+v", endpoint, message)
+//logBody logs the body at the debug level, cut at LogBodyBytes
+func (brr BasicRequestResponse) logBody(r *http.Request, message string, body []byte, size int) {
+	fields := LogFields{"method": r.Method, "path": r.URL.Path, "bytes": size}
+	if len(body) > brr.LogBodyBytes {
+		body = body[:brr.LogBodyBytes]
+	}
+	fields["body"] = string(body)
+	if len(body) < size {
+		fields["truncated"] = true
+	}
+	brr.logger().Debug(r.Context(), message, fields)
 }
+
 func (brr BasicRequestResponse) HandleError(err error, w http.ResponseWriter, r *http.Request, status int) {
 	if err == nil {
 		return //there is no error to write back
 	}
-	brr.log(r.URL.String(), err)
+	//the errors of the clients are warnings, and only the ones of the service are errors
+	fields := LogFields{"method": r.Method, "path": r.URL.Path, "status": status, "code": ErrorCode(err), "error": err.Error()}
+	if status >= http.StatusInternalServerError {
+		brr.logger().Error(r.Context(), "request failed", fields)
+	} else {
+		brr.logger().Warn(r.Context(), "request refused", fields)
+	}
 	writeError := writeJsonError(err, w, status)
 	if writeError != nil {
-		brr.log("Error writing bytes to the Response Writer:\n%s", err.Error())
+		brr.logger().Error(r.Context(), "error writing the response", LogFields{"path": r.URL.Path, "error": writeError.Error()})
 	}
 }
 
 func (brr BasicRequestResponse) HandleResponse(body interface{}, w http.ResponseWriter, r *http.Request, status int) {
-	brr.log(r.URL.String(), body)
-	err := writeJson(body, w, status)
+	bytes, err := writeJson(body, w, status)
 	if err != nil {
-		brr.log("Error writing bytes to the Response Writer:\n%s", err.Error())
+		brr.logger().Error(r.Context(), "error writing the response", LogFields{"path": r.URL.Path, "error": err.Error()})
+		return
+	}
+	if brr.logsBodies() {
+		brr.logBody(r, "response body", bytes, len(bytes))
 	}
 }
 
 func (brr BasicRequestResponse) ReadBodyAsJSON(req *http.Request, dest interface{}) (err error) {
-	err = json.NewDecoder(req.Body).Decode(dest)
+	max := brr.MaxBodyBytes
+	if max <= 0 {
+		max = DefaultMaxBodyBytes
+	}
+	//one byte more than the bound is let through, to tell a body of the bound from a larger one
+	body := &io.LimitedReader{R: req.Body, N: max + 1}
+	reader := io.Reader(body)
+	//the start of the body is kept while it is decoded, to be logged
+	var logged *boundedBuffer
+	if brr.logsBodies() {
+		logged = &boundedBuffer{max: brr.LogBodyBytes}
+		reader = io.TeeReader(body, logged)
+	}
+	err = json.NewDecoder(reader).Decode(dest)
+	if logged != nil {
+		brr.logBody(req, "request body", logged.bytes, logged.size)
+	}
+	if body.N <= 0 {
+		return NewTooLargeError(CodeBodyTooLarge, "The body must have at most %d bytes.", max)
+	}
 	return
 }
 
-func writeJson(body interface{}, w http.ResponseWriter, status int) error {
+//boundedBuffer keeps the first max bytes written to it, and counts all of them
+type boundedBuffer struct {
+	max   int
+	bytes []byte
+	size  int
+}
+
+func (b *boundedBuffer) Write(p []byte) (int, error) {
+	b.size += len(p)
+	if room := b.max - len(b.bytes); room > 0 {
+		if len(p) > room {
+			b.bytes = append(b.bytes, p[:room]...)
+		} else {
+			b.bytes = append(b.bytes, p...)
+		}
+	}
+	return len(p), nil
+}
+
+//writeJson writes the body as JSON with the status, returning what it wrote
+func writeJson(body interface{}, w http.ResponseWriter, status int) ([]byte, error) {
 	w.Header().Set("Content-Type", "application/json")
 	bytes, err := json.Marshal(body)
 	if err != nil {
 		writeErrorErr := writeJsonError(err, w, http.StatusInternalServerError)
-		if err != nil {
-			return writeErrorErr
+		if writeErrorErr != nil {
+			return nil, writeErrorErr
 		}
-		return err
+		return nil, err
 	}
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(status)
 	_, err = w.Write(bytes)
-	return err
+	return bytes, err
 }
 
 func writeJsonError(err error, w http.ResponseWriter, status int) error {
-	errorMessage := ""
-	if err != nil {
-		errorMessage = err.Error()
-	}
 	body := Response{
-		Message: errorMessage,
+		Message: ErrorMessage(err),
+		Code:    ErrorCode(err),
 		Success: false,
 		Data:    nil,
 	}
+	//Retry-After is in whole seconds, rounded up so the client never retries too early
+	if retryAfter := ErrorRetryAfter(err); retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	}
 	bytes, err := json.Marshal(body)
 	if err != nil {
 		return err
@@ -73,7 +154,9 @@ func writeJsonError(err error, w http.ResponseWriter, status int) error {
 }
 
 type Response struct {
-	Message string      `json:"message"`
+	Message string `json:"message"`
+	//Code identifies the error, see errors.go
+	Code    string      `json:"code,omitempty"`
 	Success bool        `json:"success"`
 	Data    interface{} `json:"data"`
 }
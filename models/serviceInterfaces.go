@@ -2,7 +2,9 @@ package models
 
 import (
 	"context"
+	"io"
 	"net/http"
+	"time"
 )
 
 type HandlersService interface {
@@ -10,19 +12,181 @@ type HandlersService interface {
 
 //go:generate moq -out ../mocks/service.go -pkg mocks  . Service
 type Service interface {
-	HandleSubmitScore(context.Context, *SubmitScoreRequest, string) (*SubmitScoreResponse, error)
-	HandleGetRanking(context.Context, string) (*GetRankingResponse, error)
+	HandleSubmitScore(ctx context.Context, board string, request *SubmitScoreRequest, userId string) (*SubmitScoreResponse, error)
+	HandleSubmitScores(ctx context.Context, board string, request *SubmitScoresRequest) (*SubmitScoresResponse, error)
+	HandleGetRanking(ctx context.Context, board string, request *GetRankingRequest) (*GetRankingResponse, error)
+	//HandleStreamRanking sends the ranking with send and then its changes, until ctx is done or send fails
+	HandleStreamRanking(ctx context.Context, board string, request *GetRankingRequest, send func(*RankingUpdate) error) error
+	//HandleGetRankingPage returns a page of the whole ranking, starting after the cursor of the request
+	HandleGetRankingPage(ctx context.Context, board string, request *GetRankingPageRequest) (*GetRankingPageResponse, error)
+	//HandleGetRankingStats returns the distribution of the scores of the ranking
+	HandleGetRankingStats(ctx context.Context, board string, request *GetRankingStatsRequest) (*GetRankingStatsResponse, error)
+	HandleGetUserRank(ctx context.Context, board string, userId string) (*GetUserRankResponse, error)
+	HandleGetUserHistory(ctx context.Context, board string, userId string, request *GetUserHistoryRequest) (*GetUserHistoryResponse, error)
+	HandleCreateLeaderboard(ctx context.Context, request *CreateLeaderboardRequest) (*LeaderboardResponse, error)
+	HandleGetLeaderboards(ctx context.Context) (*GetLeaderboardsResponse, error)
+	HandleDeleteLeaderboard(ctx context.Context, board string) (*LeaderboardResponse, error)
+	//HandleGetReviews returns a page of the submissions rejected or flagged by the validators
+	HandleGetReviews(ctx context.Context, request *GetReviewsRequest) (*GetReviewsResponse, error)
+	//HandleGetRateLimits returns the rate limits and how many requests each of them throttled
+	HandleGetRateLimits(ctx context.Context) (*GetRateLimitsResponse, error)
+	//HandleDeleteUser removes the user from the leaderboard and its periods, returning her/his last score
+	HandleDeleteUser(ctx context.Context, board string, userId string) (*User, error)
+	//HandleResetUser sets the score of the user to 0 in the leaderboard and its periods
+	HandleResetUser(ctx context.Context, board string, userId string) (*User, error)
+	//HandleFreezeUser rejects the submissions of the user, keeping her/him in the ranking or hiding her/him
+	HandleFreezeUser(ctx context.Context, board string, userId string, request *FreezeUserRequest) (*FrozenUser, error)
+	HandleUnfreezeUser(ctx context.Context, board string, userId string) (*FrozenUser, error)
+	HandleGetFrozenUsers(ctx context.Context, board string) (*GetFrozenUsersResponse, error)
+	//HandleMergeUsers merges the user into another one, or renames her/him when the other one does not exist
+	HandleMergeUsers(ctx context.Context, board string, userId string, request *MergeUsersRequest) (*User, error)
+	//HandleResetLeaderboard removes every user from the ranking of the leaderboard and its periods
+	HandleResetLeaderboard(ctx context.Context, board string) (*ResetLeaderboardResponse, error)
+	//HandleGetReadiness checks whether the store can be reached and the persistence log was recovered
+	HandleGetReadiness(ctx context.Context) (*ReadinessResponse, error)
 }
 
 //go:generate moq -out ../mocks/storeService.go -pkg mocks  . StoreService
 type StoreService interface {
-	CreateUser(ctx context.Context, id int, total int) error
-	UpdateRelativeUserScore(ctx context.Context, id int, score int) error
-	UpdateAbsoluteUserScore(ctx context.Context, id int, score int) error
-	GetUsers(ctx context.Context, top int) ([]Ranking, error)
-	GetUserById(ctx context.Context, id int) (*User, error)
-	GetUsersBetween(ctx context.Context, lower, upper int) ([]Ranking, error)
-	DoesUserExist(ctx context.Context, id int) (bool, error)
+	CreateLeaderboard(ctx context.Context, name string) error
+	DeleteLeaderboard(ctx context.Context, name string) error
+	DoesLeaderboardExist(ctx context.Context, name string) (bool, error)
+	GetLeaderboards(ctx context.Context) ([]string, error)
+	CreateUser(ctx context.Context, board string, id int, total int) error
+	UpdateRelativeUserScore(ctx context.Context, board string, id int, score int) error
+	UpdateAbsoluteUserScore(ctx context.Context, board string, id int, score int) error
+	//UpsertUserScore atomically creates the user or updates her/his score, returning the previous and the new score
+	UpsertUserScore(ctx context.Context, board string, submission ScoreSubmission) (*ScoreChange, error)
+	//SubmitScores applies all the submissions in a single transaction, returning their changes in the same order
+	SubmitScores(ctx context.Context, board string, submissions []ScoreSubmission) ([]ScoreChange, error)
+	//SubmitScoresWithPeriods applies the submissions to board and to the boards of its periods in a single transaction,
+	//creating the boards of the periods that don't exist yet. It returns the changes of board in the same order
+	//and the boards it created
+	SubmitScoresWithPeriods(ctx context.Context, board string, periods []string, submissions []ScoreSubmission) ([]ScoreChange, []string, error)
+	GetUsers(ctx context.Context, board string, top int) ([]Ranking, error)
+	//GetRanking executes the query, returning sql.ErrNoRows when the user of a user query does not exist
+	GetRanking(ctx context.Context, board string, query *RankingQuery) ([]Ranking, error)
+	//GetUsersAfter returns up to limit users that follow the cursor in the ranking, or the top ones when it is nil,
+	//and the cursor of the last user returned
+	GetUsersAfter(ctx context.Context, board string, after *RankingCursor, limit int) ([]Ranking, *RankingCursor, error)
+	GetUserById(ctx context.Context, board string, id int) (*User, error)
+	GetUsersBetween(ctx context.Context, board string, lower, upper int) ([]Ranking, error)
+	DoesUserExist(ctx context.Context, board string, id int) (bool, error)
+	GetUserPosition(ctx context.Context, board string, id int, score int) (int, error)
+	CountUsers(ctx context.Context, board string) (int, error)
+	//GetScores returns the scores of all the users of board, from the highest to the lowest
+	GetScores(ctx context.Context, board string) ([]int, error)
+	//DeleteUser removes the user from board, frozen or not, returning sql.ErrNoRows when she/he does not exist
+	DeleteUser(ctx context.Context, board string, id int) error
+	//ResetBoard removes every user from the ranking of board, returning how many there were.
+	//The frozen users stay frozen, the hidden ones with a score of 0
+	ResetBoard(ctx context.Context, board string) (int, error)
+	//MergeUsers moves the user from into the user into, with the score given by the strategy,
+	//or renames her/him when into does not exist. It returns sql.ErrNoRows when from does not exist
+	MergeUsers(ctx context.Context, board string, from int, into int, strategy MergeStrategy) error
+	//FreezeUser marks the user as frozen, taking her/him out of the ranking with her/his score if hidden.
+	//It returns sql.ErrNoRows when the user does not exist
+	FreezeUser(ctx context.Context, board string, id int, hidden bool) error
+	//UnfreezeUser puts a hidden user back in the ranking with her/his score, returning how she/he was frozen,
+	//or sql.ErrNoRows when she/he is not frozen
+	UnfreezeUser(ctx context.Context, board string, id int) (*FrozenUser, error)
+	//RestoreFrozenUser marks the user as frozen as she/he was, with her/his score and hidden or not,
+	//without looking her/him up in the ranking nor taking her/him out of it
+	RestoreFrozenUser(ctx context.Context, board string, user FrozenUser) error
+	//GetFrozenUsers returns the frozen users of board, by id
+	GetFrozenUsers(ctx context.Context, board string) ([]FrozenUser, error)
+	//Ping checks that the store can be reached
+	Ping(ctx context.Context) error
+}
+
+//go:generate moq -out ../mocks/historyService.go -pkg mocks  . HistoryService
+type HistoryService interface {
+	//Record adds the entries to the history, stamped with the time they are recorded
+	Record(ctx context.Context, board string, entries []ScoreHistoryEntry) error
+	//GetHistory returns a page of the history of the user and the number of entries matching the query
+	GetHistory(ctx context.Context, board string, id int, query HistoryQuery) ([]ScoreHistoryEntry, int, error)
+	DeleteLeaderboard(ctx context.Context, board string) error
+	//MergeUsers moves the entries of the user from into the history of the user into, in time order
+	MergeUsers(ctx context.Context, board string, from int, into int) error
+	//Prune removes the entries older than the retention
+	Prune(ctx context.Context) error
+}
+
+//go:generate moq -out ../mocks/persistenceService.go -pkg mocks  . PersistenceService
+type PersistenceService interface {
+	//Log writes the mutation to the log and then applies it to the store with apply
+	Log(ctx context.Context, mutation Mutation, apply func() error) error
+	//Snapshot compacts the log into a snapshot of the store
+	Snapshot(ctx context.Context) error
+	//Recover replays the snapshot and the log into the store and opens the log for writing
+	Recover(ctx context.Context) error
+	//Ready returns why the mutations can't be logged, or nil once the log was recovered and until it is closed
+	Ready() error
+	Close() error
+}
+
+//go:generate moq -out ../mocks/streamService.go -pkg mocks  . StreamService
+type StreamService interface {
+	//Subscribe returns a subscription to the changes of the scores of board
+	Subscribe(board string) *Subscription
+	Unsubscribe(subscription *Subscription)
+	//Publish notifies the subscribers of board without waiting for them
+	Publish(board string)
+	//Close ends every subscription, and the ones made after it at once
+	Close()
+}
+
+//go:generate moq -out ../mocks/scoreValidator.go -pkg mocks  . ScoreValidator
+type ScoreValidator interface {
+	//Validate returns the rule that the submission breaks, or nil when it follows the rules of the validator
+	Validate(ctx context.Context, check *ScoreCheck) (*ValidationResult, error)
+}
+
+//go:generate moq -out ../mocks/reviewQueueService.go -pkg mocks  . ReviewQueueService
+type ReviewQueueService interface {
+	//Add keeps the entries in the queue, giving them their id and stamping them with the time they are added
+	Add(ctx context.Context, entries []ReviewEntry) error
+	//List returns a page of the queue and the number of entries matching the query
+	List(ctx context.Context, query ReviewQuery) ([]ReviewEntry, int, error)
+}
+
+//go:generate moq -out ../mocks/rateLimitService.go -pkg mocks  . RateLimitService
+type RateLimitService interface {
+	//Allow takes a token from every bucket of the request, or none of them when one is empty,
+	//returning how long to wait before sending the request again in that case, or else 0
+	Allow(ctx context.Context, request *RateLimitRequest) (time.Duration, error)
+	//Status returns the rules and how many requests each of them throttled
+	Status(ctx context.Context) ([]RateLimitStatus, error)
+}
+
+//go:generate moq -out ../mocks/metricsService.go -pkg mocks  . MetricsService
+type MetricsService interface {
+	//ObserveRequest counts an HTTP request of the route by its status, and records how long it took
+	ObserveRequest(route string, method string, status int, duration time.Duration)
+	//ObserveSubmissions counts the submissions applied to board by their kind, and the users they created
+	ObserveSubmissions(board string, submissions []ScoreSubmission, changes []ScoreChange)
+	//ObserveStore records how long a call to a method of the store took, and whether it failed
+	ObserveStore(method string, duration time.Duration, err error)
+	//WriteMetrics writes every metric, with the ones read from the core at the time, in the Prometheus text format
+	WriteMetrics(ctx context.Context, w io.Writer) error
+}
+
+//go:generate moq -out ../mocks/logger.go -pkg mocks  . Logger
+type Logger interface {
+	Debug(ctx context.Context, message string, fields LogFields)
+	Info(ctx context.Context, message string, fields LogFields)
+	Warn(ctx context.Context, message string, fields LogFields)
+	Error(ctx context.Context, message string, fields LogFields)
+	//Enabled tells whether the messages of level are written, so the fields that are costly to build
+	//are only built for them
+	Enabled(level LogLevel) bool
+}
+
+//go:generate moq -out ../mocks/authService.go -pkg mocks  . AuthService
+type AuthService interface {
+	//Verify checks the signature, the timestamp and the nonce of the request,
+	//returning the key that signed it
+	Verify(ctx context.Context, request *SignedRequest) (*APIKey, error)
 }
 
 //go:generate moq -out ../mocks/requestResponse.go -pkg mocks  . RequestResponse
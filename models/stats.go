@@ -0,0 +1,40 @@
+package models
+
+type GetRankingStatsRequest struct {
+	//Percentiles are the comma separated percentiles to compute, the default ones when it is empty
+	Percentiles string
+	//Buckets is the number of buckets of the histogram, the default one when it is empty
+	Buckets string
+	//Period is the time window of the ranking, all_time when it is empty
+	Period string
+	//PeriodID selects a past period, the current one when it is empty
+	PeriodID string
+}
+
+//GetRankingStatsResponse describes the distribution of the scores of a ranking.
+//Min, Max, Mean and Median are 0 and the percentiles and histogram are empty when it has no players
+type GetRankingStatsResponse struct {
+	Period      Period            `json:"period,omitempty"`
+	PeriodID    string            `json:"period_id,omitempty"`
+	Players     int               `json:"players"`
+	Min         int               `json:"min"`
+	Max         int               `json:"max"`
+	Mean        float64           `json:"mean"`
+	Median      float64           `json:"median"`
+	Percentiles []ScorePercentile `json:"percentiles"`
+	Histogram   []HistogramBucket `json:"histogram"`
+}
+
+//ScorePercentile is the lowest score at or above which are the players of the percentile,
+//eg: the score of the percentile 99 puts a player in the top 1%
+type ScorePercentile struct {
+	Percentile float64 `json:"percentile"`
+	Score      int     `json:"score"`
+}
+
+//HistogramBucket is the number of players with scores from From to To, both included
+type HistogramBucket struct {
+	From  int `json:"from"`
+	To    int `json:"to"`
+	Count int `json:"count"`
+}
@@ -0,0 +1,34 @@
+package models
+
+//RankingUpdateType identifies the updates of a streamed ranking
+type RankingUpdateType string
+
+const (
+	//RankingSnapshot holds the whole ranking, it is sent first and whenever a new period starts
+	RankingSnapshot RankingUpdateType = "snapshot"
+	//RankingDiff holds the positions that changed since the last update
+	RankingDiff RankingUpdateType = "diff"
+	//RankingKeepAlive is sent when nothing changed for a while, so idle connections are kept open
+	RankingKeepAlive RankingUpdateType = "keepalive"
+)
+
+type RankingUpdate struct {
+	Type     RankingUpdateType `json:"type"`
+	Period   Period            `json:"period,omitempty"`
+	PeriodID string            `json:"period_id,omitempty"`
+	//Ranking is the whole ranking of a snapshot
+	Ranking []Ranking `json:"ranking,omitempty"`
+	//Changed are the users of a diff that entered the ranking or whose position or score changed
+	Changed []Ranking `json:"changed,omitempty"`
+	//Removed are the ids of the users of a diff that left the ranking
+	Removed []int `json:"removed,omitempty"`
+}
+
+//Subscription is notified whenever the scores of its board change.
+//Notifications are coalesced, so a subscriber that is behind receives a single one
+type Subscription struct {
+	Board  string
+	Notify chan struct{}
+	//Done is closed when the streams are closed, on shutdown
+	Done <-chan struct{}
+}
@@ -4,9 +4,59 @@ type SubmitScoreRequest struct {
 	UserID int    `json:"user,omitempty"`
 	Total  *int   `json:"total,omitempty"`
 	Score  string `json:"score,omitempty"`
+	//Source is the client that submitted the score, kept in the history
+	Source string `json:"-"`
 }
 
 type SubmitScoreResponse struct {
 	UserID int `json:"user_id,omitempty"`
 	Score  int `json:"score,omitempty"`
 }
+
+//ScoreSubmission is a validated score of an user: an absolute total, or a relative score to add to it.
+//Users that don't exist are created with the score
+type ScoreSubmission struct {
+	UserID   int
+	Score    int
+	Absolute bool
+}
+
+//ScoreChange is the result of a submission applied to the store
+type ScoreChange struct {
+	UserID        int
+	PreviousScore int
+	Score         int
+	//Created is true when the submission created the user
+	Created bool
+}
+
+//BatchMode defines what happens to a batch of scores when some of them fail
+type BatchMode string
+
+const (
+	//BatchAtomic applies all the scores of the batch or none of them
+	BatchAtomic BatchMode = "atomic"
+	//BatchBestEffort applies the valid scores of the batch and reports the invalid ones
+	BatchBestEffort BatchMode = "best_effort"
+)
+
+type SubmitScoresRequest struct {
+	Mode   BatchMode            `json:"mode,omitempty"`
+	Scores []SubmitScoreRequest `json:"scores"`
+	//Source is the client that submitted the scores, kept in the history
+	Source string `json:"-"`
+}
+
+type SubmitScoresResponse struct {
+	Applied int                 `json:"applied"`
+	Results []SubmitScoreResult `json:"results"`
+}
+
+//SubmitScoreResult is the result of a score of the batch, holding either its new score or an error
+type SubmitScoreResult struct {
+	UserID int    `json:"user_id"`
+	Score  *int   `json:"score,omitempty"`
+	Error  string `json:"error,omitempty"`
+	//Code is the code of the error, as in Response
+	Code string `json:"code,omitempty"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import "fmt"
+
+//TieBreakPolicy defines how users with equal scores are positioned in the ranking
+type TieBreakPolicy string
+
+const (
+	//TieBreakFirst ranks first the user that reached the score first
+	TieBreakFirst TieBreakPolicy = "first"
+	//TieBreakLowestID ranks first the user with the lowest id
+	TieBreakLowestID TieBreakPolicy = "id"
+	//TieBreakCompetition shares the position between equal scores and skips the next ones (1,2,2,4)
+	TieBreakCompetition TieBreakPolicy = "competition"
+	//TieBreakDense shares the position between equal scores without skipping the next ones (1,2,2,3)
+	TieBreakDense TieBreakPolicy = "dense"
+)
+
+//DefaultTieBreakPolicy is used when the core has no policy set
+const DefaultTieBreakPolicy = TieBreakFirst
+
+//ParseTieBreakPolicy returns the policy with the given name
+func ParseTieBreakPolicy(policy string) (TieBreakPolicy, error) {
+	switch p := TieBreakPolicy(policy); p {
+	case TieBreakFirst, TieBreakLowestID, TieBreakCompetition, TieBreakDense:
+		return p, nil
+	}
+	return "", fmt.Errorf("unknown tie break policy %q, expected one of: first, id, competition, dense", policy)
+}
+
+//SharesPositions reports whether users with equal scores get the same position
+func (p TieBreakPolicy) SharesPositions() bool {
+	return p == TieBreakCompetition || p == TieBreakDense
+}
@@ -0,0 +1,78 @@
+package models
+
+import "time"
+
+//ValidationVerdict is what the validation pipeline decided about a submission that broke a rule
+type ValidationVerdict string
+
+const (
+	//VerdictRejected submissions are not applied
+	VerdictRejected ValidationVerdict = "rejected"
+	//VerdictFlagged submissions are applied, and kept in the review queue to be looked at
+	VerdictFlagged ValidationVerdict = "flagged"
+)
+
+//ScoreCheck is a submission that is about to be applied, with the score of the user before and after it
+type ScoreCheck struct {
+	Board      string
+	Submission ScoreSubmission
+	//Exists is false when the submission creates the user
+	Exists        bool
+	PreviousScore int
+	Score         int
+	//PendingGain is how much the earlier submissions of the user in the same batch make her/him gain,
+	//which is not in the history until they are applied
+	PendingGain int
+}
+
+//Delta is how much the submission changes the score of the user
+func (c *ScoreCheck) Delta() int {
+	return c.Score - c.PreviousScore
+}
+
+//ValidationResult is the rule that a submission broke, and what to do about it
+type ValidationResult struct {
+	Verdict ValidationVerdict
+	Rule    string
+	Reason  string
+}
+
+//ReviewEntry is a rejected or flagged submission, kept in the review queue
+type ReviewEntry struct {
+	ID            int64  `json:"id"`
+	Board         string `json:"board"`
+	UserID        int    `json:"user_id"`
+	PreviousScore int    `json:"previous_score"`
+	//Score is the score the user has, or would have had, after the submission
+	Score int `json:"score"`
+	//Delta is the relative score submitted, and Total the absolute one
+	Delta     *int              `json:"delta,omitempty"`
+	Total     *int              `json:"total,omitempty"`
+	Source    string            `json:"source,omitempty"`
+	Verdict   ValidationVerdict `json:"verdict"`
+	Rule      string            `json:"rule"`
+	Reason    string            `json:"reason"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+//ReviewQuery selects a page of the review queue, from the most recent entries.
+//Empty Board or Verdict leave them open
+type ReviewQuery struct {
+	Board   string
+	Verdict ValidationVerdict
+	Limit   int
+	Offset  int
+}
+
+type GetReviewsRequest struct {
+	Board   string
+	Verdict string
+	Limit   string
+	Offset  string
+}
+
+type GetReviewsResponse struct {
+	//Total is the number of entries matching the query
+	Total   int           `json:"total"`
+	Reviews []ReviewEntry `json:"reviews"`
+}